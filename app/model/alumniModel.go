@@ -15,27 +15,28 @@ type Alumni struct {
 	Alamat     *string   `json:"alamat,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+	Version    int       `json:"version"`
 }
 
 type CreateAlumniRequest struct {
-	NIM        string `json:"nim"`
+	NIM        string `json:"nim" validate:"required,nim"`
 	UserID     string `json:"user_id"`
-	Nama       string `json:"nama"`
-	Jurusan    string `json:"jurusan"`
-	Angkatan   int    `json:"angkatan"`
-	TahunLulus int    `json:"tahun_lulus"`
-	Email      string `json:"email"`
-	NoTelepon  string `json:"no_telepon"`
+	Nama       string `json:"nama" validate:"required,max=100"`
+	Jurusan    string `json:"jurusan" validate:"required,max=100"`
+	Angkatan   int    `json:"angkatan" validate:"omitempty,min=1900,max=2100"`
+	TahunLulus int    `json:"tahun_lulus" validate:"omitempty,min=1900,max=2100,tahun_lulus"`
+	Email      string `json:"email" validate:"required,email,max=100"`
+	NoTelepon  string `json:"no_telepon" validate:"omitempty,max=20"`
 	Alamat     string `json:"alamat"`
 }
 
 type UpdateAlumniRequest struct {
-	Nama       string `json:"nama"`
-	Jurusan    string `json:"jurusan"`
-	Angkatan   int    `json:"angkatan"`
-	TahunLulus int    `json:"tahun_lulus"`
-	Email      string `json:"email"`
-	NoTelepon  string `json:"no_telepon"`
+	Nama       string `json:"nama" validate:"required,max=100"`
+	Jurusan    string `json:"jurusan" validate:"required,max=100"`
+	Angkatan   int    `json:"angkatan" validate:"omitempty,min=1900,max=2100"`
+	TahunLulus int    `json:"tahun_lulus" validate:"omitempty,min=1900,max=2100,tahun_lulus"`
+	Email      string `json:"email" validate:"required,email,max=100"`
+	NoTelepon  string `json:"no_telepon" validate:"omitempty,max=20"`
 	Alamat     string `json:"alamat"`
 }
 
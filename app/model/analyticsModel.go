@@ -0,0 +1,69 @@
+package model
+
+// AnalyticsFilter membatasi data yang dihitung oleh endpoint analytics.
+// Nilai nol berarti filter tidak dipakai.
+type AnalyticsFilter struct {
+	TahunMulai int    `json:"tahun_mulai,omitempty" query:"tahun_mulai"`
+	TahunAkhir int    `json:"tahun_akhir,omitempty" query:"tahun_akhir"`
+	Jurusan    string `json:"jurusan,omitempty" query:"jurusan"`
+}
+
+type EmploymentRate struct {
+	TahunLulus    int     `json:"tahun_lulus"`
+	Jurusan       string  `json:"jurusan"`
+	TotalAlumni   int     `json:"total_alumni"`
+	AlumniBekerja int     `json:"alumni_bekerja"`
+	Rate          float64 `json:"rate"`
+}
+
+// TimeToFirstJob dihitung dalam bulan, dari awal tahun lulus sampai
+// tanggal_mulai_kerja pekerjaan pertama. Pekerjaan yang dimulai sebelum
+// lulus dihitung 0 bulan.
+type TimeToFirstJob struct {
+	TahunLulus int     `json:"tahun_lulus"`
+	Jurusan    string  `json:"jurusan"`
+	Sampel     int     `json:"sampel"`
+	RataRata   float64 `json:"rata_rata_bulan"`
+	Median     float64 `json:"median_bulan"`
+	Min        float64 `json:"min_bulan"`
+	Max        float64 `json:"max_bulan"`
+}
+
+type DistributionItem struct {
+	Label      string  `json:"label"`
+	Jumlah     int     `json:"jumlah"`
+	Persentase float64 `json:"persentase"`
+}
+
+// GajiBand adalah satu rentang pada distribusi gaji. Max 0 berarti tanpa batas atas.
+type GajiBand struct {
+	Label string `json:"label"`
+	Min   int64  `json:"min"`
+	Max   int64  `json:"max,omitempty"`
+}
+
+var GajiBands = []GajiBand{
+	{Label: "< 3 juta", Min: 0, Max: 3000000},
+	{Label: "3 - 5 juta", Min: 3000000, Max: 5000000},
+	{Label: "5 - 8 juta", Min: 5000000, Max: 8000000},
+	{Label: "8 - 12 juta", Min: 8000000, Max: 12000000},
+	{Label: ">= 12 juta", Min: 12000000},
+}
+
+// LabelTidakDiketahui dipakai untuk nilai kosong atau gaji yang tidak bisa dibaca.
+const LabelTidakDiketahui = "Tidak diketahui"
+
+type SalaryBandItem struct {
+	GajiBand
+	Jumlah     int     `json:"jumlah"`
+	Persentase float64 `json:"persentase"`
+}
+
+type CohortTrend struct {
+	TahunLulus       int     `json:"tahun_lulus"`
+	TotalAlumni      int     `json:"total_alumni"`
+	AlumniBekerja    int     `json:"alumni_bekerja"`
+	Rate             float64 `json:"rate"`
+	RataRataBulan    float64 `json:"rata_rata_bulan_kerja_pertama"`
+	RataRataGajiAwal float64 `json:"rata_rata_gaji_awal"`
+}
@@ -14,9 +14,17 @@ type User struct {
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
+// UpdateUserRequest berisi field user yang boleh diubah admin. Password tidak
+// termasuk karena punya alur sendiri.
+type UpdateUserRequest struct {
+	Username string `json:"username" validate:"required,max=50"`
+	Email    string `json:"email" validate:"required,email,max=100"`
+	Role     string `json:"role" validate:"required,oneof=admin user"`
+}
+
 type LoginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
 }
 
 type LoginResponse struct {
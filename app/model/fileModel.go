@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// File adalah metadata file upload di tabel files. Isi file tetap di disk.
+type File struct {
+	ID           int       `json:"id"`
+	FileName     string    `json:"file_name"`
+	OriginalName string    `json:"original_name"`
+	FilePath     string    `json:"file_path"`
+	FileSize     int64     `json:"file_size"`
+	FileType     string    `json:"file_type"`
+	UploadedAt   time.Time `json:"uploaded_at"`
+	UploadedBy   int       `json:"uploaded_by"`
+	OwnerID      int       `json:"owner_id"`
+}
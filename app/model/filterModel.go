@@ -0,0 +1,110 @@
+package model
+
+// FilterOperator adalah operator pada query filter, mis. "gte" pada tahun_lulus:gte:2020.
+type FilterOperator string
+
+const (
+	FilterEq   FilterOperator = "eq"
+	FilterNe   FilterOperator = "ne"
+	FilterGt   FilterOperator = "gt"
+	FilterGte  FilterOperator = "gte"
+	FilterLt   FilterOperator = "lt"
+	FilterLte  FilterOperator = "lte"
+	FilterIn   FilterOperator = "in"
+	FilterNin  FilterOperator = "nin"
+	FilterLike FilterOperator = "like"
+	// FilterNull menerima nilai true (kosong) atau false (terisi).
+	FilterNull FilterOperator = "null"
+)
+
+// FilterType menentukan konversi nilai filter sebelum diteruskan ke database.
+type FilterType int
+
+const (
+	FilterString FilterType = iota
+	FilterInt
+	FilterDate
+)
+
+var (
+	stringOperators = []FilterOperator{FilterEq, FilterNe, FilterIn, FilterNin, FilterLike}
+	rangeOperators  = []FilterOperator{FilterEq, FilterNe, FilterGt, FilterGte, FilterLt, FilterLte, FilterIn, FilterNin}
+)
+
+// FilterField adalah satu field yang boleh difilter beserta operator yang diizinkan.
+type FilterField struct {
+	Type      FilterType
+	Operators []FilterOperator
+}
+
+// Allows melaporkan apakah operator op boleh dipakai pada field ini.
+func (f FilterField) Allows(op FilterOperator) bool {
+	for _, o := range f.Operators {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterFields adalah whitelist field per resource. Nama field sama dengan nama
+// kolom Postgres dan nama field BSON di Mongo.
+type FilterFields map[string]FilterField
+
+func stringField() FilterField { return FilterField{Type: FilterString, Operators: stringOperators} }
+func intField() FilterField    { return FilterField{Type: FilterInt, Operators: rangeOperators} }
+func dateField() FilterField   { return FilterField{Type: FilterDate, Operators: rangeOperators} }
+
+func nullable(f FilterField) FilterField {
+	f.Operators = append(append([]FilterOperator(nil), f.Operators...), FilterNull)
+	return f
+}
+
+var AlumniFilterFields = FilterFields{
+	"nim":         stringField(),
+	"nama":        stringField(),
+	"jurusan":     stringField(),
+	"email":       stringField(),
+	"angkatan":    intField(),
+	"tahun_lulus": intField(),
+	"created_at":  dateField(),
+}
+
+var PekerjaanFilterFields = FilterFields{
+	"nama_perusahaan":       stringField(),
+	"posisi_jabatan":        stringField(),
+	"bidang_industri":       stringField(),
+	"lokasi_kerja":          stringField(),
+	"status_pekerjaan":      stringField(),
+	"gaji_mata_uang":        nullable(stringField()),
+	"gaji_periode":          nullable(stringField()),
+	"gaji_min":              nullable(intField()),
+	"gaji_max":              nullable(intField()),
+	"tanggal_mulai_kerja":   dateField(),
+	"tanggal_selesai_kerja": nullable(dateField()),
+	"created_at":            dateField(),
+}
+
+var UserFilterFields = FilterFields{
+	"username":   stringField(),
+	"email":      stringField(),
+	"role":       stringField(),
+	"created_at": dateField(),
+}
+
+// FilterCondition adalah satu node pada AST filter. Values sudah dikonversi
+// sesuai FilterType field: string, int64 atau time.Time (bool untuk FilterNull).
+type FilterCondition struct {
+	Field    string
+	Operator FilterOperator
+	Values   []interface{}
+}
+
+// Filter adalah hasil parse query filter. Semua kondisi digabung dengan AND.
+type Filter struct {
+	Conditions []FilterCondition
+}
+
+func (f Filter) IsEmpty() bool {
+	return len(f.Conditions) == 0
+}
@@ -1,27 +1,31 @@
 package mongo
 
 import (
+	"latihan2/app/model"
 	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Pekerjaan struct {
 	ID                  primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	AlumniID            primitive.ObjectID `bson:"alumni_id,omitempty" json:"alumni_id"`
-	NamaPerusahaan      string             `bson:"nama_perusahaan,omitempty" json:"nama_perusahaan"`
-	PosisiJabatan       string             `bson:"posisi_jabatan,omitempty" json:"posisi_jabatan"`
-	BidangIndustri      string             `bson:"bidang_industri,omitempty" json:"bidang_industri"`
-	LokasiKerja         string             `bson:"lokasi_kerja,omitempty" json:"lokasi_kerja"`
-	GajiRange           string             `bson:"gaji_range,omitempty" json:"gaji_range"`
-	TanggalMulaiKerja   time.Time          `bson:"tanggal_mulai_kerja,omitempty" json:"tanggal_mulai_kerja"`
-	TanggalSelesaiKerja *time.Time         `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty"`
-	StatusPekerjaan     string             `bson:"status_pekerjaan,omitempty" json:"status_pekerjaan"`
+	NamaPerusahaan      string             `bson:"nama_perusahaan,omitempty" json:"nama_perusahaan" validate:"required,max=100"`
+	PosisiJabatan       string             `bson:"posisi_jabatan,omitempty" json:"posisi_jabatan" validate:"required,max=100"`
+	BidangIndustri      string             `bson:"bidang_industri,omitempty" json:"bidang_industri" validate:"omitempty,max=50"`
+	LokasiKerja         string             `bson:"lokasi_kerja,omitempty" json:"lokasi_kerja" validate:"omitempty,max=100"`
+	GajiRange           string             `bson:"gaji_range,omitempty" json:"gaji_range" validate:"omitempty,max=50"`
+	TanggalMulaiKerja   time.Time          `bson:"tanggal_mulai_kerja,omitempty" json:"tanggal_mulai_kerja" validate:"required"`
+	TanggalSelesaiKerja *time.Time         `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty" validate:"omitempty,setelah=TanggalMulaiKerja"`
+	StatusPekerjaan     string             `bson:"status_pekerjaan,omitempty" json:"status_pekerjaan" validate:"required,max=20"`
 	Deskripsi           string             `bson:"deskripsi_pekerjaan,omitempty" json:"deskripsi_pekerjaan"`
 	IsDelete            bool               `bson:"is_delete,omitempty" json:"is_delete"`
 	DeleteBy            string             `bson:"delete_by,omitempty" json:"delete_by,omitempty"`
 	DeletedAt           *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
 	CreatedAt           time.Time          `bson:"created_at,omitempty" json:"created_at"`
 	UpdatedAt           *time.Time         `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+	Version             int                `bson:"version,omitempty" json:"version"`
+	model.RentangGaji   `bson:",inline"`
 }
 
 type CreatePekerjaanRequest struct {
@@ -38,17 +42,19 @@ type CreatePekerjaanRequest struct {
 	DeskripsiPekerjaan  string             `bson:"deskripsi_pekerjaan" json:"deskripsi_pekerjaan"`
 	CreatedAt           time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt           time.Time          `bson:"updated_at" json:"updated_at"`
+	model.RentangGaji   `bson:",inline"`
 }
 
 type UpdatePekerjaanRequest struct {
-	NamaPerusahaan      string     `bson:"nama_perusahaan,omitempty" json:"nama_perusahaan,omitempty"`
-	PosisiJabatan       string     `bson:"posisi_jabatan,omitempty" json:"posisi_jabatan,omitempty"`
-	BidangIndustri      string     `bson:"bidang_industri,omitempty" json:"bidang_industri,omitempty"`
-	LokasiKerja         string     `bson:"lokasi_kerja,omitempty" json:"lokasi_kerja,omitempty"`
-	GajiRange           string     `bson:"gaji_range,omitempty" json:"gaji_range,omitempty"`
+	NamaPerusahaan      string     `bson:"nama_perusahaan,omitempty" json:"nama_perusahaan,omitempty" validate:"omitempty,max=100"`
+	PosisiJabatan       string     `bson:"posisi_jabatan,omitempty" json:"posisi_jabatan,omitempty" validate:"omitempty,max=100"`
+	BidangIndustri      string     `bson:"bidang_industri,omitempty" json:"bidang_industri,omitempty" validate:"omitempty,max=50"`
+	LokasiKerja         string     `bson:"lokasi_kerja,omitempty" json:"lokasi_kerja,omitempty" validate:"omitempty,max=100"`
+	GajiRange           string     `bson:"gaji_range,omitempty" json:"gaji_range,omitempty" validate:"omitempty,max=50"`
 	TanggalMulaiKerja   *time.Time `bson:"tanggal_mulai_kerja,omitempty" json:"tanggal_mulai_kerja,omitempty"`
-	TanggalSelesaiKerja *time.Time `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty"`
-	StatusPekerjaan     string     `bson:"status_pekerjaan,omitempty" json:"status_pekerjaan,omitempty"`
+	TanggalSelesaiKerja *time.Time `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty" validate:"omitempty,setelah=TanggalMulaiKerja"`
+	StatusPekerjaan     string     `bson:"status_pekerjaan,omitempty" json:"status_pekerjaan,omitempty" validate:"omitempty,max=20"`
 	DeskripsiPekerjaan  string     `bson:"deskripsi_pekerjaan,omitempty" json:"deskripsi_pekerjaan,omitempty"`
 	UpdatedAt           time.Time  `bson:"updated_at" json:"updated_at"`
+	model.RentangGaji   `bson:",inline"`
 }
\ No newline at end of file
@@ -0,0 +1,13 @@
+package mongo
+
+import "latihan2/app/model"
+
+type AlumniSearchResult struct {
+	Alumni          `bson:",inline"`
+	model.SearchHit `bson:",inline"`
+}
+
+type PekerjaanSearchResult struct {
+	Pekerjaan       `bson:",inline"`
+	model.SearchHit `bson:",inline"`
+}
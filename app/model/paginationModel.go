@@ -0,0 +1,39 @@
+package model
+
+// Cursor adalah posisi pada pagination keyset: nilai kolom sort dan id baris
+// terakhir (atau pertama, jika Prev) dari halaman sebelumnya. Klien hanya
+// melihatnya sebagai string opaque hasil utils.EncodeCursor.
+type Cursor struct {
+	SortBy string
+	Order  string
+	Value  interface{}
+	ID     string
+	// Prev berarti halaman yang diminta ada sebelum posisi cursor.
+	Prev bool
+}
+
+// CursorPage adalah permintaan satu halaman pada mode cursor. Cursor nil berarti halaman pertama.
+type CursorPage struct {
+	SortBy string
+	Order  string
+	Limit  int
+	Cursor *Cursor
+}
+
+// Backward melaporkan apakah halaman diambil mundur dari posisi cursor.
+func (p CursorPage) Backward() bool {
+	return p.Cursor != nil && p.Cursor.Prev
+}
+
+// CursorMetaInfo adalah meta response untuk mode cursor. Total hanya diisi
+// jika klien meminta with_total=true.
+type CursorMetaInfo struct {
+	Limit      int    `json:"limit"`
+	SortBy     string `json:"sortBy"`
+	Order      string `json:"order"`
+	Search     string `json:"search"`
+	Filter     string `json:"filter,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Total      *int   `json:"total,omitempty"`
+}
@@ -19,6 +19,8 @@ type Pekerjaan struct {
 	Deskripsi           string       `json:"deskripsi_pekerjaan"`
 	CreatedAt           time.Time    `json:"created_at"`
 	UpdatedAt           time.Time    `json:"updated_at"`
+	Version             int          `json:"version"`
+	RentangGaji
 }
 
 type TrashPekerjaanResponse struct {
@@ -31,25 +33,66 @@ type TrashPekerjaanResponse struct {
 // model/pekerjaan_request.go
 type CreatePekerjaanRequest struct {
 	AlumniID            int    `json:"alumni_id"`
-	NamaPerusahaan      string `json:"nama_perusahaan" validate:"required"`
-	PosisiJabatan       string `json:"posisi_jabatan" validate:"required"`
-	BidangIndustri      string `json:"bidang_industri"`
-	LokasiKerja         string `json:"lokasi_kerja"`
-	GajiRange           string `json:"gaji_range"`
-	TanggalMulaiKerja   string `json:"tanggal_mulai_kerja" validate:"required,datetime=2006-01-02"`    // Jadi string
-	TanggalSelesaiKerja string `json:"tanggal_selesai_kerja" validate:"omitempty,datetime=2006-01-02"` // Jadi string
-	StatusPekerjaan     string `json:"status_pekerjaan" validate:"required"`
+	NamaPerusahaan      string `json:"nama_perusahaan" validate:"required,max=100"`
+	PosisiJabatan       string `json:"posisi_jabatan" validate:"required,max=100"`
+	BidangIndustri      string `json:"bidang_industri" validate:"omitempty,max=50"`
+	LokasiKerja         string `json:"lokasi_kerja" validate:"omitempty,max=100"`
+	GajiRange           string `json:"gaji_range" validate:"omitempty,max=50"`
+	TanggalMulaiKerja   string `json:"tanggal_mulai_kerja" validate:"required,tanggal"`                              // Jadi string
+	TanggalSelesaiKerja string `json:"tanggal_selesai_kerja" validate:"omitempty,tanggal,setelah=TanggalMulaiKerja"` // Jadi string
+	StatusPekerjaan     string `json:"status_pekerjaan" validate:"required,max=20"`
 	DeskripsiPekerjaan  string `json:"deskripsi_pekerjaan"`
+	RentangGaji
 }
 
 type UpdatePekerjaanRequest struct {
-	NamaPerusahaan      string `json:"nama_perusahaan"`
-	PosisiJabatan       string `json:"posisi_jabatan"`
-	BidangIndustri      string `json:"bidang_industri"`
-	LokasiKerja         string `json:"lokasi_kerja"`
-	GajiRange           string `json:"gaji_range"`
-	TanggalMulaiKerja   string `json:"tanggal_mulai_kerja"`
-	TanggalSelesaiKerja string `json:"tanggal_selesai_kerja"`
-	StatusPekerjaan     string `json:"status_pekerjaan"`
+	NamaPerusahaan      string `json:"nama_perusahaan" validate:"required,max=100"`
+	PosisiJabatan       string `json:"posisi_jabatan" validate:"required,max=100"`
+	BidangIndustri      string `json:"bidang_industri" validate:"omitempty,max=50"`
+	LokasiKerja         string `json:"lokasi_kerja" validate:"omitempty,max=100"`
+	GajiRange           string `json:"gaji_range" validate:"omitempty,max=50"`
+	TanggalMulaiKerja   string `json:"tanggal_mulai_kerja" validate:"required,tanggal"`
+	TanggalSelesaiKerja string `json:"tanggal_selesai_kerja" validate:"omitempty,tanggal,setelah=TanggalMulaiKerja"`
+	StatusPekerjaan     string `json:"status_pekerjaan" validate:"required,max=20"`
 	DeskripsiPekerjaan  string `json:"deskripsi_pekerjaan"`
+	RentangGaji
+}
+
+const (
+	PeriodeGajiBulanan = "bulanan"
+	PeriodeGajiTahunan = "tahunan"
+)
+
+// RentangGaji adalah bentuk terstruktur dari gaji_range. GajiMin/GajiMax nil
+// berarti batas tersebut tidak diketahui (mis. "> 10 juta" hanya punya GajiMin).
+type RentangGaji struct {
+	GajiMin      *int64 `json:"gaji_min" bson:"gaji_min,omitempty" validate:"omitempty,min=0"`
+	GajiMax      *int64 `json:"gaji_max" bson:"gaji_max,omitempty" validate:"omitempty,min=0"`
+	GajiMataUang string `json:"gaji_mata_uang" bson:"gaji_mata_uang,omitempty" validate:"omitempty,len=3"`
+	GajiPeriode  string `json:"gaji_periode" bson:"gaji_periode,omitempty" validate:"omitempty,oneof=bulanan tahunan"`
+}
+
+func (g RentangGaji) IsEmpty() bool {
+	return g.GajiMin == nil && g.GajiMax == nil
+}
+
+// GajiFilter dipakai GetPekerjaanRepo untuk memfilter berdasarkan gaji.
+type GajiFilter struct {
+	Min      *int64
+	Max      *int64
+	MataUang string
+}
+
+type GajiMigrationFailure struct {
+	ID        string `json:"id"`
+	GajiRange string `json:"gaji_range"`
+	Alasan    string `json:"alasan"`
+}
+
+type GajiMigrationReport struct {
+	DryRun   bool                   `json:"dry_run"`
+	Total    int                    `json:"total"`
+	Berhasil int                    `json:"berhasil"`
+	Kosong   int                    `json:"kosong"`
+	Gagal    []GajiMigrationFailure `json:"gagal"`
 }
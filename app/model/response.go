@@ -8,19 +8,5 @@ type MetaInfo struct {
 	SortBy string `json:"sortBy"`
 	Order  string `json:"order"`
 	Search string `json:"search"`
-}
-
-type UserResponse struct {
-	Data []User   `json:"data"`
-	Meta MetaInfo `json:"meta"`
-}
-
-type AlumniResponse struct {
-	Data []Alumni `json:"data"`
-	Meta MetaInfo `json:"meta"`
-}
-
-type PekerjaanResponse struct {
-	Data []Pekerjaan `json:"data"`
-	Meta MetaInfo    `json:"meta"`
+	Filter string `json:"filter,omitempty"`
 }
@@ -0,0 +1,37 @@
+package model
+
+const (
+	// SearchModeFullText memakai tsvector di Postgres atau text index di Mongo.
+	SearchModeFullText = "fulltext"
+	// SearchModeBasic adalah pencarian substring lama (ILIKE / $regex) tanpa ranking.
+	SearchModeBasic = "basic"
+)
+
+// SearchHit adalah skor relevansi dan potongan teks dengan kata yang cocok
+// ditandai <mark>. Score selalu 0 pada SearchModeBasic.
+type SearchHit struct {
+	Score   float64 `json:"score" bson:"score"`
+	Snippet string  `json:"snippet" bson:"-"`
+}
+
+type AlumniSearchResult struct {
+	Alumni
+	SearchHit
+}
+
+type PekerjaanSearchResult struct {
+	Pekerjaan
+	SearchHit
+}
+
+// SearchQuery adalah parameter endpoint pencarian. Mode "basic" memaksa pencarian substring lama.
+type SearchQuery struct {
+	Q     string `json:"q" query:"q"`
+	Mode  string `json:"mode" query:"mode"`
+	Page  int    `json:"page" query:"page"`
+	Limit int    `json:"limit" query:"limit"`
+}
+
+func (s SearchQuery) Offset() int {
+	return (s.Page - 1) * s.Limit
+}
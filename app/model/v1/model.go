@@ -0,0 +1,100 @@
+// Package v1 berisi model /api/v1 yang tidak bergantung pada database di
+// belakangnya: semua ID berupa string (angka untuk Postgres, hex ObjectID untuk
+// Mongo) dan tanggal kosong berupa nil, bukan sql.NullTime.
+package v1
+
+import (
+	"latihan2/app/model"
+	"time"
+)
+
+type User struct {
+	ID        string     `json:"id"`
+	Username  string     `json:"username"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type Alumni struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	NIM        string    `json:"nim"`
+	Nama       string    `json:"nama"`
+	Jurusan    string    `json:"jurusan"`
+	Angkatan   int       `json:"angkatan"`
+	TahunLulus int       `json:"tahun_lulus"`
+	Email      string    `json:"email"`
+	NoTelepon  *string   `json:"no_telepon,omitempty"`
+	Alamat     *string   `json:"alamat,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Version    int       `json:"version"`
+}
+
+// Pekerjaan sama untuk semua driver. DeletedAt dan DeleteBy hanya terisi pada
+// data di trash.
+type Pekerjaan struct {
+	ID                  string     `json:"id"`
+	AlumniID            string     `json:"alumni_id"`
+	NamaPerusahaan      string     `json:"nama_perusahaan"`
+	PosisiJabatan       string     `json:"posisi_jabatan"`
+	BidangIndustri      string     `json:"bidang_industri"`
+	LokasiKerja         string     `json:"lokasi_kerja"`
+	GajiRange           string     `json:"gaji_range"`
+	TanggalMulaiKerja   time.Time  `json:"tanggal_mulai_kerja"`
+	TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja"`
+	StatusPekerjaan     string     `json:"status_pekerjaan"`
+	Deskripsi           string     `json:"deskripsi_pekerjaan"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	Version             int        `json:"version"`
+	DeleteBy            string     `json:"delete_by,omitempty"`
+	DeletedAt           *time.Time `json:"deleted_at,omitempty"`
+	model.RentangGaji
+}
+
+// CreatePekerjaanRequest sama dengan model.CreatePekerjaanRequest, hanya
+// AlumniID-nya string agar bisa berisi ID Postgres maupun ObjectID.
+type CreatePekerjaanRequest struct {
+	AlumniID            string `json:"alumni_id" validate:"required"`
+	NamaPerusahaan      string `json:"nama_perusahaan" validate:"required,max=100"`
+	PosisiJabatan       string `json:"posisi_jabatan" validate:"required,max=100"`
+	BidangIndustri      string `json:"bidang_industri" validate:"omitempty,max=50"`
+	LokasiKerja         string `json:"lokasi_kerja" validate:"omitempty,max=100"`
+	GajiRange           string `json:"gaji_range" validate:"omitempty,max=50"`
+	TanggalMulaiKerja   string `json:"tanggal_mulai_kerja" validate:"required,tanggal"`
+	TanggalSelesaiKerja string `json:"tanggal_selesai_kerja" validate:"omitempty,tanggal,setelah=TanggalMulaiKerja"`
+	StatusPekerjaan     string `json:"status_pekerjaan" validate:"required,max=20"`
+	DeskripsiPekerjaan  string `json:"deskripsi_pekerjaan"`
+	model.RentangGaji
+}
+
+// File adalah metadata file upload. UploadedBy dan OwnerID adalah ID user.
+type File struct {
+	ID           string    `json:"id"`
+	FileName     string    `json:"file_name"`
+	OriginalName string    `json:"original_name"`
+	FilePath     string    `json:"file_path"`
+	FileSize     int64     `json:"file_size"`
+	FileType     string    `json:"file_type"`
+	UploadedAt   time.Time `json:"uploaded_at"`
+	UploadedBy   string    `json:"uploaded_by"`
+	OwnerID      string    `json:"owner_id"`
+}
+
+type AlumniSearchResult struct {
+	Alumni
+	model.SearchHit
+}
+
+type PekerjaanSearchResult struct {
+	Pekerjaan
+	model.SearchHit
+}
+
+type LoginResponse struct {
+	User  User   `json:"user"`
+	Token string `json:"token"`
+}
@@ -1,13 +1,47 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"latihan2/app/model"
-	"latihan2/database"
+	"latihan2/utils"
 	"time"
 )
 
+// AlumniRepository adalah akses data alumni di Postgres. Service menerima interface
+// ini lewat constructor sehingga bisa diuji dengan implementasi palsu.
+type AlumniRepository interface {
+	GetAlumniByID(id int, role string) (*model.Alumni, error)
+	CreateAlumni(req model.CreateAlumniRequest) (*model.Alumni, error)
+	UpdateAlumni(id int, version int, req model.UpdateAlumniRequest) (*model.Alumni, error)
+	DeleteAlumni(id int) error
+	GetAlumniByTahunLulus(tahun int) ([]model.AlumniPekerjaanResponse, int, error)
+	GetAlumniRepo(search, sortBy, order string, limit, offset int, role string, filter model.Filter) ([]model.Alumni, error)
+	GetAlumniCursorRepo(search string, page model.CursorPage, role string, filter model.Filter) ([]model.Alumni, error)
+	CountAlumniRepo(search string, filter model.Filter) (int, error)
+	SoftDeleteAlumniRepo(id int) error
+	SearchAlumni(q string, limit, offset int, basic bool) ([]model.AlumniSearchResult, string, error)
+	// WithContext mengembalikan repository yang menjalankan query dengan ctx,
+	// mis. c.UserContext() agar query menjadi span anak dari span request.
+	WithContext(ctx context.Context) AlumniRepository
+}
+
+type alumniRepository struct {
+	db  *sql.DB
+	ctx context.Context
+}
+
+func NewAlumniRepository(db *sql.DB) AlumniRepository {
+	return &alumniRepository{db: db, ctx: context.Background()}
+}
+
+func (r *alumniRepository) WithContext(ctx context.Context) AlumniRepository {
+	c := *r
+	c.ctx = ctx
+	return &c
+}
+
 // func GetAllAlumni() ([]model.Alumni, error) {
 // 	rows, err := database.DB.Query(
 // 		`SELECT id, user_id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at
@@ -32,20 +66,20 @@ import (
 // 	return alumniList, nil
 // }
 
-func GetAlumniByID(id int, role string) (*model.Alumni, error) {
+func (r *alumniRepository) GetAlumniByID(id int, role string) (*model.Alumni, error) {
 
     query := `
         SELECT id, user_id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat,
-               created_at, updated_at
+               created_at, updated_at, version
         FROM alumni
         WHERE id = $1
     `
 
     var a model.Alumni
-    row := database.DB.QueryRow(query, id)
+    row := r.db.QueryRowContext(r.ctx, query, id)
     err := row.Scan(
         &a.ID, &a.UserID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus,
-        &a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt,
+        &a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt, &a.Version,
     )
     if err != nil {
         return nil, err
@@ -54,48 +88,46 @@ func GetAlumniByID(id int, role string) (*model.Alumni, error) {
 }
 
 
-func CreateAlumni(req model.CreateAlumniRequest) (*model.Alumni, error) {
+func (r *alumniRepository) CreateAlumni(req model.CreateAlumniRequest) (*model.Alumni, error) {
 	var newAlumni model.Alumni
-	err := database.DB.QueryRow(
+	err := r.db.QueryRowContext(r.ctx, 
 		`INSERT INTO alumni (user_id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at)
 		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		 RETURNING id, user_id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at`,
+		 RETURNING id, user_id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at, version`,
 		req.UserID, req.NIM, req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus, req.Email, req.NoTelepon, req.Alamat, time.Now(), time.Now()).Scan(
 		&newAlumni.ID, &newAlumni.UserID, &newAlumni.NIM, &newAlumni.Nama, &newAlumni.Jurusan, &newAlumni.Angkatan, &newAlumni.TahunLulus,
-		&newAlumni.Email, &newAlumni.NoTelepon, &newAlumni.Alamat, &newAlumni.CreatedAt, &newAlumni.UpdatedAt,
+		&newAlumni.Email, &newAlumni.NoTelepon, &newAlumni.Alamat, &newAlumni.CreatedAt, &newAlumni.UpdatedAt, &newAlumni.Version,
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, WrapDuplicate(err)
 	}
 	return &newAlumni, nil
 }
 
-func UpdateAlumni(id int, req model.UpdateAlumniRequest) (*model.Alumni, error) {
-	result, err := database.DB.Exec(
+// UpdateAlumni hanya berhasil jika version masih sama dengan di database
+// (kecuali AnyVersion), lalu menaikkan version.
+func (r *alumniRepository) UpdateAlumni(id int, version int, req model.UpdateAlumniRequest) (*model.Alumni, error) {
+	result, err := r.db.ExecContext(r.ctx, 
 		`UPDATE alumni
-		 SET nama = $1, jurusan = $2, angkatan = $3, tahun_lulus = $4, email = $5, no_telepon = $6, alamat = $7, updated_at = $8
-		 WHERE id = $9`,
-		req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus, req.Email, req.NoTelepon, req.Alamat, time.Now(), id)
+		 SET nama = $1, jurusan = $2, angkatan = $3, tahun_lulus = $4, email = $5, no_telepon = $6, alamat = $7, updated_at = $8,
+		     version = version + 1
+		 WHERE id = $9 AND ($10 = 0 OR version = $10)`,
+		req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus, req.Email, req.NoTelepon, req.Alamat, time.Now(), id, version)
 
 	if err != nil {
-		return nil, err
+		return nil, WrapDuplicate(err)
 	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
+	if err := versionChecked(r.ctx, r.db, result, "alumni", id); err != nil {
 		return nil, err
 	}
-	if rowsAffected == 0 {
-		return nil, sql.ErrNoRows
-	}
 
-	return GetAlumniByID(id, "admin")
+	return r.GetAlumniByID(id, "admin")
 }
 
 
-func DeleteAlumni(id int) error {
-	result, err := database.DB.Exec("DELETE FROM alumni WHERE id = $1", id)
+func (r *alumniRepository) DeleteAlumni(id int) error {
+	result, err := r.db.ExecContext(r.ctx, "DELETE FROM alumni WHERE id = $1", id)
 	if err != nil {
 		return err
 	}
@@ -111,8 +143,8 @@ func DeleteAlumni(id int) error {
 	return nil
 }
 
-func GetAlumniByTahunLulus(tahun int) ([]model.AlumniPekerjaanResponse, int, error) {
-	db := database.DB
+func (r *alumniRepository) GetAlumniByTahunLulus(tahun int) ([]model.AlumniPekerjaanResponse, int, error) {
+	db := r.db
 
 	rows, err := db.Query(`
 		SELECT a.id, a.user_id, a.jurusan, a.tahun_lulus, p.bidang_industri, 
@@ -144,7 +176,7 @@ func GetAlumniByTahunLulus(tahun int) ([]model.AlumniPekerjaanResponse, int, err
 		FROM alumni a
 		JOIN pekerjaan_alumni p ON a.id = p.alumni_id
 		WHERE a.tahun_lulus = $1
-		  AND CAST(REGEXP_REPLACE(split_part(p.gaji_range, '-', 1), '[^0-9]', '', 'g') AS INTEGER) >= 4000000
+		  AND `+gajiAwalExpr+` >= 4000000
 	`, tahun).Scan(&total)
 	if err != nil {
 		return nil, 0, err
@@ -153,54 +185,78 @@ func GetAlumniByTahunLulus(tahun int) ([]model.AlumniPekerjaanResponse, int, err
 	return result, total, nil
 }
 
-func GetAlumniRepo(search, sortBy, order string, limit, offset int, role string) ([]model.Alumni, error) {
-    
-    query := fmt.Sprintf(`
+const alumniListColumns = `
         SELECT id, user_id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat,
-               created_at, updated_at
+               created_at, updated_at, version
         FROM alumni
-        WHERE (nim ILIKE $1 OR nama ILIKE $1 OR email ILIKE $1)
+        WHERE (nim ILIKE $1 OR nama ILIKE $1 OR email ILIKE $1)`
+
+func (r *alumniRepository) GetAlumniRepo(search, sortBy, order string, limit, offset int, role string, filter model.Filter) ([]model.Alumni, error) {
+    where, args := utils.FilterToSQL(filter, "", []interface{}{"%" + search + "%"})
+    query := fmt.Sprintf(`%s AND %s
         ORDER BY %s %s
-        LIMIT $2 OFFSET $3
-    `, sortBy, order)
+        LIMIT $%d OFFSET $%d
+    `, alumniListColumns, where, sortBy, order, len(args)+1, len(args)+2)
+
+    rows, err := r.db.QueryContext(r.ctx, query, append(args, limit, offset)...)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    return scanAlumniList(rows)
+}
+
+// GetAlumniCursorRepo mengambil satu halaman alumni dengan pagination keyset.
+// Hasilnya berisi sampai page.Limit+1 baris; gunakan utils.CursorResult untuk memotongnya.
+func (r *alumniRepository) GetAlumniCursorRepo(search string, page model.CursorPage, role string, filter model.Filter) ([]model.Alumni, error) {
+    where, args := utils.FilterToSQL(filter, "", []interface{}{"%" + search + "%"})
+    keyset, orderBy, args := utils.CursorToSQL(page, page.SortBy, "id", args)
+    query := fmt.Sprintf(`%s AND %s AND %s
+        ORDER BY %s
+        LIMIT $%d
+    `, alumniListColumns, where, keyset, orderBy, len(args)+1)
 
-    rows, err := database.DB.Query(query, "%"+search+"%", limit, offset)
+    rows, err := r.db.QueryContext(r.ctx, query, append(args, page.Limit+1)...)
     if err != nil {
         return nil, err
     }
     defer rows.Close()
+    return scanAlumniList(rows)
+}
 
+func scanAlumniList(rows *sql.Rows) ([]model.Alumni, error) {
     var alumniList []model.Alumni
     for rows.Next() {
         var a model.Alumni
         if err := rows.Scan(
             &a.ID, &a.UserID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus,
-            &a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt,
+            &a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt, &a.Version,
         ); err != nil {
             return nil, err
         }
         alumniList = append(alumniList, a)
     }
-    return alumniList, nil
+    return alumniList, rows.Err()
 }
 
-func CountAlumniRepo(search string) (int, error) {
+func (r *alumniRepository) CountAlumniRepo(search string, filter model.Filter) (int, error) {
 	var total int
-	countQuery := `
+	where, args := utils.FilterToSQL(filter, "", []interface{}{"%" + search + "%"})
+	countQuery := fmt.Sprintf(`
 		SELECT COUNT(*) 
 		FROM alumni 
-		WHERE nim ILIKE $1 OR nama ILIKE $1 OR email ILIKE $1
-	`
-	err := database.DB.QueryRow(countQuery, "%"+search+"%").Scan(&total)
+		WHERE (nim ILIKE $1 OR nama ILIKE $1 OR email ILIKE $1) AND %s
+	`, where)
+	err := r.db.QueryRowContext(r.ctx, countQuery, args...).Scan(&total)
 	if err != nil && err != sql.ErrNoRows {
 		return 0, err
 	}
 	return total, nil
 }
 
-func SoftDeleteAlumniRepo(id int) error {
+func (r *alumniRepository) SoftDeleteAlumniRepo(id int) error {
     query := `UPDATE alumni SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
-    res, err := database.DB.Exec(query, id)
+    res, err := r.db.ExecContext(r.ctx, query, id)
     if err != nil {
         return err
     }
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"latihan2/app/model"
+	"latihan2/utils"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// AnalyticsRepository menghitung statistik alumni untuk endpoint analytics.
+// Repository Mongo dan memori punya method yang sama dengan tipe hasil yang sama.
+type AnalyticsRepository interface {
+	GetEmploymentRate(f model.AnalyticsFilter) ([]model.EmploymentRate, error)
+	GetTimeToFirstJob(f model.AnalyticsFilter) ([]model.TimeToFirstJob, error)
+	GetDistribusiPekerjaan(dimensi string, f model.AnalyticsFilter) ([]model.DistributionItem, error)
+	GetDistribusiGaji(f model.AnalyticsFilter) ([]model.SalaryBandItem, error)
+	GetCohortTrend(f model.AnalyticsFilter) ([]model.CohortTrend, error)
+	// WithContext mengembalikan repository yang menjalankan query dengan ctx,
+	// mis. c.UserContext() agar query menjadi span anak dari span request.
+	WithContext(ctx context.Context) AnalyticsRepository
+}
+
+type analyticsRepository struct {
+	db  *sql.DB
+	ctx context.Context
+}
+
+func NewAnalyticsRepository(db *sql.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db, ctx: context.Background()}
+}
+
+func (r *analyticsRepository) WithContext(ctx context.Context) AnalyticsRepository {
+	c := *r
+	c.ctx = ctx
+	return &c
+}
+
+// gajiAwalExpr adalah gaji_min per bulan dalam rupiah. Gaji dalam mata uang lain
+// bernilai NULL sehingga masuk band "Tidak diketahui".
+const gajiAwalExpr = `CASE WHEN p.gaji_mata_uang = 'IDR' THEN
+	CASE WHEN p.gaji_periode = 'tahunan' THEN p.gaji_min / 12 ELSE p.gaji_min END
+END`
+
+// bulanKerjaPertamaExpr menghitung jumlah bulan dari awal tahun lulus sampai mulai kerja.
+const bulanKerjaPertamaExpr = `GREATEST(0, (EXTRACT(YEAR FROM fj.mulai) - a.tahun_lulus) * 12 + EXTRACT(MONTH FROM fj.mulai) - 1)`
+
+// firstJobCTE berisi tanggal mulai pekerjaan pertama (yang belum dihapus) per alumni.
+const firstJobCTE = `
+	WITH first_job AS (
+		SELECT p.alumni_id, MIN(p.tanggal_mulai_kerja) AS mulai
+		FROM pekerjaan_alumni p
+		WHERE p.is_delete = false
+		GROUP BY p.alumni_id
+	)
+`
+
+var distribusiColumns = map[string]string{
+	"bidang_industri": "p.bidang_industri",
+	"lokasi_kerja":    "p.lokasi_kerja",
+}
+
+func (r *analyticsRepository) GetEmploymentRate(f model.AnalyticsFilter) ([]model.EmploymentRate, error) {
+	where, args := utils.AnalyticsToSQL(f, "a.", nil)
+	query := fmt.Sprintf(`
+		SELECT a.tahun_lulus, a.jurusan, COUNT(*) AS total,
+		       COUNT(*) FILTER (WHERE EXISTS (
+		           SELECT 1 FROM pekerjaan_alumni p WHERE p.alumni_id = a.id AND p.is_delete = false
+		       )) AS bekerja
+		FROM alumni a
+		WHERE %s
+		GROUP BY a.tahun_lulus, a.jurusan
+		ORDER BY a.tahun_lulus, a.jurusan
+	`, where)
+
+	rows, err := r.db.QueryContext(r.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []model.EmploymentRate{}
+	for rows.Next() {
+		var r model.EmploymentRate
+		if err := rows.Scan(&r.TahunLulus, &r.Jurusan, &r.TotalAlumni, &r.AlumniBekerja); err != nil {
+			return nil, err
+		}
+		r.Rate = utils.Persentase(r.AlumniBekerja, r.TotalAlumni)
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
+
+func (r *analyticsRepository) GetTimeToFirstJob(f model.AnalyticsFilter) ([]model.TimeToFirstJob, error) {
+	where, args := utils.AnalyticsToSQL(f, "a.", nil)
+	query := fmt.Sprintf(`%s
+		SELECT a.tahun_lulus, a.jurusan, ARRAY_AGG(%s ORDER BY fj.mulai)
+		FROM alumni a
+		JOIN first_job fj ON fj.alumni_id = a.id
+		WHERE %s
+		GROUP BY a.tahun_lulus, a.jurusan
+		ORDER BY a.tahun_lulus, a.jurusan
+	`, firstJobCTE, bulanKerjaPertamaExpr, where)
+
+	rows, err := r.db.QueryContext(r.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []model.TimeToFirstJob{}
+	for rows.Next() {
+		var r model.TimeToFirstJob
+		var bulan []float64
+		if err := rows.Scan(&r.TahunLulus, &r.Jurusan, pq.Array(&bulan)); err != nil {
+			return nil, err
+		}
+		result = append(result, utils.RingkasBulan(r, bulan))
+	}
+	return result, rows.Err()
+}
+
+func (r *analyticsRepository) GetDistribusiPekerjaan(dimensi string, f model.AnalyticsFilter) ([]model.DistributionItem, error) {
+	column, ok := distribusiColumns[dimensi]
+	if !ok {
+		return nil, fmt.Errorf("dimensi distribusi tidak dikenal: %s", dimensi)
+	}
+
+	where, args := utils.AnalyticsToSQL(f, "a.", nil)
+	query := fmt.Sprintf(`
+		SELECT COALESCE(NULLIF(TRIM(%s), ''), $%d) AS label, COUNT(*)
+		FROM pekerjaan_alumni p
+		JOIN alumni a ON a.id = p.alumni_id
+		WHERE p.is_delete = false AND %s
+		GROUP BY label
+		ORDER BY COUNT(*) DESC, label
+	`, column, len(args)+1, where)
+	args = append(args, model.LabelTidakDiketahui)
+
+	rows, err := r.db.QueryContext(r.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []model.DistributionItem{}
+	total := 0
+	for rows.Next() {
+		var item model.DistributionItem
+		if err := rows.Scan(&item.Label, &item.Jumlah); err != nil {
+			return nil, err
+		}
+		total += item.Jumlah
+		result = append(result, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Persentase = utils.Persentase(result[i].Jumlah, total)
+	}
+	return result, nil
+}
+
+func (r *analyticsRepository) GetDistribusiGaji(f model.AnalyticsFilter) ([]model.SalaryBandItem, error) {
+	where, args := utils.AnalyticsToSQL(f, "a.", nil)
+
+	// CASE dibangun dari model.GajiBands agar kedua backend memakai rentang yang sama.
+	var cases strings.Builder
+	for i, band := range model.GajiBands {
+		if band.Max > 0 {
+			fmt.Fprintf(&cases, " WHEN gaji >= %d AND gaji < %d THEN %d", band.Min, band.Max, i)
+		} else {
+			fmt.Fprintf(&cases, " WHEN gaji >= %d THEN %d", band.Min, i)
+		}
+	}
+
+	query := fmt.Sprintf(`
+		SELECT CASE%s ELSE -1 END AS band, COUNT(*)
+		FROM (
+			SELECT %s AS gaji
+			FROM pekerjaan_alumni p
+			JOIN alumni a ON a.id = p.alumni_id
+			WHERE p.is_delete = false AND %s
+		) t
+		GROUP BY band
+	`, cases.String(), gajiAwalExpr, where)
+
+	rows, err := r.db.QueryContext(r.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[int]int{}
+	total := 0
+	for rows.Next() {
+		var band, jumlah int
+		if err := rows.Scan(&band, &jumlah); err != nil {
+			return nil, err
+		}
+		counts[band] = jumlah
+		total += jumlah
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return utils.SusunGajiBand(counts, total), nil
+}
+
+func (r *analyticsRepository) GetCohortTrend(f model.AnalyticsFilter) ([]model.CohortTrend, error) {
+	where, args := utils.AnalyticsToSQL(f, "a.", nil)
+	query := fmt.Sprintf(`%s
+		, gaji_awal AS (
+			SELECT DISTINCT ON (p.alumni_id) p.alumni_id, %s AS gaji
+			FROM pekerjaan_alumni p
+			WHERE p.is_delete = false
+			ORDER BY p.alumni_id, p.tanggal_mulai_kerja
+		)
+		SELECT a.tahun_lulus, COUNT(*) AS total, COUNT(fj.alumni_id) AS bekerja,
+		       COALESCE(AVG(CASE WHEN fj.mulai IS NOT NULL THEN %s END), 0), COALESCE(AVG(g.gaji), 0)
+		FROM alumni a
+		LEFT JOIN first_job fj ON fj.alumni_id = a.id
+		LEFT JOIN gaji_awal g ON g.alumni_id = a.id
+		WHERE %s
+		GROUP BY a.tahun_lulus
+		ORDER BY a.tahun_lulus
+	`, firstJobCTE, gajiAwalExpr, bulanKerjaPertamaExpr, where)
+
+	rows, err := r.db.QueryContext(r.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []model.CohortTrend{}
+	for rows.Next() {
+		var r model.CohortTrend
+		if err := rows.Scan(&r.TahunLulus, &r.TotalAlumni, &r.AlumniBekerja, &r.RataRataBulan, &r.RataRataGajiAwal); err != nil {
+			return nil, err
+		}
+		r.Rate = utils.Persentase(r.AlumniBekerja, r.TotalAlumni)
+		r.RataRataBulan = utils.Round2(r.RataRataBulan)
+		r.RataRataGajiAwal = utils.Round2(r.RataRataGajiAwal)
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
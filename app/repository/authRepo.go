@@ -2,14 +2,13 @@ package repository
 
 import (
 	"latihan2/app/model"
-	"latihan2/database"
 )
 
-func GetUserByUsername(username string) (*model.User, string, error) {
+func (r *userRepository) GetUserByUsername(username string) (*model.User, string, error) {
 	var user model.User
 	var passwordHash string
 
-	row := database.DB.QueryRow(
+	row := r.db.QueryRowContext(r.ctx, 
 		`SELECT id, username, email, password_hash, role, created_at
 		 FROM users
 		 WHERE username = $1 OR email = $1`,
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// ErrDuplikat dikembalikan (lewat DuplicateKeyError) jika insert/update melanggar constraint unik.
+var ErrDuplikat = errors.New("data sudah terdaftar")
+
+// DuplicateKeyError menyebutkan field yang melanggar constraint unik, misalnya "nim" atau "email".
+type DuplicateKeyError struct {
+	Field string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	if e.Field == "" {
+		return ErrDuplikat.Error()
+	}
+	return fmt.Sprintf("%s sudah terdaftar", e.Field)
+}
+
+func (e *DuplicateKeyError) Is(target error) bool {
+	return target == ErrDuplikat
+}
+
+// WrapDuplicate mengubah unique_violation (kode 23505) menjadi *DuplicateKeyError.
+// Nama constraint bawaan Postgres berbentuk <tabel>_<kolom>_key, mis. alumni_nim_key.
+func WrapDuplicate(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != "23505" {
+		return err
+	}
+	field := strings.TrimSuffix(strings.TrimPrefix(pqErr.Constraint, pqErr.Table+"_"), "_key")
+	return &DuplicateKeyError{Field: field}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"latihan2/app/model"
+	"latihan2/utils"
+)
+
+// FileRepository adalah akses metadata file di tabel files, padanan
+// FileRepository Mongo. Isi file tetap di disk.
+type FileRepository interface {
+	CreateFile(file *model.File) error
+	FindAllFiles() ([]model.File, error)
+	FindFilesCursor(page model.CursorPage) ([]model.File, error)
+	CountFiles() (int, error)
+	FindFileByID(id int) (*model.File, error)
+	DeleteFile(id int) error
+	// WithContext mengembalikan repository yang menjalankan query dengan ctx,
+	// mis. c.UserContext() agar query menjadi span anak dari span request.
+	WithContext(ctx context.Context) FileRepository
+}
+
+type fileRepository struct {
+	db  *sql.DB
+	ctx context.Context
+}
+
+func NewFileRepository(db *sql.DB) FileRepository {
+	return &fileRepository{db: db, ctx: context.Background()}
+}
+
+func (r *fileRepository) WithContext(ctx context.Context) FileRepository {
+	c := *r
+	c.ctx = ctx
+	return &c
+}
+
+const fileColumns = `id, file_name, original_name, file_path, file_size, file_type, uploaded_at, uploaded_by, owner_id`
+
+// CreateFile menyimpan metadata file dan mengisi ID serta uploaded_at dari database.
+func (r *fileRepository) CreateFile(file *model.File) error {
+	return r.db.QueryRowContext(r.ctx,
+		`INSERT INTO files (file_name, original_name, file_path, file_size, file_type, uploaded_by, owner_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 RETURNING id, uploaded_at`,
+		file.FileName, file.OriginalName, file.FilePath, file.FileSize, file.FileType, file.UploadedBy, file.OwnerID,
+	).Scan(&file.ID, &file.UploadedAt)
+}
+
+func (r *fileRepository) FindAllFiles() ([]model.File, error) {
+	rows, err := r.db.QueryContext(r.ctx, `SELECT `+fileColumns+` FROM files ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFiles(rows)
+}
+
+// FindFilesCursor mengambil satu halaman file dengan pagination keyset.
+// Hasilnya berisi sampai page.Limit+1 baris; gunakan utils.CursorResult untuk memotongnya.
+func (r *fileRepository) FindFilesCursor(page model.CursorPage) ([]model.File, error) {
+	keyset, orderBy, args := utils.CursorToSQL(page, page.SortBy, "id", nil)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM files
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d
+	`, fileColumns, keyset, orderBy, len(args)+1)
+
+	rows, err := r.db.QueryContext(r.ctx, query, append(args, page.Limit+1)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFiles(rows)
+}
+
+// CountFiles dipakai oleh mode cursor jika klien meminta with_total=true.
+func (r *fileRepository) CountFiles() (int, error) {
+	var total int
+	err := r.db.QueryRowContext(r.ctx, `SELECT COUNT(*) FROM files`).Scan(&total)
+	return total, err
+}
+
+func (r *fileRepository) FindFileByID(id int) (*model.File, error) {
+	var f model.File
+	err := r.db.QueryRowContext(r.ctx, `SELECT `+fileColumns+` FROM files WHERE id = $1`, id).
+		Scan(&f.ID, &f.FileName, &f.OriginalName, &f.FilePath, &f.FileSize, &f.FileType, &f.UploadedAt, &f.UploadedBy, &f.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// DeleteFile mengembalikan sql.ErrNoRows jika file tidak ada.
+func (r *fileRepository) DeleteFile(id int) error {
+	result, err := r.db.ExecContext(r.ctx, `DELETE FROM files WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func scanFiles(rows *sql.Rows) ([]model.File, error) {
+	var files []model.File
+	for rows.Next() {
+		var f model.File
+		if err := rows.Scan(&f.ID, &f.FileName, &f.OriginalName, &f.FilePath, &f.FileSize, &f.FileType, &f.UploadedAt, &f.UploadedBy, &f.OwnerID); err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, rows.Err()
+}
@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"context"
+	"latihan2/app/model"
+	"latihan2/app/model/mongo"
+	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/helper"
+	"latihan2/utils"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+type alumniRepository struct {
+	s *Store
+}
+
+func NewAlumniRepository(s *Store) mongoRepo.AlumniRepository {
+	return &alumniRepository{s: s}
+}
+
+// WithContext tidak berpengaruh karena data memory tidak membuat span.
+func (r *alumniRepository) WithContext(context.Context) mongoRepo.AlumniRepository {
+	return r
+}
+
+func (r *alumniRepository) GetAlumniRepo(search, sortBy, order string, limit, offset int, f model.Filter) ([]mongo.Alumni, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return find(r.s.alumni, mongoRepo.AlumniListFilter(search, f), sortOrder(sortBy, order), offset, limit)
+}
+
+func (r *alumniRepository) GetAlumniCursorRepo(search string, page model.CursorPage, f model.Filter) ([]mongo.Alumni, error) {
+	filter, sort, err := utils.ApplyCursorBSON(mongoRepo.AlumniListFilter(search, f), page)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return find(r.s.alumni, filter, sort, 0, page.Limit+1)
+}
+
+func (r *alumniRepository) CountAlumniRepo(search string, f model.Filter) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	list, err := find(r.s.alumni, mongoRepo.AlumniListFilter(search, f), nil, 0, 0)
+	return len(list), err
+}
+
+func (r *alumniRepository) GetAlumniByID(id string) (*mongo.Alumni, error) {
+	objID, err := helper.ToObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	i := indexByID(r.s.alumni, objID, alumniID)
+	if i < 0 || r.s.alumni[i].DeletedAt != nil {
+		return nil, mongodriver.ErrNoDocuments
+	}
+	a := r.s.alumni[i]
+	return &a, nil
+}
+
+func (r *alumniRepository) CreateAlumni(alumni *mongo.Alumni) (*mongo.Alumni, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.checkUnique(primitive.NilObjectID, alumni.NIM, alumni.Email); err != nil {
+		return nil, err
+	}
+	alumni.ID = primitive.NewObjectID()
+	alumni.CreatedAt = time.Now()
+	alumni.UpdatedAt = time.Now()
+	alumni.Version = 1
+
+	stored, err := clone(*alumni)
+	if err != nil {
+		return nil, err
+	}
+	r.s.alumni = append(r.s.alumni, stored)
+	return alumni, nil
+}
+
+func (r *alumniRepository) UpdateAlumni(id string, version int, req model.UpdateAlumniRequest) (*mongo.Alumni, error) {
+	objID, err := helper.ToObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := indexByID(r.s.alumni, objID, alumniID)
+	if i < 0 || r.s.alumni[i].DeletedAt != nil {
+		return nil, mongodriver.ErrNoDocuments
+	}
+	a := r.s.alumni[i]
+	if version != mongoRepo.AnyVersion && a.Version != version {
+		return nil, mongoRepo.ErrVersionConflict
+	}
+	if err := r.checkUnique(a.ID, a.NIM, req.Email); err != nil {
+		return nil, err
+	}
+
+	a.Nama = req.Nama
+	a.Jurusan = req.Jurusan
+	a.Angkatan = req.Angkatan
+	a.TahunLulus = req.TahunLulus
+	a.Email = req.Email
+	noTelepon, alamat := req.NoTelepon, req.Alamat
+	a.NoTelepon = &noTelepon
+	a.Alamat = &alamat
+	a.UpdatedAt = time.Now()
+	a.Version++
+
+	if a, err = clone(a); err != nil {
+		return nil, err
+	}
+	r.s.alumni[i] = a
+	return &a, nil
+}
+
+func (r *alumniRepository) SoftDeleteAlumni(id string) error {
+	objID, err := helper.ToObjectID(id)
+	if err != nil {
+		return err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := indexByID(r.s.alumni, objID, alumniID)
+	if i < 0 || r.s.alumni[i].DeletedAt != nil {
+		return mongodriver.ErrNoDocuments
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	r.s.alumni[i].DeletedAt = &now
+	return nil
+}
+
+// SearchAlumni selalu memakai pencarian basic karena tidak ada text index di memori,
+// sama seperti repository Mongo saat text index belum dibuat.
+func (r *alumniRepository) SearchAlumni(q string, limit, offset int, basic bool) ([]mongo.AlumniSearchResult, string, error) {
+	r.s.mu.RLock()
+	list, err := find(r.s.alumni, mongoRepo.AlumniListFilter(q, model.Filter{}), bson.D{{Key: "_id", Value: 1}}, offset, limit)
+	r.s.mu.RUnlock()
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := make([]mongo.AlumniSearchResult, 0, len(list))
+	for _, a := range list {
+		hit := model.SearchHit{Snippet: utils.Snippet(strings.Join([]string{a.Nama, a.Jurusan}, " · "), q)}
+		result = append(result, mongo.AlumniSearchResult{Alumni: a, SearchHit: hit})
+	}
+	return result, model.SearchModeBasic, nil
+}
+
+// checkUnique meniru index unik alumni_nim_unique dan alumni_email_unique
+// (email hanya unik jika terisi). self dikecualikan saat update.
+func (r *alumniRepository) checkUnique(self primitive.ObjectID, nim, email string) error {
+	for _, a := range r.s.alumni {
+		if a.ID == self {
+			continue
+		}
+		if a.NIM == nim {
+			return &mongoRepo.DuplicateKeyError{Field: "nim"}
+		}
+		if email != "" && a.Email == email {
+			return &mongoRepo.DuplicateKeyError{Field: "email"}
+		}
+	}
+	return nil
+}
+
+// sortOrder menyusun urutan sort seperti repository Mongo: order "desc" berarti menurun.
+func sortOrder(sortBy, order string) bson.D {
+	dir := 1
+	if order == "desc" {
+		dir = -1
+	}
+	return bson.D{{Key: sortBy, Value: dir}}
+}
@@ -0,0 +1,281 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	appModel "latihan2/app/model"
+	model "latihan2/app/model/mongo"
+	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/utils"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// analyticsRepository menghitung statistik yang sama dengan pipeline
+// agregasi di repository Mongo, langsung di Go.
+type analyticsRepository struct {
+	s *Store
+}
+
+func NewAnalyticsRepository(s *Store) mongoRepo.AnalyticsRepository {
+	return &analyticsRepository{s: s}
+}
+
+// WithContext tidak berpengaruh karena data memory tidak membuat span.
+func (r *analyticsRepository) WithContext(context.Context) mongoRepo.AnalyticsRepository {
+	return r
+}
+
+// alumniPekerjaan adalah satu alumni yang lolos filter beserta pekerjaan
+// pertamanya (nil jika belum bekerja), seperti lookupFirstJob di Mongo.
+type alumniPekerjaan struct {
+	alumni   model.Alumni
+	firstJob *model.Pekerjaan
+}
+
+// alumniDenganFirstJob mengembalikan alumni yang lolos f, diurutkan menurut
+// tahun_lulus lalu jurusan. Pemanggil harus memegang r.s.mu.
+func (r *analyticsRepository) alumniDenganFirstJob(f appModel.AnalyticsFilter) ([]alumniPekerjaan, error) {
+	alumni, err := find(r.s.alumni, utils.AnalyticsToBSON(f, ""), nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(alumni, func(i, j int) bool {
+		if alumni[i].TahunLulus != alumni[j].TahunLulus {
+			return alumni[i].TahunLulus < alumni[j].TahunLulus
+		}
+		return alumni[i].Jurusan < alumni[j].Jurusan
+	})
+
+	first := map[primitive.ObjectID]*model.Pekerjaan{}
+	for _, p := range r.s.pekerjaan {
+		if p.IsDelete {
+			continue
+		}
+		if cur, ok := first[p.AlumniID]; !ok || p.TanggalMulaiKerja.Before(cur.TanggalMulaiKerja) {
+			first[p.AlumniID] = &p
+		}
+	}
+
+	result := make([]alumniPekerjaan, 0, len(alumni))
+	for _, a := range alumni {
+		result = append(result, alumniPekerjaan{alumni: a, firstJob: first[a.ID]})
+	}
+	return result, nil
+}
+
+// pekerjaanDenganAlumni mengembalikan pekerjaan yang belum dihapus milik
+// alumni yang lolos f. Pemanggil harus memegang r.s.mu.
+func (r *analyticsRepository) pekerjaanDenganAlumni(f appModel.AnalyticsFilter) ([]model.Pekerjaan, error) {
+	alumni, err := find(r.s.alumni, utils.AnalyticsToBSON(f, ""), nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[primitive.ObjectID]bool, len(alumni))
+	for _, a := range alumni {
+		ids[a.ID] = true
+	}
+
+	var result []model.Pekerjaan
+	for _, p := range r.s.pekerjaan {
+		if !p.IsDelete && ids[p.AlumniID] {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// bulanKerjaPertama sama dengan bulanKerjaPertamaExpr di repository Mongo.
+func bulanKerjaPertama(a model.Alumni, p *model.Pekerjaan) float64 {
+	bulan := (p.TanggalMulaiKerja.Year()-a.TahunLulus)*12 + int(p.TanggalMulaiKerja.Month()) - 1
+	return float64(max(0, bulan))
+}
+
+// gajiAwal sama dengan gajiAwalExpr di repository Mongo: gaji_min per bulan
+// dalam rupiah, atau false jika tidak diketahui.
+func gajiAwal(p *model.Pekerjaan) (float64, bool) {
+	if p.GajiMin == nil || p.GajiMataUang != utils.MataUangDefault {
+		return 0, false
+	}
+	gaji := float64(*p.GajiMin)
+	if p.GajiPeriode == appModel.PeriodeGajiTahunan {
+		gaji /= 12
+	}
+	return gaji, true
+}
+
+type kelompokLulusan struct {
+	tahunLulus int
+	jurusan    string
+}
+
+func (r *analyticsRepository) GetEmploymentRate(f appModel.AnalyticsFilter) ([]appModel.EmploymentRate, error) {
+	r.s.mu.RLock()
+	list, err := r.alumniDenganFirstJob(f)
+	r.s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []appModel.EmploymentRate{}
+	index := map[kelompokLulusan]int{}
+	for _, item := range list {
+		key := kelompokLulusan{item.alumni.TahunLulus, item.alumni.Jurusan}
+		i, ok := index[key]
+		if !ok {
+			i = len(result)
+			index[key] = i
+			result = append(result, appModel.EmploymentRate{TahunLulus: key.tahunLulus, Jurusan: key.jurusan})
+		}
+		result[i].TotalAlumni++
+		if item.firstJob != nil {
+			result[i].AlumniBekerja++
+		}
+	}
+	for i := range result {
+		result[i].Rate = utils.Persentase(result[i].AlumniBekerja, result[i].TotalAlumni)
+	}
+	return result, nil
+}
+
+func (r *analyticsRepository) GetTimeToFirstJob(f appModel.AnalyticsFilter) ([]appModel.TimeToFirstJob, error) {
+	r.s.mu.RLock()
+	list, err := r.alumniDenganFirstJob(f)
+	r.s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []kelompokLulusan
+	bulan := map[kelompokLulusan][]float64{}
+	for _, item := range list {
+		if item.firstJob == nil {
+			continue
+		}
+		key := kelompokLulusan{item.alumni.TahunLulus, item.alumni.Jurusan}
+		if _, ok := bulan[key]; !ok {
+			keys = append(keys, key)
+		}
+		bulan[key] = append(bulan[key], bulanKerjaPertama(item.alumni, item.firstJob))
+	}
+
+	result := make([]appModel.TimeToFirstJob, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, utils.RingkasBulan(appModel.TimeToFirstJob{
+			TahunLulus: key.tahunLulus,
+			Jurusan:    key.jurusan,
+		}, bulan[key]))
+	}
+	return result, nil
+}
+
+func (r *analyticsRepository) GetDistribusiPekerjaan(dimensi string, f appModel.AnalyticsFilter) ([]appModel.DistributionItem, error) {
+	var field func(p model.Pekerjaan) string
+	switch dimensi {
+	case "bidang_industri":
+		field = func(p model.Pekerjaan) string { return p.BidangIndustri }
+	case "lokasi_kerja":
+		field = func(p model.Pekerjaan) string { return p.LokasiKerja }
+	default:
+		return nil, fmt.Errorf("dimensi distribusi tidak dikenal: %s", dimensi)
+	}
+
+	r.s.mu.RLock()
+	list, err := r.pekerjaanDenganAlumni(f)
+	r.s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []appModel.DistributionItem{}
+	index := map[string]int{}
+	for _, p := range list {
+		label := strings.TrimSpace(field(p))
+		if label == "" {
+			label = appModel.LabelTidakDiketahui
+		}
+		i, ok := index[label]
+		if !ok {
+			i = len(result)
+			index[label] = i
+			result = append(result, appModel.DistributionItem{Label: label})
+		}
+		result[i].Jumlah++
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Jumlah != result[j].Jumlah {
+			return result[i].Jumlah > result[j].Jumlah
+		}
+		return result[i].Label < result[j].Label
+	})
+	for i := range result {
+		result[i].Persentase = utils.Persentase(result[i].Jumlah, len(list))
+	}
+	return result, nil
+}
+
+func (r *analyticsRepository) GetDistribusiGaji(f appModel.AnalyticsFilter) ([]appModel.SalaryBandItem, error) {
+	r.s.mu.RLock()
+	list, err := r.pekerjaanDenganAlumni(f)
+	r.s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[int]int{}
+	for i := range list {
+		band := -1
+		if gaji, ok := gajiAwal(&list[i]); ok {
+			for j, b := range appModel.GajiBands {
+				if gaji >= float64(b.Min) && (b.Max == 0 || gaji < float64(b.Max)) {
+					band = j
+					break
+				}
+			}
+		}
+		counts[band]++
+	}
+	return utils.SusunGajiBand(counts, len(list)), nil
+}
+
+func (r *analyticsRepository) GetCohortTrend(f appModel.AnalyticsFilter) ([]appModel.CohortTrend, error) {
+	r.s.mu.RLock()
+	list, err := r.alumniDenganFirstJob(f)
+	r.s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	type akumulasi struct {
+		bulan, gaji []float64
+	}
+	result := []appModel.CohortTrend{}
+	acc := []akumulasi{}
+	index := map[int]int{}
+	for _, item := range list {
+		i, ok := index[item.alumni.TahunLulus]
+		if !ok {
+			i = len(result)
+			index[item.alumni.TahunLulus] = i
+			result = append(result, appModel.CohortTrend{TahunLulus: item.alumni.TahunLulus})
+			acc = append(acc, akumulasi{})
+		}
+		result[i].TotalAlumni++
+		if item.firstJob == nil {
+			continue
+		}
+		result[i].AlumniBekerja++
+		acc[i].bulan = append(acc[i].bulan, bulanKerjaPertama(item.alumni, item.firstJob))
+		if gaji, ok := gajiAwal(item.firstJob); ok {
+			acc[i].gaji = append(acc[i].gaji, gaji)
+		}
+	}
+	for i := range result {
+		result[i].Rate = utils.Persentase(result[i].AlumniBekerja, result[i].TotalAlumni)
+		result[i].RataRataBulan = utils.Round2(utils.Mean(acc[i].bulan))
+		result[i].RataRataGajiAwal = utils.Round2(utils.Mean(acc[i].gaji))
+	}
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"latihan2/app/model"
+	"latihan2/app/model/mongo"
+	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/helper"
+	"latihan2/utils"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// fileRepository hanya menyimpan metadata; isi file tetap ditulis ke disk oleh FileHandler.
+type fileRepository struct {
+	s *Store
+}
+
+func NewFileRepository(s *Store) mongoRepo.FileRepository {
+	return &fileRepository{s: s}
+}
+
+// WithContext tidak berpengaruh karena data memory tidak membuat span.
+func (r *fileRepository) WithContext(context.Context) mongoRepo.FileRepository {
+	return r
+}
+
+func (r *fileRepository) CreateFile(file *mongo.File) error {
+	file.ID = primitive.NewObjectID()
+	file.UploadedAt = time.Now()
+
+	stored, err := clone(*file)
+	if err != nil {
+		return err
+	}
+	r.s.mu.Lock()
+	r.s.files = append(r.s.files, stored)
+	r.s.mu.Unlock()
+	return nil
+}
+
+func (r *fileRepository) FindAllFiles() ([]mongo.File, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return find(r.s.files, bson.M{}, nil, 0, 0)
+}
+
+func (r *fileRepository) FindFilesCursor(page model.CursorPage) ([]mongo.File, error) {
+	filter, sort, err := utils.ApplyCursorBSON(bson.M{}, page)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return find(r.s.files, filter, sort, 0, page.Limit+1)
+}
+
+func (r *fileRepository) CountFiles() (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return len(r.s.files), nil
+}
+
+func (r *fileRepository) FindFileByID(id string) (*mongo.File, error) {
+	objID, err := helper.ToObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.OpenFileByID(objID)
+}
+
+func (r *fileRepository) OpenFileByID(id primitive.ObjectID) (*mongo.File, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	i := indexByID(r.s.files, id, fileID)
+	if i < 0 {
+		return nil, mongodriver.ErrNoDocuments
+	}
+	f := r.s.files[i]
+	return &f, nil
+}
+
+func (r *fileRepository) DeleteFile(id string) error {
+	objID, err := helper.ToObjectID(id)
+	if err != nil {
+		return err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := indexByID(r.s.files, objID, fileID)
+	if i < 0 {
+		return mongodriver.ErrNoDocuments
+	}
+	r.s.files = append(r.s.files[:i], r.s.files[i+1:]...)
+	return nil
+}
@@ -0,0 +1,288 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	appModel "latihan2/app/model"
+	model "latihan2/app/model/mongo"
+	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/utils"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type pekerjaanRepository struct {
+	s *Store
+}
+
+func NewPekerjaanRepository(s *Store) mongoRepo.PekerjaanRepository {
+	return &pekerjaanRepository{s: s}
+}
+
+// WithContext tidak berpengaruh karena data memory tidak membuat span.
+func (r *pekerjaanRepository) WithContext(context.Context) mongoRepo.PekerjaanRepository {
+	return r
+}
+
+func (r *pekerjaanRepository) GetPekerjaanRepo(search, sortBy, order string, limit, offset int, gaji appModel.GajiFilter, f appModel.Filter) ([]model.Pekerjaan, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return find(r.s.pekerjaan, mongoRepo.PekerjaanListFilter(search, gaji, f), sortOrder(sortBy, order), offset, limit)
+}
+
+func (r *pekerjaanRepository) GetPekerjaanCursorRepo(search string, page appModel.CursorPage, gaji appModel.GajiFilter, f appModel.Filter) ([]model.Pekerjaan, error) {
+	filter, sort, err := utils.ApplyCursorBSON(mongoRepo.PekerjaanListFilter(search, gaji, f), page)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return find(r.s.pekerjaan, filter, sort, 0, page.Limit+1)
+}
+
+func (r *pekerjaanRepository) CountPekerjaanRepo(search string, gaji appModel.GajiFilter, f appModel.Filter) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	list, err := find(r.s.pekerjaan, mongoRepo.PekerjaanListFilter(search, gaji, f), nil, 0, 0)
+	return len(list), err
+}
+
+func (r *pekerjaanRepository) GetPekerjaanByIDRepo(id string) (*model.Pekerjaan, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	i := indexByID(r.s.pekerjaan, objID, pekerjaanID)
+	if i < 0 || r.s.pekerjaan[i].DeletedAt != nil {
+		return nil, mongoRepo.ErrPekerjaanNotFound
+	}
+	p := r.s.pekerjaan[i]
+	return &p, nil
+}
+
+func (r *pekerjaanRepository) GetPekerjaanByAlumniID(alumniID string) ([]model.Pekerjaan, error) {
+	objID, err := primitive.ObjectIDFromHex(alumniID)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return find(r.s.pekerjaan, bson.M{"alumni_id": objID}, nil, 0, 0)
+}
+
+func (r *pekerjaanRepository) CreatePekerjaan(p *model.Pekerjaan) (*model.Pekerjaan, error) {
+	p.ID = primitive.NewObjectID()
+	p.CreatedAt = time.Now()
+	p.IsDelete = false
+	p.Version = 1
+
+	stored, err := clone(*p)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.Lock()
+	r.s.pekerjaan = append(r.s.pekerjaan, stored)
+	r.s.mu.Unlock()
+	return p, nil
+}
+
+func (r *pekerjaanRepository) UpdatePekerjaan(id string, version int, req model.UpdatePekerjaanRequest) (*model.Pekerjaan, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.s.mu.Lock()
+	if i := indexByID(r.s.pekerjaan, objID, pekerjaanID); i >= 0 {
+		p := r.s.pekerjaan[i]
+		if version != mongoRepo.AnyVersion && p.Version != version {
+			r.s.mu.Unlock()
+			return nil, mongoRepo.ErrVersionConflict
+		}
+		p.NamaPerusahaan = req.NamaPerusahaan
+		p.PosisiJabatan = req.PosisiJabatan
+		p.BidangIndustri = req.BidangIndustri
+		p.LokasiKerja = req.LokasiKerja
+		p.GajiRange = req.GajiRange
+		p.TanggalMulaiKerja = time.Time{}
+		if req.TanggalMulaiKerja != nil {
+			p.TanggalMulaiKerja = *req.TanggalMulaiKerja
+		}
+		p.TanggalSelesaiKerja = req.TanggalSelesaiKerja
+		p.StatusPekerjaan = req.StatusPekerjaan
+		p.Deskripsi = req.DeskripsiPekerjaan
+		p.RentangGaji = req.RentangGaji
+		now := time.Now()
+		p.UpdatedAt = &now
+		p.Version++
+
+		if p, err = clone(p); err != nil {
+			r.s.mu.Unlock()
+			return nil, err
+		}
+		r.s.pekerjaan[i] = p
+	}
+	r.s.mu.Unlock()
+
+	return r.GetPekerjaanByIDRepo(id)
+}
+
+// SoftDeletePekerjaan, RestorePekerjaan dan HardDeletePekerjaan memakai aturan
+// kepemilikan yang sama dengan repository Postgres: role "user" hanya boleh
+// mengubah pekerjaan milik alumni dengan user_id miliknya sendiri.
+func (r *pekerjaanRepository) SoftDeletePekerjaan(pekerjaanID, userID, role string) error {
+	objID, err := primitive.ObjectIDFromHex(pekerjaanID)
+	if err != nil {
+		return err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := r.find(objID)
+	if i < 0 || r.s.pekerjaan[i].IsDelete {
+		return mongoRepo.ErrPekerjaanNotFound
+	}
+	if role == "user" && !r.ownedBy(r.s.pekerjaan[i], userID) {
+		return mongoRepo.ErrForbidden
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	r.s.pekerjaan[i].IsDelete = true
+	r.s.pekerjaan[i].DeleteBy = userID
+	r.s.pekerjaan[i].DeletedAt = &now
+	return nil
+}
+
+func (r *pekerjaanRepository) RestorePekerjaan(pekerjaanID, userID, role string) error {
+	objID, err := primitive.ObjectIDFromHex(pekerjaanID)
+	if err != nil {
+		return err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := r.find(objID)
+	if i < 0 || !r.s.pekerjaan[i].IsDelete {
+		return mongoRepo.ErrPekerjaanNotFound
+	}
+	if role == "user" && !r.ownedBy(r.s.pekerjaan[i], userID) {
+		return mongoRepo.ErrForbidden
+	}
+
+	r.s.pekerjaan[i].IsDelete = false
+	r.s.pekerjaan[i].DeleteBy = ""
+	r.s.pekerjaan[i].DeletedAt = nil
+	return nil
+}
+
+func (r *pekerjaanRepository) HardDeletePekerjaan(pekerjaanID, userID, role string) error {
+	objID, err := primitive.ObjectIDFromHex(pekerjaanID)
+	if err != nil {
+		return err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := r.find(objID)
+	if i < 0 || !r.s.pekerjaan[i].IsDelete {
+		return mongoRepo.ErrPekerjaanNotFound
+	}
+	if role == "user" && !r.ownedBy(r.s.pekerjaan[i], userID) {
+		return mongoRepo.ErrPekerjaanNotFound
+	}
+
+	r.s.pekerjaan = append(r.s.pekerjaan[:i], r.s.pekerjaan[i+1:]...)
+	return nil
+}
+
+func (r *pekerjaanRepository) GetTrashPekerjaan(id, role string) ([]model.Pekerjaan, error) {
+	filter := bson.M{"is_delete": true}
+	if id != "" {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID format: %v", err)
+		}
+		filter["_id"] = objID
+	}
+	if role == "user" {
+		return nil, fmt.Errorf("forbidden: user cannot access trash")
+	}
+
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	list, err := find(r.s.pekerjaan, filter, nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("Pekerjaan not found")
+	}
+	return list, nil
+}
+
+func (r *pekerjaanRepository) MigrateGajiRange(dryRun bool) (*appModel.GajiMigrationReport, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	report := &appModel.GajiMigrationReport{DryRun: dryRun, Gagal: []appModel.GajiMigrationFailure{}}
+	for i := range r.s.pekerjaan {
+		p := &r.s.pekerjaan[i]
+		if !p.RentangGaji.IsEmpty() {
+			continue
+		}
+		report.Total++
+		g, err := utils.ParseGajiRange(p.GajiRange)
+		switch {
+		case errors.Is(err, utils.ErrGajiKosong):
+			report.Kosong++
+		case err != nil:
+			report.Gagal = append(report.Gagal, appModel.GajiMigrationFailure{
+				ID: p.ID.Hex(), GajiRange: p.GajiRange, Alasan: err.Error(),
+			})
+		default:
+			report.Berhasil++
+			if !dryRun {
+				p.RentangGaji = g
+			}
+		}
+	}
+	return report, nil
+}
+
+// SearchPekerjaan selalu memakai pencarian basic, lihat SearchAlumni.
+func (r *pekerjaanRepository) SearchPekerjaan(q string, limit, offset int, basic bool) ([]model.PekerjaanSearchResult, string, error) {
+	filter := mongoRepo.PekerjaanListFilter(q, appModel.GajiFilter{}, appModel.Filter{})
+	filter["is_delete"] = bson.M{"$ne": true}
+
+	r.s.mu.RLock()
+	list, err := find(r.s.pekerjaan, filter, bson.D{{Key: "_id", Value: 1}}, offset, limit)
+	r.s.mu.RUnlock()
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := make([]model.PekerjaanSearchResult, 0, len(list))
+	for _, p := range list {
+		hit := appModel.SearchHit{Snippet: utils.Snippet(strings.Join([]string{p.NamaPerusahaan, p.PosisiJabatan, p.Deskripsi}, " · "), q)}
+		result = append(result, model.PekerjaanSearchResult{Pekerjaan: p, SearchHit: hit})
+	}
+	return result, appModel.SearchModeBasic, nil
+}
+
+func (r *pekerjaanRepository) find(id primitive.ObjectID) int {
+	return indexByID(r.s.pekerjaan, id, pekerjaanID)
+}
+
+// ownedBy melaporkan apakah p milik alumni yang user_id-nya userID. Dipanggil
+// dengan lock Store sudah dipegang.
+func (r *pekerjaanRepository) ownedBy(p model.Pekerjaan, userID string) bool {
+	i := indexByID(r.s.alumni, p.AlumniID, alumniID)
+	return i >= 0 && r.s.alumni[i].UserID.Hex() == userID
+}
@@ -0,0 +1,307 @@
+package memory
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Repository memory memakai filter BSON yang sama dengan repository Mongo
+// (mongoRepo.AlumniListFilter, utils.ApplyCursorBSON, dst.) lalu mengevaluasinya
+// sendiri. Operator yang didukung hanya yang dipakai filter tersebut.
+
+// clone menyalin v lewat marshal BSON sehingga tipe dan presisi waktunya
+// (milidetik, UTC) sama dengan dokumen yang dibaca dari Mongo.
+func clone[T any](v T) (T, error) {
+	var out T
+	raw, err := bson.Marshal(v)
+	if err != nil {
+		return out, err
+	}
+	err = bson.Unmarshal(raw, &out)
+	return out, err
+}
+
+func toDoc(v interface{}) (bson.M, error) {
+	raw, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.M
+	err = bson.Unmarshal(raw, &doc)
+	return doc, err
+}
+
+// find mengembalikan dokumen items yang cocok dengan filter, diurutkan menurut
+// order lalu dipotong skip dan limit. limit 0 berarti tanpa batas, seperti di Mongo.
+func find[T any](items []T, filter bson.M, order bson.D, skip, limit int) ([]T, error) {
+	type row struct {
+		item T
+		doc  bson.M
+	}
+	var rows []row
+	for _, item := range items {
+		doc, err := toDoc(item)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := match(doc, filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rows = append(rows, row{item: item, doc: doc})
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, key := range order {
+			c := compare(rows[i].doc[key.Key], rows[j].doc[key.Key])
+			if c == 0 {
+				continue
+			}
+			if dir, _ := key.Value.(int); dir < 0 {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	if skip > len(rows) {
+		skip = len(rows)
+	}
+	rows = rows[skip:]
+	if limit > 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+
+	out := make([]T, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, r.item)
+	}
+	return out, nil
+}
+
+func match(doc bson.M, filter bson.M) (bool, error) {
+	for key, cond := range filter {
+		switch key {
+		case "$and", "$or":
+			subs, err := subFilters(cond)
+			if err != nil {
+				return false, err
+			}
+			matched := false
+			for _, sub := range subs {
+				ok, err := match(doc, sub)
+				if err != nil {
+					return false, err
+				}
+				if key == "$and" && !ok {
+					return false, nil
+				}
+				matched = matched || ok
+			}
+			if key == "$or" && !matched {
+				return false, nil
+			}
+		default:
+			value, exists := doc[key]
+			ok, err := matchField(value, exists, cond)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+func subFilters(cond interface{}) ([]bson.M, error) {
+	switch v := cond.(type) {
+	case []bson.M:
+		return v, nil
+	case []interface{}:
+		subs := make([]bson.M, 0, len(v))
+		for _, s := range v {
+			m, ok := s.(bson.M)
+			if !ok {
+				return nil, fmt.Errorf("memory: kondisi %T tidak didukung", s)
+			}
+			subs = append(subs, m)
+		}
+		return subs, nil
+	}
+	return nil, fmt.Errorf("memory: kondisi %T tidak didukung", cond)
+}
+
+func matchField(value interface{}, exists bool, cond interface{}) (bool, error) {
+	ops, isOps := cond.(bson.M)
+	if !isOps {
+		return equal(value, cond), nil
+	}
+	for op, arg := range ops {
+		var ok bool
+		switch op {
+		case "$eq":
+			ok = equal(value, arg)
+		case "$ne":
+			ok = !equal(value, arg)
+		case "$gt", "$gte", "$lt", "$lte":
+			c, comparable := compareSameType(value, arg)
+			switch op {
+			case "$gt":
+				ok = comparable && c > 0
+			case "$gte":
+				ok = comparable && c >= 0
+			case "$lt":
+				ok = comparable && c < 0
+			case "$lte":
+				ok = comparable && c <= 0
+			}
+		case "$in", "$nin":
+			values, valid := arg.([]interface{})
+			if !valid {
+				return false, fmt.Errorf("memory: argumen %s harus array", op)
+			}
+			found := false
+			for _, v := range values {
+				found = found || equal(value, v)
+			}
+			ok = found == (op == "$in")
+		case "$exists":
+			want, _ := arg.(bool)
+			ok = exists == want
+		case "$regex":
+			s, isString := value.(string)
+			if !isString {
+				return false, nil
+			}
+			options, _ := ops["$options"].(string)
+			re, err := compileRegex(fmt.Sprint(arg), options)
+			if err != nil {
+				return false, err
+			}
+			ok = re.MatchString(s)
+		case "$options":
+			continue
+		default:
+			return false, fmt.Errorf("memory: operator %s tidak didukung", op)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func compileRegex(pattern, options string) (*regexp.Regexp, error) {
+	if strings.Contains(options, "i") {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("memory: regex %q tidak valid: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// equal mengikuti Mongo: {field: nil} cocok dengan field yang kosong maupun tidak ada.
+func equal(a, b interface{}) bool {
+	c, ok := compareSameType(a, b)
+	return ok && c == 0
+}
+
+// normalize menyamakan tipe hasil decode BSON dengan tipe nilai di filter.
+func normalize(v interface{}) interface{} {
+	switch x := v.(type) {
+	case int:
+		return float64(x)
+	case int32:
+		return float64(x)
+	case int64:
+		return float64(x)
+	case *int64:
+		if x == nil {
+			return nil
+		}
+		return float64(*x)
+	case primitive.DateTime:
+		return x.Time()
+	case *time.Time:
+		if x == nil {
+			return nil
+		}
+		return *x
+	}
+	return v
+}
+
+// Urutan antar tipe sama seperti urutan BSON: null < angka < string < ObjectId < bool < tanggal.
+func typeRank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case float64:
+		return 1
+	case string:
+		return 2
+	case primitive.ObjectID:
+		return 3
+	case bool:
+		return 4
+	case time.Time:
+		return 5
+	}
+	return 6
+}
+
+func compareSameType(a, b interface{}) (int, bool) {
+	a, b = normalize(a), normalize(b)
+	if typeRank(a) != typeRank(b) {
+		return 0, false
+	}
+	return compare(a, b), true
+}
+
+func compare(a, b interface{}) int {
+	a, b = normalize(a), normalize(b)
+	if ra, rb := typeRank(a), typeRank(b); ra != rb {
+		return ra - rb
+	}
+	switch x := a.(type) {
+	case nil:
+		return 0
+	case float64:
+		y := b.(float64)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(x, b.(string))
+	case primitive.ObjectID:
+		y := b.(primitive.ObjectID)
+		return bytes.Compare(x[:], y[:])
+	case bool:
+		y := b.(bool)
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		}
+		return 1
+	case time.Time:
+		return x.Compare(b.(time.Time))
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"fmt"
+	"latihan2/app/model/mongo"
+	"latihan2/utils"
+	"time"
+)
+
+// Akun bawaan mode demo. Hanya untuk pengembangan lokal dan CI.
+const (
+	DemoAdminUsername = "admin"
+	DemoAdminPassword = "admin123"
+	DemoUserUsername  = "alumni"
+	DemoUserPassword  = "alumni123"
+)
+
+// Seed mengisi s dengan data contoh: satu admin, satu user yang terhubung ke
+// alumni pertama, beberapa alumni dan pekerjaan (termasuk satu di trash dan
+// satu yang gaji_range-nya belum dimigrasi ke field gaji terstruktur).
+func Seed(s *Store) error {
+	users := NewUserRepository(s)
+	alumniRepo := NewAlumniRepository(s)
+	pekerjaanRepo := NewPekerjaanRepository(s)
+
+	newUser := func(username, password, role string) (*mongo.User, error) {
+		hash, err := utils.HashPassword(password)
+		if err != nil {
+			return nil, err
+		}
+		return users.CreateUser(&mongo.User{
+			Username: username,
+			Email:    username + "@demo.local",
+			Password: hash,
+			Role:     role,
+		})
+	}
+	if _, err := newUser(DemoAdminUsername, DemoAdminPassword, "admin"); err != nil {
+		return fmt.Errorf("seed user admin: %w", err)
+	}
+	owner, err := newUser(DemoUserUsername, DemoUserPassword, "user")
+	if err != nil {
+		return fmt.Errorf("seed user alumni: %w", err)
+	}
+
+	alumniSeed := []mongo.Alumni{
+		{UserID: owner.ID, NIM: "2019001", Nama: "Budi Santoso", Jurusan: "Teknik Informatika", Angkatan: 2019, TahunLulus: 2023, Email: "budi@demo.local"},
+		{NIM: "2019002", Nama: "Siti Rahmawati", Jurusan: "Sistem Informasi", Angkatan: 2019, TahunLulus: 2023, Email: "siti@demo.local"},
+		{NIM: "2018014", Nama: "Andi Pratama", Jurusan: "Teknik Informatika", Angkatan: 2018, TahunLulus: 2022, Email: "andi@demo.local"},
+		{NIM: "2020007", Nama: "Dewi Lestari", Jurusan: "Manajemen Informatika", Angkatan: 2020, TahunLulus: 2024, Email: "dewi@demo.local"},
+		{NIM: "2017021", Nama: "Rizky Hidayat", Jurusan: "Sistem Informasi", Angkatan: 2017, TahunLulus: 2021, Email: ""},
+	}
+	for i := range alumniSeed {
+		if _, err := alumniRepo.CreateAlumni(&alumniSeed[i]); err != nil {
+			return fmt.Errorf("seed alumni %s: %w", alumniSeed[i].NIM, err)
+		}
+	}
+
+	tanggal := func(s string) time.Time {
+		t, _ := time.Parse("2006-01-02", s)
+		return t
+	}
+	pekerjaanSeed := []struct {
+		alumni       int
+		p            mongo.Pekerjaan
+		trash        bool
+		belumMigrasi bool
+	}{
+		{alumni: 0, p: mongo.Pekerjaan{NamaPerusahaan: "PT Teknologi Nusantara", PosisiJabatan: "Backend Engineer", BidangIndustri: "Teknologi", LokasiKerja: "Jakarta", GajiRange: "Rp 8.000.000 - 12.000.000", TanggalMulaiKerja: tanggal("2023-09-01"), StatusPekerjaan: "aktif", Deskripsi: "Mengembangkan API dengan Go"}},
+		{alumni: 0, p: mongo.Pekerjaan{NamaPerusahaan: "CV Kreatif Digital", PosisiJabatan: "Web Developer", BidangIndustri: "Teknologi", LokasiKerja: "Bandung", GajiRange: "4-6 juta", TanggalMulaiKerja: tanggal("2022-08-01"), StatusPekerjaan: "selesai", Deskripsi: "Magang lalu kontrak"}, trash: true},
+		{alumni: 1, p: mongo.Pekerjaan{NamaPerusahaan: "Bank Sejahtera", PosisiJabatan: "Business Analyst", BidangIndustri: "Perbankan", LokasiKerja: "Surabaya", GajiRange: "Rp 10 jt", TanggalMulaiKerja: tanggal("2023-11-15"), StatusPekerjaan: "aktif", Deskripsi: "Analisis kebutuhan sistem core banking"}},
+		{alumni: 2, p: mongo.Pekerjaan{NamaPerusahaan: "Global Soft Pte Ltd", PosisiJabatan: "Software Engineer", BidangIndustri: "Teknologi", LokasiKerja: "Singapura", GajiRange: "SGD 5,000 - 6,500", TanggalMulaiKerja: tanggal("2022-10-01"), StatusPekerjaan: "aktif", Deskripsi: "Tim platform pembayaran"}},
+		{alumni: 3, p: mongo.Pekerjaan{NamaPerusahaan: "Startup Edukasi", PosisiJabatan: "Frontend Developer", BidangIndustri: "Pendidikan", LokasiKerja: "Yogyakarta", GajiRange: "> 7jt", TanggalMulaiKerja: tanggal("2024-07-01"), StatusPekerjaan: "aktif", Deskripsi: "Membangun aplikasi belajar daring"}},
+		// gaji_min/gaji_max kosong sampai POST /pekerjaan/migrasi-gaji dijalankan.
+		{alumni: 4, p: mongo.Pekerjaan{NamaPerusahaan: "Konsultan Data Indonesia", PosisiJabatan: "Data Analyst", BidangIndustri: "Konsultasi", LokasiKerja: "Jakarta", GajiRange: "9-11 juta", TanggalMulaiKerja: tanggal("2021-12-01"), StatusPekerjaan: "aktif", Deskripsi: "Dashboard dan pelaporan klien"}, belumMigrasi: true},
+	}
+	for _, seed := range pekerjaanSeed {
+		p := seed.p
+		p.AlumniID = alumniSeed[seed.alumni].ID
+		if !seed.belumMigrasi {
+			if g, err := utils.ParseGajiRange(p.GajiRange); err == nil {
+				p.RentangGaji = g
+			}
+		}
+		created, err := pekerjaanRepo.CreatePekerjaan(&p)
+		if err != nil {
+			return fmt.Errorf("seed pekerjaan %s: %w", p.NamaPerusahaan, err)
+		}
+		if seed.trash {
+			if err := pekerjaanRepo.SoftDeletePekerjaan(created.ID.Hex(), owner.ID.Hex(), "user"); err != nil {
+				return fmt.Errorf("seed trash pekerjaan: %w", err)
+			}
+		}
+	}
+	return nil
+}
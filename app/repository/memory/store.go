@@ -0,0 +1,43 @@
+// Package memory berisi implementasi in-memory dari interface repository Mongo
+// (mongoRepo.AlumniRepository, PekerjaanRepository, UserRepository,
+// FileRepository dan AnalyticsRepository). Dipakai oleh mode --demo dan oleh
+// test yang tidak ingin bergantung pada database sungguhan. Data hilang saat
+// proses berhenti.
+package memory
+
+import (
+	"latihan2/app/model/mongo"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Store menyimpan semua koleksi di memori. Satu Store dipakai bersama oleh
+// semua repository agar aturan lintas koleksi (mis. kepemilikan pekerjaan
+// lewat alumni.user_id) bisa diperiksa.
+type Store struct {
+	mu        sync.RWMutex
+	users     []mongo.User
+	alumni    []mongo.Alumni
+	pekerjaan []mongo.Pekerjaan
+	files     []mongo.File
+}
+
+func NewStore() *Store {
+	return &Store{}
+}
+
+// indexByID mengembalikan posisi dokumen dengan _id id, atau -1 jika tidak ada.
+func indexByID[T any](items []T, id primitive.ObjectID, idOf func(T) primitive.ObjectID) int {
+	for i, item := range items {
+		if idOf(item) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func userID(u mongo.User) primitive.ObjectID           { return u.ID }
+func alumniID(a mongo.Alumni) primitive.ObjectID       { return a.ID }
+func pekerjaanID(p mongo.Pekerjaan) primitive.ObjectID { return p.ID }
+func fileID(f mongo.File) primitive.ObjectID           { return f.ID }
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"latihan2/app/model"
+	mongoModel "latihan2/app/model/mongo"
+	"latihan2/app/repository/memory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// analyticsStore berisi alumni TI 2020 (satu bekerja, satu belum), alumni SI
+// 2021 (satu bekerja dengan gaji USD, satu hanya punya pekerjaan yang sudah
+// dihapus) dan alumni TI 2020 yang sudah di-soft-delete.
+func analyticsStore(t *testing.T) *memory.Store {
+	t.Helper()
+	store := memory.NewStore()
+	alumni := memory.NewAlumniRepository(store)
+	pekerjaan := memory.NewPekerjaanRepository(store)
+
+	newAlumni := func(nim, jurusan string, lulus int) primitive.ObjectID {
+		a, err := alumni.CreateAlumni(&mongoModel.Alumni{NIM: nim, Nama: nim, Jurusan: jurusan, TahunLulus: lulus, Email: nim + "@demo.local"})
+		require.NoError(t, err)
+		return a.ID
+	}
+	newPekerjaan := func(alumniID primitive.ObjectID, mulai string, bidang, lokasi string, gaji int64, mataUang, periode string) primitive.ObjectID {
+		tgl, err := time.Parse(time.DateOnly, mulai)
+		require.NoError(t, err)
+		p, err := pekerjaan.CreatePekerjaan(&mongoModel.Pekerjaan{
+			AlumniID: alumniID, NamaPerusahaan: "PT " + bidang, PosisiJabatan: "Staff", StatusPekerjaan: "aktif",
+			BidangIndustri: bidang, LokasiKerja: lokasi, TanggalMulaiKerja: tgl,
+			RentangGaji: model.RentangGaji{GajiMin: &gaji, GajiMataUang: mataUang, GajiPeriode: periode},
+		})
+		require.NoError(t, err)
+		return p.ID
+	}
+
+	budi := newAlumni("2016001", "TI", 2020)
+	newPekerjaan(budi, "2022-01-10", "Teknologi", " ", 120000000, "IDR", model.PeriodeGajiTahunan)
+	newPekerjaan(budi, "2020-07-01", "Teknologi", "Jakarta", 6000000, "IDR", model.PeriodeGajiBulanan)
+	newAlumni("2016002", "TI", 2020)
+
+	sari := newAlumni("2017001", "SI", 2021)
+	newPekerjaan(sari, "2021-03-15", "Keuangan", "Bandung", 3000, "USD", model.PeriodeGajiBulanan)
+	dodi := newAlumni("2017002", "SI", 2021)
+	hapus := newPekerjaan(dodi, "2021-01-01", "Teknologi", "Jakarta", 9000000, "IDR", model.PeriodeGajiBulanan)
+	require.NoError(t, pekerjaan.SoftDeletePekerjaan(hapus.Hex(), "", "admin"))
+
+	terhapus := newAlumni("2016003", "TI", 2020)
+	newPekerjaan(terhapus, "2020-01-01", "Teknologi", "Jakarta", 20000000, "IDR", model.PeriodeGajiBulanan)
+	require.NoError(t, alumni.SoftDeleteAlumni(terhapus.Hex()))
+	return store
+}
+
+func TestAnalyticsEmploymentRateAndTimeToFirstJob(t *testing.T) {
+	repo := memory.NewAnalyticsRepository(analyticsStore(t))
+
+	rate, err := repo.GetEmploymentRate(model.AnalyticsFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []model.EmploymentRate{
+		{TahunLulus: 2020, Jurusan: "TI", TotalAlumni: 2, AlumniBekerja: 1, Rate: 50},
+		{TahunLulus: 2021, Jurusan: "SI", TotalAlumni: 2, AlumniBekerja: 1, Rate: 50},
+	}, rate)
+
+	rate, err = repo.GetEmploymentRate(model.AnalyticsFilter{Jurusan: "SI"})
+	require.NoError(t, err)
+	require.Len(t, rate, 1)
+	assert.Equal(t, 2021, rate[0].TahunLulus)
+
+	waktu, err := repo.GetTimeToFirstJob(model.AnalyticsFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []model.TimeToFirstJob{
+		{TahunLulus: 2020, Jurusan: "TI", Sampel: 1, RataRata: 6, Median: 6, Min: 6, Max: 6},
+		{TahunLulus: 2021, Jurusan: "SI", Sampel: 1, RataRata: 2, Median: 2, Min: 2, Max: 2},
+	}, waktu)
+}
+
+func TestAnalyticsDistribusi(t *testing.T) {
+	repo := memory.NewAnalyticsRepository(analyticsStore(t))
+
+	bidang, err := repo.GetDistribusiPekerjaan("bidang_industri", model.AnalyticsFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []model.DistributionItem{
+		{Label: "Teknologi", Jumlah: 2, Persentase: 66.67},
+		{Label: "Keuangan", Jumlah: 1, Persentase: 33.33},
+	}, bidang)
+
+	lokasi, err := repo.GetDistribusiPekerjaan("lokasi_kerja", model.AnalyticsFilter{TahunAkhir: 2020})
+	require.NoError(t, err)
+	assert.Equal(t, []model.DistributionItem{
+		{Label: "Jakarta", Jumlah: 1, Persentase: 50},
+		{Label: model.LabelTidakDiketahui, Jumlah: 1, Persentase: 50},
+	}, lokasi)
+
+	_, err = repo.GetDistribusiPekerjaan("posisi_jabatan", model.AnalyticsFilter{})
+	assert.Error(t, err)
+
+	gaji, err := repo.GetDistribusiGaji(model.AnalyticsFilter{})
+	require.NoError(t, err)
+	jumlah := map[string]int{}
+	for _, band := range gaji {
+		jumlah[band.Label] = band.Jumlah
+	}
+	assert.Equal(t, map[string]int{
+		"< 3 juta": 0, "3 - 5 juta": 0, "5 - 8 juta": 1, "8 - 12 juta": 1, ">= 12 juta": 0,
+		model.LabelTidakDiketahui: 1,
+	}, jumlah, "gaji tahunan dibagi 12, gaji USD tidak diketahui")
+}
+
+func TestAnalyticsCohortTrend(t *testing.T) {
+	repo := memory.NewAnalyticsRepository(analyticsStore(t))
+
+	trend, err := repo.GetCohortTrend(model.AnalyticsFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []model.CohortTrend{
+		{TahunLulus: 2020, TotalAlumni: 2, AlumniBekerja: 1, Rate: 50, RataRataBulan: 6, RataRataGajiAwal: 6000000},
+		{TahunLulus: 2021, TotalAlumni: 2, AlumniBekerja: 1, Rate: 50, RataRataBulan: 2},
+	}, trend)
+}
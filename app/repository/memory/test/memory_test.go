@@ -0,0 +1,141 @@
+package test
+
+import (
+	"latihan2/app/model"
+	mongoModel "latihan2/app/model/mongo"
+	"latihan2/app/repository/memory"
+	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seededStore(t *testing.T) *memory.Store {
+	t.Helper()
+	store := memory.NewStore()
+	require.NoError(t, memory.Seed(store))
+	return store
+}
+
+func TestAlumniSearchSortAndFilter(t *testing.T) {
+	repo := memory.NewAlumniRepository(seededStore(t))
+
+	list, err := repo.GetAlumniRepo("informatika", "nama", "asc", 10, 0, model.Filter{})
+	require.NoError(t, err)
+	assert.Empty(t, list, "search hanya mencari nim, nama dan email")
+
+	list, err = repo.GetAlumniRepo("", "tahun_lulus", "desc", 2, 1, model.Filter{})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.GreaterOrEqual(t, list[0].TahunLulus, list[1].TahunLulus)
+
+	f, err := utils.ParseFilter("jurusan:eq:Teknik Informatika,tahun_lulus:gte:2023", model.AlumniFilterFields)
+	require.NoError(t, err)
+	list, err = repo.GetAlumniRepo("", "nama", "asc", 10, 0, f)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "Budi Santoso", list[0].Nama)
+
+	total, err := repo.CountAlumniRepo("", model.Filter{})
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+}
+
+func TestAlumniCursorVisitsEveryRowOnce(t *testing.T) {
+	repo := memory.NewAlumniRepository(seededStore(t))
+	sortable := map[string]bool{"_id": true, "nama": true}
+
+	seen := map[string]bool{}
+	page, err := utils.ParseCursorPage("", "nama", "desc", 2, sortable)
+	require.NoError(t, err)
+	for {
+		rows, err := repo.GetAlumniCursorRepo("", page, model.Filter{})
+		require.NoError(t, err)
+		items, next, _ := utils.CursorResult(rows, page)
+		for _, a := range items {
+			assert.False(t, seen[a.NIM], "alumni %s muncul dua kali", a.NIM)
+			seen[a.NIM] = true
+		}
+		if next == "" {
+			break
+		}
+		page, err = utils.ParseCursorPage(next, "", "", 2, sortable)
+		require.NoError(t, err)
+	}
+	assert.Len(t, seen, 5)
+}
+
+func TestAlumniSoftDeleteAndDuplicate(t *testing.T) {
+	repo := memory.NewAlumniRepository(seededStore(t))
+
+	_, err := repo.CreateAlumni(&mongoModel.Alumni{NIM: "2019001", Nama: "Duplikat"})
+	assert.ErrorIs(t, err, mongoRepo.ErrDuplikat)
+
+	a, err := repo.CreateAlumni(&mongoModel.Alumni{NIM: "2024001", Nama: "Baru", Email: "baru@demo.local"})
+	require.NoError(t, err)
+	require.NoError(t, repo.SoftDeleteAlumni(a.ID.Hex()))
+
+	_, err = repo.GetAlumniByID(a.ID.Hex())
+	assert.Error(t, err)
+	assert.Error(t, repo.SoftDeleteAlumni(a.ID.Hex()))
+}
+
+func TestPekerjaanOwnershipAndTrash(t *testing.T) {
+	store := seededStore(t)
+	users := memory.NewUserRepository(store)
+	repo := memory.NewPekerjaanRepository(store)
+
+	owner, err := users.GetUserByUsername(memory.DemoUserUsername)
+	require.NoError(t, err)
+	admin, err := users.GetUserByUsername(memory.DemoAdminUsername)
+	require.NoError(t, err)
+
+	trash, err := repo.GetTrashPekerjaan("", "admin")
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	trashID := trash[0].ID.Hex()
+
+	_, err = repo.GetTrashPekerjaan("", "user")
+	assert.Error(t, err)
+
+	assert.ErrorIs(t, repo.RestorePekerjaan(trashID, admin.ID.Hex(), "user"), mongoRepo.ErrForbidden)
+	require.NoError(t, repo.RestorePekerjaan(trashID, owner.ID.Hex(), "user"))
+	_, err = repo.GetPekerjaanByIDRepo(trashID)
+	assert.NoError(t, err)
+
+	list, err := repo.GetPekerjaanRepo("Bank", "nama_perusahaan", "asc", 10, 0, model.GajiFilter{}, model.Filter{})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.ErrorIs(t, repo.SoftDeletePekerjaan(list[0].ID.Hex(), owner.ID.Hex(), "user"), mongoRepo.ErrForbidden)
+	require.NoError(t, repo.SoftDeletePekerjaan(list[0].ID.Hex(), admin.ID.Hex(), "admin"))
+	assert.ErrorIs(t, repo.HardDeletePekerjaan(list[0].ID.Hex(), owner.ID.Hex(), "user"), mongoRepo.ErrPekerjaanNotFound)
+	require.NoError(t, repo.HardDeletePekerjaan(list[0].ID.Hex(), admin.ID.Hex(), "admin"))
+}
+
+func TestPekerjaanGajiFilterAndMigration(t *testing.T) {
+	repo := memory.NewPekerjaanRepository(seededStore(t))
+
+	min := int64(8000000)
+	list, err := repo.GetPekerjaanRepo("", "gaji_min", "asc", 10, 0, model.GajiFilter{Min: &min, MataUang: "IDR"}, model.Filter{})
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	for _, p := range list {
+		require.NotNil(t, p.GajiMin)
+		assert.GreaterOrEqual(t, *p.GajiMin, min)
+	}
+
+	report, err := repo.MigrateGajiRange(false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Berhasil)
+
+	report, err = repo.MigrateGajiRange(false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Total)
+
+	result, mode, err := repo.SearchPekerjaan("engineer", 10, 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, model.SearchModeBasic, mode)
+	assert.Len(t, result, 2)
+}
@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"context"
+	"latihan2/app/model"
+	"latihan2/app/model/mongo"
+	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/helper"
+	"latihan2/utils"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// UserRepository menambahkan CreateUser ke mongoRepo.UserRepository karena
+// data user di mode demo diisi lewat kode, bukan lewat API.
+type UserRepository interface {
+	mongoRepo.UserRepository
+	CreateUser(user *mongo.User) (*mongo.User, error)
+}
+
+type userRepository struct {
+	s *Store
+}
+
+func NewUserRepository(s *Store) UserRepository {
+	return &userRepository{s: s}
+}
+
+// WithContext tidak berpengaruh karena data memory tidak membuat span.
+func (r *userRepository) WithContext(context.Context) mongoRepo.UserRepository {
+	return r
+}
+
+func (r *userRepository) GetUsersRepo(search, sortBy, order string, limit, offset int, f model.Filter) ([]mongo.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return find(r.s.users, mongoRepo.UsersListFilter(search, f), sortOrder(sortBy, order), offset, limit)
+}
+
+func (r *userRepository) GetUsersCursorRepo(search string, page model.CursorPage, f model.Filter) ([]mongo.User, error) {
+	filter, sort, err := utils.ApplyCursorBSON(mongoRepo.UsersListFilter(search, f), page)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return find(r.s.users, filter, sort, 0, page.Limit+1)
+}
+
+func (r *userRepository) CountUsersRepo(search string, f model.Filter) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	list, err := find(r.s.users, mongoRepo.UsersListFilter(search, f), nil, 0, 0)
+	return len(list), err
+}
+
+func (r *userRepository) GetUserByID(id string) (*mongo.User, error) {
+	objID, err := helper.ToObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	i := indexByID(r.s.users, objID, userID)
+	if i < 0 || r.s.users[i].DeletedAt != nil {
+		return nil, mongodriver.ErrNoDocuments
+	}
+	u := r.s.users[i]
+	return &u, nil
+}
+
+// GetUserByUsername mencocokkan username atau email, sama seperti login Mongo.
+func (r *userRepository) GetUserByUsername(username string) (*mongo.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, u := range r.s.users {
+		if u.Username == username || u.Email == username {
+			return &u, nil
+		}
+	}
+	return nil, mongodriver.ErrNoDocuments
+}
+
+// CreateUser menyimpan user baru. Password harus sudah di-hash dengan utils.HashPassword.
+func (r *userRepository) CreateUser(user *mongo.User) (*mongo.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, u := range r.s.users {
+		if u.Username == user.Username {
+			return nil, &mongoRepo.DuplicateKeyError{Field: "username"}
+		}
+		if user.Email != "" && u.Email == user.Email {
+			return nil, &mongoRepo.DuplicateKeyError{Field: "email"}
+		}
+	}
+	user.ID = primitive.NewObjectID()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	stored, err := clone(*user)
+	if err != nil {
+		return nil, err
+	}
+	r.s.users = append(r.s.users, stored)
+	return user, nil
+}
+
+func (r *userRepository) UpdateUser(id string, req model.UpdateUserRequest) (*mongo.User, error) {
+	objID, err := helper.ToObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := indexByID(r.s.users, objID, userID)
+	if i < 0 || r.s.users[i].DeletedAt != nil {
+		return nil, mongodriver.ErrNoDocuments
+	}
+	for _, u := range r.s.users {
+		if u.ID == objID {
+			continue
+		}
+		if u.Username == req.Username {
+			return nil, &mongoRepo.DuplicateKeyError{Field: "username"}
+		}
+		if req.Email != "" && u.Email == req.Email {
+			return nil, &mongoRepo.DuplicateKeyError{Field: "email"}
+		}
+	}
+
+	u := r.s.users[i]
+	u.Username = req.Username
+	u.Email = req.Email
+	u.Role = req.Role
+	u.UpdatedAt = time.Now()
+	r.s.users[i] = u
+	return &u, nil
+}
+
+func (r *userRepository) UpdatePassword(id string, passwordHash string) error {
+	objID, err := helper.ToObjectID(id)
+	if err != nil {
+		return err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := indexByID(r.s.users, objID, userID)
+	if i < 0 || r.s.users[i].DeletedAt != nil {
+		return mongodriver.ErrNoDocuments
+	}
+	r.s.users[i].Password = passwordHash
+	r.s.users[i].UpdatedAt = time.Now()
+	return nil
+}
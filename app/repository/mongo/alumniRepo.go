@@ -5,6 +5,7 @@ import (
 	"latihan2/app/model" // DTO Bersama (CreateAlumniRequest)
 	"latihan2/app/model/mongo"
 	"latihan2/helper"
+	"latihan2/utils"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -13,13 +14,40 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func GetAlumniRepo(search, sortBy, order string, limit, offset int) ([]mongo.Alumni, error) {
-	collection := helper.GetCollection("alumni")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// AlumniRepository adalah akses data koleksi alumni. Service menerima interface
+// ini lewat constructor sehingga bisa diuji dengan implementasi palsu.
+type AlumniRepository interface {
+	GetAlumniRepo(search, sortBy, order string, limit, offset int, f model.Filter) ([]mongo.Alumni, error)
+	GetAlumniCursorRepo(search string, page model.CursorPage, f model.Filter) ([]mongo.Alumni, error)
+	CountAlumniRepo(search string, f model.Filter) (int, error)
+	GetAlumniByID(id string) (*mongo.Alumni, error)
+	CreateAlumni(alumni *mongo.Alumni) (*mongo.Alumni, error)
+	UpdateAlumni(id string, version int, req model.UpdateAlumniRequest) (*mongo.Alumni, error)
+	SoftDeleteAlumni(id string) error
+	SearchAlumni(q string, limit, offset int, basic bool) ([]mongo.AlumniSearchResult, string, error)
+	// WithContext mengembalikan repository yang menjalankan operasi dengan ctx,
+	// mis. c.UserContext() agar command Mongo menjadi span anak dari span request.
+	WithContext(ctx context.Context) AlumniRepository
+}
 
-	var alumniList []mongo.Alumni
+type alumniRepository struct {
+	db  *mongodriver.Database
+	ctx context.Context
+}
+
+func NewAlumniRepository(db *mongodriver.Database) AlumniRepository {
+	return &alumniRepository{db: db, ctx: context.Background()}
+}
 
+func (r *alumniRepository) WithContext(ctx context.Context) AlumniRepository {
+	c := *r
+	c.ctx = ctx
+	return &c
+}
+
+// AlumniListFilter dipakai bersama oleh GetAlumniRepo, GetAlumniCursorRepo dan CountAlumniRepo,
+// juga oleh repository memory agar hasil pencarian dan filternya sama persis.
+func AlumniListFilter(search string, f model.Filter) bson.M {
 	filter := bson.M{
 		"$or": []bson.M{
 			{"nim": bson.M{"$regex": search, "$options": "i"}},
@@ -28,6 +56,17 @@ func GetAlumniRepo(search, sortBy, order string, limit, offset int) ([]mongo.Alu
 		},
 		"deleted_at": bson.M{"$exists": false}, // Filter soft delete
 	}
+	return utils.ApplyFilterBSON(filter, f)
+}
+
+func (r *alumniRepository) GetAlumniRepo(search, sortBy, order string, limit, offset int, f model.Filter) ([]mongo.Alumni, error) {
+	collection := r.db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
+	defer cancel()
+
+	var alumniList []mongo.Alumni
+
+	filter := AlumniListFilter(search, f)
 
 	orderVal := 1
 	if order == "desc" {
@@ -50,20 +89,39 @@ func GetAlumniRepo(search, sortBy, order string, limit, offset int) ([]mongo.Alu
 	return alumniList, nil
 }
 
-// CountAlumniRepo dipanggil oleh service.GetAllAlumni
-func CountAlumniRepo(search string) (int, error) {
-	collection := helper.GetCollection("alumni")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// GetAlumniCursorRepo mengambil satu halaman alumni dengan pagination keyset.
+// Hasilnya berisi sampai page.Limit+1 dokumen; gunakan utils.CursorResult untuk memotongnya.
+func (r *alumniRepository) GetAlumniCursorRepo(search string, page model.CursorPage, f model.Filter) ([]mongo.Alumni, error) {
+	collection := r.db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
-	filter := bson.M{
-		"$or": []bson.M{
-			{"nim": bson.M{"$regex": search, "$options": "i"}},
-			{"nama": bson.M{"$regex": search, "$options": "i"}},
-			{"email": bson.M{"$regex": search, "$options": "i"}},
-		},
-		"deleted_at": bson.M{"$exists": false}, // Filter soft delete
+	filter, sort, err := utils.ApplyCursorBSON(AlumniListFilter(search, f), page)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().SetSort(sort).SetLimit(int64(page.Limit + 1))
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
 	}
+	defer cursor.Close(ctx)
+
+	var alumniList []mongo.Alumni
+	if err = cursor.All(ctx, &alumniList); err != nil {
+		return nil, err
+	}
+	return alumniList, nil
+}
+
+// CountAlumniRepo dipanggil oleh service.GetAllAlumni
+func (r *alumniRepository) CountAlumniRepo(search string, f model.Filter) (int, error) {
+	collection := r.db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
+	defer cancel()
+
+	filter := AlumniListFilter(search, f)
 
 	count, err := collection.CountDocuments(ctx, filter)
 	if err != nil {
@@ -73,9 +131,9 @@ func CountAlumniRepo(search string) (int, error) {
 }
 
 // GetAlumniByID dipanggil oleh service.GetAlumniByID
-func GetAlumniByID(id string) (*mongo.Alumni, error) {
-	collection := helper.GetCollection("alumni")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *alumniRepository) GetAlumniByID(id string) (*mongo.Alumni, error) {
+	collection := r.db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
 	objID, err := helper.ToObjectID(id)
@@ -93,26 +151,28 @@ func GetAlumniByID(id string) (*mongo.Alumni, error) {
 }
 
 // CreateAlumni dipanggil oleh service.CreateAlumni
-func CreateAlumni(alumni *mongo.Alumni) (*mongo.Alumni, error) {
-	collection := helper.GetCollection("alumni")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *alumniRepository) CreateAlumni(alumni *mongo.Alumni) (*mongo.Alumni, error) {
+	collection := r.db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
 	alumni.ID = primitive.NewObjectID()
 	alumni.CreatedAt = time.Now()
 	alumni.UpdatedAt = time.Now()
+	alumni.Version = 1
 
 	_, err := collection.InsertOne(ctx, alumni)
 	if err != nil {
-		return nil, err
+		return nil, WrapDuplicate(err)
 	}
 	return alumni, nil
 }
 
-// UpdateAlumni dipanggil oleh service.UpdateAlumni
-func UpdateAlumni(id string, req model.UpdateAlumniRequest) (*mongo.Alumni, error) {
-	collection := helper.GetCollection("alumni")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// UpdateAlumni dipanggil oleh service.UpdateAlumni. Update hanya berhasil jika
+// version masih sama dengan di database (kecuali AnyVersion), lalu menaikkan version.
+func (r *alumniRepository) UpdateAlumni(id string, version int, req model.UpdateAlumniRequest) (*mongo.Alumni, error) {
+	collection := r.db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
 	objID, err := helper.ToObjectID(id)
@@ -131,24 +191,25 @@ func UpdateAlumni(id string, req model.UpdateAlumniRequest) (*mongo.Alumni, erro
 			"alamat":      req.Alamat,
 			"updated_at":  time.Now(),
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
 	filter := bson.M{"_id": objID, "deleted_at": bson.M{"$exists": false}}
-	result, err := collection.UpdateOne(ctx, filter, update)
+	result, err := collection.UpdateOne(ctx, withVersion(filter, version), update)
 	if err != nil {
-		return nil, err
+		return nil, WrapDuplicate(err)
 	}
-	if result.MatchedCount == 0 {
-		return nil, mongodriver.ErrNoDocuments
+	if err := versionChecked(ctx, collection, result, filter, mongodriver.ErrNoDocuments); err != nil {
+		return nil, err
 	}
 
-	return GetAlumniByID(id)
+	return r.GetAlumniByID(id)
 }
 
 // SoftDeleteAlumni dipanggil oleh service.SoftDeleteAlumni
-func SoftDeleteAlumni(id string) error {
-	collection := helper.GetCollection("alumni")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *alumniRepository) SoftDeleteAlumni(id string) error {
+	collection := r.db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
 	objID, err := helper.ToObjectID(id)
@@ -0,0 +1,317 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"latihan2/app/model"
+	"latihan2/utils"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// AnalyticsRepository menghitung statistik alumni untuk endpoint analytics,
+// dengan method dan tipe hasil yang sama seperti versi Postgres.
+type AnalyticsRepository interface {
+	GetEmploymentRate(f model.AnalyticsFilter) ([]model.EmploymentRate, error)
+	GetTimeToFirstJob(f model.AnalyticsFilter) ([]model.TimeToFirstJob, error)
+	GetDistribusiPekerjaan(dimensi string, f model.AnalyticsFilter) ([]model.DistributionItem, error)
+	GetDistribusiGaji(f model.AnalyticsFilter) ([]model.SalaryBandItem, error)
+	GetCohortTrend(f model.AnalyticsFilter) ([]model.CohortTrend, error)
+	// WithContext mengembalikan repository yang menjalankan operasi dengan ctx,
+	// mis. c.UserContext() agar command Mongo menjadi span anak dari span request.
+	WithContext(ctx context.Context) AnalyticsRepository
+}
+
+type analyticsRepository struct {
+	db  *mongodriver.Database
+	ctx context.Context
+}
+
+func NewAnalyticsRepository(db *mongodriver.Database) AnalyticsRepository {
+	return &analyticsRepository{db: db, ctx: context.Background()}
+}
+
+func (r *analyticsRepository) WithContext(ctx context.Context) AnalyticsRepository {
+	c := *r
+	c.ctx = ctx
+	return &c
+}
+
+// gajiAwalExpr adalah gaji_min per bulan dalam rupiah, sama seperti versi Postgres.
+// prefix adalah path dokumen pekerjaan, mis. "$" atau "$first_job.".
+func gajiAwalExpr(prefix string) bson.M {
+	gajiMin := prefix + "gaji_min"
+	return bson.M{"$cond": bson.A{
+		bson.M{"$eq": bson.A{prefix + "gaji_mata_uang", "IDR"}},
+		bson.M{"$cond": bson.A{
+			bson.M{"$eq": bson.A{prefix + "gaji_periode", model.PeriodeGajiTahunan}},
+			bson.M{"$divide": bson.A{gajiMin, 12}},
+			bson.M{"$ifNull": bson.A{gajiMin, nil}},
+		}},
+		nil,
+	}}
+}
+
+// bulanKerjaPertamaExpr menghitung jumlah bulan dari awal tahun lulus sampai mulai kerja.
+var bulanKerjaPertamaExpr = bson.M{
+	"$max": bson.A{0, bson.M{"$add": bson.A{
+		bson.M{"$multiply": bson.A{
+			bson.M{"$subtract": bson.A{bson.M{"$year": "$first_job.tanggal_mulai_kerja"}, "$tahun_lulus"}}, 12,
+		}},
+		bson.M{"$subtract": bson.A{bson.M{"$month": "$first_job.tanggal_mulai_kerja"}, 1}},
+	}}},
+}
+
+// lookupFirstJob menaruh pekerjaan pertama (yang belum dihapus) tiap alumni di field first_job.
+var lookupFirstJob = bson.A{
+	bson.M{"$lookup": bson.M{
+		"from": "pekerjaan",
+		"let":  bson.M{"aid": "$_id"},
+		"pipeline": bson.A{
+			bson.M{"$match": bson.M{
+				"$expr":     bson.M{"$eq": bson.A{"$alumni_id", "$$aid"}},
+				"is_delete": bson.M{"$ne": true},
+			}},
+			bson.M{"$sort": bson.M{"tanggal_mulai_kerja": 1}},
+			bson.M{"$limit": 1},
+		},
+		"as": "first_job",
+	}},
+	bson.M{"$unwind": bson.M{"path": "$first_job", "preserveNullAndEmptyArrays": true}},
+}
+
+var distribusiFields = map[string]string{
+	"bidang_industri": "$bidang_industri",
+	"lokasi_kerja":    "$lokasi_kerja",
+}
+
+// pekerjaanDenganAlumni menyiapkan stage untuk agregasi di koleksi pekerjaan yang
+// perlu difilter berdasarkan data alumni.
+func pekerjaanDenganAlumni(f model.AnalyticsFilter) bson.A {
+	return bson.A{
+		bson.M{"$match": bson.M{"is_delete": bson.M{"$ne": true}}},
+		bson.M{"$lookup": bson.M{
+			"from":         "alumni",
+			"localField":   "alumni_id",
+			"foreignField": "_id",
+			"as":           "alumni",
+		}},
+		bson.M{"$unwind": "$alumni"},
+		bson.M{"$match": utils.AnalyticsToBSON(f, "alumni.")},
+	}
+}
+
+func (r *analyticsRepository) aggregate(collection string, pipeline bson.A, out interface{}) error {
+	ctx, cancel := context.WithTimeout(r.ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := r.db.Collection(collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	return cursor.All(ctx, out)
+}
+
+func (r *analyticsRepository) GetEmploymentRate(f model.AnalyticsFilter) ([]model.EmploymentRate, error) {
+	pipeline := bson.A{bson.M{"$match": utils.AnalyticsToBSON(f, "")}}
+	pipeline = append(pipeline, lookupFirstJob...)
+	pipeline = append(pipeline,
+		bson.M{"$group": bson.M{
+			"_id":     bson.M{"tahun_lulus": "$tahun_lulus", "jurusan": "$jurusan"},
+			"total":   bson.M{"$sum": 1},
+			"bekerja": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$ifNull": bson.A{"$first_job", false}}, 1, 0}}},
+		}},
+		bson.M{"$sort": bson.D{{Key: "_id.tahun_lulus", Value: 1}, {Key: "_id.jurusan", Value: 1}}},
+	)
+
+	var rows []struct {
+		ID struct {
+			TahunLulus int    `bson:"tahun_lulus"`
+			Jurusan    string `bson:"jurusan"`
+		} `bson:"_id"`
+		Total   int `bson:"total"`
+		Bekerja int `bson:"bekerja"`
+	}
+	if err := r.aggregate("alumni", pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	result := make([]model.EmploymentRate, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, model.EmploymentRate{
+			TahunLulus:    r.ID.TahunLulus,
+			Jurusan:       r.ID.Jurusan,
+			TotalAlumni:   r.Total,
+			AlumniBekerja: r.Bekerja,
+			Rate:          utils.Persentase(r.Bekerja, r.Total),
+		})
+	}
+	return result, nil
+}
+
+func (r *analyticsRepository) GetTimeToFirstJob(f model.AnalyticsFilter) ([]model.TimeToFirstJob, error) {
+	pipeline := bson.A{bson.M{"$match": utils.AnalyticsToBSON(f, "")}}
+	pipeline = append(pipeline, lookupFirstJob...)
+	pipeline = append(pipeline,
+		bson.M{"$match": bson.M{"first_job": bson.M{"$exists": true}}},
+		bson.M{"$group": bson.M{
+			"_id":   bson.M{"tahun_lulus": "$tahun_lulus", "jurusan": "$jurusan"},
+			"bulan": bson.M{"$push": bulanKerjaPertamaExpr},
+		}},
+		bson.M{"$sort": bson.D{{Key: "_id.tahun_lulus", Value: 1}, {Key: "_id.jurusan", Value: 1}}},
+	)
+
+	var rows []struct {
+		ID struct {
+			TahunLulus int    `bson:"tahun_lulus"`
+			Jurusan    string `bson:"jurusan"`
+		} `bson:"_id"`
+		Bulan []float64 `bson:"bulan"`
+	}
+	if err := r.aggregate("alumni", pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	result := make([]model.TimeToFirstJob, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, utils.RingkasBulan(model.TimeToFirstJob{
+			TahunLulus: r.ID.TahunLulus,
+			Jurusan:    r.ID.Jurusan,
+		}, r.Bulan))
+	}
+	return result, nil
+}
+
+func (r *analyticsRepository) GetDistribusiPekerjaan(dimensi string, f model.AnalyticsFilter) ([]model.DistributionItem, error) {
+	field, ok := distribusiFields[dimensi]
+	if !ok {
+		return nil, fmt.Errorf("dimensi distribusi tidak dikenal: %s", dimensi)
+	}
+
+	label := bson.M{"$let": bson.M{
+		"vars": bson.M{"v": bson.M{"$trim": bson.M{"input": bson.M{"$ifNull": bson.A{field, ""}}}}},
+		"in":   bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$$v", ""}}, model.LabelTidakDiketahui, "$$v"}},
+	}}
+
+	pipeline := append(pekerjaanDenganAlumni(f),
+		bson.M{"$group": bson.M{"_id": label, "jumlah": bson.M{"$sum": 1}}},
+		bson.M{"$sort": bson.D{{Key: "jumlah", Value: -1}, {Key: "_id", Value: 1}}},
+	)
+
+	var rows []struct {
+		Label  string `bson:"_id"`
+		Jumlah int    `bson:"jumlah"`
+	}
+	if err := r.aggregate("pekerjaan", pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, r := range rows {
+		total += r.Jumlah
+	}
+	result := make([]model.DistributionItem, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, model.DistributionItem{
+			Label:      r.Label,
+			Jumlah:     r.Jumlah,
+			Persentase: utils.Persentase(r.Jumlah, total),
+		})
+	}
+	return result, nil
+}
+
+func (r *analyticsRepository) GetDistribusiGaji(f model.AnalyticsFilter) ([]model.SalaryBandItem, error) {
+	// Batas $bucket dibangun dari model.GajiBands agar kedua backend memakai rentang yang sama.
+	boundaries := bson.A{}
+	for _, band := range model.GajiBands {
+		boundaries = append(boundaries, band.Min)
+	}
+	boundaries = append(boundaries, int64(math.MaxInt64))
+
+	pipeline := append(pekerjaanDenganAlumni(f),
+		bson.M{"$bucket": bson.M{
+			"groupBy":    gajiAwalExpr("$"),
+			"boundaries": boundaries,
+			"default":    -1,
+			"output":     bson.M{"jumlah": bson.M{"$sum": 1}},
+		}},
+	)
+
+	var rows []struct {
+		Min    int64 `bson:"_id"`
+		Jumlah int   `bson:"jumlah"`
+	}
+	if err := r.aggregate("pekerjaan", pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := map[int]int{}
+	total := 0
+	for _, r := range rows {
+		idx := -1
+		for i, band := range model.GajiBands {
+			if r.Min >= 0 && band.Min == r.Min {
+				idx = i
+				break
+			}
+		}
+		counts[idx] += r.Jumlah
+		total += r.Jumlah
+	}
+	return utils.SusunGajiBand(counts, total), nil
+}
+
+func (r *analyticsRepository) GetCohortTrend(f model.AnalyticsFilter) ([]model.CohortTrend, error) {
+	pipeline := bson.A{bson.M{"$match": utils.AnalyticsToBSON(f, "")}}
+	pipeline = append(pipeline, lookupFirstJob...)
+	adaPekerjaan := bson.M{"$ifNull": bson.A{"$first_job", false}}
+	pipeline = append(pipeline,
+		bson.M{"$set": bson.M{
+			"bekerja": bson.M{"$cond": bson.A{adaPekerjaan, 1, 0}},
+			"bulan":   bson.M{"$cond": bson.A{adaPekerjaan, bulanKerjaPertamaExpr, nil}},
+			"gaji":    bson.M{"$cond": bson.A{adaPekerjaan, gajiAwalExpr("$first_job."), nil}},
+		}},
+		bson.M{"$group": bson.M{
+			"_id":     "$tahun_lulus",
+			"total":   bson.M{"$sum": 1},
+			"bekerja": bson.M{"$sum": "$bekerja"},
+			"bulan":   bson.M{"$avg": "$bulan"},
+			"gaji":    bson.M{"$avg": "$gaji"},
+		}},
+		bson.M{"$sort": bson.M{"_id": 1}},
+	)
+
+	var rows []struct {
+		TahunLulus int      `bson:"_id"`
+		Total      int      `bson:"total"`
+		Bekerja    int      `bson:"bekerja"`
+		Bulan      *float64 `bson:"bulan"`
+		Gaji       *float64 `bson:"gaji"`
+	}
+	if err := r.aggregate("alumni", pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	result := make([]model.CohortTrend, 0, len(rows))
+	for _, r := range rows {
+		trend := model.CohortTrend{
+			TahunLulus:    r.TahunLulus,
+			TotalAlumni:   r.Total,
+			AlumniBekerja: r.Bekerja,
+			Rate:          utils.Persentase(r.Bekerja, r.Total),
+		}
+		if r.Bulan != nil {
+			trend.RataRataBulan = utils.Round2(*r.Bulan)
+		}
+		if r.Gaji != nil {
+			trend.RataRataGajiAwal = utils.Round2(*r.Gaji)
+		}
+		result = append(result, trend)
+	}
+	return result, nil
+}
@@ -3,16 +3,15 @@ package mongo
 import (
 	"context"
 	"latihan2/app/model/mongo"
-	"latihan2/helper" // Mengimpor helper/base.go
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-func GetUserByUsername(username string) (*mongo.User, error) {
+func (r *userRepository) GetUserByUsername(username string) (*mongo.User, error) {
 	
-	collection := helper.GetCollection("user")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	collection := r.db.Collection("user")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
 	var user mongo.User
@@ -0,0 +1,43 @@
+package mongo
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrDuplikat dikembalikan (lewat DuplicateKeyError) jika insert/update melanggar index unik.
+var ErrDuplikat = errors.New("data sudah terdaftar")
+
+// DuplicateKeyError menyebutkan field yang melanggar index unik, misalnya "nim" atau "email".
+type DuplicateKeyError struct {
+	Field string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	if e.Field == "" {
+		return ErrDuplikat.Error()
+	}
+	return fmt.Sprintf("%s sudah terdaftar", e.Field)
+}
+
+func (e *DuplicateKeyError) Is(target error) bool {
+	return target == ErrDuplikat
+}
+
+// Pesan E11000 berbentuk "... index: alumni_nim_unique dup key: { nim: \"123\" }".
+var dupKeyField = regexp.MustCompile(`dup key: \{ ?"?([A-Za-z0-9_.]+)"?:`)
+
+// WrapDuplicate mengubah error duplicate key dari driver menjadi *DuplicateKeyError.
+func WrapDuplicate(err error) error {
+	if err == nil || !mongodriver.IsDuplicateKeyError(err) {
+		return err
+	}
+	dup := &DuplicateKeyError{}
+	if m := dupKeyField.FindStringSubmatch(err.Error()); m != nil {
+		dup.Field = m[1]
+	}
+	return dup
+}
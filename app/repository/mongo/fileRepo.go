@@ -2,19 +2,51 @@ package mongo
 
 import (
 	"context"
+	"latihan2/app/model"
 	"latihan2/app/model/mongo"
 	"latihan2/helper"
+	"latihan2/utils"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive" 
 
 	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func CreateFile(file *mongo.File) error {
-	collection := helper.GetCollection("files") 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// FileRepository adalah akses metadata file di koleksi files. Isi file tetap di disk.
+type FileRepository interface {
+	CreateFile(file *mongo.File) error
+	FindAllFiles() ([]mongo.File, error)
+	FindFilesCursor(page model.CursorPage) ([]mongo.File, error)
+	CountFiles() (int, error)
+	FindFileByID(id string) (*mongo.File, error)
+	OpenFileByID(id primitive.ObjectID) (*mongo.File, error)
+	DeleteFile(id string) error
+	// WithContext mengembalikan repository yang menjalankan operasi dengan ctx,
+	// mis. c.UserContext() agar command Mongo menjadi span anak dari span request.
+	WithContext(ctx context.Context) FileRepository
+}
+
+type fileRepository struct {
+	db  *mongodriver.Database
+	ctx context.Context
+}
+
+func NewFileRepository(db *mongodriver.Database) FileRepository {
+	return &fileRepository{db: db, ctx: context.Background()}
+}
+
+func (r *fileRepository) WithContext(ctx context.Context) FileRepository {
+	c := *r
+	c.ctx = ctx
+	return &c
+}
+
+func (r *fileRepository) CreateFile(file *mongo.File) error {
+	collection := r.db.Collection("files") 
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
 	file.UploadedAt = time.Now()
@@ -28,9 +60,9 @@ func CreateFile(file *mongo.File) error {
 	return nil
 }
 
-func FindAllFiles() ([]mongo.File, error) {
-	collection := helper.GetCollection("files")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *fileRepository) FindAllFiles() ([]mongo.File, error) {
+	collection := r.db.Collection("files")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
 	var files []mongo.File
@@ -46,9 +78,48 @@ func FindAllFiles() ([]mongo.File, error) {
 	return files, nil
 }
 
-func FindFileByID(id string) (*mongo.File, error) {
-	collection := helper.GetCollection("files")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// FindFilesCursor mengambil satu halaman file dengan pagination keyset.
+// Hasilnya berisi sampai page.Limit+1 dokumen; gunakan utils.CursorResult untuk memotongnya.
+func (r *fileRepository) FindFilesCursor(page model.CursorPage) ([]mongo.File, error) {
+	collection := r.db.Collection("files")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
+	defer cancel()
+
+	filter, sort, err := utils.ApplyCursorBSON(bson.M{}, page)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().SetSort(sort).SetLimit(int64(page.Limit + 1))
+
+	var files []mongo.File
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// CountFiles dipakai oleh mode cursor jika klien meminta with_total=true.
+func (r *fileRepository) CountFiles() (int, error) {
+	collection := r.db.Collection("files")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
+	defer cancel()
+
+	count, err := collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *fileRepository) FindFileByID(id string) (*mongo.File, error) {
+	collection := r.db.Collection("files")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
 	objID, err := helper.ToObjectID(id)
@@ -64,9 +135,9 @@ func FindFileByID(id string) (*mongo.File, error) {
 	return &file, nil
 }
 
-func OpenFileByID(id primitive.ObjectID) (*mongo.File, error) {
-	collection := helper.GetCollection("files")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *fileRepository) OpenFileByID(id primitive.ObjectID) (*mongo.File, error) {
+	collection := r.db.Collection("files")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
 	var file mongo.File
@@ -77,9 +148,9 @@ func OpenFileByID(id primitive.ObjectID) (*mongo.File, error) {
 	return &file, nil
 }
 
-func DeleteFile(id string) error {
-	collection := helper.GetCollection("files")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *fileRepository) DeleteFile(id string) error {
+	collection := r.db.Collection("files")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
 	objID, err := helper.ToObjectID(id)
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	appModel "latihan2/app/model"
 	model "latihan2/app/model/mongo"
-	"latihan2/database"
+	"latihan2/utils"
+	"log/slog"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -14,12 +16,53 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// PekerjaanRepository adalah akses data koleksi pekerjaan.
+type PekerjaanRepository interface {
+	GetPekerjaanRepo(search, sortBy, order string, limit, offset int, gaji appModel.GajiFilter, f appModel.Filter) ([]model.Pekerjaan, error)
+	GetPekerjaanCursorRepo(search string, page appModel.CursorPage, gaji appModel.GajiFilter, f appModel.Filter) ([]model.Pekerjaan, error)
+	CountPekerjaanRepo(search string, gaji appModel.GajiFilter, f appModel.Filter) (int, error)
+	GetPekerjaanByIDRepo(id string) (*model.Pekerjaan, error)
+	GetPekerjaanByAlumniID(alumniID string) ([]model.Pekerjaan, error)
+	CreatePekerjaan(p *model.Pekerjaan) (*model.Pekerjaan, error)
+	UpdatePekerjaan(id string, version int, req model.UpdatePekerjaanRequest) (*model.Pekerjaan, error)
+	SoftDeletePekerjaan(pekerjaanID, userID, role string) error
+	RestorePekerjaan(pekerjaanID, userID, role string) error
+	HardDeletePekerjaan(pekerjaanID, userID, role string) error
+	GetTrashPekerjaan(id, role string) ([]model.Pekerjaan, error)
+	MigrateGajiRange(dryRun bool) (*appModel.GajiMigrationReport, error)
+	SearchPekerjaan(q string, limit, offset int, basic bool) ([]model.PekerjaanSearchResult, string, error)
+	// WithContext mengembalikan repository yang menjalankan operasi dengan ctx,
+	// mis. c.UserContext() agar command Mongo menjadi span anak dari span request.
+	WithContext(ctx context.Context) PekerjaanRepository
+}
+
+type pekerjaanRepository struct {
+	db  *mongo.Database
+	ctx context.Context
+}
+
+func NewPekerjaanRepository(db *mongo.Database) PekerjaanRepository {
+	return &pekerjaanRepository{db: db, ctx: context.Background()}
+}
+
+func (r *pekerjaanRepository) WithContext(ctx context.Context) PekerjaanRepository {
+	c := *r
+	c.ctx = ctx
+	return &c
+}
+
 var pekerjaanColl *mongo.Collection
 var (
 	ErrPekerjaanNotFound = errors.New("pekerjaan not found")
 	ErrForbidden         = errors.New("forbidden access")
+	// ErrVersionConflict dikembalikan update jika version yang dikirim klien
+	// (lewat If-Match) sudah tidak sama dengan version di database.
+	ErrVersionConflict = errors.New("version conflict")
 )
 
+// AnyVersion dipakai sebagai version pada update yang tidak perlu dicek (If-Match: *).
+const AnyVersion = 0
+
 // func init() {
 //     // Pastikan database.MongoDB sudah diinisialisasi di tempat lain
 //     // (seperti di TestMain atau main.go) sebelum package ini di-import.
@@ -31,11 +74,8 @@ var (
 // 	}
 // }
 
-func GetPekerjaanRepo(search, sortBy, order string, limit, offset int) ([]model.Pekerjaan, error) {
-	pekerjaanColl := database.MongoDB.Collection("pekerjaan")
-	if pekerjaanColl == nil {
-		return nil, errors.New("pekerjaanColl belum diinisialisasi")
-	}
+// PekerjaanListFilter dipakai bersama oleh GetPekerjaanRepo dan CountPekerjaanRepo.
+func PekerjaanListFilter(search string, gaji appModel.GajiFilter, f appModel.Filter) bson.M {
 	filter := bson.M{
 		"$or": []bson.M{
 			{"nama_perusahaan": bson.M{"$regex": search, "$options": "i"}},
@@ -44,6 +84,28 @@ func GetPekerjaanRepo(search, sortBy, order string, limit, offset int) ([]model.
 			{"lokasi_kerja": bson.M{"$regex": search, "$options": "i"}},
 		},
 	}
+	// Filter gaji: gaji_min >= Min dan batas atas (gaji_max, atau gaji_min jika tanpa batas atas) <= Max
+	if gaji.Min != nil {
+		filter["gaji_min"] = bson.M{"$gte": *gaji.Min}
+	}
+	if gaji.Max != nil {
+		filter["$and"] = []bson.M{{"$or": []bson.M{
+			{"gaji_max": bson.M{"$lte": *gaji.Max}},
+			{"gaji_max": nil, "gaji_min": bson.M{"$lte": *gaji.Max}},
+		}}}
+	}
+	if gaji.MataUang != "" {
+		filter["gaji_mata_uang"] = gaji.MataUang
+	}
+	return utils.ApplyFilterBSON(filter, f)
+}
+
+func (r *pekerjaanRepository) GetPekerjaanRepo(search, sortBy, order string, limit, offset int, gaji appModel.GajiFilter, f appModel.Filter) ([]model.Pekerjaan, error) {
+	pekerjaanColl := r.db.Collection("pekerjaan")
+	if pekerjaanColl == nil {
+		return nil, errors.New("pekerjaanColl belum diinisialisasi")
+	}
+	filter := PekerjaanListFilter(search, gaji, f)
 	sortOrder := 1
 	if order == "desc" {
 		sortOrder = -1
@@ -52,40 +114,54 @@ func GetPekerjaanRepo(search, sortBy, order string, limit, offset int) ([]model.
 		SetSort(bson.D{{Key: sortBy, Value: sortOrder}}).
 		SetLimit(int64(limit)).
 		SetSkip(int64(offset))
-	cursor, err := pekerjaanColl.Find(context.TODO(), filter, opts)
+	cursor, err := pekerjaanColl.Find(r.ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(r.ctx)
+	var pekerjaanList []model.Pekerjaan
+	if err := cursor.All(r.ctx, &pekerjaanList); err != nil {
+		return nil, err
+	}
+	return pekerjaanList, nil
+}
+
+// GetPekerjaanCursorRepo mengambil satu halaman pekerjaan dengan pagination keyset.
+// Hasilnya berisi sampai page.Limit+1 dokumen; gunakan utils.CursorResult untuk memotongnya.
+func (r *pekerjaanRepository) GetPekerjaanCursorRepo(search string, page appModel.CursorPage, gaji appModel.GajiFilter, f appModel.Filter) ([]model.Pekerjaan, error) {
+	pekerjaanColl := r.db.Collection("pekerjaan")
+	filter, sort, err := utils.ApplyCursorBSON(PekerjaanListFilter(search, gaji, f), page)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().SetSort(sort).SetLimit(int64(page.Limit + 1))
+	cursor, err := pekerjaanColl.Find(r.ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.TODO())
+	defer cursor.Close(r.ctx)
 	var pekerjaanList []model.Pekerjaan
-	if err := cursor.All(context.TODO(), &pekerjaanList); err != nil {
+	if err := cursor.All(r.ctx, &pekerjaanList); err != nil {
 		return nil, err
 	}
 	return pekerjaanList, nil
 }
 
-func CountPekerjaanRepo(search string) (int, error) {
-	pekerjaanColl := database.MongoDB.Collection("pekerjaan")
+func (r *pekerjaanRepository) CountPekerjaanRepo(search string, gaji appModel.GajiFilter, f appModel.Filter) (int, error) {
+	pekerjaanColl := r.db.Collection("pekerjaan")
 	if pekerjaanColl == nil {
 		return 0, errors.New("pekerjaanColl belum diinisialisasi")
 	}
-	filter := bson.M{
-		"$or": []bson.M{
-			{"nama_perusahaan": bson.M{"$regex": search, "$options": "i"}},
-			{"posisi_jabatan": bson.M{"$regex": search, "$options": "i"}},
-			{"bidang_industri": bson.M{"$regex": search, "$options": "i"}},
-			{"lokasi_kerja": bson.M{"$regex": search, "$options": "i"}},
-		},
-	}
-	count, err := pekerjaanColl.CountDocuments(context.TODO(), filter)
+	filter := PekerjaanListFilter(search, gaji, f)
+	count, err := pekerjaanColl.CountDocuments(r.ctx, filter)
 	if err != nil {
 		return 0, err
 	}
 	return int(count), nil
 }
 
-func GetPekerjaanByIDRepo(id string) (*model.Pekerjaan, error) {
-    pekerjaanColl := database.MongoDB.Collection("pekerjaan")
+func (r *pekerjaanRepository) GetPekerjaanByIDRepo(id string) (*model.Pekerjaan, error) {
+    pekerjaanColl := r.db.Collection("pekerjaan")
 
     objID, err := primitive.ObjectIDFromHex(id)
     if err != nil {
@@ -98,81 +174,97 @@ func GetPekerjaanByIDRepo(id string) (*model.Pekerjaan, error) {
     }
 
     var p model.Pekerjaan
-    err = pekerjaanColl.FindOne(context.TODO(), filter).Decode(&p) 
+    err = pekerjaanColl.FindOne(r.ctx, filter).Decode(&p) 
     if err != nil {
         return nil, ErrPekerjaanNotFound 
     }
     return &p, nil
 }
 
-func GetPekerjaanByAlumniID(alumniID string) ([]model.Pekerjaan, error) {
-	pekerjaanColl := database.MongoDB.Collection("pekerjaan")
+func (r *pekerjaanRepository) GetPekerjaanByAlumniID(alumniID string) ([]model.Pekerjaan, error) {
+	pekerjaanColl := r.db.Collection("pekerjaan")
 	objID, err := primitive.ObjectIDFromHex(alumniID)
 	if err != nil {
-		// fmt.Println("DEBUG: alumni_id bukan ObjectID valid:", alumniID)
 		return nil, err
 	}
 
 	filter := bson.M{"alumni_id": objID}
-	cursor, err := pekerjaanColl.Find(context.TODO(), filter)
+	cursor, err := pekerjaanColl.Find(r.ctx, filter)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.TODO())
+	defer cursor.Close(r.ctx)
 	var pekerjaanList []model.Pekerjaan
-	if err := cursor.All(context.TODO(), &pekerjaanList); err != nil {
+	if err := cursor.All(r.ctx, &pekerjaanList); err != nil {
 		return nil, err
 	}
-	fmt.Printf("DEBUG: Total data ditemukan: %d\n", len(pekerjaanList))
+	slog.Debug("pekerjaan per alumni", "alumni_id", alumniID, "total", len(pekerjaanList))
 	return pekerjaanList, nil
 }
 
-func CreatePekerjaan(p *model.Pekerjaan) (*model.Pekerjaan, error) {
-	pekerjaanColl := database.MongoDB.Collection("pekerjaan")
+func (r *pekerjaanRepository) CreatePekerjaan(p *model.Pekerjaan) (*model.Pekerjaan, error) {
+	pekerjaanColl := r.db.Collection("pekerjaan")
 
 	p.ID = primitive.NewObjectID()
 	p.CreatedAt = time.Now()
 	p.IsDelete = false
-	if _, err := pekerjaanColl.InsertOne(context.TODO(), p); err != nil {
+	p.Version = 1
+	if _, err := pekerjaanColl.InsertOne(r.ctx, p); err != nil {
 		return nil, err
 	}
 	return p, nil
 }
 
-func UpdatePekerjaan(id string, req model.UpdatePekerjaanRequest) (*model.Pekerjaan, error) {
-	pekerjaanColl := database.MongoDB.Collection("pekerjaan")
+// UpdatePekerjaan hanya berhasil jika version masih sama dengan di database
+// (kecuali AnyVersion), lalu menaikkan version.
+func (r *pekerjaanRepository) UpdatePekerjaan(id string, version int, req model.UpdatePekerjaanRequest) (*model.Pekerjaan, error) {
+	pekerjaanColl := r.db.Collection("pekerjaan")
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, err
 	}
-	update := bson.M{
-		"$set": bson.M{
-			"nama_perusahaan":     req.NamaPerusahaan,
-			"posisi_jabatan":      req.PosisiJabatan,
-			"bidang_industri":     req.BidangIndustri,
-			"lokasi_kerja":        req.LokasiKerja,
-			"gaji_range":          req.GajiRange,
-			"tanggal_mulai_kerja": req.TanggalMulaiKerja,
-			"status_pekerjaan":    req.StatusPekerjaan,
-			"deskripsi_pekerjaan": req.DeskripsiPekerjaan,
-			"updated_at":          time.Now(),
-		},
-	}
-	_, err = pekerjaanColl.UpdateByID(context.TODO(), objID, update)
+	set := bson.M{
+		"nama_perusahaan":     req.NamaPerusahaan,
+		"posisi_jabatan":      req.PosisiJabatan,
+		"bidang_industri":     req.BidangIndustri,
+		"lokasi_kerja":        req.LokasiKerja,
+		"gaji_range":          req.GajiRange,
+		"tanggal_mulai_kerja": req.TanggalMulaiKerja,
+		"status_pekerjaan":    req.StatusPekerjaan,
+		"deskripsi_pekerjaan": req.DeskripsiPekerjaan,
+		"gaji_min":            req.GajiMin,
+		"gaji_max":            req.GajiMax,
+		"gaji_mata_uang":      req.GajiMataUang,
+		"gaji_periode":        req.GajiPeriode,
+		"updated_at":          time.Now(),
+	}
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	// Tanggal selesai kosong berarti masih bekerja: field dihapus, bukan diisi null,
+	// sama dengan dokumen yang dibuat tanpa tanggal_selesai_kerja.
+	if req.TanggalSelesaiKerja != nil {
+		set["tanggal_selesai_kerja"] = req.TanggalSelesaiKerja
+	} else {
+		update["$unset"] = bson.M{"tanggal_selesai_kerja": ""}
+	}
+	filter := bson.M{"_id": objID}
+	result, err := pekerjaanColl.UpdateOne(r.ctx, withVersion(filter, version), update)
 	if err != nil {
 		return nil, err
 	}
-	return GetPekerjaanByIDRepo(id)
+	if err := versionChecked(r.ctx, pekerjaanColl, result, filter, ErrPekerjaanNotFound); err != nil {
+		return nil, err
+	}
+	return r.GetPekerjaanByIDRepo(id)
 }
 
-func SoftDeletePekerjaan(pekerjaanID, userID, role string) error {
-	pekerjaanColl := database.MongoDB.Collection("pekerjaan")
+func (r *pekerjaanRepository) SoftDeletePekerjaan(pekerjaanID, userID, role string) error {
+	pekerjaanColl := r.db.Collection("pekerjaan")
 	objID, err := primitive.ObjectIDFromHex(pekerjaanID)
 	if err != nil {
 		return err
 	}
 	var pekerjaan model.Pekerjaan
-	err = pekerjaanColl.FindOne(context.TODO(), bson.M{"_id": objID}).Decode(&pekerjaan)
+	err = pekerjaanColl.FindOne(r.ctx, bson.M{"_id": objID}).Decode(&pekerjaan)
 	if err != nil {
 		return ErrPekerjaanNotFound
 	}
@@ -186,17 +278,16 @@ func SoftDeletePekerjaan(pekerjaanID, userID, role string) error {
 			"deleted_at": time.Now(),
 		},
 	}
-	result, err := pekerjaanColl.UpdateByID(context.TODO(), objID, update)
+	result, err := pekerjaanColl.UpdateByID(r.ctx, objID, update)
 	if err != nil {
-		fmt.Println("DEBUG: Gagal update:", err)
 		return err
 	}
-	fmt.Printf("DEBUG: Update result: %+v\n", result)
+	slog.Debug("soft delete pekerjaan", "id", pekerjaanID, "modified", result.ModifiedCount)
 	return nil
 }
 
-func RestorePekerjaan(pekerjaanID, userID, role string) error {
-	pekerjaanColl := database.MongoDB.Collection("pekerjaan")
+func (r *pekerjaanRepository) RestorePekerjaan(pekerjaanID, userID, role string) error {
+	pekerjaanColl := r.db.Collection("pekerjaan")
 	objID, err := primitive.ObjectIDFromHex(pekerjaanID)
 	if err != nil {
 		return err
@@ -215,7 +306,7 @@ func RestorePekerjaan(pekerjaanID, userID, role string) error {
 		},
 	}
 
-	result, err := pekerjaanColl.UpdateOne(context.TODO(), filter, update)
+	result, err := pekerjaanColl.UpdateOne(r.ctx, filter, update)
 	if err != nil {
 		return err
 	}
@@ -225,8 +316,8 @@ func RestorePekerjaan(pekerjaanID, userID, role string) error {
 	return nil
 }
 
-func HardDeletePekerjaan(pekerjaanID, userID, role string) error {
-	pekerjaanColl := database.MongoDB.Collection("pekerjaan")
+func (r *pekerjaanRepository) HardDeletePekerjaan(pekerjaanID, userID, role string) error {
+	pekerjaanColl := r.db.Collection("pekerjaan")
 	objID, err := primitive.ObjectIDFromHex(pekerjaanID)
 	if err != nil {
 		return err
@@ -237,7 +328,7 @@ func HardDeletePekerjaan(pekerjaanID, userID, role string) error {
 		filter["user_id"] = userID
 	}
 
-	result, err := pekerjaanColl.DeleteOne(context.TODO(), filter)
+	result, err := pekerjaanColl.DeleteOne(r.ctx, filter)
 	if err != nil {
 		return err
 	}
@@ -247,8 +338,8 @@ func HardDeletePekerjaan(pekerjaanID, userID, role string) error {
 	return nil
 }
 
-func GetTrashPekerjaan(id, role string) ([]model.Pekerjaan, error) {
-	pekerjaanColl := database.MongoDB.Collection("pekerjaan")
+func (r *pekerjaanRepository) GetTrashPekerjaan(id, role string) ([]model.Pekerjaan, error) {
+	pekerjaanColl := r.db.Collection("pekerjaan")
 	filter := bson.M{"is_delete": true}
 
 	// Jika ID dikirim lewat params, ambil berdasarkan _id
@@ -265,16 +356,16 @@ func GetTrashPekerjaan(id, role string) ([]model.Pekerjaan, error) {
 		return nil, fmt.Errorf("forbidden: user cannot access trash")
 	}
 
-	fmt.Println("DEBUG FILTER:", filter) // untuk bantu debug
+	slog.Debug("filter trash pekerjaan", "filter", filter)
 
-	cursor, err := pekerjaanColl.Find(context.TODO(), filter)
+	cursor, err := pekerjaanColl.Find(r.ctx, filter)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.TODO())
+	defer cursor.Close(r.ctx)
 
 	var pekerjaanList []model.Pekerjaan
-	if err := cursor.All(context.TODO(), &pekerjaanList); err != nil {
+	if err := cursor.All(r.ctx, &pekerjaanList); err != nil {
 		return nil, err
 	}
 
@@ -284,3 +375,57 @@ func GetTrashPekerjaan(id, role string) ([]model.Pekerjaan, error) {
 
 	return pekerjaanList, nil
 }
+
+// MigrateGajiRange mengisi field gaji terstruktur dari gaji_range untuk dokumen yang
+// belum punya gaji_min/gaji_max. Dokumen yang tidak bisa diparse dilaporkan, tidak diubah.
+func (r *pekerjaanRepository) MigrateGajiRange(dryRun bool) (*appModel.GajiMigrationReport, error) {
+	pekerjaanColl := r.db.Collection("pekerjaan")
+	ctx, cancel := context.WithTimeout(r.ctx, 5*time.Minute)
+	defer cancel()
+
+	filter := bson.M{"gaji_min": nil, "gaji_max": nil}
+	opts := options.Find().SetProjection(bson.M{"gaji_range": 1}).SetSort(bson.M{"_id": 1})
+	cursor, err := pekerjaanColl.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	report := &appModel.GajiMigrationReport{DryRun: dryRun, Gagal: []appModel.GajiMigrationFailure{}}
+	var writes []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID        primitive.ObjectID `bson:"_id"`
+			GajiRange string             `bson:"gaji_range"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		report.Total++
+		g, err := utils.ParseGajiRange(doc.GajiRange)
+		switch {
+		case errors.Is(err, utils.ErrGajiKosong):
+			report.Kosong++
+		case err != nil:
+			report.Gagal = append(report.Gagal, appModel.GajiMigrationFailure{
+				ID: doc.ID.Hex(), GajiRange: doc.GajiRange, Alasan: err.Error(),
+			})
+		default:
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": doc.ID}).
+				SetUpdate(bson.M{"$set": g}))
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	report.Berhasil = len(writes)
+	if dryRun || len(writes) == 0 {
+		return report, nil
+	}
+	if _, err := pekerjaanColl.BulkWrite(ctx, writes); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan hasil migrasi gaji: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,116 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"latihan2/app/model"
+	mongoModel "latihan2/app/model/mongo"
+	"latihan2/logging"
+	"latihan2/utils"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Kode error Mongo "IndexNotFound" saat $text dipakai tanpa text index.
+const errCodeIndexNotFound = 27
+
+func textIndexMissing(err error) bool {
+	var se mongodriver.ServerError
+	return errors.As(err, &se) && se.HasErrorCode(errCodeIndexNotFound)
+}
+
+// textSearchOptions mengurutkan hasil $text berdasarkan skor relevansi.
+func textSearchOptions(limit, offset int) *options.FindOptions {
+	score := bson.M{"$meta": "textScore"}
+	return options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+}
+
+func basicSearchOptions(limit, offset int) *options.FindOptions {
+	return options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+}
+
+func findSearch(ctx context.Context, collection *mongodriver.Collection, filter bson.M, opts *options.FindOptions, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	return cursor.All(ctx, out)
+}
+
+// SearchAlumni mencari alumni dengan text index, diurutkan menurut relevansi.
+// Jika basic true atau text index belum ada, dipakai pencarian $regex lama.
+// Mode yang benar-benar dipakai dikembalikan bersama hasilnya.
+func (r *alumniRepository) SearchAlumni(q string, limit, offset int, basic bool) ([]mongoModel.AlumniSearchResult, string, error) {
+	result := []mongoModel.AlumniSearchResult{}
+	mode := model.SearchModeBasic
+
+	if !basic {
+		filter := bson.M{"$text": bson.M{"$search": q}, "deleted_at": bson.M{"$exists": false}}
+		err := findSearch(r.ctx, r.db.Collection("alumni"), filter, textSearchOptions(limit, offset), &result)
+		switch {
+		case err == nil:
+			mode = model.SearchModeFullText
+		case textIndexMissing(err):
+			logging.FromContext(r.ctx).Warn("text index alumni tidak tersedia, memakai pencarian basic", "error", err)
+		default:
+			return nil, "", err
+		}
+	}
+	if mode == model.SearchModeBasic {
+		if err := findSearch(r.ctx, r.db.Collection("alumni"), AlumniListFilter(q, model.Filter{}), basicSearchOptions(limit, offset), &result); err != nil {
+			return nil, "", err
+		}
+	}
+
+	for i := range result {
+		result[i].Snippet = utils.Snippet(strings.Join([]string{result[i].Nama, result[i].Jurusan}, " · "), q)
+	}
+	return result, mode, nil
+}
+
+// SearchPekerjaan mencari pekerjaan yang belum dihapus dengan text index, diurutkan menurut relevansi.
+func (r *pekerjaanRepository) SearchPekerjaan(q string, limit, offset int, basic bool) ([]mongoModel.PekerjaanSearchResult, string, error) {
+	result := []mongoModel.PekerjaanSearchResult{}
+	mode := model.SearchModeBasic
+
+	if !basic {
+		filter := bson.M{"$text": bson.M{"$search": q}, "is_delete": bson.M{"$ne": true}}
+		err := findSearch(r.ctx, r.db.Collection("pekerjaan"), filter, textSearchOptions(limit, offset), &result)
+		switch {
+		case err == nil:
+			mode = model.SearchModeFullText
+		case textIndexMissing(err):
+			logging.FromContext(r.ctx).Warn("text index pekerjaan tidak tersedia, memakai pencarian basic", "error", err)
+		default:
+			return nil, "", err
+		}
+	}
+	if mode == model.SearchModeBasic {
+		filter := PekerjaanListFilter(q, model.GajiFilter{}, model.Filter{})
+		filter["is_delete"] = bson.M{"$ne": true}
+		if err := findSearch(r.ctx, r.db.Collection("pekerjaan"), filter, basicSearchOptions(limit, offset), &result); err != nil {
+			return nil, "", err
+		}
+	}
+
+	for i := range result {
+		p := result[i].Pekerjaan
+		result[i].Snippet = utils.Snippet(strings.Join([]string{p.NamaPerusahaan, p.PosisiJabatan, p.Deskripsi}, " · "), q)
+	}
+	return result, mode, nil
+}
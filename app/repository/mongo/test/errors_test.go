@@ -0,0 +1,75 @@
+package test
+
+import (
+	"errors"
+	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/response"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// writeException membentuk error seperti yang dikembalikan driver untuk
+// InsertOne/UpdateOne yang melanggar index unik.
+func writeException(msg string) error {
+	return mongodriver.WriteException{WriteErrors: mongodriver.WriteErrors{{Code: 11000, Message: msg}}}
+}
+
+func TestWrapDuplicate(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		field  string
+		detail string
+	}{
+		{"satu field",
+			writeException(`E11000 duplicate key error collection: latihan2.alumni index: alumni_nim_unique dup key: { nim: "2019001" }`),
+			"nim", "nim sudah terdaftar"},
+		{"index gabungan memakai field pertama",
+			writeException(`E11000 duplicate key error collection: latihan2.pekerjaan index: alumni_id_1_nama_perusahaan_1 dup key: { alumni_id: ObjectId('65f1c2a4e13a5b0d9c8e7f61'), nama_perusahaan: "PT Maju" }`),
+			"alumni_id", "alumni_id sudah terdaftar"},
+		{"nama field dikutip",
+			writeException(`E11000 duplicate key error collection: latihan2.users index: profile.email_1 dup key: { "profile.email": "budi@x.id" }`),
+			"profile.email", "profile.email sudah terdaftar"},
+		{"tanpa spasi setelah kurung",
+			writeException(`E11000 duplicate key error collection: latihan2.users index: users_email_unique dup key: {email: "budi@x.id"}`),
+			"email", "email sudah terdaftar"},
+		// MongoDB sebelum 4.2 tidak menyebutkan nama field.
+		{"server lama tanpa nama field",
+			writeException(`E11000 duplicate key error collection: latihan2.users index: username_1 dup key: { : "budi" }`),
+			"", "data sudah terdaftar"},
+		{"command error",
+			mongodriver.CommandError{Code: 11000, Message: `E11000 duplicate key error collection: latihan2.users index: users_username_unique dup key: { username: "budi" }`},
+			"username", "username sudah terdaftar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mongoRepo.WrapDuplicate(tt.err)
+
+			var dup *mongoRepo.DuplicateKeyError
+			require.ErrorAs(t, err, &dup)
+			assert.Equal(t, tt.field, dup.Field)
+			assert.EqualError(t, err, tt.detail)
+			assert.ErrorIs(t, err, mongoRepo.ErrDuplikat)
+
+			// Duplikat dari Mongo dipetakan ke 409 dengan nama field di detail.
+			httpErr := response.From(err)
+			assert.Equal(t, fiber.StatusConflict, httpErr.Status)
+			assert.Equal(t, response.CodeDuplicate, httpErr.Code)
+			assert.Equal(t, tt.detail, httpErr.Detail)
+		})
+	}
+}
+
+func TestWrapDuplicateBukanDuplikat(t *testing.T) {
+	assert.NoError(t, mongoRepo.WrapDuplicate(nil))
+
+	other := mongodriver.WriteException{WriteErrors: mongodriver.WriteErrors{{Code: 121, Message: "Document failed validation"}}}
+	assert.Equal(t, error(other), mongoRepo.WrapDuplicate(other))
+
+	plain := errors.New("connection reset")
+	assert.Same(t, plain, mongoRepo.WrapDuplicate(plain))
+}
@@ -2,28 +2,70 @@ package mongo
 
 import (
 	"context"
+	"latihan2/app/model"
 	"latihan2/app/model/mongo"
 	"latihan2/helper" // <-- Mengimpor helper/base.go
+	"latihan2/utils"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func GetUsersRepo(search, sortBy, order string, limit, offset int) ([]mongo.User, error) {
-    collection := helper.GetCollection("user") 
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// UserRepository adalah akses data koleksi user, termasuk pencarian user saat login.
+type UserRepository interface {
+	GetUsersRepo(search, sortBy, order string, limit, offset int, f model.Filter) ([]mongo.User, error)
+	GetUsersCursorRepo(search string, page model.CursorPage, f model.Filter) ([]mongo.User, error)
+	CountUsersRepo(search string, f model.Filter) (int, error)
+	GetUserByID(id string) (*mongo.User, error)
+	GetUserByUsername(username string) (*mongo.User, error)
+	UpdateUser(id string, req model.UpdateUserRequest) (*mongo.User, error)
+	// CreateUser dan UpdatePassword menerima password yang sudah di-hash
+	// dengan utils.HashPassword. Dipakai CLI, belum ada endpoint-nya.
+	CreateUser(user *mongo.User) (*mongo.User, error)
+	UpdatePassword(id string, passwordHash string) error
+	// WithContext mengembalikan repository yang menjalankan operasi dengan ctx,
+	// mis. c.UserContext() agar command Mongo menjadi span anak dari span request.
+	WithContext(ctx context.Context) UserRepository
+}
+
+type userRepository struct {
+	db  *mongodriver.Database
+	ctx context.Context
+}
+
+func NewUserRepository(db *mongodriver.Database) UserRepository {
+	return &userRepository{db: db, ctx: context.Background()}
+}
+
+func (r *userRepository) WithContext(ctx context.Context) UserRepository {
+	c := *r
+	c.ctx = ctx
+	return &c
+}
+
+// UsersListFilter dipakai bersama oleh GetUsersRepo, GetUsersCursorRepo dan CountUsersRepo.
+func UsersListFilter(search string, f model.Filter) bson.M {
+	filter := bson.M{
+		"$or": []bson.M{
+			{"username": bson.M{"$regex": search, "$options": "i"}},
+			{"email": bson.M{"$regex": search, "$options": "i"}},
+		},
+		"deleted_at": nil,
+	}
+	return utils.ApplyFilterBSON(filter, f)
+}
+
+func (r *userRepository) GetUsersRepo(search, sortBy, order string, limit, offset int, f model.Filter) ([]mongo.User, error) {
+    collection := r.db.Collection("user") 
+    ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
     defer cancel()
 
     var users []mongo.User
 
-    filter := bson.M{
-        "$or": []bson.M{
-            {"username": bson.M{"$regex": search, "$options": "i"}},
-            {"email": bson.M{"$regex": search, "$options": "i"}},
-        },
-        "deleted_at": nil,
-    }
+    filter := UsersListFilter(search, f)
 
     orderVal := 1
     if order == "desc" {
@@ -46,18 +88,38 @@ func GetUsersRepo(search, sortBy, order string, limit, offset int) ([]mongo.User
     return users, nil
 }
 
-func CountUsersRepo(search string) (int, error) {
-	collection := helper.GetCollection("user")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// GetUsersCursorRepo mengambil satu halaman user dengan pagination keyset.
+// Hasilnya berisi sampai page.Limit+1 dokumen; gunakan utils.CursorResult untuk memotongnya.
+func (r *userRepository) GetUsersCursorRepo(search string, page model.CursorPage, f model.Filter) ([]mongo.User, error) {
+	collection := r.db.Collection("user")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
-	filter := bson.M{
-		"$or": []bson.M{
-			{"username": bson.M{"$regex": search, "$options": "i"}},
-			{"email": bson.M{"$regex": search, "$options": "i"}},
-		},
-		"deleted_at": nil,
+	filter, sort, err := utils.ApplyCursorBSON(UsersListFilter(search, f), page)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().SetSort(sort).SetLimit(int64(page.Limit + 1))
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []mongo.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
 	}
+	return users, nil
+}
+
+func (r *userRepository) CountUsersRepo(search string, f model.Filter) (int, error) {
+	collection := r.db.Collection("user")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
+	defer cancel()
+
+	filter := UsersListFilter(search, f)
 
 	count, err := collection.CountDocuments(ctx, filter)
 	if err != nil {
@@ -67,9 +129,9 @@ func CountUsersRepo(search string) (int, error) {
 }
 
 // GetUserByID dipanggil oleh service.GetUsersByID
-func GetUserByID(id string) (*mongo.User, error) {
-	collection := helper.GetCollection("user") // <-- Menggunakan helper
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *userRepository) GetUserByID(id string) (*mongo.User, error) {
+	collection := r.db.Collection("user") // <-- Menggunakan helper
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
 	defer cancel()
 
 	objID, err := helper.ToObjectID(id) // <-- Menggunakan helper
@@ -84,4 +146,68 @@ func GetUserByID(id string) (*mongo.User, error) {
 		return nil, err
 	}
 	return &user, nil
-}
\ No newline at end of file
+}
+
+// UpdateUser mengganti username, email dan role user yang belum dihapus.
+func (r *userRepository) UpdateUser(id string, req model.UpdateUserRequest) (*mongo.User, error) {
+	collection := r.db.Collection("user")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
+	defer cancel()
+
+	objID, err := helper.ToObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	update := bson.M{"$set": bson.M{
+		"username":   req.Username,
+		"email":      req.Email,
+		"role":       req.Role,
+		"updated_at": time.Now(),
+	}}
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": objID, "deleted_at": nil}, update)
+	if err != nil {
+		return nil, WrapDuplicate(err)
+	}
+	if result.MatchedCount == 0 {
+		return nil, mongodriver.ErrNoDocuments
+	}
+	return r.GetUserByID(id)
+}
+
+// CreateUser menyimpan user baru dengan ID dan waktu yang dibuat di sini.
+func (r *userRepository) CreateUser(user *mongo.User) (*mongo.User, error) {
+	collection := r.db.Collection("user")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
+	defer cancel()
+
+	user.ID = primitive.NewObjectID()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	if _, err := collection.InsertOne(ctx, user); err != nil {
+		return nil, WrapDuplicate(err)
+	}
+	return user, nil
+}
+
+// UpdatePassword mengganti hash password user yang belum dihapus.
+func (r *userRepository) UpdatePassword(id string, passwordHash string) error {
+	collection := r.db.Collection("user")
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Second)
+	defer cancel()
+
+	objID, err := helper.ToObjectID(id)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{"password": passwordHash, "updated_at": time.Now()}}
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": objID, "deleted_at": nil}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+	return nil
+}
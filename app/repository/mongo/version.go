@@ -0,0 +1,39 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// withVersion menambahkan syarat version ke filter update, kecuali AnyVersion.
+// Dokumen lama tanpa field version (migrasi 5 belum jalan) terbaca sebagai
+// version 0, yang sama dengan AnyVersion, sehingga tetap bisa diupdate.
+func withVersion(filter bson.M, version int) bson.M {
+	if version == AnyVersion {
+		return filter
+	}
+	withVersion := bson.M{}
+	for k, v := range filter {
+		withVersion[k] = v
+	}
+	withVersion["version"] = version
+	return withVersion
+}
+
+// versionChecked membedakan dua penyebab update yang tidak mengenai dokumen
+// apa pun: dokumen tidak ada (notFound) atau version sudah berubah (ErrVersionConflict).
+func versionChecked(ctx context.Context, collection *mongodriver.Collection, result *mongodriver.UpdateResult, filter bson.M, notFound error) error {
+	if result.MatchedCount > 0 {
+		return nil
+	}
+	n, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+	return ErrVersionConflict
+}
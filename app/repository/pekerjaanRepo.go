@@ -1,140 +1,212 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"latihan2/app/model"
-	"latihan2/database"
-	"log"
+	"latihan2/logging"
+	"latihan2/utils"
+	"strconv"
 	"time"
 )
 
+// PekerjaanRepository adalah akses data pekerjaan_alumni di Postgres.
+type PekerjaanRepository interface {
+	GetPekerjaanRepo(search, sortBy, order string, limit, offset int, role string, userID int, gaji model.GajiFilter, filter model.Filter) ([]model.Pekerjaan, error)
+	GetPekerjaanCursorRepo(search string, page model.CursorPage, role string, userID int, gaji model.GajiFilter, filter model.Filter) ([]model.Pekerjaan, error)
+	CountPekerjaanRepo(search string, role string, userID int, gaji model.GajiFilter, filter model.Filter) (int, error)
+	GetPekerjaanByIDRepo(id int, userID int, role string) (*model.Pekerjaan, error)
+	GetPekerjaanByAlumniID(alumniID int) ([]model.Pekerjaan, error)
+	CreatePekerjaan(req model.CreatePekerjaanRequest) (*model.Pekerjaan, error)
+	UpdatePekerjaan(id int, userID int, role string, version int, req model.UpdatePekerjaanRequest) (*model.Pekerjaan, error)
+	SoftDeletePekerjaan(pekerjaanID int, userID int, role string) error
+	RestorePekerjaan(pekerjaanID int, userID int, role string) error
+	GetTrashPekerjaanByID(pekerjaanID int, userID int, role string) (*model.TrashPekerjaanResponse, error)
+	HardDeletePekerjaan(pekerjaanID int, userID int, role string) error
+	MigrateGajiRange(dryRun bool) (*model.GajiMigrationReport, error)
+	SearchPekerjaan(q string, limit, offset int, role string, userID int, basic bool) ([]model.PekerjaanSearchResult, string, error)
+	// WithContext mengembalikan repository yang menjalankan query dengan ctx,
+	// mis. c.UserContext() agar query menjadi span anak dari span request.
+	WithContext(ctx context.Context) PekerjaanRepository
+}
+
+type pekerjaanRepository struct {
+	db  *sql.DB
+	ctx context.Context
+}
+
+func NewPekerjaanRepository(db *sql.DB) PekerjaanRepository {
+	return &pekerjaanRepository{db: db, ctx: context.Background()}
+}
+
+func (r *pekerjaanRepository) WithContext(ctx context.Context) PekerjaanRepository {
+	c := *r
+	c.ctx = ctx
+	return &c
+}
+
 var (
 	ErrPekerjaanNotFound = errors.New("pekerjaan not found")
 	ErrForbidden         = errors.New("forbidden access")
+	// ErrVersionConflict dikembalikan update jika version yang dikirim klien
+	// (lewat If-Match) sudah tidak sama dengan version di database.
+	ErrVersionConflict = errors.New("version conflict")
 )
 
-func GetPekerjaanRepo(search, sortBy, order string, limit, offset int, role string, userID int) ([]model.Pekerjaan, error) {
-    
-    // 1. Buat query dasar
-    baseQuery := `
-        FROM pekerjaan_alumni pa
-        LEFT JOIN alumni a ON pa.alumni_id = a.id
-        WHERE (
-            pa.nama_perusahaan ILIKE $1 
-            OR pa.posisi_jabatan ILIKE $1 
-            OR pa.bidang_industri ILIKE $1 
-            OR pa.lokasi_kerja ILIKE $1
-        ) AND pa.is_delete = false
-    `
-    // 2. Siapkan argumen
-    args := []interface{}{"%" + search + "%"}
-    
-    // 3. Tambahkan filter user jika role bukan admin
-    if role == "user" {
-        baseQuery += " AND a.user_id = $2"
-        args = append(args, userID)
-    }
+// AnyVersion dipakai sebagai version pada update yang tidak perlu dicek (If-Match: *).
+const AnyVersion = 0
 
-    // 4. Buat query SELECT
-    selectQuery := fmt.Sprintf(`
-        SELECT pa.id, pa.alumni_id, pa.nama_perusahaan, pa.posisi_jabatan, pa.bidang_industri, pa.lokasi_kerja,
-               pa.gaji_range, pa.tanggal_mulai_kerja, pa.tanggal_selesai_kerja, pa.status_pekerjaan,
-               pa.deskripsi_pekerjaan, pa.created_at, pa.updated_at
-        %s
-        ORDER BY %s %s
-        LIMIT $%d OFFSET $%d
-    `, baseQuery, sortBy, order, len(args)+1, len(args)+2) // Penomoran $ sudah benar
-    
-    args = append(args, limit, offset)
+// gajiColumns dibaca bersama kolom pekerjaan lain; pasangannya adalah gajiScanDest.
+const gajiColumns = `pa.gaji_min, pa.gaji_max, COALESCE(pa.gaji_mata_uang, ''), COALESCE(pa.gaji_periode, '')`
 
-    // 5. Eksekusi
-    rows, err := database.DB.Query(selectQuery, args...)
-    if err != nil {
-        log.Println("Query error:", err)
-        return nil, err
-    }
-    defer rows.Close()
-
-    // 6. Scan hasilnya (INI BAGIAN YANG PERLU DILENGKAPI)
-    var pekerjaanList []model.Pekerjaan
-    for rows.Next() {
-        var p model.Pekerjaan
-        if err := rows.Scan(
-            &p.ID,
-            &p.AlumniID,
-            &p.NamaPerusahaan,
-            &p.PosisiJabatan,
-            &p.BidangIndustri,
-            &p.LokasiKerja,
-            &p.GajiRange,
-            &p.TanggalMulaiKerja,
-            &p.TanggalSelesaiKerja,
-            &p.StatusPekerjaan,
-            &p.Deskripsi,
-            &p.CreatedAt,
-            &p.UpdatedAt,
-        ); err != nil {
-            return nil, err // Tangani error saat scanning
-        }
-        pekerjaanList = append(pekerjaanList, p)
-    }
+func gajiScanDest(g *model.RentangGaji) []interface{} {
+	return []interface{}{&g.GajiMin, &g.GajiMax, &g.GajiMataUang, &g.GajiPeriode}
+}
 
-    // Selalu cek error setelah loop rows
-    if err = rows.Err(); err != nil {
-        return nil, err
-    }
+// pekerjaanListFilter menyusun FROM/WHERE yang dipakai bersama oleh GetPekerjaanRepo dan CountPekerjaanRepo.
+func pekerjaanListFilter(search string, role string, userID int, gaji model.GajiFilter, filter model.Filter) (string, []interface{}) {
+	baseQuery := `
+		FROM pekerjaan_alumni pa
+		LEFT JOIN alumni a ON pa.alumni_id = a.id
+		WHERE (
+			pa.nama_perusahaan ILIKE $1
+			OR pa.posisi_jabatan ILIKE $1
+			OR pa.bidang_industri ILIKE $1
+			OR pa.lokasi_kerja ILIKE $1
+		) AND pa.is_delete = false
+	`
+	args := []interface{}{"%" + search + "%"}
+
+	// Filter user jika role bukan admin
+	if role == "user" {
+		args = append(args, userID)
+		baseQuery += fmt.Sprintf(" AND a.user_id = $%d", len(args))
+	}
+
+	// Filter gaji: gaji_min >= Min dan batas atas (gaji_max, atau gaji_min jika tanpa batas atas) <= Max
+	if gaji.Min != nil {
+		args = append(args, *gaji.Min)
+		baseQuery += fmt.Sprintf(" AND pa.gaji_min >= $%d", len(args))
+	}
+	if gaji.Max != nil {
+		args = append(args, *gaji.Max)
+		baseQuery += fmt.Sprintf(" AND COALESCE(pa.gaji_max, pa.gaji_min) <= $%d", len(args))
+	}
+	if gaji.MataUang != "" {
+		args = append(args, gaji.MataUang)
+		baseQuery += fmt.Sprintf(" AND pa.gaji_mata_uang = $%d", len(args))
+	}
 
-    return pekerjaanList, nil
+	where, args := utils.FilterToSQL(filter, "pa.", args)
+	baseQuery += " AND " + where
+
+	return baseQuery, args
 }
 
-func CountPekerjaanRepo(search string, role string, userID int) (int, error) {
-    var total int
-    baseQuery := `
-        FROM pekerjaan_alumni pa
-        LEFT JOIN alumni a ON pa.alumni_id = a.id
-        WHERE (
-            pa.nama_perusahaan ILIKE $1 
-            OR pa.posisi_jabatan ILIKE $1 
-            OR pa.bidang_industri ILIKE $1 
-            OR pa.lokasi_kerja ILIKE $1
-        ) AND pa.is_delete = false
-    `
-    args := []interface{}{"%" + search + "%"}
-
-    if role == "user" {
-        baseQuery += " AND a.user_id = $2"
-        args = append(args, userID)
-    }
-    countQuery := fmt.Sprintf("SELECT count(*) %s", baseQuery)
+const pekerjaanListColumns = `
+		SELECT pa.id, pa.alumni_id, pa.nama_perusahaan, pa.posisi_jabatan, pa.bidang_industri, pa.lokasi_kerja,
+		       pa.gaji_range, pa.tanggal_mulai_kerja, pa.tanggal_selesai_kerja, pa.status_pekerjaan,
+		       pa.deskripsi_pekerjaan, pa.created_at, pa.updated_at, pa.version, ` + gajiColumns
 
-    err := database.DB.QueryRow(countQuery, args...).Scan(&total)
-    if err != nil {
-        log.Printf("Error counting pekerjaan: %v", err)
-        return 0, err
-    }
+func (r *pekerjaanRepository) GetPekerjaanRepo(search, sortBy, order string, limit, offset int, role string, userID int, gaji model.GajiFilter, filter model.Filter) ([]model.Pekerjaan, error) {
+	baseQuery, args := pekerjaanListFilter(search, role, userID, gaji, filter)
+
+	selectQuery := fmt.Sprintf(`%s
+		%s
+		ORDER BY pa.%s %s NULLS LAST
+		LIMIT $%d OFFSET $%d
+	`, pekerjaanListColumns, baseQuery, sortBy, order, len(args)+1, len(args)+2)
+
+	args = append(args, limit, offset)
+
+	rows, err := r.db.QueryContext(r.ctx, selectQuery, args...)
+	if err != nil {
+		logging.FromContext(r.ctx).Error("query pekerjaan gagal", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPekerjaanList(rows)
+}
+
+// GetPekerjaanCursorRepo mengambil satu halaman pekerjaan dengan pagination keyset.
+// Hasilnya berisi sampai page.Limit+1 baris; gunakan utils.CursorResult untuk memotongnya.
+func (r *pekerjaanRepository) GetPekerjaanCursorRepo(search string, page model.CursorPage, role string, userID int, gaji model.GajiFilter, filter model.Filter) ([]model.Pekerjaan, error) {
+	baseQuery, args := pekerjaanListFilter(search, role, userID, gaji, filter)
+	keyset, orderBy, args := utils.CursorToSQL(page, "pa."+page.SortBy, "pa.id", args)
+
+	selectQuery := fmt.Sprintf(`%s
+		%s AND %s
+		ORDER BY %s
+		LIMIT $%d
+	`, pekerjaanListColumns, baseQuery, keyset, orderBy, len(args)+1)
+
+	rows, err := r.db.QueryContext(r.ctx, selectQuery, append(args, page.Limit+1)...)
+	if err != nil {
+		logging.FromContext(r.ctx).Error("query pekerjaan gagal", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPekerjaanList(rows)
+}
+
+func scanPekerjaanList(rows *sql.Rows) ([]model.Pekerjaan, error) {
+	var pekerjaanList []model.Pekerjaan
+	for rows.Next() {
+		var p model.Pekerjaan
+		dest := []interface{}{
+			&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja,
+			&p.GajiRange, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan,
+			&p.Deskripsi, &p.CreatedAt, &p.UpdatedAt, &p.Version,
+		}
+		if err := rows.Scan(append(dest, gajiScanDest(&p.RentangGaji)...)...); err != nil {
+			return nil, err
+		}
+		pekerjaanList = append(pekerjaanList, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pekerjaanList, nil
+}
+
+func (r *pekerjaanRepository) CountPekerjaanRepo(search string, role string, userID int, gaji model.GajiFilter, filter model.Filter) (int, error) {
+	var total int
+	baseQuery, args := pekerjaanListFilter(search, role, userID, gaji, filter)
+	countQuery := fmt.Sprintf("SELECT count(*) %s", baseQuery)
+
+	err := r.db.QueryRowContext(r.ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		logging.FromContext(r.ctx).Error("menghitung pekerjaan gagal", "error", err)
+		return 0, err
+	}
 
-    return total, nil
+	return total, nil
 }
 
-func GetPekerjaanByIDRepo(id int, userID int, role string) (*model.Pekerjaan, error) {
+func (r *pekerjaanRepository) GetPekerjaanByIDRepo(id int, userID int, role string) (*model.Pekerjaan, error) {
 	var p model.Pekerjaan
 	
     // Kolom-kolom yang akan di-SELECT
 	queryFields := `
         pa.id, pa.alumni_id, pa.nama_perusahaan, pa.posisi_jabatan, pa.bidang_industri,
         pa.lokasi_kerja, pa.gaji_range, pa.tanggal_mulai_kerja, pa.tanggal_selesai_kerja,
-        pa.status_pekerjaan, pa.deskripsi_pekerjaan, pa.created_at, pa.updated_at
-    ` // <-- 'updated_at' sudah ditambahkan
+        pa.status_pekerjaan, pa.deskripsi_pekerjaan, pa.created_at, pa.updated_at, pa.version,
+    ` + gajiColumns // <-- 'updated_at' sudah ditambahkan
     
     // Tujuan Scan
 	scanDest := []interface{}{
 		&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan,
 		&p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja,
 		&p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.Deskripsi, &p.CreatedAt,
-		&p.UpdatedAt, // <-- 'UpdatedAt' sudah ditambahkan
+		&p.UpdatedAt, &p.Version, // <-- 'UpdatedAt' sudah ditambahkan
 	}
+	scanDest = append(scanDest, gajiScanDest(&p.RentangGaji)...)
 
 	var query string
     var err error
@@ -146,7 +218,7 @@ func GetPekerjaanByIDRepo(id int, userID int, role string) (*model.Pekerjaan, er
             FROM pekerjaan_alumni pa
             WHERE pa.id = $1 AND pa.is_delete = false
         `, queryFields)
-		err = database.DB.QueryRow(query, id).Scan(scanDest...)
+		err = r.db.QueryRowContext(r.ctx, query, id).Scan(scanDest...)
 	
     } else {
         // User hanya bisa lihat data miliknya sendiri (yang belum di-soft-delete)
@@ -156,7 +228,7 @@ func GetPekerjaanByIDRepo(id int, userID int, role string) (*model.Pekerjaan, er
             JOIN alumni a ON pa.alumni_id = a.id
             WHERE pa.id = $1 AND a.user_id = $2 AND pa.is_delete = false
         `, queryFields)
-		err = database.DB.QueryRow(query, id, userID).Scan(scanDest...)
+		err = r.db.QueryRowContext(r.ctx, query, id, userID).Scan(scanDest...)
 	}
 
 	if err != nil {
@@ -165,14 +237,14 @@ func GetPekerjaanByIDRepo(id int, userID int, role string) (*model.Pekerjaan, er
 	return &p, nil
 }
 
-func GetPekerjaanByAlumniID(alumniID int) ([]model.Pekerjaan, error) {
-	rows, err := database.DB.Query(`
-		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
-			   lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-			   status_pekerjaan, deskripsi_pekerjaan, created_at
-		FROM pekerjaan_alumni
-		WHERE alumni_id = $1
-		ORDER BY tanggal_mulai_kerja DESC`, alumniID)
+func (r *pekerjaanRepository) GetPekerjaanByAlumniID(alumniID int) ([]model.Pekerjaan, error) {
+	rows, err := r.db.QueryContext(r.ctx, `
+		SELECT pa.id, pa.alumni_id, pa.nama_perusahaan, pa.posisi_jabatan, pa.bidang_industri,
+			   pa.lokasi_kerja, pa.gaji_range, pa.tanggal_mulai_kerja, pa.tanggal_selesai_kerja,
+			   pa.status_pekerjaan, pa.deskripsi_pekerjaan, pa.created_at, pa.updated_at, pa.version, `+gajiColumns+`
+		FROM pekerjaan_alumni pa
+		WHERE pa.alumni_id = $1
+		ORDER BY pa.tanggal_mulai_kerja DESC`, alumniID)
 	if err != nil {
 		return nil, err
 	}
@@ -181,11 +253,13 @@ func GetPekerjaanByAlumniID(alumniID int) ([]model.Pekerjaan, error) {
 	var pekerjaanList []model.Pekerjaan
 	for rows.Next() {
 		var p model.Pekerjaan
-		if err := rows.Scan(
+		dest := []interface{}{
 			&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan,
 			&p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja,
 			&p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.Deskripsi, &p.CreatedAt,
-		); err != nil {
+			&p.UpdatedAt, &p.Version,
+		}
+		if err := rows.Scan(append(dest, gajiScanDest(&p.RentangGaji)...)...); err != nil {
 			return nil, err
 		}
 		pekerjaanList = append(pekerjaanList, p)
@@ -193,24 +267,25 @@ func GetPekerjaanByAlumniID(alumniID int) ([]model.Pekerjaan, error) {
 	return pekerjaanList, nil
 }
 
-func CreatePekerjaan(req model.CreatePekerjaanRequest) (*model.Pekerjaan, error) {
+func (r *pekerjaanRepository) CreatePekerjaan(req model.CreatePekerjaanRequest) (*model.Pekerjaan, error) {
 	// Gunakan model.Pekerjaan (dari prompt pertama) sebagai target Scan
 	var p model.Pekerjaan
 
 	// Ini adalah query SQL LENGKAP tanpa '...'
 	query := `
-    INSERT INTO pekerjaan_alumni 
+    INSERT INTO pekerjaan_alumni AS pa
     (
         alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
         lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-        status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at
+        status_pekerjaan, deskripsi_pekerjaan, is_delete, created_at,
+        gaji_min, gaji_max, gaji_mata_uang, gaji_periode
     )
-    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, FALSE, $11)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, FALSE, $11, $12, $13, NULLIF($14, ''), NULLIF($15, ''))
     RETURNING 
         id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
         lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-        status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at
-    `
+        status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, version,
+    ` + gajiColumns
 
 	// --- Konversi Tipe Data (Sama seperti sebelumnya) ---
 	tglMulai, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
@@ -229,7 +304,7 @@ func CreatePekerjaan(req model.CreatePekerjaanRequest) (*model.Pekerjaan, error)
 	// --- Selesai Konversi ---
 
 	// Eksekusi query dengan argumen yang benar
-	err = database.DB.QueryRow(query,
+	err = r.db.QueryRowContext(r.ctx, query,
 		req.AlumniID,           // $1
 		req.NamaPerusahaan,     // $2
 		req.PosisiJabatan,      // $3
@@ -241,6 +316,10 @@ func CreatePekerjaan(req model.CreatePekerjaanRequest) (*model.Pekerjaan, error)
 		req.StatusPekerjaan,    // $9
 		req.DeskripsiPekerjaan, // $10
 		time.Now(),             // $11 (untuk created_at)
+		req.GajiMin,            // $12
+		req.GajiMax,            // $13
+		req.GajiMataUang,       // $14
+		req.GajiPeriode,        // $15
 	).Scan(
 		// Scan ke struct model.Pekerjaan (p)
 		// Urutan ini HARUS cocok dengan urutan RETURNING
@@ -257,18 +336,30 @@ func CreatePekerjaan(req model.CreatePekerjaanRequest) (*model.Pekerjaan, error)
 		&p.Deskripsi, // Target adalah string (deskripsi_pekerjaan -> Deskripsi)
 		&p.CreatedAt,
 		&p.UpdatedAt,
+		&p.Version,
+		&p.GajiMin,
+		&p.GajiMax,
+		&p.GajiMataUang,
+		&p.GajiPeriode,
 	)
 
 	if err != nil {
-		log.Println("SQL Insert/Scan Error:", err)
-		return nil, err
+		logging.FromContext(r.ctx).Debug("insert pekerjaan gagal", "error", err)
+		return nil, WrapDuplicate(err)
 	}
 
 	// Kembalikan struct Pekerjaan yang sudah terisi
 	return &p, nil
 }
 
-func UpdatePekerjaan(id int, userID int, role string, req model.UpdatePekerjaanRequest) (*model.Pekerjaan, error) {
+// UpdatePekerjaan hanya berhasil jika version masih sama dengan di database
+// (kecuali AnyVersion), lalu menaikkan version.
+func (r *pekerjaanRepository) UpdatePekerjaan(id int, userID int, role string, version int, req model.UpdatePekerjaanRequest) (*model.Pekerjaan, error) {
+	tglMulai, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
+	if err != nil {
+		return nil, fmt.Errorf("format tanggal_mulai_kerja salah: %w", err)
+	}
+
 	var tglSelesaiVal sql.NullTime
 	if req.TanggalSelesaiKerja != "" {
 		if t, err := time.Parse("2006-01-02", req.TanggalSelesaiKerja); err == nil {
@@ -280,22 +371,28 @@ func UpdatePekerjaan(id int, userID int, role string, req model.UpdatePekerjaanR
 		UPDATE pekerjaan_alumni
 		SET nama_perusahaan=$1, posisi_jabatan=$2, bidang_industri=$3, lokasi_kerja=$4,
 			gaji_range=$5, tanggal_mulai_kerja=$6, tanggal_selesai_kerja=$7,
-			status_pekerjaan=$8, deskripsi_pekerjaan=$9
-		WHERE id=$10
+			status_pekerjaan=$8, deskripsi_pekerjaan=$9,
+			gaji_min=$11, gaji_max=$12, gaji_mata_uang=NULLIF($13, ''), gaji_periode=NULLIF($14, ''),
+			updated_at=NOW(), version=version+1
+		WHERE id=$10 AND ($15 = 0 OR version = $15)
 	`
-	_, err := database.DB.Exec(query,
+	result, err := r.db.ExecContext(r.ctx, query,
 		req.NamaPerusahaan, req.PosisiJabatan, req.BidangIndustri, req.LokasiKerja,
-		req.GajiRange, req.TanggalMulaiKerja, tglSelesaiVal, req.StatusPekerjaan,
+		req.GajiRange, tglMulai, tglSelesaiVal, req.StatusPekerjaan,
 		req.DeskripsiPekerjaan, id,
+		req.GajiMin, req.GajiMax, req.GajiMataUang, req.GajiPeriode, version,
 	)
 	if err != nil {
+		return nil, WrapDuplicate(err)
+	}
+	if err := versionChecked(r.ctx, r.db, result, "pekerjaan_alumni", id); err != nil {
 		return nil, err
 	}
-	return GetPekerjaanByIDRepo(id, userID, role)
+	return r.GetPekerjaanByIDRepo(id, userID, role)
 }
 
-func SoftDeletePekerjaan(pekerjaanID int, userID int, role string) error {
-	tx, err := database.DB.Begin()
+func (r *pekerjaanRepository) SoftDeletePekerjaan(pekerjaanID int, userID int, role string) error {
+	tx, err := r.db.BeginTx(r.ctx, nil)
 	if err != nil {
 		return fmt.Errorf("gagal memulai transaksi: %w", err)
 	}
@@ -309,7 +406,7 @@ func SoftDeletePekerjaan(pekerjaanID int, userID int, role string) error {
 		JOIN alumni a ON pa.alumni_id = a.id
 		WHERE pa.id = $1
 	`
-	err = tx.QueryRow(checkQuery, pekerjaanID).Scan(&currentOwnerUserID, &isDeleted)
+	err = tx.QueryRowContext(r.ctx, checkQuery, pekerjaanID).Scan(&currentOwnerUserID, &isDeleted)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -333,7 +430,7 @@ func SoftDeletePekerjaan(pekerjaanID int, userID int, role string) error {
             deleted_at = $2
         WHERE id = $3
 	`
-	_, err = tx.Exec(queryUpdate, userID, time.Now(), pekerjaanID)
+	_, err = tx.ExecContext(r.ctx, queryUpdate, userID, time.Now(), pekerjaanID)
 	if err != nil {
 		return fmt.Errorf("gagal melakukan soft delete: %w", err)
 	}
@@ -341,8 +438,8 @@ func SoftDeletePekerjaan(pekerjaanID int, userID int, role string) error {
 	return tx.Commit()
 }
 
-func RestorePekerjaan(pekerjaanID int, userID int, role string) error {
-	tx, err := database.DB.Begin()
+func (r *pekerjaanRepository) RestorePekerjaan(pekerjaanID int, userID int, role string) error {
+	tx, err := r.db.BeginTx(r.ctx, nil)
 	if err != nil {
 		return fmt.Errorf("gagal memulai transaksi: %w", err)
 	}
@@ -356,7 +453,7 @@ func RestorePekerjaan(pekerjaanID int, userID int, role string) error {
 			JOIN alumni a ON pa.alumni_id = a.id
 			WHERE pa.id = $1 AND pa.is_delete = TRUE
 		`
-		err := tx.QueryRow(queryCekKepemilikan, pekerjaanID).Scan(&ownerUserID)
+		err := tx.QueryRowContext(r.ctx, queryCekKepemilikan, pekerjaanID).Scan(&ownerUserID)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return ErrPekerjaanNotFound
@@ -376,7 +473,7 @@ func RestorePekerjaan(pekerjaanID int, userID int, role string) error {
             deleted_at = NULL
         WHERE id = $1 AND COALESCE(is_delete, FALSE) = TRUE
 	`
-	result, err := tx.Exec(queryUpdate, pekerjaanID)
+	result, err := tx.ExecContext(r.ctx, queryUpdate, pekerjaanID)
 	if err != nil {
 		return fmt.Errorf("gagal melakukan restore: %w", err)
 	}
@@ -393,7 +490,7 @@ func RestorePekerjaan(pekerjaanID int, userID int, role string) error {
 	return tx.Commit()
 }
 
-func GetTrashPekerjaanByID(pekerjaanID int, userID int, role string) (*model.TrashPekerjaanResponse, error) {
+func (r *pekerjaanRepository) GetTrashPekerjaanByID(pekerjaanID int, userID int, role string) (*model.TrashPekerjaanResponse, error) {
     var p model.TrashPekerjaanResponse
     var err error
 
@@ -401,18 +498,19 @@ func GetTrashPekerjaanByID(pekerjaanID int, userID int, role string) (*model.Tra
     queryFields := `
         pa.id, pa.alumni_id, pa.nama_perusahaan, pa.posisi_jabatan, pa.bidang_industri,
         pa.lokasi_kerja, pa.gaji_range, pa.tanggal_mulai_kerja, pa.tanggal_selesai_kerja,
-        pa.status_pekerjaan, pa.deskripsi_pekerjaan, pa.created_at, pa.updated_at,
-        pa.is_delete, pa.delete_by, pa.deleted_at
-    `
+        pa.status_pekerjaan, pa.deskripsi_pekerjaan, pa.created_at, pa.updated_at, pa.version,
+        pa.is_delete, pa.delete_by, pa.deleted_at,
+    ` + gajiColumns
 
     // PERBAIKAN: Tambahkan 3 field tujuan untuk soft delete di akhir
     scanDest := []interface{}{
         &p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan,
         &p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja,
         &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.Deskripsi,
-        &p.CreatedAt, &p.UpdatedAt,
+        &p.CreatedAt, &p.UpdatedAt, &p.Version,
         &p.IsDelete, &p.DeletedBy, &p.DeletedAt, // <-- Ditambahkan di sini
     }
+    scanDest = append(scanDest, gajiScanDest(&p.RentangGaji)...)
 
     if role == "admin" {
         query := fmt.Sprintf(`
@@ -421,7 +519,7 @@ func GetTrashPekerjaanByID(pekerjaanID int, userID int, role string) (*model.Tra
             WHERE pa.id = $1 AND pa.is_delete = TRUE
         `, queryFields)
         // Gunakan ... untuk "membongkar" slice scanDest
-        err = database.DB.QueryRow(query, pekerjaanID).Scan(scanDest...) 
+        err = r.db.QueryRowContext(r.ctx, query, pekerjaanID).Scan(scanDest...) 
     } else {
         query := fmt.Sprintf(`
             SELECT %s
@@ -430,7 +528,7 @@ func GetTrashPekerjaanByID(pekerjaanID int, userID int, role string) (*model.Tra
             WHERE pa.id = $1 AND a.user_id = $2 AND pa.is_delete = TRUE
         `, queryFields)
         // Gunakan ... untuk "membongkar" slice scanDest
-        err = database.DB.QueryRow(query, pekerjaanID, userID).Scan(scanDest...) 
+        err = r.db.QueryRowContext(r.ctx, query, pekerjaanID, userID).Scan(scanDest...) 
     }
 
     if err != nil {
@@ -440,7 +538,7 @@ func GetTrashPekerjaanByID(pekerjaanID int, userID int, role string) (*model.Tra
     return &p, nil
 }
 
-func HardDeletePekerjaan(pekerjaanID int, userID int, role string) error {
+func (r *pekerjaanRepository) HardDeletePekerjaan(pekerjaanID int, userID int, role string) error {
 	var query string
 	var result sql.Result
 	var err error
@@ -448,7 +546,7 @@ func HardDeletePekerjaan(pekerjaanID int, userID int, role string) error {
 	if role == "admin" {
 
 		query = `DELETE FROM pekerjaan_alumni WHERE id = $1 AND is_delete = TRUE`
-		result, err = database.DB.Exec(query, pekerjaanID)
+		result, err = r.db.ExecContext(r.ctx, query, pekerjaanID)
 	} else {
 
 		query = `
@@ -456,7 +554,7 @@ func HardDeletePekerjaan(pekerjaanID int, userID int, role string) error {
 			WHERE id = $1 AND is_delete = TRUE
 			AND alumni_id IN (SELECT id FROM alumni WHERE user_id = $2)
 		`
-		result, err = database.DB.Exec(query, pekerjaanID, userID)
+		result, err = r.db.ExecContext(r.ctx, query, pekerjaanID, userID)
 	}
 
 	if err != nil {
@@ -474,3 +572,79 @@ func HardDeletePekerjaan(pekerjaanID int, userID int, role string) error {
 
 	return nil
 }
+
+// MigrateGajiRange mengisi kolom gaji terstruktur dari gaji_range untuk baris yang
+// belum punya gaji_min/gaji_max. Baris yang tidak bisa diparse dilaporkan, tidak diubah.
+func (r *pekerjaanRepository) MigrateGajiRange(dryRun bool) (*model.GajiMigrationReport, error) {
+	rows, err := r.db.QueryContext(r.ctx, `
+		SELECT id, COALESCE(gaji_range, '')
+		FROM pekerjaan_alumni
+		WHERE gaji_min IS NULL AND gaji_max IS NULL
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	type kandidat struct {
+		id   int
+		gaji model.RentangGaji
+	}
+	report := &model.GajiMigrationReport{DryRun: dryRun, Gagal: []model.GajiMigrationFailure{}}
+	var updates []kandidat
+	for rows.Next() {
+		var id int
+		var gajiRange string
+		if err := rows.Scan(&id, &gajiRange); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		report.Total++
+		g, err := utils.ParseGajiRange(gajiRange)
+		switch {
+		case errors.Is(err, utils.ErrGajiKosong):
+			report.Kosong++
+		case err != nil:
+			report.Gagal = append(report.Gagal, model.GajiMigrationFailure{
+				ID: strconv.Itoa(id), GajiRange: gajiRange, Alasan: err.Error(),
+			})
+		default:
+			updates = append(updates, kandidat{id: id, gaji: g})
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	report.Berhasil = len(updates)
+	if dryRun || len(updates) == 0 {
+		return report, nil
+	}
+
+	tx, err := r.db.BeginTx(r.ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gagal memulai transaksi: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(r.ctx, `
+		UPDATE pekerjaan_alumni
+		SET gaji_min = $1, gaji_max = $2, gaji_mata_uang = $3, gaji_periode = $4
+		WHERE id = $5
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	for _, u := range updates {
+		if _, err := stmt.ExecContext(r.ctx, u.gaji.GajiMin, u.gaji.GajiMax, u.gaji.GajiMataUang, u.gaji.GajiPeriode, u.id); err != nil {
+			return nil, fmt.Errorf("gagal update pekerjaan %d: %w", u.id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"latihan2/app/model"
+	"latihan2/logging"
+	"latihan2/utils"
+
+	"github.com/lib/pq"
+)
+
+const searchHeadlineOptions = `'StartSel=` + utils.SnippetStart + `, StopSel=` + utils.SnippetStop + `, MaxWords=25, MinWords=10, MaxFragments=2'`
+
+// htmlEscapeSQL meng-escape ekspresi teks seperti html.EscapeString sebelum
+// diberikan ke ts_headline, sehingga snippet hanya berisi tag <mark> dari
+// StartSel/StopSel. & harus diganti paling awal.
+func htmlEscapeSQL(expr string) string {
+	return `replace(replace(replace(replace(replace(` + expr +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`
+}
+
+// fullTextUnavailable melaporkan error karena kolom search_vector belum dibuat
+// (migrasi 0005 belum dijalankan), sehingga pencarian perlu jatuh ke mode basic.
+func fullTextUnavailable(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && (pqErr.Code == "42703" || pqErr.Code == "42883")
+}
+
+const alumniSearchColumns = `a.id, a.user_id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email,
+		       a.no_telepon, a.alamat, a.created_at, a.updated_at, a.version`
+
+// SearchAlumni mencari alumni yang belum dihapus berdasarkan nama dan jurusan, diurutkan menurut relevansi.
+// Jika basic true atau kolom search_vector belum ada, dipakai pencarian ILIKE lama.
+// Mode yang benar-benar dipakai dikembalikan bersama hasilnya.
+func (r *alumniRepository) SearchAlumni(q string, limit, offset int, basic bool) ([]model.AlumniSearchResult, string, error) {
+	if !basic {
+		query := fmt.Sprintf(`
+			SELECT %s, ts_rank(a.search_vector, q) AS score,
+			       ts_headline('simple', %s, q, %s) AS snippet
+			FROM alumni a, websearch_to_tsquery('simple', $1) q
+			WHERE a.search_vector @@ q AND a.deleted_at IS NULL
+			ORDER BY score DESC, a.id
+			LIMIT $2 OFFSET $3
+		`, alumniSearchColumns, htmlEscapeSQL(`concat_ws(' · ', a.nama, a.jurusan)`), searchHeadlineOptions)
+
+		result, err := r.queryAlumniSearch(query, q, limit, offset)
+		if err == nil {
+			return result, model.SearchModeFullText, nil
+		}
+		if !fullTextUnavailable(err) {
+			return nil, "", err
+		}
+		logging.FromContext(r.ctx).Warn("full-text search alumni tidak tersedia, memakai pencarian basic", "error", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, 0 AS score, concat_ws(' · ', a.nama, a.jurusan) AS snippet
+		FROM alumni a
+		WHERE (a.nim ILIKE $1 OR a.nama ILIKE $1 OR a.email ILIKE $1) AND a.deleted_at IS NULL
+		ORDER BY a.id
+		LIMIT $2 OFFSET $3
+	`, alumniSearchColumns)
+	result, err := r.queryAlumniSearch(query, "%"+q+"%", limit, offset)
+	if err != nil {
+		return nil, "", err
+	}
+	for i := range result {
+		result[i].Snippet = utils.Snippet(result[i].Snippet, q)
+	}
+	return result, model.SearchModeBasic, nil
+}
+
+func (r *alumniRepository) queryAlumniSearch(query, q string, limit, offset int) ([]model.AlumniSearchResult, error) {
+	rows, err := r.db.QueryContext(r.ctx, query, q, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []model.AlumniSearchResult{}
+	for rows.Next() {
+		var r model.AlumniSearchResult
+		if err := rows.Scan(
+			&r.ID, &r.UserID, &r.NIM, &r.Nama, &r.Jurusan, &r.Angkatan, &r.TahunLulus,
+			&r.Email, &r.NoTelepon, &r.Alamat, &r.CreatedAt, &r.UpdatedAt, &r.Version, &r.Score, &r.Snippet,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
+
+// SearchPekerjaan mencari pekerjaan berdasarkan nama_perusahaan, posisi_jabatan dan
+// deskripsi_pekerjaan. User biasa hanya melihat pekerjaan miliknya, sama seperti GetPekerjaanRepo.
+func (r *pekerjaanRepository) SearchPekerjaan(q string, limit, offset int, role string, userID int, basic bool) ([]model.PekerjaanSearchResult, string, error) {
+	args := []interface{}{q}
+	owner := "1=1"
+	if role == "user" {
+		args = append(args, userID)
+		owner = fmt.Sprintf("a.user_id = $%d", len(args))
+	}
+	paging := fmt.Sprintf("LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	if !basic {
+		query := fmt.Sprintf(`%s, ts_rank(pa.search_vector, q) AS score,
+			       ts_headline('simple', %s, q, %s) AS snippet
+			FROM pekerjaan_alumni pa
+			LEFT JOIN alumni a ON pa.alumni_id = a.id
+			CROSS JOIN websearch_to_tsquery('simple', $1) q
+			WHERE pa.search_vector @@ q AND pa.is_delete = false AND %s
+			ORDER BY score DESC, pa.id
+			%s
+		`, pekerjaanListColumns, htmlEscapeSQL(`concat_ws(' · ', pa.nama_perusahaan, pa.posisi_jabatan, pa.deskripsi_pekerjaan)`),
+			searchHeadlineOptions, owner, paging)
+
+		result, err := r.queryPekerjaanSearch(query, args)
+		if err == nil {
+			return result, model.SearchModeFullText, nil
+		}
+		if !fullTextUnavailable(err) {
+			return nil, "", err
+		}
+		logging.FromContext(r.ctx).Warn("full-text search pekerjaan tidak tersedia, memakai pencarian basic", "error", err)
+	}
+
+	query := fmt.Sprintf(`%s, 0 AS score,
+		       concat_ws(' · ', pa.nama_perusahaan, pa.posisi_jabatan, pa.deskripsi_pekerjaan) AS snippet
+		FROM pekerjaan_alumni pa
+		LEFT JOIN alumni a ON pa.alumni_id = a.id
+		WHERE (
+			pa.nama_perusahaan ILIKE $1
+			OR pa.posisi_jabatan ILIKE $1
+			OR pa.bidang_industri ILIKE $1
+			OR pa.lokasi_kerja ILIKE $1
+		) AND pa.is_delete = false AND %s
+		ORDER BY pa.id
+		%s
+	`, pekerjaanListColumns, owner, paging)
+	args[0] = "%" + q + "%"
+	result, err := r.queryPekerjaanSearch(query, args)
+	if err != nil {
+		return nil, "", err
+	}
+	for i := range result {
+		result[i].Snippet = utils.Snippet(result[i].Snippet, q)
+	}
+	return result, model.SearchModeBasic, nil
+}
+
+func (r *pekerjaanRepository) queryPekerjaanSearch(query string, args []interface{}) ([]model.PekerjaanSearchResult, error) {
+	rows, err := r.db.QueryContext(r.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []model.PekerjaanSearchResult{}
+	for rows.Next() {
+		var r model.PekerjaanSearchResult
+		p := &r.Pekerjaan
+		dest := []interface{}{
+			&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja,
+			&p.GajiRange, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan,
+			&p.Deskripsi, &p.CreatedAt, &p.UpdatedAt, &p.Version,
+		}
+		dest = append(dest, gajiScanDest(&p.RentangGaji)...)
+		if err := rows.Scan(append(dest, &r.Score, &r.Snippet)...); err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
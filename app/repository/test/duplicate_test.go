@@ -0,0 +1,41 @@
+package test
+
+import (
+	"errors"
+	"latihan2/app/model"
+	"latihan2/app/repository"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Pelanggaran constraint unik dari Postgres dilaporkan sebagai
+// *repository.DuplicateKeyError dengan nama kolomnya.
+func TestCreateAlumniDuplikat(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(containsMatcher))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO alumni").
+		WillReturnError(&pq.Error{Code: "23505", Table: "alumni", Constraint: "alumni_nim_key"})
+
+	_, err = repository.NewAlumniRepository(db).CreateAlumni(model.CreateAlumniRequest{NIM: "2019001"})
+	var dup *repository.DuplicateKeyError
+	require.True(t, errors.As(err, &dup), "error: %v", err)
+	assert.Equal(t, "nim", dup.Field)
+	assert.ErrorIs(t, err, repository.ErrDuplikat)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWrapDuplicate(t *testing.T) {
+	assert.Nil(t, repository.WrapDuplicate(nil))
+
+	fk := &pq.Error{Code: "23503", Table: "pekerjaan_alumni", Constraint: "pekerjaan_alumni_alumni_id_fkey"}
+	assert.Same(t, fk, repository.WrapDuplicate(fk), "selain 23505 dikembalikan apa adanya")
+
+	err := repository.WrapDuplicate(&pq.Error{Code: "23505", Table: "users", Constraint: "users_email_key"})
+	assert.EqualError(t, err, "email sudah terdaftar")
+}
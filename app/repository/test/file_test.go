@@ -0,0 +1,58 @@
+package test
+
+import (
+	"database/sql"
+	v1Model "latihan2/app/model/v1"
+	"latihan2/app/repository"
+	v1Repo "latihan2/app/repository/v1"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPostgresFiles(t *testing.T) (v1Repo.FileRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(containsMatcher))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return v1Repo.NewPostgresFiles(repository.NewFileRepository(db)), mock
+}
+
+// Metadata file Postgres dipakai /api/v1 dengan ID string, sama dengan Mongo.
+func TestPostgresFiles(t *testing.T) {
+	files, mock := newPostgresFiles(t)
+	uploadedAt := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("INSERT INTO files").
+		WithArgs("a.pdf", "cv.pdf", "uploads/a.pdf", int64(12), "application/pdf", 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uploaded_at"}).AddRow(7, uploadedAt))
+	f := &v1Model.File{FileName: "a.pdf", OriginalName: "cv.pdf", FilePath: "uploads/a.pdf",
+		FileSize: 12, FileType: "application/pdf", UploadedBy: "1", OwnerID: "2"}
+	require.NoError(t, files.CreateFile(f))
+	assert.Equal(t, "7", f.ID)
+	assert.Equal(t, uploadedAt, f.UploadedAt)
+	assert.Equal(t, "2", f.OwnerID)
+
+	mock.ExpectQuery("FROM files WHERE id = $1").WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "file_name", "original_name", "file_path", "file_size", "file_type", "uploaded_at", "uploaded_by", "owner_id"}).
+			AddRow(7, "a.pdf", "cv.pdf", "uploads/a.pdf", 12, "application/pdf", uploadedAt, 1, 2))
+	got, err := files.GetFileByID("7")
+	require.NoError(t, err)
+	assert.Equal(t, *f, *got)
+
+	mock.ExpectQuery("FROM files WHERE id = $1").WithArgs(8).WillReturnError(sql.ErrNoRows)
+	_, err = files.GetFileByID("8")
+	assert.ErrorIs(t, err, v1Repo.ErrNotFound)
+
+	mock.ExpectExec("DELETE FROM files WHERE id = $1").WithArgs(8).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, files.DeleteFile("8"), v1Repo.ErrNotFound)
+
+	// ID Mongo pada driver postgres tidak mungkin ada dan tidak sampai ke database.
+	_, err = files.GetFileByID("65a1b2c3d4e5f6a7b8c9d0e1")
+	assert.ErrorIs(t, err, v1Repo.ErrNotFound)
+	assert.ErrorIs(t, files.CreateFile(&v1Model.File{UploadedBy: "65a1b2c3d4e5f6a7b8c9d0e1", OwnerID: "2"}), v1Repo.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
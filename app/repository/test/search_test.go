@@ -0,0 +1,87 @@
+package test
+
+import (
+	"fmt"
+	"latihan2/app/model"
+	"latihan2/app/repository"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// containsMatcher mencocokkan query yang memuat teks ekspektasi apa adanya.
+var containsMatcher = sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+	if !strings.Contains(actualSQL, expectedSQL) {
+		return fmt.Errorf("query tidak memuat %q", expectedSQL)
+	}
+	return nil
+})
+
+// Alumni yang sudah di-soft-delete tidak boleh muncul di hasil pencarian,
+// baik lewat full-text maupun fallback ILIKE.
+func TestSearchAlumniTanpaSoftDeleted(t *testing.T) {
+	tests := []struct {
+		name  string
+		basic bool
+		setup func(mock sqlmock.Sqlmock)
+		mode  string
+	}{
+		{"full-text", false, func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("a.search_vector @@ q AND a.deleted_at IS NULL").
+				WithArgs("Budi Terhapus", 10, 0).
+				WillReturnRows(sqlmock.NewRows(nil))
+		}, model.SearchModeFullText},
+		{"basic", true, func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("a.email ILIKE $1) AND a.deleted_at IS NULL").
+				WithArgs("%Budi Terhapus%", 10, 0).
+				WillReturnRows(sqlmock.NewRows(nil))
+		}, model.SearchModeBasic},
+		{"fallback tanpa search_vector", false, func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("a.search_vector @@ q AND a.deleted_at IS NULL").
+				WillReturnError(&pq.Error{Code: "42703"})
+			mock.ExpectQuery("a.email ILIKE $1) AND a.deleted_at IS NULL").
+				WithArgs("%Budi Terhapus%", 10, 0).
+				WillReturnRows(sqlmock.NewRows(nil))
+		}, model.SearchModeBasic},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(containsMatcher))
+			require.NoError(t, err)
+			defer db.Close()
+			tt.setup(mock)
+
+			result, mode, err := repository.NewAlumniRepository(db).SearchAlumni("Budi Terhapus", 10, 0, tt.basic)
+			require.NoError(t, err)
+			assert.Empty(t, result)
+			assert.Equal(t, tt.mode, mode)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// Teks diberikan ke ts_headline dalam keadaan sudah di-escape sehingga tag
+// HTML di data tidak ikut keluar bersama <mark>.
+func TestSearchHeadlineEscapeHTML(t *testing.T) {
+	const escaped = `'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(containsMatcher))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("ts_headline('simple', replace(replace(replace(replace(replace(concat_ws(' · ', a.nama, a.jurusan), " + escaped).
+		WillReturnRows(sqlmock.NewRows(nil))
+	_, _, err = repository.NewAlumniRepository(db).SearchAlumni("budi", 10, 0, false)
+	require.NoError(t, err)
+
+	mock.ExpectQuery("ts_headline('simple', replace(replace(replace(replace(replace(concat_ws(' · ', pa.nama_perusahaan, pa.posisi_jabatan, pa.deskripsi_pekerjaan), " + escaped).
+		WillReturnRows(sqlmock.NewRows(nil))
+	_, _, err = repository.NewPekerjaanRepository(db).SearchPekerjaan("golang", 10, 0, "admin", 1, false)
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,34 +1,103 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"latihan2/app/model"
-	"latihan2/database"
-	"log"
+	"latihan2/logging"
+	"latihan2/utils"
 )
 
-func GetUsersRepo(search, sortBy, order, role string, limit, offset int) ([]model.User, error) {
-	condition := "deleted_at IS NULL" // default untuk user biasa
+// UserRepository adalah akses data users di Postgres, termasuk pencarian user saat login.
+type UserRepository interface {
+	GetUsersRepo(search, sortBy, order, role string, limit, offset int, filter model.Filter) ([]model.User, error)
+	GetUsersCursorRepo(search string, page model.CursorPage, role string, filter model.Filter) ([]model.User, error)
+	CountUsersRepo(search string, filter model.Filter) (int, error)
+	SoftDeleteUserRepo(id int) error
+	GetUserByID(id int, role string) (*model.User, error)
+	GetUserByUsername(username string) (*model.User, string, error)
+	UpdateUser(id int, req model.UpdateUserRequest) (*model.User, error)
+	// CreateUser dan UpdatePassword menerima password yang sudah di-hash
+	// dengan utils.HashPassword. Dipakai CLI, belum ada endpoint-nya.
+	CreateUser(user model.User, passwordHash string) (*model.User, error)
+	UpdatePassword(id int, passwordHash string) error
+	// WithContext mengembalikan repository yang menjalankan query dengan ctx,
+	// mis. c.UserContext() agar query menjadi span anak dari span request.
+	WithContext(ctx context.Context) UserRepository
+}
+
+type userRepository struct {
+	db  *sql.DB
+	ctx context.Context
+}
+
+func NewUserRepository(db *sql.DB) UserRepository {
+	return &userRepository{db: db, ctx: context.Background()}
+}
+
+func (r *userRepository) WithContext(ctx context.Context) UserRepository {
+	c := *r
+	c.ctx = ctx
+	return &c
+}
+
+// usersVisibility membatasi user biasa hanya melihat user yang belum dihapus.
+func usersVisibility(role string) string {
 	if role == "admin" {
-		condition = "1=1" // admin bisa lihat semua
+		return "1=1" // admin bisa lihat semua
 	}
+	return "deleted_at IS NULL" // default untuk user biasa
+}
+
+func (r *userRepository) GetUsersRepo(search, sortBy, order, role string, limit, offset int, filter model.Filter) ([]model.User, error) {
+	condition := usersVisibility(role)
+	where, args := utils.FilterToSQL(filter, "", []interface{}{"%" + search + "%"})
 
 	query := fmt.Sprintf(`
 		SELECT id, username, email, role, created_at, deleted_at
 		FROM users
-		WHERE (%s) AND (username ILIKE $1 OR email ILIKE $1)
+		WHERE (%s) AND (username ILIKE $1 OR email ILIKE $1) AND %s
 		ORDER BY %s %s
-		LIMIT $2 OFFSET $3
-	`, condition, sortBy, order)
+		LIMIT $%d OFFSET $%d
+	`, condition, where, sortBy, order, len(args)+1, len(args)+2)
+
+	rows, err := r.db.QueryContext(r.ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		logging.FromContext(r.ctx).Error("query users gagal", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanUsers(rows)
+}
+
+// GetUsersCursorRepo mengambil satu halaman user dengan pagination keyset.
+// Hasilnya berisi sampai page.Limit+1 baris; gunakan utils.CursorResult untuk memotongnya.
+func (r *userRepository) GetUsersCursorRepo(search string, page model.CursorPage, role string, filter model.Filter) ([]model.User, error) {
+	condition := usersVisibility(role)
+	where, args := utils.FilterToSQL(filter, "", []interface{}{"%" + search + "%"})
+	keyset, orderBy, args := utils.CursorToSQL(page, page.SortBy, "id", args)
 
-	rows, err := database.DB.Query(query, "%"+search+"%", limit, offset)
+	query := fmt.Sprintf(`
+		SELECT id, username, email, role, created_at, deleted_at
+		FROM users
+		WHERE (%s) AND (username ILIKE $1 OR email ILIKE $1) AND %s AND %s
+		ORDER BY %s
+		LIMIT $%d
+	`, condition, where, keyset, orderBy, len(args)+1)
+
+	rows, err := r.db.QueryContext(r.ctx, query, append(args, page.Limit+1)...)
 	if err != nil {
-		log.Println("Query error:", err)
+		logging.FromContext(r.ctx).Error("query users gagal", "error", err)
 		return nil, err
 	}
 	defer rows.Close()
 
+	return scanUsers(rows)
+}
+
+func scanUsers(rows *sql.Rows) ([]model.User, error) {
 	var users []model.User
 	for rows.Next() {
 		var u model.User
@@ -37,31 +106,31 @@ func GetUsersRepo(search, sortBy, order, role string, limit, offset int) ([]mode
 		}
 		users = append(users, u)
 	}
-	return users, nil
+	return users, rows.Err()
 }
 
-
-func CountUsersRepo(search string) (int, error) {
+func (r *userRepository) CountUsersRepo(search string, filter model.Filter) (int, error) {
 	var total int
-	countQuery := `SELECT COUNT(*) FROM users WHERE username ILIKE $1 OR email ILIKE $1`
-	err := database.DB.QueryRow(countQuery, "%"+search+"%").Scan(&total)
+	where, args := utils.FilterToSQL(filter, "", []interface{}{"%" + search + "%"})
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM users WHERE (username ILIKE $1 OR email ILIKE $1) AND %s`, where)
+	err := r.db.QueryRowContext(r.ctx, countQuery, args...).Scan(&total)
 	if err != nil && err != sql.ErrNoRows {
 		return 0, err
 	}
 	return total, nil
 }
 
-func SoftDeleteUserRepo(id int) error {
+func (r *userRepository) SoftDeleteUserRepo(id int) error {
 	query := `UPDATE users SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
-	_, err := database.DB.Exec(query, id)
+	_, err := r.db.ExecContext(r.ctx, query, id)
 	if err != nil {
-		log.Println("Soft delete error:", err)
+		logging.FromContext(r.ctx).Error("soft delete user gagal", "error", err)
 		return err
 	}
 	return nil
 }
 
-func GetUserByID(id int, role string) (*model.User, error) {
+func (r *userRepository) GetUserByID(id int, role string) (*model.User, error) {
     condition := "deleted_at IS NULL" // default user biasa
     if role == "admin" {
         condition = "1=1" // admin bisa lihat semua
@@ -74,10 +143,61 @@ func GetUserByID(id int, role string) (*model.User, error) {
     `, condition)
 
     var u model.User
-    row := database.DB.QueryRow(query, id)
+    row := r.db.QueryRowContext(r.ctx, query, id)
     err := row.Scan(&u.ID, &u.Username, &u.Email, &u.Role, &u.CreatedAt, &u.DeletedAt)
     if err != nil {
         return nil, err
     }
     return &u, nil
 }
+
+// UpdateUser mengganti username, email dan role user yang belum dihapus.
+func (r *userRepository) UpdateUser(id int, req model.UpdateUserRequest) (*model.User, error) {
+	result, err := r.db.ExecContext(r.ctx, 
+		`UPDATE users SET username = $1, email = $2, role = $3, updated_at = NOW()
+		 WHERE id = $4 AND deleted_at IS NULL`,
+		req.Username, req.Email, req.Role, id)
+	if err != nil {
+		return nil, WrapDuplicate(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return r.GetUserByID(id, "admin")
+}
+
+// CreateUser menyimpan user baru dan mengisi ID serta created_at dari database.
+func (r *userRepository) CreateUser(user model.User, passwordHash string) (*model.User, error) {
+	err := r.db.QueryRowContext(r.ctx,
+		`INSERT INTO users (username, email, password_hash, role)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id, created_at`,
+		user.Username, user.Email, passwordHash, user.Role).Scan(&user.ID, &user.CreatedAt)
+	if err != nil {
+		return nil, WrapDuplicate(err)
+	}
+	return &user, nil
+}
+
+// UpdatePassword mengganti hash password user yang belum dihapus.
+func (r *userRepository) UpdatePassword(id int, passwordHash string) error {
+	result, err := r.db.ExecContext(r.ctx,
+		`UPDATE users SET password_hash = $1, updated_at = NOW()
+		 WHERE id = $2 AND deleted_at IS NULL`,
+		passwordHash, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
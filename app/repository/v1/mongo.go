@@ -0,0 +1,775 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"latihan2/app/model"
+	mongoModel "latihan2/app/model/mongo"
+	v1 "latihan2/app/model/v1"
+	mongoRepo "latihan2/app/repository/mongo"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// NewMongoStorage membungkus repository Mongo agar bisa dipakai /api/v1.
+// Repository memory memakai interface yang sama sehingga driver "memory" juga
+// dirakit lewat fungsi ini.
+func NewMongoStorage(driver string, users mongoRepo.UserRepository, alumni mongoRepo.AlumniRepository, pekerjaan mongoRepo.PekerjaanRepository, files mongoRepo.FileRepository) Storage {
+	return Storage{
+		Driver:    driver,
+		Users:     &mgUserRepository{repo: users},
+		Alumni:    &mgAlumniRepository{repo: alumni},
+		Pekerjaan: &mgPekerjaanRepository{repo: pekerjaan, alumni: alumni},
+		Files:     NewMongoFiles(files),
+	}
+}
+
+// mgError menerjemahkan error repository Mongo ke error /api/v1.
+func mgError(err error) error {
+	var dup *mongoRepo.DuplicateKeyError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, mongodriver.ErrNoDocuments), errors.Is(err, mongoRepo.ErrPekerjaanNotFound):
+		return ErrNotFound
+	case errors.Is(err, mongoRepo.ErrForbidden):
+		return ErrForbidden
+	case errors.Is(err, mongoRepo.ErrVersionConflict):
+		return ErrVersionConflict
+	case errors.As(err, &dup):
+		return &DuplicateError{Field: dup.Field}
+	}
+	return err
+}
+
+// mgID mengubah ID string menjadi ObjectID. ID yang bukan hex ObjectID tidak
+// mungkin ada sehingga dilaporkan sebagai ErrNotFound.
+func mgID(id string) (primitive.ObjectID, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return objID, ErrNotFound
+	}
+	return objID, nil
+}
+
+// mgField menerjemahkan nama field /api/v1 ke nama field BSON.
+func mgField(name string) string {
+	if name == "id" {
+		return "_id"
+	}
+	return name
+}
+
+func mgPage(p model.CursorPage) model.CursorPage {
+	p.SortBy = mgField(p.SortBy)
+	if p.Cursor != nil {
+		c := *p.Cursor
+		c.SortBy = mgField(c.SortBy)
+		p.Cursor = &c
+	}
+	return p
+}
+
+// hexOrEmpty mengembalikan "" untuk ObjectID kosong, mis. alumni tanpa user.
+func hexOrEmpty(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.Hex()
+}
+
+func with(f model.Filter, conds ...model.FilterCondition) model.Filter {
+	all := append([]model.FilterCondition(nil), f.Conditions...)
+	return model.Filter{Conditions: append(all, conds...)}
+}
+
+type mgUserRepository struct {
+	repo mongoRepo.UserRepository
+}
+
+func (r *mgUserRepository) WithContext(ctx context.Context) UserRepository {
+	return &mgUserRepository{repo: r.repo.WithContext(ctx)}
+}
+
+func fromMgUser(u mongoModel.User) v1.User {
+	return v1.User{
+		ID:        u.ID.Hex(),
+		Username:  u.Username,
+		Email:     u.Email,
+		Role:      u.Role,
+		CreatedAt: u.CreatedAt,
+		DeletedAt: u.DeletedAt,
+	}
+}
+
+func fromMgUsers(list []mongoModel.User) []v1.User {
+	result := make([]v1.User, 0, len(list))
+	for _, u := range list {
+		result = append(result, fromMgUser(u))
+	}
+	return result
+}
+
+func (r *mgUserRepository) GetUsers(q ListQuery, p OffsetPage, actor Actor) ([]v1.User, error) {
+	list, err := r.repo.GetUsersRepo(q.Search, mgField(p.SortBy), p.Order, p.Limit, p.Offset, q.Filter)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	return fromMgUsers(list), nil
+}
+
+func (r *mgUserRepository) GetUsersCursor(q ListQuery, page model.CursorPage, actor Actor) ([]v1.User, error) {
+	list, err := r.repo.GetUsersCursorRepo(q.Search, mgPage(page), q.Filter)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	return fromMgUsers(list), nil
+}
+
+func (r *mgUserRepository) CountUsers(q ListQuery) (int, error) {
+	total, err := r.repo.CountUsersRepo(q.Search, q.Filter)
+	return total, mgError(err)
+}
+
+func (r *mgUserRepository) GetUserByID(id string, actor Actor) (*v1.User, error) {
+	if _, err := mgID(id); err != nil {
+		return nil, err
+	}
+	u, err := r.repo.GetUserByID(id)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	result := fromMgUser(*u)
+	return &result, nil
+}
+
+func (r *mgUserRepository) GetUserByUsername(username string) (*v1.User, string, error) {
+	u, err := r.repo.GetUserByUsername(username)
+	if err != nil {
+		return nil, "", mgError(err)
+	}
+	result := fromMgUser(*u)
+	return &result, u.Password, nil
+}
+
+func (r *mgUserRepository) UpdateUser(id string, req model.UpdateUserRequest) (*v1.User, error) {
+	if _, err := mgID(id); err != nil {
+		return nil, err
+	}
+	u, err := r.repo.UpdateUser(id, req)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	result := fromMgUser(*u)
+	return &result, nil
+}
+
+func (r *mgUserRepository) CreateUser(username, email, role, passwordHash string) (*v1.User, error) {
+	u, err := r.repo.CreateUser(&mongoModel.User{Username: username, Email: email, Role: role, Password: passwordHash})
+	if err != nil {
+		return nil, mgError(err)
+	}
+	result := fromMgUser(*u)
+	return &result, nil
+}
+
+func (r *mgUserRepository) UpdatePassword(id, passwordHash string) error {
+	if _, err := mgID(id); err != nil {
+		return err
+	}
+	return mgError(r.repo.UpdatePassword(id, passwordHash))
+}
+
+type mgAlumniRepository struct {
+	repo mongoRepo.AlumniRepository
+}
+
+func (r *mgAlumniRepository) WithContext(ctx context.Context) AlumniRepository {
+	return &mgAlumniRepository{repo: r.repo.WithContext(ctx)}
+}
+
+func fromMgAlumni(a mongoModel.Alumni) v1.Alumni {
+	return v1.Alumni{
+		ID:         a.ID.Hex(),
+		UserID:     hexOrEmpty(a.UserID),
+		NIM:        a.NIM,
+		Nama:       a.Nama,
+		Jurusan:    a.Jurusan,
+		Angkatan:   a.Angkatan,
+		TahunLulus: a.TahunLulus,
+		Email:      a.Email,
+		NoTelepon:  a.NoTelepon,
+		Alamat:     a.Alamat,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+		Version:    a.Version,
+	}
+}
+
+func fromMgAlumniList(list []mongoModel.Alumni) []v1.Alumni {
+	result := make([]v1.Alumni, 0, len(list))
+	for _, a := range list {
+		result = append(result, fromMgAlumni(a))
+	}
+	return result
+}
+
+func (r *mgAlumniRepository) GetAlumni(q ListQuery, p OffsetPage) ([]v1.Alumni, error) {
+	list, err := r.repo.GetAlumniRepo(q.Search, mgField(p.SortBy), p.Order, p.Limit, p.Offset, q.Filter)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	return fromMgAlumniList(list), nil
+}
+
+func (r *mgAlumniRepository) GetAlumniCursor(q ListQuery, page model.CursorPage) ([]v1.Alumni, error) {
+	list, err := r.repo.GetAlumniCursorRepo(q.Search, mgPage(page), q.Filter)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	return fromMgAlumniList(list), nil
+}
+
+func (r *mgAlumniRepository) CountAlumni(q ListQuery) (int, error) {
+	total, err := r.repo.CountAlumniRepo(q.Search, q.Filter)
+	return total, mgError(err)
+}
+
+func (r *mgAlumniRepository) GetAlumniByID(id string) (*v1.Alumni, error) {
+	if _, err := mgID(id); err != nil {
+		return nil, err
+	}
+	a, err := r.repo.GetAlumniByID(id)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	result := fromMgAlumni(*a)
+	return &result, nil
+}
+
+func (r *mgAlumniRepository) CreateAlumni(req model.CreateAlumniRequest) (*v1.Alumni, error) {
+	a := &mongoModel.Alumni{
+		NIM:        req.NIM,
+		Nama:       req.Nama,
+		Jurusan:    req.Jurusan,
+		Angkatan:   req.Angkatan,
+		TahunLulus: req.TahunLulus,
+		Email:      req.Email,
+	}
+	if req.UserID != "" {
+		userID, err := primitive.ObjectIDFromHex(req.UserID)
+		if err != nil {
+			return nil, ErrNotFound
+		}
+		a.UserID = userID
+	}
+	if req.NoTelepon != "" {
+		a.NoTelepon = &req.NoTelepon
+	}
+	if req.Alamat != "" {
+		a.Alamat = &req.Alamat
+	}
+	created, err := r.repo.CreateAlumni(a)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	result := fromMgAlumni(*created)
+	return &result, nil
+}
+
+func (r *mgAlumniRepository) UpdateAlumni(id string, version int, req model.UpdateAlumniRequest) (*v1.Alumni, error) {
+	if _, err := r.GetAlumniByID(id); err != nil {
+		return nil, err
+	}
+	a, err := r.repo.UpdateAlumni(id, version, req)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	result := fromMgAlumni(*a)
+	return &result, nil
+}
+
+func (r *mgAlumniRepository) SoftDeleteAlumni(id string) error {
+	if _, err := r.GetAlumniByID(id); err != nil {
+		return err
+	}
+	return mgError(r.repo.SoftDeleteAlumni(id))
+}
+
+func (r *mgAlumniRepository) SearchAlumni(q string, limit, offset int, basic bool) ([]v1.AlumniSearchResult, string, error) {
+	list, mode, err := r.repo.SearchAlumni(q, limit, offset, basic)
+	if err != nil {
+		return nil, "", mgError(err)
+	}
+	result := make([]v1.AlumniSearchResult, 0, len(list))
+	for _, a := range list {
+		result = append(result, v1.AlumniSearchResult{Alumni: fromMgAlumni(a.Alumni), SearchHit: a.SearchHit})
+	}
+	return result, mode, nil
+}
+
+// mgPekerjaanRepository menerapkan aturan kepemilikan Postgres di atas
+// repository Mongo: role "user" hanya melihat pekerjaan milik alumni dengan
+// user_id miliknya, dan data di trash tidak ikut di list.
+type mgPekerjaanRepository struct {
+	repo   mongoRepo.PekerjaanRepository
+	alumni mongoRepo.AlumniRepository
+}
+
+func (r *mgPekerjaanRepository) WithContext(ctx context.Context) PekerjaanRepository {
+	return &mgPekerjaanRepository{repo: r.repo.WithContext(ctx), alumni: r.alumni.WithContext(ctx)}
+}
+
+func fromMgPekerjaan(p mongoModel.Pekerjaan) v1.Pekerjaan {
+	result := v1.Pekerjaan{
+		ID:                  p.ID.Hex(),
+		AlumniID:            hexOrEmpty(p.AlumniID),
+		NamaPerusahaan:      p.NamaPerusahaan,
+		PosisiJabatan:       p.PosisiJabatan,
+		BidangIndustri:      p.BidangIndustri,
+		LokasiKerja:         p.LokasiKerja,
+		GajiRange:           p.GajiRange,
+		TanggalMulaiKerja:   p.TanggalMulaiKerja,
+		TanggalSelesaiKerja: p.TanggalSelesaiKerja,
+		StatusPekerjaan:     p.StatusPekerjaan,
+		Deskripsi:           p.Deskripsi,
+		CreatedAt:           p.CreatedAt,
+		UpdatedAt:           p.CreatedAt,
+		Version:             p.Version,
+		RentangGaji:         p.RentangGaji,
+	}
+	if p.UpdatedAt != nil {
+		result.UpdatedAt = *p.UpdatedAt
+	}
+	if p.IsDelete {
+		result.DeleteBy = p.DeleteBy
+		result.DeletedAt = p.DeletedAt
+	}
+	return result
+}
+
+func fromMgPekerjaanList(list []mongoModel.Pekerjaan) []v1.Pekerjaan {
+	result := make([]v1.Pekerjaan, 0, len(list))
+	for _, p := range list {
+		result = append(result, fromMgPekerjaan(p))
+	}
+	return result
+}
+
+var bukanTrash = model.FilterCondition{Field: "is_delete", Operator: model.FilterNe, Values: []interface{}{true}}
+
+// scope menambahkan syarat bukan trash dan, untuk role "user", syarat
+// alumni_id milik actor.
+func (r *mgPekerjaanRepository) scope(f model.Filter, actor Actor) (model.Filter, error) {
+	f = with(f, bukanTrash)
+	if actor.IsAdmin() {
+		return f, nil
+	}
+	ids, err := r.ownedAlumni(actor)
+	if err != nil {
+		return f, err
+	}
+	return with(f, model.FilterCondition{Field: "alumni_id", Operator: model.FilterIn, Values: ids}), nil
+}
+
+// ownedAlumni mengembalikan ObjectID semua alumni dengan user_id milik actor.
+func (r *mgPekerjaanRepository) ownedAlumni(actor Actor) ([]interface{}, error) {
+	userID, err := primitive.ObjectIDFromHex(actor.UserID)
+	if err != nil {
+		return []interface{}{}, nil
+	}
+	f := model.Filter{Conditions: []model.FilterCondition{
+		{Field: "user_id", Operator: model.FilterEq, Values: []interface{}{userID}},
+	}}
+	list, err := r.alumni.GetAlumniRepo("", "_id", "asc", 0, 0, f)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	ids := make([]interface{}, 0, len(list))
+	for _, a := range list {
+		ids = append(ids, a.ID)
+	}
+	return ids, nil
+}
+
+// ownedBy melaporkan apakah p milik alumni dengan user_id actor.
+func (r *mgPekerjaanRepository) ownedBy(p mongoModel.Pekerjaan, actor Actor) (bool, error) {
+	if actor.IsAdmin() {
+		return true, nil
+	}
+	a, err := r.alumni.GetAlumniByID(p.AlumniID.Hex())
+	if err != nil {
+		if errors.Is(mgError(err), ErrNotFound) {
+			return false, nil
+		}
+		return false, mgError(err)
+	}
+	return a.UserID.Hex() == actor.UserID, nil
+}
+
+// findOne mencari satu pekerjaan (termasuk yang ada di trash jika trash true).
+func (r *mgPekerjaanRepository) findOne(id string, trash bool) (*mongoModel.Pekerjaan, error) {
+	objID, err := mgID(id)
+	if err != nil {
+		return nil, err
+	}
+	cond := bukanTrash
+	if trash {
+		cond = model.FilterCondition{Field: "is_delete", Operator: model.FilterEq, Values: []interface{}{true}}
+	}
+	f := model.Filter{Conditions: []model.FilterCondition{
+		{Field: "_id", Operator: model.FilterEq, Values: []interface{}{objID}}, cond,
+	}}
+	list, err := r.repo.GetPekerjaanRepo("", "_id", "asc", 1, 0, model.GajiFilter{}, f)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	if len(list) == 0 {
+		return nil, ErrNotFound
+	}
+	return &list[0], nil
+}
+
+func (r *mgPekerjaanRepository) GetPekerjaan(q ListQuery, p OffsetPage, actor Actor) ([]v1.Pekerjaan, error) {
+	f, err := r.scope(q.Filter, actor)
+	if err != nil {
+		return nil, err
+	}
+	list, err := r.repo.GetPekerjaanRepo(q.Search, mgField(p.SortBy), p.Order, p.Limit, p.Offset, q.Gaji, f)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	return fromMgPekerjaanList(list), nil
+}
+
+func (r *mgPekerjaanRepository) GetPekerjaanCursor(q ListQuery, page model.CursorPage, actor Actor) ([]v1.Pekerjaan, error) {
+	f, err := r.scope(q.Filter, actor)
+	if err != nil {
+		return nil, err
+	}
+	list, err := r.repo.GetPekerjaanCursorRepo(q.Search, mgPage(page), q.Gaji, f)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	return fromMgPekerjaanList(list), nil
+}
+
+func (r *mgPekerjaanRepository) CountPekerjaan(q ListQuery, actor Actor) (int, error) {
+	f, err := r.scope(q.Filter, actor)
+	if err != nil {
+		return 0, err
+	}
+	total, err := r.repo.CountPekerjaanRepo(q.Search, q.Gaji, f)
+	return total, mgError(err)
+}
+
+func (r *mgPekerjaanRepository) GetPekerjaanByID(id string, actor Actor) (*v1.Pekerjaan, error) {
+	p, err := r.findOne(id, false)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := r.ownedBy(*p, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !owned {
+		return nil, ErrNotFound
+	}
+	result := fromMgPekerjaan(*p)
+	return &result, nil
+}
+
+func (r *mgPekerjaanRepository) GetPekerjaanByAlumniID(alumniID string) ([]v1.Pekerjaan, error) {
+	objID, err := mgID(alumniID)
+	if err != nil {
+		return nil, err
+	}
+	f := model.Filter{Conditions: []model.FilterCondition{
+		{Field: "alumni_id", Operator: model.FilterEq, Values: []interface{}{objID}}, bukanTrash,
+	}}
+	list, err := r.repo.GetPekerjaanRepo("", "tanggal_mulai_kerja", "desc", 0, 0, model.GajiFilter{}, f)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	return fromMgPekerjaanList(list), nil
+}
+
+// parseTanggal mengubah tanggal "2006-01-02"; string kosong menjadi nil.
+func parseTanggal(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *mgPekerjaanRepository) CreatePekerjaan(req v1.CreatePekerjaanRequest) (*v1.Pekerjaan, error) {
+	alumniID, err := mgID(req.AlumniID)
+	if err != nil {
+		return nil, err
+	}
+	mulai, err := parseTanggal(req.TanggalMulaiKerja)
+	if err != nil {
+		return nil, err
+	}
+	selesai, err := parseTanggal(req.TanggalSelesaiKerja)
+	if err != nil {
+		return nil, err
+	}
+	p := &mongoModel.Pekerjaan{
+		AlumniID:            alumniID,
+		NamaPerusahaan:      req.NamaPerusahaan,
+		PosisiJabatan:       req.PosisiJabatan,
+		BidangIndustri:      req.BidangIndustri,
+		LokasiKerja:         req.LokasiKerja,
+		GajiRange:           req.GajiRange,
+		TanggalSelesaiKerja: selesai,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		Deskripsi:           req.DeskripsiPekerjaan,
+		RentangGaji:         req.RentangGaji,
+	}
+	if mulai != nil {
+		p.TanggalMulaiKerja = *mulai
+	}
+	created, err := r.repo.CreatePekerjaan(p)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	result := fromMgPekerjaan(*created)
+	return &result, nil
+}
+
+func (r *mgPekerjaanRepository) UpdatePekerjaan(id string, version int, req model.UpdatePekerjaanRequest) (*v1.Pekerjaan, error) {
+	if _, err := r.findOne(id, false); err != nil {
+		return nil, err
+	}
+	mulai, err := parseTanggal(req.TanggalMulaiKerja)
+	if err != nil {
+		return nil, err
+	}
+	selesai, err := parseTanggal(req.TanggalSelesaiKerja)
+	if err != nil {
+		return nil, err
+	}
+	p, err := r.repo.UpdatePekerjaan(id, version, mongoModel.UpdatePekerjaanRequest{
+		NamaPerusahaan:      req.NamaPerusahaan,
+		PosisiJabatan:       req.PosisiJabatan,
+		BidangIndustri:      req.BidangIndustri,
+		LokasiKerja:         req.LokasiKerja,
+		GajiRange:           req.GajiRange,
+		TanggalMulaiKerja:   mulai,
+		TanggalSelesaiKerja: selesai,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
+		RentangGaji:         req.RentangGaji,
+	})
+	if err != nil {
+		return nil, mgError(err)
+	}
+	result := fromMgPekerjaan(*p)
+	return &result, nil
+}
+
+// SoftDeletePekerjaan, RestorePekerjaan dan HardDeletePekerjaan memeriksa
+// kepemilikan di sini lalu memanggil repository sebagai admin, karena
+// repository Mongo mencocokkan user_id pada dokumen pekerjaan yang tidak ada.
+// Error yang dikembalikan sama dengan repository Postgres: ErrForbidden untuk
+// soft delete dan restore, ErrNotFound untuk hard delete.
+func (r *mgPekerjaanRepository) SoftDeletePekerjaan(id string, actor Actor) error {
+	p, err := r.findOne(id, false)
+	if err != nil {
+		return err
+	}
+	owned, err := r.ownedBy(*p, actor)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return ErrForbidden
+	}
+	return mgError(r.repo.SoftDeletePekerjaan(id, actor.UserID, "admin"))
+}
+
+func (r *mgPekerjaanRepository) RestorePekerjaan(id string, actor Actor) error {
+	p, err := r.findOne(id, true)
+	if err != nil {
+		return err
+	}
+	owned, err := r.ownedBy(*p, actor)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return ErrForbidden
+	}
+	return mgError(r.repo.RestorePekerjaan(id, actor.UserID, "admin"))
+}
+
+func (r *mgPekerjaanRepository) HardDeletePekerjaan(id string, actor Actor) error {
+	p, err := r.findOne(id, true)
+	if err != nil {
+		return err
+	}
+	owned, err := r.ownedBy(*p, actor)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return ErrNotFound
+	}
+	return mgError(r.repo.HardDeletePekerjaan(id, actor.UserID, "admin"))
+}
+
+func (r *mgPekerjaanRepository) GetTrashPekerjaanByID(id string, actor Actor) (*v1.Pekerjaan, error) {
+	p, err := r.findOne(id, true)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := r.ownedBy(*p, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !owned {
+		return nil, ErrNotFound
+	}
+	result := fromMgPekerjaan(*p)
+	return &result, nil
+}
+
+func (r *mgPekerjaanRepository) MigrateGajiRange(dryRun bool) (*model.GajiMigrationReport, error) {
+	report, err := r.repo.MigrateGajiRange(dryRun)
+	return report, mgError(err)
+}
+
+// SearchPekerjaan untuk role "user" menyaring hasil setelah query, sehingga
+// satu halaman bisa berisi kurang dari limit.
+func (r *mgPekerjaanRepository) SearchPekerjaan(q string, limit, offset int, basic bool, actor Actor) ([]v1.PekerjaanSearchResult, string, error) {
+	list, mode, err := r.repo.SearchPekerjaan(q, limit, offset, basic)
+	if err != nil {
+		return nil, "", mgError(err)
+	}
+	owned := map[primitive.ObjectID]bool{}
+	if !actor.IsAdmin() {
+		ids, err := r.ownedAlumni(actor)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, id := range ids {
+			owned[id.(primitive.ObjectID)] = true
+		}
+	}
+	result := make([]v1.PekerjaanSearchResult, 0, len(list))
+	for _, p := range list {
+		if p.IsDelete || (!actor.IsAdmin() && !owned[p.AlumniID]) {
+			continue
+		}
+		result = append(result, v1.PekerjaanSearchResult{Pekerjaan: fromMgPekerjaan(p.Pekerjaan), SearchHit: p.SearchHit})
+	}
+	return result, mode, nil
+}
+
+// NewMongoFiles adalah padanan NewPostgresFiles untuk metadata file Mongo
+// atau memory.
+func NewMongoFiles(repo mongoRepo.FileRepository) FileRepository {
+	return &mgFileRepository{repo: repo}
+}
+
+type mgFileRepository struct {
+	repo mongoRepo.FileRepository
+}
+
+func (r *mgFileRepository) WithContext(ctx context.Context) FileRepository {
+	return &mgFileRepository{repo: r.repo.WithContext(ctx)}
+}
+
+func fromMgFile(f mongoModel.File) v1.File {
+	return v1.File{
+		ID:           f.ID.Hex(),
+		FileName:     f.FileName,
+		OriginalName: f.OriginalName,
+		FilePath:     f.FilePath,
+		FileSize:     f.FileSize,
+		FileType:     f.FileType,
+		UploadedAt:   f.UploadedAt,
+		UploadedBy:   hexOrEmpty(f.UploadedBy),
+		OwnerID:      hexOrEmpty(f.OwnerID),
+	}
+}
+
+func fromMgFiles(list []mongoModel.File) []v1.File {
+	result := make([]v1.File, 0, len(list))
+	for _, f := range list {
+		result = append(result, fromMgFile(f))
+	}
+	return result
+}
+
+func (r *mgFileRepository) GetFiles() ([]v1.File, error) {
+	list, err := r.repo.FindAllFiles()
+	if err != nil {
+		return nil, mgError(err)
+	}
+	return fromMgFiles(list), nil
+}
+
+func (r *mgFileRepository) GetFilesCursor(page model.CursorPage) ([]v1.File, error) {
+	list, err := r.repo.FindFilesCursor(mgPage(page))
+	if err != nil {
+		return nil, mgError(err)
+	}
+	return fromMgFiles(list), nil
+}
+
+func (r *mgFileRepository) CountFiles() (int, error) {
+	total, err := r.repo.CountFiles()
+	return total, mgError(err)
+}
+
+func (r *mgFileRepository) GetFileByID(id string) (*v1.File, error) {
+	if _, err := mgID(id); err != nil {
+		return nil, err
+	}
+	f, err := r.repo.FindFileByID(id)
+	if err != nil {
+		return nil, mgError(err)
+	}
+	result := fromMgFile(*f)
+	return &result, nil
+}
+
+func (r *mgFileRepository) CreateFile(f *v1.File) error {
+	uploadedBy, err := mgID(f.UploadedBy)
+	if err != nil {
+		return err
+	}
+	ownerID, err := mgID(f.OwnerID)
+	if err != nil {
+		return err
+	}
+	file := mongoModel.File{
+		FileName:     f.FileName,
+		OriginalName: f.OriginalName,
+		FilePath:     f.FilePath,
+		FileSize:     f.FileSize,
+		FileType:     f.FileType,
+		UploadedBy:   uploadedBy,
+		OwnerID:      ownerID,
+	}
+	if err := r.repo.CreateFile(&file); err != nil {
+		return mgError(err)
+	}
+	*f = fromMgFile(file)
+	return nil
+}
+
+func (r *mgFileRepository) DeleteFile(id string) error {
+	if _, err := mgID(id); err != nil {
+		return err
+	}
+	return mgError(r.repo.DeleteFile(id))
+}
@@ -0,0 +1,585 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"latihan2/app/model"
+	v1 "latihan2/app/model/v1"
+	"latihan2/app/repository"
+	"math"
+	"strconv"
+	"time"
+)
+
+// semuaBaris dipakai sebagai LIMIT jika semua baris harus diambil.
+const semuaBaris = math.MaxInt32
+
+// NewPostgresStorage membungkus repository Postgres agar bisa dipakai /api/v1.
+// Repository diterima dari luar, sama dengan NewMongoStorage, agar pemanggil
+// bisa memasang pembungkus seperti audit log.
+func NewPostgresStorage(users repository.UserRepository, alumni repository.AlumniRepository, pekerjaan repository.PekerjaanRepository, files repository.FileRepository) Storage {
+	return Storage{
+		Driver:    DriverPostgres,
+		Users:     &pgUserRepository{repo: users},
+		Alumni:    &pgAlumniRepository{repo: alumni},
+		Pekerjaan: &pgPekerjaanRepository{repo: pekerjaan},
+		Files:     NewPostgresFiles(files),
+	}
+}
+
+// pgError menerjemahkan error repository Postgres ke error /api/v1.
+func pgError(err error) error {
+	var dup *repository.DuplicateKeyError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, repository.ErrPekerjaanNotFound):
+		return ErrNotFound
+	case errors.Is(err, repository.ErrForbidden):
+		return ErrForbidden
+	case errors.Is(err, repository.ErrVersionConflict):
+		return ErrVersionConflict
+	case errors.As(err, &dup):
+		return &DuplicateError{Field: dup.Field}
+	}
+	return err
+}
+
+// pgID mengubah ID string menjadi ID Postgres. ID yang bukan angka tidak
+// mungkin ada sehingga dilaporkan sebagai ErrNotFound.
+func pgID(id string) (int, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil || n <= 0 {
+		return 0, ErrNotFound
+	}
+	return n, nil
+}
+
+// pgActorID mengembalikan 0 untuk user ID yang tidak valid; role "user"
+// dengan ID 0 tidak memiliki data apa pun.
+func pgActorID(a Actor) int {
+	n, _ := strconv.Atoi(a.UserID)
+	return n
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+type pgUserRepository struct {
+	repo repository.UserRepository
+}
+
+func (r *pgUserRepository) WithContext(ctx context.Context) UserRepository {
+	return &pgUserRepository{repo: r.repo.WithContext(ctx)}
+}
+
+func fromPgUser(u model.User) v1.User {
+	return v1.User{
+		ID:        strconv.Itoa(u.ID),
+		Username:  u.Username,
+		Email:     u.Email,
+		Role:      u.Role,
+		CreatedAt: u.CreatedAt,
+		DeletedAt: u.DeletedAt,
+	}
+}
+
+func fromPgUsers(list []model.User) []v1.User {
+	result := make([]v1.User, 0, len(list))
+	for _, u := range list {
+		result = append(result, fromPgUser(u))
+	}
+	return result
+}
+
+func (r *pgUserRepository) GetUsers(q ListQuery, p OffsetPage, actor Actor) ([]v1.User, error) {
+	list, err := r.repo.GetUsersRepo(q.Search, p.SortBy, p.Order, actor.Role, p.Limit, p.Offset, q.Filter)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	return fromPgUsers(list), nil
+}
+
+func (r *pgUserRepository) GetUsersCursor(q ListQuery, page model.CursorPage, actor Actor) ([]v1.User, error) {
+	list, err := r.repo.GetUsersCursorRepo(q.Search, page, actor.Role, q.Filter)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	return fromPgUsers(list), nil
+}
+
+func (r *pgUserRepository) CountUsers(q ListQuery) (int, error) {
+	total, err := r.repo.CountUsersRepo(q.Search, q.Filter)
+	return total, pgError(err)
+}
+
+func (r *pgUserRepository) GetUserByID(id string, actor Actor) (*v1.User, error) {
+	n, err := pgID(id)
+	if err != nil {
+		return nil, err
+	}
+	u, err := r.repo.GetUserByID(n, actor.Role)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	result := fromPgUser(*u)
+	return &result, nil
+}
+
+func (r *pgUserRepository) GetUserByUsername(username string) (*v1.User, string, error) {
+	u, hash, err := r.repo.GetUserByUsername(username)
+	if err != nil {
+		return nil, "", pgError(err)
+	}
+	result := fromPgUser(*u)
+	return &result, hash, nil
+}
+
+func (r *pgUserRepository) UpdateUser(id string, req model.UpdateUserRequest) (*v1.User, error) {
+	n, err := pgID(id)
+	if err != nil {
+		return nil, err
+	}
+	u, err := r.repo.UpdateUser(n, req)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	result := fromPgUser(*u)
+	return &result, nil
+}
+
+func (r *pgUserRepository) CreateUser(username, email, role, passwordHash string) (*v1.User, error) {
+	u, err := r.repo.CreateUser(model.User{Username: username, Email: email, Role: role}, passwordHash)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	result := fromPgUser(*u)
+	return &result, nil
+}
+
+func (r *pgUserRepository) UpdatePassword(id, passwordHash string) error {
+	n, err := pgID(id)
+	if err != nil {
+		return err
+	}
+	return pgError(r.repo.UpdatePassword(n, passwordHash))
+}
+
+type pgAlumniRepository struct {
+	repo repository.AlumniRepository
+}
+
+func (r *pgAlumniRepository) WithContext(ctx context.Context) AlumniRepository {
+	return &pgAlumniRepository{repo: r.repo.WithContext(ctx)}
+}
+
+func fromPgAlumni(a model.Alumni) v1.Alumni {
+	return v1.Alumni{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		NIM:        a.NIM,
+		Nama:       a.Nama,
+		Jurusan:    a.Jurusan,
+		Angkatan:   a.Angkatan,
+		TahunLulus: a.TahunLulus,
+		Email:      a.Email,
+		NoTelepon:  a.NoTelepon,
+		Alamat:     a.Alamat,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+		Version:    a.Version,
+	}
+}
+
+func fromPgAlumniList(list []model.Alumni) []v1.Alumni {
+	result := make([]v1.Alumni, 0, len(list))
+	for _, a := range list {
+		result = append(result, fromPgAlumni(a))
+	}
+	return result
+}
+
+// aktif menambahkan syarat deleted_at IS NULL; repository Postgres sendiri
+// masih mengembalikan alumni yang sudah di-soft-delete.
+func aktif(f model.Filter) model.Filter {
+	conds := append([]model.FilterCondition(nil), f.Conditions...)
+	conds = append(conds, model.FilterCondition{Field: "deleted_at", Operator: model.FilterNull, Values: []interface{}{true}})
+	return model.Filter{Conditions: conds}
+}
+
+func (r *pgAlumniRepository) GetAlumni(q ListQuery, p OffsetPage) ([]v1.Alumni, error) {
+	list, err := r.repo.GetAlumniRepo(q.Search, p.SortBy, p.Order, p.Limit, p.Offset, "admin", aktif(q.Filter))
+	if err != nil {
+		return nil, pgError(err)
+	}
+	return fromPgAlumniList(list), nil
+}
+
+func (r *pgAlumniRepository) GetAlumniCursor(q ListQuery, page model.CursorPage) ([]v1.Alumni, error) {
+	list, err := r.repo.GetAlumniCursorRepo(q.Search, page, "admin", aktif(q.Filter))
+	if err != nil {
+		return nil, pgError(err)
+	}
+	return fromPgAlumniList(list), nil
+}
+
+func (r *pgAlumniRepository) CountAlumni(q ListQuery) (int, error) {
+	total, err := r.repo.CountAlumniRepo(q.Search, aktif(q.Filter))
+	return total, pgError(err)
+}
+
+func (r *pgAlumniRepository) GetAlumniByID(id string) (*v1.Alumni, error) {
+	n, err := pgID(id)
+	if err != nil {
+		return nil, err
+	}
+	f := aktif(model.Filter{Conditions: []model.FilterCondition{
+		{Field: "id", Operator: model.FilterEq, Values: []interface{}{int64(n)}},
+	}})
+	list, err := r.repo.GetAlumniRepo("", "id", "asc", 1, 0, "admin", f)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	if len(list) == 0 {
+		return nil, ErrNotFound
+	}
+	a := fromPgAlumni(list[0])
+	return &a, nil
+}
+
+func (r *pgAlumniRepository) CreateAlumni(req model.CreateAlumniRequest) (*v1.Alumni, error) {
+	a, err := r.repo.CreateAlumni(req)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	result := fromPgAlumni(*a)
+	return &result, nil
+}
+
+func (r *pgAlumniRepository) UpdateAlumni(id string, version int, req model.UpdateAlumniRequest) (*v1.Alumni, error) {
+	if _, err := r.GetAlumniByID(id); err != nil {
+		return nil, err
+	}
+	n, _ := pgID(id)
+	a, err := r.repo.UpdateAlumni(n, version, req)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	result := fromPgAlumni(*a)
+	return &result, nil
+}
+
+func (r *pgAlumniRepository) SoftDeleteAlumni(id string) error {
+	n, err := pgID(id)
+	if err != nil {
+		return err
+	}
+	return pgError(r.repo.SoftDeleteAlumniRepo(n))
+}
+
+func (r *pgAlumniRepository) SearchAlumni(q string, limit, offset int, basic bool) ([]v1.AlumniSearchResult, string, error) {
+	list, mode, err := r.repo.SearchAlumni(q, limit, offset, basic)
+	if err != nil {
+		return nil, "", pgError(err)
+	}
+	result := make([]v1.AlumniSearchResult, 0, len(list))
+	for _, a := range list {
+		result = append(result, v1.AlumniSearchResult{Alumni: fromPgAlumni(a.Alumni), SearchHit: a.SearchHit})
+	}
+	return result, mode, nil
+}
+
+type pgPekerjaanRepository struct {
+	repo repository.PekerjaanRepository
+}
+
+func (r *pgPekerjaanRepository) WithContext(ctx context.Context) PekerjaanRepository {
+	return &pgPekerjaanRepository{repo: r.repo.WithContext(ctx)}
+}
+
+func fromPgPekerjaan(p model.Pekerjaan) v1.Pekerjaan {
+	return v1.Pekerjaan{
+		ID:                  strconv.Itoa(p.ID),
+		AlumniID:            strconv.Itoa(p.AlumniID),
+		NamaPerusahaan:      p.NamaPerusahaan,
+		PosisiJabatan:       p.PosisiJabatan,
+		BidangIndustri:      p.BidangIndustri,
+		LokasiKerja:         p.LokasiKerja,
+		GajiRange:           p.GajiRange,
+		TanggalMulaiKerja:   p.TanggalMulaiKerja,
+		TanggalSelesaiKerja: nullTime(p.TanggalSelesaiKerja),
+		StatusPekerjaan:     p.StatusPekerjaan,
+		Deskripsi:           p.Deskripsi,
+		CreatedAt:           p.CreatedAt,
+		UpdatedAt:           p.UpdatedAt,
+		Version:             p.Version,
+		RentangGaji:         p.RentangGaji,
+	}
+}
+
+func fromPgPekerjaanList(list []model.Pekerjaan) []v1.Pekerjaan {
+	result := make([]v1.Pekerjaan, 0, len(list))
+	for _, p := range list {
+		result = append(result, fromPgPekerjaan(p))
+	}
+	return result
+}
+
+func (r *pgPekerjaanRepository) GetPekerjaan(q ListQuery, p OffsetPage, actor Actor) ([]v1.Pekerjaan, error) {
+	list, err := r.repo.GetPekerjaanRepo(q.Search, p.SortBy, p.Order, p.Limit, p.Offset, actor.Role, pgActorID(actor), q.Gaji, q.Filter)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	return fromPgPekerjaanList(list), nil
+}
+
+func (r *pgPekerjaanRepository) GetPekerjaanCursor(q ListQuery, page model.CursorPage, actor Actor) ([]v1.Pekerjaan, error) {
+	list, err := r.repo.GetPekerjaanCursorRepo(q.Search, page, actor.Role, pgActorID(actor), q.Gaji, q.Filter)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	return fromPgPekerjaanList(list), nil
+}
+
+func (r *pgPekerjaanRepository) CountPekerjaan(q ListQuery, actor Actor) (int, error) {
+	total, err := r.repo.CountPekerjaanRepo(q.Search, actor.Role, pgActorID(actor), q.Gaji, q.Filter)
+	return total, pgError(err)
+}
+
+func (r *pgPekerjaanRepository) GetPekerjaanByID(id string, actor Actor) (*v1.Pekerjaan, error) {
+	n, err := pgID(id)
+	if err != nil {
+		return nil, err
+	}
+	p, err := r.repo.GetPekerjaanByIDRepo(n, pgActorID(actor), actor.Role)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	result := fromPgPekerjaan(*p)
+	return &result, nil
+}
+
+// GetPekerjaanByAlumniID memakai GetPekerjaanRepo, bukan GetPekerjaanByAlumniID
+// milik repository Postgres, karena yang terakhir ikut mengembalikan isi trash.
+func (r *pgPekerjaanRepository) GetPekerjaanByAlumniID(alumniID string) ([]v1.Pekerjaan, error) {
+	n, err := pgID(alumniID)
+	if err != nil {
+		return nil, err
+	}
+	f := model.Filter{Conditions: []model.FilterCondition{
+		{Field: "alumni_id", Operator: model.FilterEq, Values: []interface{}{int64(n)}},
+	}}
+	list, err := r.repo.GetPekerjaanRepo("", "tanggal_mulai_kerja", "desc", semuaBaris, 0, "admin", 0, model.GajiFilter{}, f)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	return fromPgPekerjaanList(list), nil
+}
+
+func (r *pgPekerjaanRepository) CreatePekerjaan(req v1.CreatePekerjaanRequest) (*v1.Pekerjaan, error) {
+	alumniID, err := pgID(req.AlumniID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := r.repo.CreatePekerjaan(model.CreatePekerjaanRequest{
+		AlumniID:            alumniID,
+		NamaPerusahaan:      req.NamaPerusahaan,
+		PosisiJabatan:       req.PosisiJabatan,
+		BidangIndustri:      req.BidangIndustri,
+		LokasiKerja:         req.LokasiKerja,
+		GajiRange:           req.GajiRange,
+		TanggalMulaiKerja:   req.TanggalMulaiKerja,
+		TanggalSelesaiKerja: req.TanggalSelesaiKerja,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
+		RentangGaji:         req.RentangGaji,
+	})
+	if err != nil {
+		return nil, pgError(err)
+	}
+	result := fromPgPekerjaan(*p)
+	return &result, nil
+}
+
+func (r *pgPekerjaanRepository) UpdatePekerjaan(id string, version int, req model.UpdatePekerjaanRequest) (*v1.Pekerjaan, error) {
+	n, err := pgID(id)
+	if err != nil {
+		return nil, err
+	}
+	admin := Actor{Role: "admin"}
+	if _, err := r.GetPekerjaanByID(id, admin); err != nil {
+		return nil, err
+	}
+	p, err := r.repo.UpdatePekerjaan(n, 0, admin.Role, version, req)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	result := fromPgPekerjaan(*p)
+	return &result, nil
+}
+
+func (r *pgPekerjaanRepository) SoftDeletePekerjaan(id string, actor Actor) error {
+	n, err := pgID(id)
+	if err != nil {
+		return err
+	}
+	return pgError(r.repo.SoftDeletePekerjaan(n, pgActorID(actor), actor.Role))
+}
+
+func (r *pgPekerjaanRepository) RestorePekerjaan(id string, actor Actor) error {
+	n, err := pgID(id)
+	if err != nil {
+		return err
+	}
+	return pgError(r.repo.RestorePekerjaan(n, pgActorID(actor), actor.Role))
+}
+
+func (r *pgPekerjaanRepository) HardDeletePekerjaan(id string, actor Actor) error {
+	n, err := pgID(id)
+	if err != nil {
+		return err
+	}
+	return pgError(r.repo.HardDeletePekerjaan(n, pgActorID(actor), actor.Role))
+}
+
+func (r *pgPekerjaanRepository) GetTrashPekerjaanByID(id string, actor Actor) (*v1.Pekerjaan, error) {
+	n, err := pgID(id)
+	if err != nil {
+		return nil, err
+	}
+	t, err := r.repo.GetTrashPekerjaanByID(n, pgActorID(actor), actor.Role)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	result := fromPgPekerjaan(t.Pekerjaan)
+	result.DeletedAt = nullTime(t.DeletedAt)
+	if t.DeletedBy.Valid {
+		result.DeleteBy = strconv.FormatInt(t.DeletedBy.Int64, 10)
+	}
+	return &result, nil
+}
+
+func (r *pgPekerjaanRepository) MigrateGajiRange(dryRun bool) (*model.GajiMigrationReport, error) {
+	report, err := r.repo.MigrateGajiRange(dryRun)
+	return report, pgError(err)
+}
+
+func (r *pgPekerjaanRepository) SearchPekerjaan(q string, limit, offset int, basic bool, actor Actor) ([]v1.PekerjaanSearchResult, string, error) {
+	list, mode, err := r.repo.SearchPekerjaan(q, limit, offset, actor.Role, pgActorID(actor), basic)
+	if err != nil {
+		return nil, "", pgError(err)
+	}
+	result := make([]v1.PekerjaanSearchResult, 0, len(list))
+	for _, p := range list {
+		result = append(result, v1.PekerjaanSearchResult{Pekerjaan: fromPgPekerjaan(p.Pekerjaan), SearchHit: p.SearchHit})
+	}
+	return result, mode, nil
+}
+
+// NewPostgresFiles membungkus metadata file Postgres tanpa repository lain,
+// mis. untuk perintah "files reconcile".
+func NewPostgresFiles(repo repository.FileRepository) FileRepository {
+	return &pgFileRepository{repo: repo}
+}
+
+type pgFileRepository struct {
+	repo repository.FileRepository
+}
+
+func (r *pgFileRepository) WithContext(ctx context.Context) FileRepository {
+	return &pgFileRepository{repo: r.repo.WithContext(ctx)}
+}
+
+func fromPgFile(f model.File) v1.File {
+	return v1.File{
+		ID:           strconv.Itoa(f.ID),
+		FileName:     f.FileName,
+		OriginalName: f.OriginalName,
+		FilePath:     f.FilePath,
+		FileSize:     f.FileSize,
+		FileType:     f.FileType,
+		UploadedAt:   f.UploadedAt,
+		UploadedBy:   strconv.Itoa(f.UploadedBy),
+		OwnerID:      strconv.Itoa(f.OwnerID),
+	}
+}
+
+func fromPgFiles(list []model.File) []v1.File {
+	result := make([]v1.File, 0, len(list))
+	for _, f := range list {
+		result = append(result, fromPgFile(f))
+	}
+	return result
+}
+
+func (r *pgFileRepository) GetFiles() ([]v1.File, error) {
+	list, err := r.repo.FindAllFiles()
+	if err != nil {
+		return nil, pgError(err)
+	}
+	return fromPgFiles(list), nil
+}
+
+func (r *pgFileRepository) GetFilesCursor(page model.CursorPage) ([]v1.File, error) {
+	list, err := r.repo.FindFilesCursor(page)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	return fromPgFiles(list), nil
+}
+
+func (r *pgFileRepository) CountFiles() (int, error) {
+	total, err := r.repo.CountFiles()
+	return total, pgError(err)
+}
+
+func (r *pgFileRepository) GetFileByID(id string) (*v1.File, error) {
+	n, err := pgID(id)
+	if err != nil {
+		return nil, err
+	}
+	f, err := r.repo.FindFileByID(n)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	result := fromPgFile(*f)
+	return &result, nil
+}
+
+func (r *pgFileRepository) CreateFile(f *v1.File) error {
+	uploadedBy, err := pgID(f.UploadedBy)
+	if err != nil {
+		return err
+	}
+	ownerID, err := pgID(f.OwnerID)
+	if err != nil {
+		return err
+	}
+	file := model.File{
+		FileName:     f.FileName,
+		OriginalName: f.OriginalName,
+		FilePath:     f.FilePath,
+		FileSize:     f.FileSize,
+		FileType:     f.FileType,
+		UploadedBy:   uploadedBy,
+		OwnerID:      ownerID,
+	}
+	if err := r.repo.CreateFile(&file); err != nil {
+		return pgError(err)
+	}
+	*f = fromPgFile(file)
+	return nil
+}
+
+func (r *pgFileRepository) DeleteFile(id string) error {
+	n, err := pgID(id)
+	if err != nil {
+		return err
+	}
+	return pgError(r.repo.DeleteFile(n))
+}
@@ -0,0 +1,165 @@
+// Package v1 berisi repository untuk /api/v1. Interface di sini tidak
+// bergantung pada database: ID berupa string dan error dari driver
+// diterjemahkan ke ErrNotFound, ErrForbidden, ErrVersionConflict dan *DuplicateError. Implementasinya
+// adalah adapter di atas repository Postgres (NewPostgresStorage) dan
+// repository Mongo atau memory (NewMongoStorage).
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"latihan2/app/model"
+	v1 "latihan2/app/model/v1"
+)
+
+// Driver storage yang bisa dipilih lewat STORAGE_DRIVER.
+const (
+	DriverPostgres = "postgres"
+	DriverMongo    = "mongo"
+	DriverMemory   = "memory"
+)
+
+var (
+	ErrNotFound  = errors.New("data tidak ditemukan")
+	ErrForbidden = errors.New("akses ditolak")
+	// ErrDuplikat dikembalikan lewat *DuplicateError jika data melanggar constraint unik.
+	ErrDuplikat = errors.New("data sudah terdaftar")
+	// ErrVersionConflict dikembalikan update jika data sudah diubah pihak lain
+	// sejak version yang dikirim klien.
+	ErrVersionConflict = errors.New("data sudah diubah, ambil ulang data terbaru")
+)
+
+// AnyVersion dipakai sebagai version pada update yang tidak perlu dicek (If-Match: *).
+const AnyVersion = 0
+
+// DuplicateError menyebutkan field yang sudah dipakai data lain, misalnya "nim".
+type DuplicateError struct {
+	Field string
+}
+
+func (e *DuplicateError) Error() string {
+	if e.Field == "" {
+		return ErrDuplikat.Error()
+	}
+	return fmt.Sprintf("%s sudah terdaftar", e.Field)
+}
+
+func (e *DuplicateError) Is(target error) bool {
+	return target == ErrDuplikat
+}
+
+// ListQuery adalah kriteria pencarian yang dipakai bersama oleh list, cursor dan count.
+// Gaji hanya dipakai oleh pekerjaan.
+type ListQuery struct {
+	Search string
+	Filter model.Filter
+	Gaji   model.GajiFilter
+}
+
+// OffsetPage adalah pagination page/limit. SortBy memakai nama field /api/v1
+// ("id", bukan "_id"); adapter Mongo menerjemahkannya sendiri.
+type OffsetPage struct {
+	SortBy string
+	Order  string
+	Limit  int
+	Offset int
+}
+
+// Actor adalah user yang sedang login. Role "user" hanya boleh melihat dan
+// mengubah pekerjaan milik alumni dengan user_id miliknya.
+type Actor struct {
+	UserID string
+	Role   string
+}
+
+func (a Actor) IsAdmin() bool {
+	return a.Role == "admin"
+}
+
+type UserRepository interface {
+	GetUsers(q ListQuery, p OffsetPage, actor Actor) ([]v1.User, error)
+	GetUsersCursor(q ListQuery, page model.CursorPage, actor Actor) ([]v1.User, error)
+	CountUsers(q ListQuery) (int, error)
+	GetUserByID(id string, actor Actor) (*v1.User, error)
+	// GetUserByUsername mengembalikan user beserta hash password-nya untuk login.
+	GetUserByUsername(username string) (*v1.User, string, error)
+	UpdateUser(id string, req model.UpdateUserRequest) (*v1.User, error)
+	// CreateUser dan UpdatePassword menerima hash dari utils.HashPassword.
+	CreateUser(username, email, role, passwordHash string) (*v1.User, error)
+	UpdatePassword(id, passwordHash string) error
+	// WithContext meneruskan ctx ke repository driver (lihat repository Postgres dan Mongo).
+	WithContext(ctx context.Context) UserRepository
+}
+
+// AlumniRepository tidak pernah mengembalikan alumni yang sudah di-soft-delete.
+type AlumniRepository interface {
+	GetAlumni(q ListQuery, p OffsetPage) ([]v1.Alumni, error)
+	GetAlumniCursor(q ListQuery, page model.CursorPage) ([]v1.Alumni, error)
+	CountAlumni(q ListQuery) (int, error)
+	GetAlumniByID(id string) (*v1.Alumni, error)
+	CreateAlumni(req model.CreateAlumniRequest) (*v1.Alumni, error)
+	UpdateAlumni(id string, version int, req model.UpdateAlumniRequest) (*v1.Alumni, error)
+	SoftDeleteAlumni(id string) error
+	SearchAlumni(q string, limit, offset int, basic bool) ([]v1.AlumniSearchResult, string, error)
+	WithContext(ctx context.Context) AlumniRepository
+}
+
+// PekerjaanRepository memakai aturan kepemilikan yang sama untuk semua driver
+// (lihat Actor). Data di trash hanya terlihat lewat GetTrashPekerjaanByID.
+type PekerjaanRepository interface {
+	GetPekerjaan(q ListQuery, p OffsetPage, actor Actor) ([]v1.Pekerjaan, error)
+	GetPekerjaanCursor(q ListQuery, page model.CursorPage, actor Actor) ([]v1.Pekerjaan, error)
+	CountPekerjaan(q ListQuery, actor Actor) (int, error)
+	GetPekerjaanByID(id string, actor Actor) (*v1.Pekerjaan, error)
+	GetPekerjaanByAlumniID(alumniID string) ([]v1.Pekerjaan, error)
+	CreatePekerjaan(req v1.CreatePekerjaanRequest) (*v1.Pekerjaan, error)
+	UpdatePekerjaan(id string, version int, req model.UpdatePekerjaanRequest) (*v1.Pekerjaan, error)
+	SoftDeletePekerjaan(id string, actor Actor) error
+	RestorePekerjaan(id string, actor Actor) error
+	HardDeletePekerjaan(id string, actor Actor) error
+	GetTrashPekerjaanByID(id string, actor Actor) (*v1.Pekerjaan, error)
+	MigrateGajiRange(dryRun bool) (*model.GajiMigrationReport, error)
+	SearchPekerjaan(q string, limit, offset int, basic bool, actor Actor) ([]v1.PekerjaanSearchResult, string, error)
+	WithContext(ctx context.Context) PekerjaanRepository
+}
+
+// FileRepository adalah metadata file upload; isi file tetap di disk.
+// CreateFile mengisi ID dan UploadedAt pada f.
+type FileRepository interface {
+	GetFiles() ([]v1.File, error)
+	GetFilesCursor(page model.CursorPage) ([]v1.File, error)
+	CountFiles() (int, error)
+	GetFileByID(id string) (*v1.File, error)
+	CreateFile(f *v1.File) error
+	DeleteFile(id string) error
+	WithContext(ctx context.Context) FileRepository
+}
+
+// Storage adalah kumpulan repository untuk satu driver.
+type Storage struct {
+	Driver    string
+	Users     UserRepository
+	Alumni    AlumniRepository
+	Pekerjaan PekerjaanRepository
+	Files     FileRepository
+}
+
+// WithContext mengembalikan Storage yang semua repository-nya memakai ctx,
+// untuk pemanggil di luar request HTTP seperti CLI.
+func (s Storage) WithContext(ctx context.Context) Storage {
+	s.Users = s.Users.WithContext(ctx)
+	s.Alumni = s.Alumni.WithContext(ctx)
+	s.Pekerjaan = s.Pekerjaan.WithContext(ctx)
+	s.Files = s.Files.WithContext(ctx)
+	return s
+}
+
+// ValidDriver melaporkan apakah name adalah driver yang dikenal.
+func ValidDriver(name string) bool {
+	switch name {
+	case DriverPostgres, DriverMongo, DriverMemory:
+		return true
+	}
+	return false
+}
@@ -2,15 +2,29 @@ package service
 
 import (
 	"database/sql"
-	"fmt"
+	"errors"
 	"latihan2/app/model"
 	"latihan2/app/repository"
-	"log"
+	"latihan2/middleware"
+	"latihan2/response"
+	"latihan2/utils"
+	"latihan2/validation"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// AlumniHandler menangani endpoint /api/pg/alumni. Repository disuntikkan lewat
+// NewAlumniHandler sehingga handler bisa diuji tanpa database.
+type AlumniHandler struct {
+	repo repository.AlumniRepository
+}
+
+func NewAlumniHandler(repo repository.AlumniRepository) *AlumniHandler {
+	return &AlumniHandler{repo: repo}
+}
+
 // func GetAllAlumni(c *fiber.Ctx) error {
 // 	alumni, err := repository.GetAllAlumni()
 // 	if err != nil {
@@ -26,37 +40,36 @@ import (
 // 	})
 // }
 
-func GetAlumniByID(c *fiber.Ctx) error {
+func (h *AlumniHandler) GetAlumniByID(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "ID tidak valid")
 	}
 
 	role := c.Locals("role").(string)
 
-	alumni, err := repository.GetAlumniByID(id, role)
+	alumni, err := h.repo.WithContext(c.UserContext()).GetAlumniByID(id, role)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Alumni tidak ditemukan"})
+			return response.Fail(c, fiber.StatusNotFound, "Alumni tidak ditemukan")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal mengambil data alumni"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal mengambil data alumni")
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    alumni,
-		"message": "Data alumni berhasil diambil",
+	c.Set(fiber.HeaderETag, utils.ETag(alumni.Version))
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    alumni,
+		Message: "Data alumni berhasil diambil",
 	})
 }
 
-func CreateAlumni(c *fiber.Ctx) error {
+func (h *AlumniHandler) CreateAlumni(c *fiber.Ctx) error {
 	var req model.CreateAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Request body tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
 	}
-
-	if req.NIM == "" || req.Nama == "" || req.Jurusan == "" || req.Email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Field wajib (NIM, Nama, Jurusan, Email) harus diisi"})
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
 	}
 
 	// Ambil userID dari c.Locals (diasumsikan middleware menyimpannya di sini)
@@ -67,93 +80,90 @@ func CreateAlumni(c *fiber.Ctx) error {
 	// }
 
 	// Teruskan userID saat memanggil repository
-	newAlumni, err := repository.CreateAlumni(req)
+	newAlumni, err := h.repo.WithContext(c.UserContext()).CreateAlumni(req)
 	if err != nil {
-		// Jika error masih terjadi, kemungkinan karena constraint UNIQUE di database
-		log.Println("Error creating alumni:", err) // Tambahkan log untuk debugging
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal menambah alumni. Pastikan NIM dan email belum digunakan"})
+		// NIM/email yang sudah dipakai menjadi 409 lewat repository.DuplicateKeyError.
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"data":    newAlumni,
-		"message": "Alumni berhasil ditambahkan",
+	return response.Send(c, fiber.StatusCreated, response.Envelope{
+		Data:    newAlumni,
+		Message: "Alumni berhasil ditambahkan",
 	})
 }
 
-func UpdateAlumni(c *fiber.Ctx) error {
+func (h *AlumniHandler) UpdateAlumni(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "ID tidak valid")
 	}
 
 	var req model.UpdateAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Request body tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
 	}
 
-	updatedAlumni, err := repository.UpdateAlumni(id, req)
+	updatedAlumni, err := h.repo.WithContext(c.UserContext()).UpdateAlumni(id, middleware.IfMatchVersion(c), req)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Alumni tidak ditemukan untuk diupdate"})
+			return response.Fail(c, fiber.StatusNotFound, "Alumni tidak ditemukan untuk diupdate")
+		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return response.Fail(c, fiber.StatusPreconditionFailed, "Alumni sudah diubah, ambil ulang data terbaru")
+		}
+		if errors.Is(err, repository.ErrDuplikat) {
+			return response.Error(c, err)
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal mengupdate alumni"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal mengupdate alumni")
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    updatedAlumni,
-		"message": "Alumni berhasil diupdate",
+	c.Set(fiber.HeaderETag, utils.ETag(updatedAlumni.Version))
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    updatedAlumni,
+		Message: "Alumni berhasil diupdate",
 	})
 }
 
-func DeleteAlumni(c *fiber.Ctx) error {
+func (h *AlumniHandler) DeleteAlumni(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "ID tidak valid")
 	}
 
-	err = repository.DeleteAlumni(id)
+	err = h.repo.WithContext(c.UserContext()).DeleteAlumni(id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Alumni tidak ditemukan untuk dihapus"})
+			return response.Fail(c, fiber.StatusNotFound, "Alumni tidak ditemukan untuk dihapus")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal menghapus alumni"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal menghapus alumni")
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Alumni berhasil dihapus",
-	})
+	return response.Message(c, "Alumni berhasil dihapus")
 }
 
-func GetAlumniByTahunLulus(c *fiber.Ctx) error {
+func (h *AlumniHandler) GetAlumniByTahunLulus(c *fiber.Ctx) error {
 	tahunParam := c.Params("tahun")
 	tahun, err := strconv.Atoi(tahunParam)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error":   "Tahun lulus tidak valid",
-		})
+		return response.Fail(c, fiber.StatusBadRequest, "Tahun lulus tidak valid")
 	}
 
-	data, total, err := repository.GetAlumniByTahunLulus(tahun)
+	data, total, err := h.repo.WithContext(c.UserContext()).GetAlumniByTahunLulus(tahun)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"error":   "Gagal mengambil data alumni: " + err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	return c.JSON(fiber.Map{
-		"success":                     true,
-		"data":                        data,
-		"total_alumni_gaji_lebih_4jt": total,
-		"message":                     "Data alumni berdasarkan tahun lulus",
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    data,
+		Meta:    fiber.Map{"total_alumni_gaji_lebih_4jt": total},
+		Message: "Data alumni berdasarkan tahun lulus",
 	})
 }
 
-func GetAlumniService(c *fiber.Ctx) error {
+func (h *AlumniHandler) GetAlumniService(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	sortBy := c.Query("sortBy", "id")
@@ -161,60 +171,111 @@ func GetAlumniService(c *fiber.Ctx) error {
 	search := c.Query("search", "")
 	offset := (page - 1) * limit
 
+	filter, err := utils.ParseFilter(c.Query("filter"), model.AlumniFilterFields)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if !alumniSortWhitelist[sortBy] {
+		sortBy = "id"
+	}
+	if strings.ToLower(order) != "desc" {
+		order = "asc"
+	}
+
 	role := c.Locals("role").(string)
 
-	alumni, err := repository.GetAlumniRepo(search, sortBy, order, limit, offset, role)
+	if utils.CursorRequested(c.Query("pagination"), c.Query("cursor")) {
+		return h.getAlumniCursor(c, search, sortBy, order, limit, role, filter)
+	}
+
+	alumni, err := h.repo.WithContext(c.UserContext()).GetAlumniRepo(search, sortBy, order, limit, offset, role, filter)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error":   "Failed to fetch alumni",
-			"details": err.Error(),
-			"query":   fmt.Sprintf("search=%s, sortBy=%s, order=%s, limit=%d, offset=%d, role=%s", search, sortBy, order, limit, offset, role),
-		})
+		return response.Error(c, err)
 	}
 
-	total, err := repository.CountAlumniRepo(search)
+	total, err := h.repo.WithContext(c.UserContext()).CountAlumniRepo(search, filter)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to count alumni"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to count alumni")
 	}
 
-	response := model.AlumniResponse{
-		Data: alumni,
-		Meta: model.MetaInfo{
-			Page:   page,
-			Limit:  limit,
-			Total:  total,
-			Pages:  (total + limit - 1) / limit,
-			SortBy: sortBy,
-			Order:  order,
-			Search: search,
-		},
+	return response.List(c, alumni, model.MetaInfo{
+		Page:   page,
+		Limit:  limit,
+		Total:  total,
+		Pages:  (total + limit - 1) / limit,
+		SortBy: sortBy,
+		Order:  order,
+		Search: search,
+		Filter: c.Query("filter"),
+	})
+}
+
+var alumniSortWhitelist = map[string]bool{
+	"id":          true,
+	"nim":         true,
+	"nama":        true,
+	"jurusan":     true,
+	"angkatan":    true,
+	"tahun_lulus": true,
+	"email":       true,
+	"created_at":  true,
+	"updated_at":  true,
+}
+
+// getAlumniCursor melayani GetAlumniService pada mode pagination cursor.
+// Semua kolom di alumniSortWhitelist NOT NULL sehingga bisa dipakai sebagai keyset.
+func (h *AlumniHandler) getAlumniCursor(c *fiber.Ctx, search, sortBy, order string, limit int, role string, filter model.Filter) error {
+	page, err := utils.ParseCursorPage(c.Query("cursor"), sortBy, order, limit, alumniSortWhitelist)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
 	}
 
-	return c.JSON(response)
+	alumni, err := h.repo.WithContext(c.UserContext()).GetAlumniCursorRepo(search, page, role, filter)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	alumni, next, prev := utils.CursorResult(alumni, page)
+
+	meta := model.CursorMetaInfo{
+		Limit:      page.Limit,
+		SortBy:     page.SortBy,
+		Order:      page.Order,
+		Search:     search,
+		Filter:     c.Query("filter"),
+		NextCursor: next,
+		PrevCursor: prev,
+	}
+	if c.QueryBool("with_total") {
+		total, err := h.repo.WithContext(c.UserContext()).CountAlumniRepo(search, filter)
+		if err != nil {
+			return response.Fail(c, fiber.StatusInternalServerError, "Failed to count alumni")
+		}
+		meta.Total = &total
+	}
+
+	return response.List(c, alumni, meta)
 }
 
-func SoftDeleteAlumniService(c *fiber.Ctx) error {
+func (h *AlumniHandler) SoftDeleteAlumniService(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "ID tidak valid")
 	}
 
 	// hanya admin boleh delete
 	role := c.Locals("role").(string)
 	if role != "admin" {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Hanya admin yang bisa menghapus alumni"})
+		return response.Fail(c, fiber.StatusForbidden, "Hanya admin yang bisa menghapus alumni")
 	}
 
-	err = repository.SoftDeleteAlumniRepo(id)
+	err = h.repo.WithContext(c.UserContext()).SoftDeleteAlumniRepo(id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Alumni tidak ditemukan atau sudah dihapus"})
+			return response.Fail(c, fiber.StatusNotFound, "Alumni tidak ditemukan atau sudah dihapus")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal menghapus alumni"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal menghapus alumni")
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Alumni berhasil dihapus (soft delete)",
-	})
+	return response.Message(c, "Alumni berhasil dihapus (soft delete)")
 }
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"latihan2/app/repository"
+	"latihan2/response"
+	"latihan2/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AnalyticsHandler menangani endpoint /api/pg/analytics. Repository disuntikkan
+// lewat NewAnalyticsHandler sehingga handler bisa diuji tanpa database.
+type AnalyticsHandler struct {
+	repo repository.AnalyticsRepository
+}
+
+func NewAnalyticsHandler(repo repository.AnalyticsRepository) *AnalyticsHandler {
+	return &AnalyticsHandler{repo: repo}
+}
+
+func (h *AnalyticsHandler) GetEmploymentRateService(c *fiber.Ctx) error {
+	f, err := utils.ParseAnalyticsFilter(c.Query("tahun_mulai"), c.Query("tahun_akhir"), c.Query("jurusan"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, err := h.repo.WithContext(c.UserContext()).GetEmploymentRate(f)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{"filter": f})
+}
+
+func (h *AnalyticsHandler) GetTimeToFirstJobService(c *fiber.Ctx) error {
+	f, err := utils.ParseAnalyticsFilter(c.Query("tahun_mulai"), c.Query("tahun_akhir"), c.Query("jurusan"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, err := h.repo.WithContext(c.UserContext()).GetTimeToFirstJob(f)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{"filter": f})
+}
+
+func (h *AnalyticsHandler) GetDistribusiPekerjaanService(c *fiber.Ctx) error {
+	f, err := utils.ParseAnalyticsFilter(c.Query("tahun_mulai"), c.Query("tahun_akhir"), c.Query("jurusan"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	dimensi := c.Params("dimensi")
+	if dimensi != "bidang_industri" && dimensi != "lokasi_kerja" {
+		return response.Fail(c, fiber.StatusBadRequest, "Dimensi harus bidang_industri atau lokasi_kerja")
+	}
+	data, err := h.repo.WithContext(c.UserContext()).GetDistribusiPekerjaan(dimensi, f)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{"filter": f})
+}
+
+func (h *AnalyticsHandler) GetDistribusiGajiService(c *fiber.Ctx) error {
+	f, err := utils.ParseAnalyticsFilter(c.Query("tahun_mulai"), c.Query("tahun_akhir"), c.Query("jurusan"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, err := h.repo.WithContext(c.UserContext()).GetDistribusiGaji(f)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{"filter": f})
+}
+
+func (h *AnalyticsHandler) GetCohortTrendService(c *fiber.Ctx) error {
+	f, err := utils.ParseAnalyticsFilter(c.Query("tahun_mulai"), c.Query("tahun_akhir"), c.Query("jurusan"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, err := h.repo.WithContext(c.UserContext()).GetCohortTrend(f)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{"filter": f})
+}
@@ -4,67 +4,67 @@ import (
 	"database/sql"
 	"latihan2/app/model"
 	"latihan2/app/repository"
+	"latihan2/metrics"
+	"latihan2/response"
 	"latihan2/utils"
+	"latihan2/validation"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func Login(c *fiber.Ctx) error {
+// AuthHandler menangani login dan profile Postgres.
+type AuthHandler struct {
+	users repository.UserRepository
+}
+
+func NewAuthHandler(users repository.UserRepository) *AuthHandler {
+	return &AuthHandler{users: users}
+}
+
+func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	var req model.LoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Request body tidak valid",
-		})
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
 	}
-	if req.Username == "" || req.Password == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Username dan password harus diisi",
-		})
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
 	}
-	user, passwordHash, err := repository.GetUserByUsername(req.Username)
+	user, passwordHash, err := h.users.WithContext(c.UserContext()).GetUserByUsername(req.Username)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Username atau password salah",
-			})
+			metrics.ObserveLogin("pg", metrics.LoginFailure)
+			return response.Fail(c, fiber.StatusUnauthorized, "Username atau password salah")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Error database",
-		})
+		return response.Fail(c, fiber.StatusInternalServerError, "Error database")
 	}
 	if !utils.CheckPassword(req.Password, passwordHash) {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Username atau password salah",
-		})
+		metrics.ObserveLogin("pg", metrics.LoginFailure)
+		return response.Fail(c, fiber.StatusUnauthorized, "Username atau password salah")
 	}
 	token, err := utils.GenerateToken(*user)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Gagal generate token",
-		})
-	}
-	response := model.LoginResponse{
-		User:  *user,
-		Token: token,
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal generate token")
 	}
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Login berhasil",
-		"data":    response,
+	metrics.ObserveLogin("pg", metrics.LoginSuccess)
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data: model.LoginResponse{
+			User:  *user,
+			Token: token,
+		},
+		Message: "Login berhasil",
 	})
 }
 
-func GetProfile(c *fiber.Ctx) error {
+func (h *AuthHandler) GetProfile(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(int)
 	username := c.Locals("username").(string)
 	role := c.Locals("role").(string)
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Profile berhasil diambil",
-		"data": fiber.Map{
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data: fiber.Map{
 			"user_id":  userID,
 			"username": username,
 			"role":     role,
 		},
+		Message: "Profile berhasil diambil",
 	})
 }
\ No newline at end of file
@@ -1,9 +1,14 @@
 package mongo
 
 import (
+	"errors"
 	"latihan2/app/model"
 	"latihan2/app/model/mongo"
 	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/middleware"
+	"latihan2/response"
+	"latihan2/utils"
+	"latihan2/validation"
 	"strconv"
 	"strings"
 
@@ -12,6 +17,16 @@ import (
 	mongodriver "go.mongodb.org/mongo-driver/mongo"
 )
 
+// AlumniHandler menangani endpoint /api/mg/alumni. Repository disuntikkan lewat
+// NewAlumniHandler sehingga handler bisa diuji tanpa MongoDB.
+type AlumniHandler struct {
+	repo mongoRepo.AlumniRepository
+}
+
+func NewAlumniHandler(repo mongoRepo.AlumniRepository) *AlumniHandler {
+	return &AlumniHandler{repo: repo}
+}
+
 // GetAllAlumni godoc
 // @Summary Mendapatkan daftar alumni
 // @Description Menampilkan semua data alumni dengan pagination, sorting, dan search
@@ -23,11 +38,16 @@ import (
 // @Param sortBy query string false "Kolom pengurutan (default: _id)"
 // @Param order query string false "Urutan pengurutan (asc/desc)"
 // @Param search query string false "Kata kunci pencarian"
+// @Param filter query string false "Filter field:operator:nilai dipisah koma, mis. tahun_lulus:gte:2020,jurusan:in:TI|SI"
+// @Param pagination query string false "Isi 'cursor' untuk pagination keyset"
+// @Param cursor query string false "next_cursor atau prev_cursor dari response sebelumnya"
+// @Param with_total query bool false "Hitung total data pada mode cursor"
 // @Security BearerAuth
-// @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Router /api/mg/alumni [get]
-func GetAllAlumni(c *fiber.Ctx) error {
+func (h *AlumniHandler) GetAllAlumni(c *fiber.Ctx) error {
 	// Parsing query params
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
@@ -36,24 +56,27 @@ func GetAllAlumni(c *fiber.Ctx) error {
 	search := c.Query("search", "")
 	offset := (page - 1) * limit
 
+	filter, err := utils.ParseFilter(c.Query("filter"), model.AlumniFilterFields)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if utils.CursorRequested(c.Query("pagination"), c.Query("cursor")) {
+		return h.getAlumniCursor(c, search, sortBy, order, limit, filter)
+	}
+
 	// Panggil Repo
-	data, err := mongoRepo.GetAlumniRepo(search, sortBy, order, limit, offset)
+	data, err := h.repo.WithContext(c.UserContext()).GetAlumniRepo(search, sortBy, order, limit, offset, filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	total, err := mongoRepo.CountAlumniRepo(search)
+	total, err := h.repo.WithContext(c.UserContext()).CountAlumniRepo(search, filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": data,
-		"meta": fiber.Map{
+	return response.List(c, data, fiber.Map{
 			"page":   page,
 			"limit":  limit,
 			"total":  total,
@@ -61,8 +84,53 @@ func GetAllAlumni(c *fiber.Ctx) error {
 			"sortBy": sortBy,
 			"order":  order,
 			"search": search,
-		},
-	})
+			"filter": c.Query("filter"),
+		})
+}
+
+var alumniCursorSortable = map[string]bool{
+	"_id":         true,
+	"nim":         true,
+	"nama":        true,
+	"jurusan":     true,
+	"angkatan":    true,
+	"tahun_lulus": true,
+	"email":       true,
+	"created_at":  true,
+	"updated_at":  true,
+}
+
+// getAlumniCursor melayani GetAllAlumni pada mode pagination cursor.
+func (h *AlumniHandler) getAlumniCursor(c *fiber.Ctx, search, sortBy, order string, limit int, filter model.Filter) error {
+	page, err := utils.ParseCursorPage(c.Query("cursor"), sortBy, order, limit, alumniCursorSortable)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	data, err := h.repo.WithContext(c.UserContext()).GetAlumniCursorRepo(search, page, filter)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	data, next, prev := utils.CursorResult(data, page)
+
+	meta := model.CursorMetaInfo{
+		Limit:      page.Limit,
+		SortBy:     page.SortBy,
+		Order:      page.Order,
+		Search:     search,
+		Filter:     c.Query("filter"),
+		NextCursor: next,
+		PrevCursor: prev,
+	}
+	if c.QueryBool("with_total") {
+		total, err := h.repo.WithContext(c.UserContext()).CountAlumniRepo(search, filter)
+		if err != nil {
+			return response.Error(c, err)
+		}
+		meta.Total = &total
+	}
+
+	return response.List(c, data, meta)
 }
 
 // GetAlumniByID godoc
@@ -73,29 +141,24 @@ func GetAllAlumni(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "ID Alumni"
 // @Security BearerAuth
-// @Success 200 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Success 200 {object} response.Envelope
+// @Header 200 {string} ETag "Version data, kirim ulang lewat If-Match saat update"
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Router /api/mg/alumni/{id} [get]
-func GetAlumniByID(c *fiber.Ctx) error {
+func (h *AlumniHandler) GetAlumniByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 
-	data, err := mongoRepo.GetAlumniByID(id)
+	data, err := h.repo.WithContext(c.UserContext()).GetAlumniByID(id)
 	if err != nil {
 		if err == mongodriver.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Alumni tidak ditemukan",
-			})
+			return response.Fail(c, fiber.StatusNotFound, "Alumni tidak ditemukan")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"success": true,
-		"data":    data,
-	})
+	c.Set(fiber.HeaderETag, utils.ETag(data.Version))
+	return response.OK(c, data)
 }
 
 // CreateAlumni godoc
@@ -106,32 +169,31 @@ func GetAlumniByID(c *fiber.Ctx) error {
 // @Produce json
 // @Param request body model.CreateAlumniRequest true "Data Alumni Baru"
 // @Security BearerAuth
-// @Success 201 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Success 201 {object} response.Envelope
+// @Failure 400 {object} response.Problem
+// @Failure 422 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Router /api/mg/alumni [post]
-func CreateAlumni(c *fiber.Ctx) error {
+func (h *AlumniHandler) CreateAlumni(c *fiber.Ctx) error {
 	// 1. Parse DTO Request (dari app/model/alumni.go)
 	var req model.CreateAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return response.Fail(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
 	}
 
 	// 2. Ambil UserID dari token (ini PENTING)
 	// Kita asumsikan middleware sudah menaruh string ObjectID
 	userIDHex, ok := c.Locals("userID").(string)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "UserID tidak ditemukan di token",
-		})
+		return response.Fail(c, fiber.StatusUnauthorized, "UserID tidak ditemukan di token")
 	}
 	userIDObj, err := primitive.ObjectIDFromHex(userIDHex)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Format UserID di token tidak valid",
-		})
+		return response.Fail(c, fiber.StatusUnauthorized, "Format UserID di token tidak valid")
 	}
 
 	// 3. Konversi DTO ke Model Database (app/model/mongo/alumni.go)
@@ -148,18 +210,15 @@ func CreateAlumni(c *fiber.Ctx) error {
 	}
 
 	// 4. Panggil Repo
-	createdData, err := mongoRepo.CreateAlumni(newAlumni)
+	createdData, err := h.repo.WithContext(c.UserContext()).CreateAlumni(newAlumni)
 	if err != nil {
-		// TODO: Handle duplikat NIM/Email
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		if errors.Is(err, mongoRepo.ErrDuplikat) {
+			return response.Fail(c, fiber.StatusConflict, err.Error())
+		}
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"data":    createdData,
-	})
+	return response.Created(c, createdData)
 }
 
 // UpdateAlumni godoc
@@ -169,41 +228,47 @@ func CreateAlumni(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "ID Alumni"
+// @Param If-Match header string true "ETag dari GET alumni, atau * untuk melewati pengecekan"
 // @Param request body model.UpdateAlumniRequest true "Data Alumni Terbaru"
 // @Security BearerAuth
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.Problem
+// @Failure 422 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 412 {object} response.Problem
+// @Failure 428 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Router /api/mg/alumni/{id} [put]
-func UpdateAlumni(c *fiber.Ctx) error {
+func (h *AlumniHandler) UpdateAlumni(c *fiber.Ctx) error {
 	id := c.Params("id")
 
 	// Parse DTO Request (dari app/model/alumni.go)
 	var req model.UpdateAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return response.Fail(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
 	}
 
 	// Panggil Repo
-	updatedData, err := mongoRepo.UpdateAlumni(id, req)
+	updatedData, err := h.repo.WithContext(c.UserContext()).UpdateAlumni(id, middleware.IfMatchVersion(c), req)
 	if err != nil {
 		if err == mongodriver.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Alumni tidak ditemukan",
-			})
+			return response.Fail(c, fiber.StatusNotFound, "Alumni tidak ditemukan")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		if errors.Is(err, mongoRepo.ErrDuplikat) {
+			return response.Fail(c, fiber.StatusConflict, err.Error())
+		}
+		if errors.Is(err, mongoRepo.ErrVersionConflict) {
+			return response.Fail(c, fiber.StatusPreconditionFailed, "Alumni sudah diubah, ambil ulang data terbaru")
+		}
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"success": true,
-		"data":    updatedData,
-	})
+	c.Set(fiber.HeaderETag, utils.ETag(updatedData.Version))
+	return response.OK(c, updatedData)
 }
 
 // SoftDeleteAlumni godoc
@@ -214,27 +279,20 @@ func UpdateAlumni(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "ID Alumni"
 // @Security BearerAuth
-// @Success 200 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Success 200 {object} response.Envelope
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Router /api/mg/alumni/soft-delete/{id} [delete]
-func SoftDeleteAlumni(c *fiber.Ctx) error {
+func (h *AlumniHandler) SoftDeleteAlumni(c *fiber.Ctx) error {
 	id := c.Params("id")
 
-	err := mongoRepo.SoftDeleteAlumni(id)
+	err := h.repo.WithContext(c.UserContext()).SoftDeleteAlumni(id)
 	if err != nil {
 		if err == mongodriver.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Alumni tidak ditemukan atau sudah dihapus",
-			})
+			return response.Fail(c, fiber.StatusNotFound, "Alumni tidak ditemukan atau sudah dihapus")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"success": true,
-		"message": "Alumni berhasil di soft delete",
-	})
+	return response.Message(c, "Alumni berhasil di soft delete")
 }
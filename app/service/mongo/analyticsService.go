@@ -0,0 +1,149 @@
+package mongo
+
+import (
+	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/response"
+	"latihan2/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AnalyticsHandler menangani endpoint /api/mg/analytics. Repository disuntikkan
+// lewat NewAnalyticsHandler sehingga handler bisa diuji tanpa MongoDB.
+type AnalyticsHandler struct {
+	repo mongoRepo.AnalyticsRepository
+}
+
+func NewAnalyticsHandler(repo mongoRepo.AnalyticsRepository) *AnalyticsHandler {
+	return &AnalyticsHandler{repo: repo}
+}
+
+// GetEmploymentRate godoc
+// @Summary      Tingkat keterserapan kerja alumni
+// @Description  Persentase alumni yang sudah bekerja per tahun lulus dan jurusan
+// @Tags         Analytics
+// @Produce      json
+// @Param        tahun_mulai  query  int     false  "Tahun lulus paling awal"
+// @Param        tahun_akhir  query  int     false  "Tahun lulus paling akhir"
+// @Param        jurusan      query  string  false  "Filter jurusan"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /api/mg/analytics/employment-rate [get]
+// @Security     BearerAuth
+func (h *AnalyticsHandler) GetEmploymentRate(c *fiber.Ctx) error {
+	f, err := utils.ParseAnalyticsFilter(c.Query("tahun_mulai"), c.Query("tahun_akhir"), c.Query("jurusan"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, err := h.repo.WithContext(c.UserContext()).GetEmploymentRate(f)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{"filter": f})
+}
+
+// GetTimeToFirstJob godoc
+// @Summary      Lama waktu mendapat pekerjaan pertama
+// @Description  Rata-rata, median, min dan max bulan dari tahun lulus sampai pekerjaan pertama
+// @Tags         Analytics
+// @Produce      json
+// @Param        tahun_mulai  query  int     false  "Tahun lulus paling awal"
+// @Param        tahun_akhir  query  int     false  "Tahun lulus paling akhir"
+// @Param        jurusan      query  string  false  "Filter jurusan"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /api/mg/analytics/time-to-first-job [get]
+// @Security     BearerAuth
+func (h *AnalyticsHandler) GetTimeToFirstJob(c *fiber.Ctx) error {
+	f, err := utils.ParseAnalyticsFilter(c.Query("tahun_mulai"), c.Query("tahun_akhir"), c.Query("jurusan"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, err := h.repo.WithContext(c.UserContext()).GetTimeToFirstJob(f)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{"filter": f})
+}
+
+// GetDistribusiPekerjaan godoc
+// @Summary      Distribusi pekerjaan alumni
+// @Description  Jumlah pekerjaan per bidang_industri atau lokasi_kerja
+// @Tags         Analytics
+// @Produce      json
+// @Param        dimensi      path   string  true   "bidang_industri atau lokasi_kerja"
+// @Param        tahun_mulai  query  int     false  "Tahun lulus paling awal"
+// @Param        tahun_akhir  query  int     false  "Tahun lulus paling akhir"
+// @Param        jurusan      query  string  false  "Filter jurusan"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /api/mg/analytics/distribusi/{dimensi} [get]
+// @Security     BearerAuth
+func (h *AnalyticsHandler) GetDistribusiPekerjaan(c *fiber.Ctx) error {
+	f, err := utils.ParseAnalyticsFilter(c.Query("tahun_mulai"), c.Query("tahun_akhir"), c.Query("jurusan"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	dimensi := c.Params("dimensi")
+	if dimensi != "bidang_industri" && dimensi != "lokasi_kerja" {
+		return response.Fail(c, fiber.StatusBadRequest, "Dimensi harus bidang_industri atau lokasi_kerja")
+	}
+	data, err := h.repo.WithContext(c.UserContext()).GetDistribusiPekerjaan(dimensi, f)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{"filter": f})
+}
+
+// GetDistribusiGaji godoc
+// @Summary      Distribusi rentang gaji
+// @Description  Jumlah pekerjaan per rentang gaji awal
+// @Tags         Analytics
+// @Produce      json
+// @Param        tahun_mulai  query  int     false  "Tahun lulus paling awal"
+// @Param        tahun_akhir  query  int     false  "Tahun lulus paling akhir"
+// @Param        jurusan      query  string  false  "Filter jurusan"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /api/mg/analytics/gaji [get]
+// @Security     BearerAuth
+func (h *AnalyticsHandler) GetDistribusiGaji(c *fiber.Ctx) error {
+	f, err := utils.ParseAnalyticsFilter(c.Query("tahun_mulai"), c.Query("tahun_akhir"), c.Query("jurusan"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, err := h.repo.WithContext(c.UserContext()).GetDistribusiGaji(f)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{"filter": f})
+}
+
+// GetCohortTrend godoc
+// @Summary      Tren per angkatan lulus
+// @Description  Tingkat keterserapan, lama mendapat kerja dan gaji awal rata-rata per tahun lulus
+// @Tags         Analytics
+// @Produce      json
+// @Param        tahun_mulai  query  int     false  "Tahun lulus paling awal"
+// @Param        tahun_akhir  query  int     false  "Tahun lulus paling akhir"
+// @Param        jurusan      query  string  false  "Filter jurusan"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /api/mg/analytics/trend [get]
+// @Security     BearerAuth
+func (h *AnalyticsHandler) GetCohortTrend(c *fiber.Ctx) error {
+	f, err := utils.ParseAnalyticsFilter(c.Query("tahun_mulai"), c.Query("tahun_akhir"), c.Query("jurusan"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, err := h.repo.WithContext(c.UserContext()).GetCohortTrend(f)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{"filter": f})
+}
@@ -5,8 +5,11 @@ import (
 	"latihan2/app/model" 
 	mongoModel "latihan2/app/model/mongo"
 	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/logging"
+	"latihan2/metrics"
+	"latihan2/response"
 	"latihan2/utils"
-	"os"
+	"latihan2/validation"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,6 +18,15 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// AuthHandler menangani login dan profile MongoDB.
+type AuthHandler struct {
+	users mongoRepo.UserRepository
+}
+
+func NewAuthHandler(users mongoRepo.UserRepository) *AuthHandler {
+	return &AuthHandler{users: users}
+}
+
 // LoginMongo godoc
 // @Summary Login user
 // @Description Login untuk mendapatkan JWT token
@@ -22,47 +34,42 @@ import (
 // @Accept json
 // @Produce json
 // @Param request body model.LoginRequest true "Data login"
-// @Success 200 {object} mongoModel.LoginResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 401 {object} map[string]interface{}
+// @Success 200 {object} response.Envelope{data=mongoModel.LoginResponse}
+// @Failure 400 {object} response.Problem
+// @Failure 422 {object} response.Problem
+// @Failure 401 {object} response.Problem
 // @Router /api/mg/login [post]
-func LoginMongo(c *fiber.Ctx) error {
+func (h *AuthHandler) LoginMongo(c *fiber.Ctx) error {
 	var req model.LoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Request body tidak valid",
-		})
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
 	}
-	user, err := mongoRepo.GetUserByUsername(req.Username)
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
+	}
+	user, err := h.users.WithContext(c.UserContext()).GetUserByUsername(req.Username)
 
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Username atau password salah",
-			})
+			metrics.ObserveLogin("mg", metrics.LoginFailure)
+			return response.Fail(c, fiber.StatusUnauthorized, "Username atau password salah")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Username atau password salah",
-		})
+		metrics.ObserveLogin("mg", metrics.LoginFailure)
+		return response.Fail(c, fiber.StatusUnauthorized, "Username atau password salah")
 	}
 
-	// fmt.Printf(">>> [DEBUG] Memanggil GenerateMongoToken dengan User: %+v", user)
 	token, err := utils.GenerateMongoToken(user.ID, user.Username, user.Role)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Gagal membuat token",
-		})
+		logging.FromContext(c.UserContext()).Error("membuat token mongo gagal", "error", err)
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal membuat token")
 	}
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"success": true,
-		"message": "Login berhasil",
-		"data": mongoModel.LoginResponse{
+	metrics.ObserveLogin("mg", metrics.LoginSuccess)
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    mongoModel.LoginResponse{
 			User: mongoModel.UserMongo{
 				ID:        user.ID,
 				Username:  user.Username,
@@ -72,50 +79,37 @@ func LoginMongo(c *fiber.Ctx) error {
 			},
 			Token: token,
 		},
+		Message: "Login berhasil",
 	})
 }
 
-func GetProfileMongo(c *fiber.Ctx) error {
+func (h *AuthHandler) GetProfileMongo(c *fiber.Ctx) error {
 	userID, ok := c.Locals("userID").(string)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "UserID tidak ditemukan dari token",
-		})
+		return response.Fail(c, fiber.StatusUnauthorized, "UserID tidak ditemukan dari token")
 	}
 
-	user, err := mongoRepo.GetUserByID(userID)
+	user, err := h.users.WithContext(c.UserContext()).GetUserByID(userID)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "User profile tidak ditemukan",
-			})
+			return response.Fail(c, fiber.StatusNotFound, "User profile tidak ditemukan")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"success": true,
-		"data":    user,
-	})
+	return response.OK(c, user)
 }
 
 func GenerateMongoToken(user *mongoModel.User) (string, error) {
 	claims := jwt.MapClaims{
 		"userID":   user.ID.Hex(),
 		"username": user.Username,
-		"exp":      time.Now().Add(time.Hour * 24).Unix(),
+		"exp":      time.Now().Add(utils.MongoTokenTTL).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	secretKey := os.Getenv("JWT_SECRET_KEY")
-	if secretKey == "" {
-		secretKey = "your-default-secret-key"
-	}
-
-	tokenString, err := token.SignedString([]byte(secretKey))
+	tokenString, err := token.SignedString(utils.JWTSecret())
 	if err != nil {
 		return "", errors.New("failed to create token")
 	}
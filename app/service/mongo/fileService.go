@@ -2,13 +2,20 @@ package mongo
 
 import (
 	"fmt"
-	"log"
 	"time"
 
+	"latihan2/app/model"
 	mongoModel "latihan2/app/model/mongo"
 	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/logging"
+	"latihan2/metrics"
+	"latihan2/response"
+	"latihan2/tracing"
+	"latihan2/utils"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -17,7 +24,40 @@ import (
 	mongodriver "go.mongodb.org/mongo-driver/mongo"
 )
 
-const uploadPath = "./uploads"
+// FileHandler menangani upload dan akses file. Metadata lewat FileRepository, isi file di disk.
+type FileHandler struct {
+	repo mongoRepo.FileRepository
+}
+
+func NewFileHandler(repo mongoRepo.FileRepository) *FileHandler {
+	return &FileHandler{repo: repo}
+}
+
+// Lokasi dan batas ukuran file upload, diisi config.Apply dari bagian upload
+// konfigurasi.
+var (
+	UploadPath         = "./uploads"
+	MaxImageSize int64 = 1 << 20
+	MaxPDFSize   int64 = 2 << 20
+)
+
+// ValidateUpload memeriksa tipe dan ukuran file upload terhadap batas di atas.
+// Dipakai juga oleh /api/v1/files.
+func ValidateUpload(contentType string, size int64) error {
+	var maxSize int64
+	switch contentType {
+	case "image/jpeg", "image/png", "image/jpg":
+		maxSize = MaxImageSize
+	case "application/pdf":
+		maxSize = MaxPDFSize
+	default:
+		return fmt.Errorf("File type '%s' not allowed", contentType)
+	}
+	if size > maxSize {
+		return fmt.Errorf("File size exceeds limit for type %s (max %d MB)", contentType, maxSize/(1024*1024))
+	}
+	return nil
+}
 
 func toFileResponse(file *mongoModel.File, ownerID primitive.ObjectID) *mongoModel.FileResponse {
 	return &mongoModel.FileResponse{
@@ -42,31 +82,25 @@ func toFileResponse(file *mongoModel.File, ownerID primitive.ObjectID) *mongoMod
 // @Security BearerAuth
 // @Param file formData file true "File yang akan diupload"
 // @Param target_user_id formData string false "Hanya digunakan oleh admin untuk menentukan pemilik file"
-// @Success 201 {object} map[string]interface{} "File uploaded successfully"
-// @Failure 400 {object} map[string]interface{} "Bad Request"
-// @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 403 {object} map[string]interface{} "Forbidden"
-// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Success 201 {object} response.Envelope "File uploaded successfully"
+// @Failure 400 {object} response.Problem "Bad Request"
+// @Failure 401 {object} response.Problem "Unauthorized"
+// @Failure 403 {object} response.Problem "Forbidden"
+// @Failure 500 {object} response.Problem "Internal Server Error"
 // @Router /api/mg/files/upload [post]
-func UploadFile(c *fiber.Ctx) error {
+func (h *FileHandler) UploadFile(c *fiber.Ctx) error {
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false, "message": "No file uploaded", "error": err.Error(),
-		})
+		return response.Fail(c, fiber.StatusBadRequest, "No file uploaded")
 	}
 	loggedInUserIDHex, okUserID := c.Locals("userID").(string)
 	role, okRole := c.Locals("role").(string)
 	if !okUserID || !okRole {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false, "message": "Informasi user tidak ditemukan di token",
-		})
+		return response.Fail(c, fiber.StatusUnauthorized, "Informasi user tidak ditemukan di token")
 	}
 	loggedInUserID, err := primitive.ObjectIDFromHex(loggedInUserIDHex)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false, "message": "Format UserID di token tidak valid",
-		})
+		return response.Fail(c, fiber.StatusUnauthorized, "Format UserID di token tidak valid")
 	}
 
 	var ownerID primitive.ObjectID
@@ -74,74 +108,38 @@ func UploadFile(c *fiber.Ctx) error {
 
 	if role == "admin" {
 		if targetUserIDHex == "" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Admin harus menyertakan target_user_id",
-			})
+			return response.Fail(c, fiber.StatusBadRequest, "Admin harus menyertakan target_user_id")
 		}
 		targetObjID, err := primitive.ObjectIDFromHex(targetUserIDHex)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": "Format target_user_id tidak valid",
-			})
+			return response.Fail(c, fiber.StatusBadRequest, "Format target_user_id tidak valid")
 		}
 		ownerID = targetObjID
 	} else if role == "user" {
 		if targetUserIDHex != "" {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"message": "User tidak diperbolehkan menentukan target_user_id",
-			})
+			return response.Fail(c, fiber.StatusForbidden, "User tidak diperbolehkan menentukan target_user_id")
 		}
 		ownerID = loggedInUserID
 	} else {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"success": false,
-			"message": fmt.Sprintf("Role '%s' tidak diizinkan melakukan upload", role),
-		})
+		return response.Fail(c, fiber.StatusForbidden, fmt.Sprintf("Role '%s' tidak diizinkan melakukan upload", role))
 	}
 
 	contentType := fileHeader.Header.Get("Content-Type")
-	allowedTypes := map[string]bool{
-		"image/jpeg":      true,
-		"image/png":       true,
-		"image/jpg":       true,
-		"application/pdf": true,
-	}
-	if !allowedTypes[contentType] {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": fmt.Sprintf("File type '%s' not allowed", contentType),
-		})
-	}
-
-	maxSize := int64(0)
-	switch contentType {
-	case "image/jpeg", "image/png", "image/jpg":
-		maxSize = 1 * 1024 * 1024 // 1 MB
-	case "application/pdf":
-		maxSize = 2 * 1024 * 1024 // 2 MB
-	}
-	if fileHeader.Size > maxSize {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": fmt.Sprintf("File size exceeds limit for type %s (max %d MB)", contentType, maxSize/(1024*1024)),
-		})
+	if err := ValidateUpload(contentType, fileHeader.Size); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
 	}
 	ext := filepath.Ext(fileHeader.Filename)
 	newFileName := uuid.New().String() + ext
-	filePath := filepath.Join(uploadPath, newFileName)
+	filePath := filepath.Join(UploadPath, newFileName)
 
-	if err := os.MkdirAll(uploadPath, os.ModePerm); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false, "message": "Failed to create upload directory", "error": err.Error(),
-		})
+	if err := os.MkdirAll(UploadPath, os.ModePerm); err != nil {
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to create upload directory")
 	}
-	if err := c.SaveFile(fileHeader, filePath); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false, "message": "Failed to save file to disk", "error": err.Error(),
-		})
+	err = tracing.FileIO(c.UserContext(), "save", filePath, func() error {
+		return c.SaveFile(fileHeader, filePath)
+	})
+	if err != nil {
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to save file to disk")
 	}
 
 	fileModel := &mongoModel.File{
@@ -155,38 +153,45 @@ func UploadFile(c *fiber.Ctx) error {
 		UploadedAt:   time.Now(),
 	}
 
-	if err := mongoRepo.CreateFile(fileModel); err != nil {
-		os.Remove(filePath)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false, "message": "Failed to save file metadata", "error": err.Error(),
-		})
+	if err := h.repo.WithContext(c.UserContext()).CreateFile(fileModel); err != nil {
+		tracing.FileIO(c.UserContext(), "remove", filePath, func() error { return os.Remove(filePath) })
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to save file metadata")
 	}
 
-	log.Printf("[DEBUG-UploadFile] file saved. ID: %s, OwnerID: %s", fileModel.ID.Hex(), ownerID.Hex())
+	metrics.ObserveUpload(contentType, fileHeader.Size)
+	logging.FromContext(c.UserContext()).Debug("file tersimpan", "file_id", fileModel.ID.Hex(), "owner_id", ownerID.Hex())
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"message": "File uploaded successfully",
-		"data":    toFileResponse(fileModel, ownerID),
+	return response.Send(c, fiber.StatusCreated, response.Envelope{
+		Data:    toFileResponse(fileModel, ownerID),
+		Message: "File uploaded successfully",
 	})
 }
 
 // GetAllFiles godoc
 // @Summary Ambil semua file
-// @Description Mengambil daftar semua file yang tersimpan di database.
+// @Description Mengambil daftar semua file yang tersimpan di database. Dengan pagination=cursor, file diambil per halaman.
 // @Tags Files
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} map[string]interface{} "Files retrieved successfully"
-// @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Param pagination query string false "Isi 'cursor' untuk pagination keyset"
+// @Param cursor query string false "next_cursor atau prev_cursor dari response sebelumnya"
+// @Param limit query int false "Jumlah data per halaman (default 10)"
+// @Param sortBy query string false "_id, uploaded_at, file_name, original_name atau file_size (default: uploaded_at)"
+// @Param order query string false "Urutan pengurutan (asc/desc, default desc)"
+// @Param with_total query bool false "Hitung total data pada mode cursor"
+// @Success 200 {object} response.Envelope "Files retrieved successfully"
+// @Failure 400 {object} response.Problem "Invalid cursor"
+// @Failure 401 {object} response.Problem "Unauthorized"
+// @Failure 500 {object} response.Problem "Internal Server Error"
 // @Router /api/mg/files [get]
-func GetAllFiles(c *fiber.Ctx) error {
-	files, err := mongoRepo.FindAllFiles()
+func (h *FileHandler) GetAllFiles(c *fiber.Ctx) error {
+	if utils.CursorRequested(c.Query("pagination"), c.Query("cursor")) {
+		return h.getFilesCursor(c)
+	}
+
+	files, err := h.repo.WithContext(c.UserContext()).FindAllFiles()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false, "message": "Failed to get files", "error": err.Error(),
-		})
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to get files")
 	}
 
 	var responses []mongoModel.FileResponse
@@ -194,8 +199,58 @@ func GetAllFiles(c *fiber.Ctx) error {
 		responses = append(responses, *toFileResponse(&file, file.OwnerID))
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true, "message": "Files retrieved successfully", "data": responses,
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    responses,
+		Message: "Files retrieved successfully",
+	})
+}
+
+var filesCursorSortable = map[string]bool{
+	"_id":           true,
+	"uploaded_at":   true,
+	"file_name":     true,
+	"original_name": true,
+	"file_size":     true,
+}
+
+// getFilesCursor melayani GetAllFiles pada mode pagination cursor.
+func (h *FileHandler) getFilesCursor(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	page, err := utils.ParseCursorPage(c.Query("cursor"), c.Query("sortBy", "uploaded_at"), strings.ToLower(c.Query("order", "desc")), limit, filesCursorSortable)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, "Invalid cursor")
+	}
+
+	files, err := h.repo.WithContext(c.UserContext()).FindFilesCursor(page)
+	if err != nil {
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to get files")
+	}
+	files, next, prev := utils.CursorResult(files, page)
+
+	responses := make([]mongoModel.FileResponse, 0, len(files))
+	for _, file := range files {
+		responses = append(responses, *toFileResponse(&file, file.OwnerID))
+	}
+
+	meta := model.CursorMetaInfo{
+		Limit:      page.Limit,
+		SortBy:     page.SortBy,
+		Order:      page.Order,
+		NextCursor: next,
+		PrevCursor: prev,
+	}
+	if c.QueryBool("with_total") {
+		total, err := h.repo.WithContext(c.UserContext()).CountFiles()
+		if err != nil {
+			return response.Fail(c, fiber.StatusInternalServerError, "Failed to count files")
+		}
+		meta.Total = &total
+	}
+
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    responses,
+		Meta:    meta,
+		Message: "Files retrieved successfully",
 	})
 }
 
@@ -206,90 +261,67 @@ func GetAllFiles(c *fiber.Ctx) error {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "File ID"
-// @Success 200 {object} map[string]interface{} "File retrieved successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid ID"
-// @Failure 404 {object} map[string]interface{} "File not found"
-// @Failure 500 {object} map[string]interface{} "Database error"
+// @Success 200 {object} response.Envelope "File retrieved successfully"
+// @Failure 400 {object} response.Problem "Invalid ID"
+// @Failure 404 {object} response.Problem "File not found"
+// @Failure 500 {object} response.Problem "Database error"
 // @Router /api/mg/files/{id} [get]
-func GetFileByID(c *fiber.Ctx) error {
+func (h *FileHandler) GetFileByID(c *fiber.Ctx) error {
 	id := c.Params("id")
-	file, err := mongoRepo.FindFileByID(id)
+	file, err := h.repo.WithContext(c.UserContext()).FindFileByID(id)
 	if err != nil {
 		if err == mongodriver.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false, "message": "File not found",
-			})
+			return response.Fail(c, fiber.StatusNotFound, "File not found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false, "message": "Database error", "error": err.Error(),
-		})
+		return response.Fail(c, fiber.StatusInternalServerError, "Database error")
 	}
 
 	// ownerID = file.OwnerID
-	return c.JSON(fiber.Map{
-		"success": true, "message": "File retrieved successfully", "data": toFileResponse(file, file.OwnerID),
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    toFileResponse(file, file.OwnerID),
+		Message: "File retrieved successfully",
 	})
 }
 
-func GetContentByID(c *fiber.Ctx) error {
+func (h *FileHandler) GetContentByID(c *fiber.Ctx) error {
 	idHex := c.Params("id")
 	fileID, err := primitive.ObjectIDFromHex(idHex)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Invalid file ID format",
-		})
+		return response.Fail(c, fiber.StatusBadRequest, "Invalid file ID format")
 	}
 
 	loggedInUserIDHex, okUser := c.Locals("userID").(string)
 	role, okRole := c.Locals("role").(string)
 	if !okUser || !okRole {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "Token tidak valid atau user belum login",
-		})
+		return response.Fail(c, fiber.StatusUnauthorized, "Token tidak valid atau user belum login")
 	}
 
 	loggedInUserID, err := primitive.ObjectIDFromHex(loggedInUserIDHex)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"success": false,
-			"message": "Format user ID tidak valid",
-		})
+		return response.Fail(c, fiber.StatusUnauthorized, "Format user ID tidak valid")
 	}
 
-	file, err := mongoRepo.OpenFileByID(fileID)
+	file, err := h.repo.WithContext(c.UserContext()).OpenFileByID(fileID)
 	if err != nil {
 		if err == mongodriver.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false,
-				"message": "File tidak ditemukan",
-			})
+			return response.Fail(c, fiber.StatusNotFound, "File tidak ditemukan")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Gagal mengambil file dari database",
-			"error":   err.Error(),
-		})
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal mengambil file dari database")
 	}
 
 	if role == "user" && file.OwnerID != loggedInUserID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"success": false,
-			"message": "Kamu tidak punya izin untuk mengakses file ini",
-		})
+		return response.Fail(c, fiber.StatusForbidden, "Kamu tidak punya izin untuk mengakses file ini")
 	}
 
 	if _, err := os.Stat(file.FilePath); os.IsNotExist(err) {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "File fisik tidak ditemukan di server",
-		})
+		return response.Fail(c, fiber.StatusNotFound, "File fisik tidak ditemukan di server")
 	}
 
 	c.Set("Content-Type", file.FileType)
 	c.Set("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", file.OriginalName))
-	return c.SendFile(file.FilePath)
+	return tracing.FileIO(c.UserContext(), "send", file.FilePath, func() error {
+		return c.SendFile(file.FilePath)
+	})
 }
 
 // DeleteFile godoc
@@ -299,39 +331,32 @@ func GetContentByID(c *fiber.Ctx) error {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "File ID"
-// @Success 200 {object} map[string]interface{} "File deleted successfully"
-// @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 403 {object} map[string]interface{} "Forbidden"
-// @Failure 404 {object} map[string]interface{} "File not found"
-// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Success 200 {object} response.Envelope "File deleted successfully"
+// @Failure 401 {object} response.Problem "Unauthorized"
+// @Failure 403 {object} response.Problem "Forbidden"
+// @Failure 404 {object} response.Problem "File not found"
+// @Failure 500 {object} response.Problem "Internal Server Error"
 // @Router /api/mg/files/{id} [delete]
-func DeleteFile(c *fiber.Ctx) error {
+func (h *FileHandler) DeleteFile(c *fiber.Ctx) error {
 	id := c.Params("id")
 
-	file, err := mongoRepo.FindFileByID(id)
+	file, err := h.repo.WithContext(c.UserContext()).FindFileByID(id)
 	if err != nil {
 		if err == mongodriver.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"success": false, "message": "File not found",
-			})
+			return response.Fail(c, fiber.StatusNotFound, "File not found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false, "message": "Database error", "error": err.Error(),
-		})
+		return response.Fail(c, fiber.StatusInternalServerError, "Database error")
 	}
 
-	if err := os.Remove(file.FilePath); err != nil {
-		fmt.Println("Warning: Failed to delete file from storage:", err)
+	err = tracing.FileIO(c.UserContext(), "remove", file.FilePath, func() error { return os.Remove(file.FilePath) })
+	if err != nil {
+		logging.FromContext(c.UserContext()).Warn("gagal menghapus file dari storage", "path", file.FilePath, "error", err)
 	}
 
-	if err := mongoRepo.DeleteFile(id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false, "message": "Failed to delete file metadata", "error": err.Error(),
-		})
+	if err := h.repo.WithContext(c.UserContext()).DeleteFile(id); err != nil {
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to delete file metadata")
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true, "message": "File deleted successfully",
-	})
+	return response.Message(c, "File deleted successfully")
 }
 
@@ -1,9 +1,15 @@
 package mongo
 
 import (
-	"fmt"
+	"errors"
+	"latihan2/app/model"
 	mongoModel "latihan2/app/model/mongo"
 	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/logging"
+	"latihan2/middleware"
+	"latihan2/response"
+	"latihan2/utils"
+	"latihan2/validation"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +17,15 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// PekerjaanHandler menangani endpoint /api/mg/pekerjaan.
+type PekerjaanHandler struct {
+	repo mongoRepo.PekerjaanRepository
+}
+
+func NewPekerjaanHandler(repo mongoRepo.PekerjaanRepository) *PekerjaanHandler {
+	return &PekerjaanHandler{repo: repo}
+}
+
 // GetAllPekerjaan godoc
 // @Summary      Mendapatkan semua data pekerjaan
 // @Description  Mengambil daftar pekerjaan dengan pagination, sorting, dan search
@@ -22,11 +37,19 @@ import (
 // @Param        sortBy   query     string  false  "Kolom pengurutan (default: created_at)"
 // @Param        order    query     string  false  "Arah pengurutan (asc/desc)"
 // @Param        search   query     string  false  "Kata kunci pencarian"
-// @Success      200 {object} map[string]interface{}
-// @Failure      500 {object} map[string]interface{}
+// @Param        gaji_min   query   int     false  "Gaji minimum (gaji_min >= nilai)"
+// @Param        gaji_max   query   int     false  "Gaji maksimum (batas atas gaji <= nilai)"
+// @Param        mata_uang  query   string  false  "Kode mata uang gaji, mis. IDR"
+// @Param        filter     query   string  false  "Filter field:operator:nilai dipisah koma, mis. gaji_min:gte:5000000,status_pekerjaan:eq:aktif"
+// @Param        pagination query   string  false  "Isi 'cursor' untuk pagination keyset"
+// @Param        cursor     query   string  false  "next_cursor atau prev_cursor dari response sebelumnya"
+// @Param        with_total query   bool    false  "Hitung total data pada mode cursor"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      500 {object} response.Problem
 // @Router       /api/mg/pekerjaan [get]
 // @Security     BearerAuth
-func GetAllPekerjaan(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) GetAllPekerjaan(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	sortBy := c.Query("sortBy", "created_at")
@@ -34,6 +57,16 @@ func GetAllPekerjaan(c *fiber.Ctx) error {
 	search := c.Query("search", "")
 	offset := (page - 1) * limit
 
+	gaji, err := utils.ParseGajiFilter(c.Query("gaji_min"), c.Query("gaji_max"), c.Query("mata_uang"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	filter, err := utils.ParseFilter(c.Query("filter"), model.PekerjaanFilterFields)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
 	sortWhitelist := map[string]bool{
 		"_id":                   true,
 		"user_id":               true,
@@ -43,6 +76,8 @@ func GetAllPekerjaan(c *fiber.Ctx) error {
 		"bidang_industri":       true,
 		"lokasi_kerja":          true,
 		"gaji_range":            true,
+		"gaji_min":              true,
+		"gaji_max":              true,
 		"tanggal_mulai_kerja":   true,
 		"tanggal_selesai_kerja": true,
 		"status_pekerjaan":      true,
@@ -58,23 +93,21 @@ func GetAllPekerjaan(c *fiber.Ctx) error {
 		order = "asc"
 	}
 
-	data, err := mongoRepo.GetPekerjaanRepo(search, sortBy, order, limit, offset)
+	if utils.CursorRequested(c.Query("pagination"), c.Query("cursor")) {
+		return h.getPekerjaanCursor(c, search, sortBy, order, limit, gaji, filter)
+	}
+
+	data, err := h.repo.WithContext(c.UserContext()).GetPekerjaanRepo(search, sortBy, order, limit, offset, gaji, filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	total, err := mongoRepo.CountPekerjaanRepo(search)
+	total, err := h.repo.WithContext(c.UserContext()).CountPekerjaanRepo(search, gaji, filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": data,
-		"meta": fiber.Map{
+	return response.List(c, data, fiber.Map{
 			"page":   page,
 			"limit":  limit,
 			"total":  total,
@@ -82,8 +115,51 @@ func GetAllPekerjaan(c *fiber.Ctx) error {
 			"sortBy": sortBy,
 			"order":  order,
 			"search": search,
-		},
-	})
+			"filter": c.Query("filter"),
+		})
+}
+
+// pekerjaanCursorSortable hanya berisi field yang selalu terisi; field lain
+// bisa tidak ada di dokumen (omitempty) sehingga tidak bisa dipakai sebagai keyset.
+var pekerjaanCursorSortable = map[string]bool{
+	"_id":                 true,
+	"nama_perusahaan":     true,
+	"posisi_jabatan":      true,
+	"tanggal_mulai_kerja": true,
+	"created_at":          true,
+}
+
+// getPekerjaanCursor melayani GetAllPekerjaan pada mode pagination cursor.
+func (h *PekerjaanHandler) getPekerjaanCursor(c *fiber.Ctx, search, sortBy, order string, limit int, gaji model.GajiFilter, filter model.Filter) error {
+	page, err := utils.ParseCursorPage(c.Query("cursor"), sortBy, order, limit, pekerjaanCursorSortable)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	data, err := h.repo.WithContext(c.UserContext()).GetPekerjaanCursorRepo(search, page, gaji, filter)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	data, next, prev := utils.CursorResult(data, page)
+
+	meta := model.CursorMetaInfo{
+		Limit:      page.Limit,
+		SortBy:     page.SortBy,
+		Order:      page.Order,
+		Search:     search,
+		Filter:     c.Query("filter"),
+		NextCursor: next,
+		PrevCursor: prev,
+	}
+	if c.QueryBool("with_total") {
+		total, err := h.repo.WithContext(c.UserContext()).CountPekerjaanRepo(search, gaji, filter)
+		if err != nil {
+			return response.Error(c, err)
+		}
+		meta.Total = &total
+	}
+
+	return response.List(c, data, meta)
 }
 
 // GetPekerjaanByID godoc
@@ -93,19 +169,19 @@ func GetAllPekerjaan(c *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "ID pekerjaan"
-// @Success      200 {object} mongoModel.Pekerjaan
-// @Failure      404 {object} map[string]interface{}
+// @Success      200 {object} response.Envelope{data=mongoModel.Pekerjaan}
+// @Header       200 {string} ETag "Version data, kirim ulang lewat If-Match saat update"
+// @Failure      404 {object} response.Problem
 // @Router       /api/mg/pekerjaan/{id} [get]
 // @Security     BearerAuth
-func GetPekerjaanByID(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) GetPekerjaanByID(c *fiber.Ctx) error {
 	id := c.Params("id")
-	data, err := mongoRepo.GetPekerjaanByIDRepo(id)
+	data, err := h.repo.WithContext(c.UserContext()).GetPekerjaanByIDRepo(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Pekerjaan not found",
-		})
+		return response.Fail(c, fiber.StatusNotFound, "Pekerjaan not found")
 	}
-	return c.Status(fiber.StatusOK).JSON(data)
+	c.Set(fiber.HeaderETag, utils.ETag(data.Version))
+	return response.OK(c, data)
 }
 
 // GetPekerjaanByAlumniID godoc
@@ -115,40 +191,24 @@ func GetPekerjaanByID(c *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        alumni_id   path      string  true  "ID Alumni"
-// @Success      200  {object}  map[string]interface{}  "Daftar pekerjaan berdasarkan alumni"
-// @Failure      404  {object}  map[string]string  "Tidak ada data pekerjaan untuk alumni ini"
-// @Failure      500  {object}  map[string]string  "Terjadi kesalahan server"
+// @Success      200  {object}  response.Envelope  "Daftar pekerjaan berdasarkan alumni"
+// @Failure      404  {object}  response.Problem  "Tidak ada data pekerjaan untuk alumni ini"
+// @Failure      500  {object}  response.Problem  "Terjadi kesalahan server"
 // @Router       /api/mg/pekerjaan/alumni/{alumni_id} [get]
 // @Security     BearerAuth
-func GetPekerjaanByAlumniID(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) GetPekerjaanByAlumniID(c *fiber.Ctx) error {
 	alumniID := c.Params("alumni_id")
 
-	data, err := mongoRepo.GetPekerjaanByAlumniID(alumniID)
+	data, err := h.repo.WithContext(c.UserContext()).GetPekerjaanByAlumniID(alumniID)
 	if err != nil {
-		fmt.Println("DEBUG: Terjadi error saat ambil data dari repo:", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	// fmt.Printf("DEBUG: Jumlah data ditemukan: %d\n", len(data))
-	// for i, d := range data {
-	// 	fmt.Printf("DEBUG: Data[%d] = %+v\n", i, d)
-	// }
-
 	if len(data) == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"message": "Tidak ada data pekerjaan untuk alumni ini",
-			"debug": fiber.Map{
-				"alumni_id": alumniID,
-			},
-		})
+		return response.Fail(c, fiber.StatusNotFound, "Tidak ada data pekerjaan untuk alumni ini")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"alumni_id": alumniID,
-		"data":      data,
-	})
+	return response.List(c, data, fiber.Map{"alumni_id": alumniID})
 }
 
 // CreatePekerjaan godoc
@@ -158,17 +218,19 @@ func GetPekerjaanByAlumniID(c *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        request body mongoModel.Pekerjaan true "Data pekerjaan baru"
-// @Success      201 {object} mongoModel.Pekerjaan
-// @Failure      400 {object} map[string]interface{}
-// @Failure      500 {object} map[string]interface{}
+// @Success      201 {object} response.Envelope{data=mongoModel.Pekerjaan}
+// @Failure      400 {object} response.Problem
+// @Failure      422 {object} response.Problem
+// @Failure      500 {object} response.Problem
 // @Router       /api/mg/pekerjaan [post]
 // @Security     BearerAuth
-func CreatePekerjaan(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) CreatePekerjaan(c *fiber.Ctx) error {
 	var req mongoModel.Pekerjaan
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return response.Fail(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
 	}
 
 	// Simulate user/alumni ownership
@@ -179,17 +241,21 @@ func CreatePekerjaan(c *fiber.Ctx) error {
 	// 	}
 	// }
 
+	gaji, gajiRange, err := utils.ResolveGaji(req.GajiRange, req.RentangGaji)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	req.RentangGaji, req.GajiRange = gaji, gajiRange
+
 	req.CreatedAt = time.Now()
 	req.IsDelete = false
 
-	newPekerjaan, err := mongoRepo.CreatePekerjaan(&req)
+	newPekerjaan, err := h.repo.WithContext(c.UserContext()).CreatePekerjaan(&req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(newPekerjaan)
+	return response.Created(c, newPekerjaan)
 }
 
 // UpdatePekerjaan godoc
@@ -199,28 +265,44 @@ func CreatePekerjaan(c *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "ID pekerjaan"
+// @Param        If-Match header string true "ETag dari GET pekerjaan, atau * untuk melewati pengecekan"
 // @Param        request body mongoModel.UpdatePekerjaanRequest true "Data pekerjaan yang diperbarui"
-// @Success      200 {object} mongoModel.Pekerjaan
-// @Failure      400 {object} map[string]interface{}
-// @Failure      404 {object} map[string]interface{}
+// @Success      200 {object} response.Envelope{data=mongoModel.Pekerjaan}
+// @Failure      400 {object} response.Problem
+// @Failure      422 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      412 {object} response.Problem
+// @Failure      428 {object} response.Problem
 // @Router       /api/mg/pekerjaan/{id} [put]
 // @Security     BearerAuth
-func UpdatePekerjaan(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) UpdatePekerjaan(c *fiber.Ctx) error {
 	id := c.Params("id")
 	var req mongoModel.UpdatePekerjaanRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return response.Fail(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
 	}
 
-	data, err := mongoRepo.UpdatePekerjaan(id, req)
+	gaji, gajiRange, err := utils.ResolveGaji(req.GajiRange, req.RentangGaji)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	req.RentangGaji, req.GajiRange = gaji, gajiRange
+
+	data, err := h.repo.WithContext(c.UserContext()).UpdatePekerjaan(id, middleware.IfMatchVersion(c), req)
+	if err != nil {
+		if errors.Is(err, mongoRepo.ErrPekerjaanNotFound) {
+			return response.Fail(c, fiber.StatusNotFound, "Pekerjaan not found")
+		}
+		if errors.Is(err, mongoRepo.ErrVersionConflict) {
+			return response.Fail(c, fiber.StatusPreconditionFailed, "Pekerjaan sudah diubah, ambil ulang data terbaru")
+		}
+		return response.Error(c, err)
 	}
-	return c.Status(fiber.StatusOK).JSON(data)
+	c.Set(fiber.HeaderETag, utils.ETag(data.Version))
+	return response.OK(c, data)
 }
 
 // SoftDeletePekerjaan godoc
@@ -230,35 +312,29 @@ func UpdatePekerjaan(c *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "ID pekerjaan"
-// @Success      200 {object} map[string]interface{}
-// @Failure      400 {object} map[string]interface{}
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
 // @Router       /api/mg/pekerjaan/soft-delete/{id} [delete]
 // @Security     BearerAuth
-func SoftDeletePekerjaan(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) SoftDeletePekerjaan(c *fiber.Ctx) error {
 	id := c.Params("id")
-	fmt.Println("DEBUG: ID diterima dari URL =", id)
 
 	userID, ok := c.Locals("userID").(string)
 	if !ok {
-		fmt.Println("DEBUG: userID tidak ditemukan di Locals — menggunakan dummy untuk testing")
+		logging.FromContext(c.UserContext()).Debug("userID tidak ada di Locals, memakai dummy untuk testing")
 		userID = "dummy_user_id"
 	}
 	role, ok := c.Locals("role").(string)
 	if !ok {
-		fmt.Println("DEBUG: role tidak ditemukan di Locals — menggunakan dummy role admin")
+		logging.FromContext(c.UserContext()).Debug("role tidak ada di Locals, memakai dummy role admin")
 		role = "admin"
 	}
 
-	if err := mongoRepo.SoftDeletePekerjaan(id, userID, role); err != nil {
-		// fmt.Println("DEBUG: Error saat SoftDeletePekerjaan:", err)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if err := h.repo.WithContext(c.UserContext()).SoftDeletePekerjaan(id, userID, role); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Pekerjaan successfully soft deleted",
-	})
+	return response.Message(c, "Pekerjaan successfully soft deleted")
 }
 
 // RestorePekerjaan godoc
@@ -268,23 +344,19 @@ func SoftDeletePekerjaan(c *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "ID pekerjaan"
-// @Success      200 {object} map[string]interface{}
-// @Failure      400 {object} map[string]interface{}
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
 // @Router       /api/mg/pekerjaan/restore/{id} [post]
 // @Security     BearerAuth
-func RestorePekerjaan(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) RestorePekerjaan(c *fiber.Ctx) error {
 	id := c.Params("id")
 	userID := c.Locals("userID").(string)
 	role := c.Locals("role").(string)
 
-	if err := mongoRepo.RestorePekerjaan(id, userID, role); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if err := h.repo.WithContext(c.UserContext()).RestorePekerjaan(id, userID, role); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
 	}
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Pekerjaan successfully restored",
-	})
+	return response.Message(c, "Pekerjaan successfully restored")
 }
 
 // HardDeletePekerjaan godoc
@@ -294,23 +366,19 @@ func RestorePekerjaan(c *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "ID pekerjaan"
-// @Success      200 {object} map[string]interface{}
-// @Failure      400 {object} map[string]interface{}
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
 // @Router       /api/mg/pekerjaan/hard-delete/{id} [delete]
 // @Security     BearerAuth
-func HardDeletePekerjaan(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) HardDeletePekerjaan(c *fiber.Ctx) error {
 	id := c.Params("id")
 	userID := c.Locals("userID").(string)
 	role := c.Locals("role").(string)
 
-	if err := mongoRepo.HardDeletePekerjaan(id, userID, role); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if err := h.repo.WithContext(c.UserContext()).HardDeletePekerjaan(id, userID, role); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
 	}
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Pekerjaan permanently deleted",
-	})
+	return response.Message(c, "Pekerjaan permanently deleted")
 }
 
 // GetTrashPekerjaanByID godoc
@@ -320,24 +388,44 @@ func HardDeletePekerjaan(c *fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "ID pekerjaan"
-// @Success      200  {object}  map[string]interface{}  "Data pekerjaan yang sudah dihapus berhasil diambil"
-// @Failure      400  {object}  map[string]interface{}  "Format ID tidak valid"
-// @Failure      404  {object}  map[string]interface{}  "Data pekerjaan tidak ditemukan di trash"
-// @Failure      500  {object}  map[string]interface{}  "Terjadi kesalahan pada server"
+// @Success      200  {object}  response.Envelope  "Data pekerjaan yang sudah dihapus berhasil diambil"
+// @Failure      400  {object}  response.Problem  "Format ID tidak valid"
+// @Failure      404  {object}  response.Problem  "Data pekerjaan tidak ditemukan di trash"
+// @Failure      500  {object}  response.Problem  "Terjadi kesalahan pada server"
 // @Security     BearerAuth
 // @Router       /api/mg/pekerjaan/trash/{id} [get]
-func GetTrashPekerjaan(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) GetTrashPekerjaan(c *fiber.Ctx) error {
 	id := c.Params("id")
 	role, _ := c.Locals("role").(string)
 
-	pekerjaanList, err := mongoRepo.GetTrashPekerjaan(id, role)
+	pekerjaanList, err := h.repo.WithContext(c.UserContext()).GetTrashPekerjaan(id, role)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Fail(c, fiber.StatusNotFound, err.Error())
+	}
+
+	return response.OK(c, pekerjaanList)
+}
+
+// MigrateGaji godoc
+// @Summary      Migrasi gaji_range ke field gaji terstruktur
+// @Description  Mengisi gaji_min, gaji_max, gaji_mata_uang dan gaji_periode dari gaji_range. Hanya admin.
+// @Tags         Pekerjaan
+// @Produce      json
+// @Param        dry_run  query  bool  false  "Hanya laporkan hasil parse tanpa menyimpan"
+// @Success      200 {object} response.Envelope
+// @Failure      403 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /api/mg/pekerjaan/migrasi-gaji [post]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) MigrateGaji(c *fiber.Ctx) error {
+	if role, _ := c.Locals("role").(string); role != "admin" {
+		return response.Fail(c, fiber.StatusForbidden, "Hanya admin yang bisa menjalankan migrasi gaji")
+	}
+
+	report, err := h.repo.WithContext(c.UserContext()).MigrateGajiRange(c.QueryBool("dry_run", false))
+	if err != nil {
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": pekerjaanList,
-	})
+	return response.OK(c, report)
 }
@@ -0,0 +1,86 @@
+package mongo
+
+import (
+	"errors"
+	"latihan2/app/model"
+	"latihan2/response"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func parseSearchQuery(c *fiber.Ctx) (model.SearchQuery, error) {
+	s := model.SearchQuery{Page: 1, Limit: 10}
+	if err := c.QueryParser(&s); err != nil {
+		return s, errors.New("Parameter pencarian tidak valid")
+	}
+	s.Q = strings.TrimSpace(s.Q)
+	if s.Q == "" {
+		return s, errors.New("Parameter q wajib diisi")
+	}
+	if s.Page < 1 {
+		s.Page = 1
+	}
+	if s.Limit < 1 || s.Limit > 100 {
+		s.Limit = 10
+	}
+	return s, nil
+}
+
+func searchResponse(c *fiber.Ctx, s model.SearchQuery, mode string, data interface{}, err error) error {
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{
+			"q":     s.Q,
+			"mode":  mode,
+			"page":  s.Page,
+			"limit": s.Limit,
+		})
+}
+
+// SearchAlumni godoc
+// @Summary      Full-text search alumni
+// @Description  Mencari alumni berdasarkan nama dan jurusan, diurutkan menurut relevansi, dengan snippet yang menandai kata yang cocok. Jika text index belum ada, memakai pencarian basic.
+// @Tags         Alumni
+// @Produce      json
+// @Param        q      query  string  true   "Kata kunci"
+// @Param        mode   query  string  false  "Isi 'basic' untuk pencarian substring tanpa ranking"
+// @Param        page   query  int     false  "Halaman"
+// @Param        limit  query  int     false  "Jumlah data per halaman (maks 100)"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /api/mg/alumni/search [get]
+// @Security     BearerAuth
+func (h *AlumniHandler) SearchAlumni(c *fiber.Ctx) error {
+	s, err := parseSearchQuery(c)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, mode, err := h.repo.WithContext(c.UserContext()).SearchAlumni(s.Q, s.Limit, s.Offset(), s.Mode == model.SearchModeBasic)
+	return searchResponse(c, s, mode, data, err)
+}
+
+// SearchPekerjaan godoc
+// @Summary      Full-text search pekerjaan
+// @Description  Mencari pekerjaan berdasarkan nama perusahaan, posisi jabatan dan deskripsi, diurutkan menurut relevansi, dengan snippet yang menandai kata yang cocok. Jika text index belum ada, memakai pencarian basic.
+// @Tags         Pekerjaan
+// @Produce      json
+// @Param        q      query  string  true   "Kata kunci"
+// @Param        mode   query  string  false  "Isi 'basic' untuk pencarian substring tanpa ranking"
+// @Param        page   query  int     false  "Halaman"
+// @Param        limit  query  int     false  "Jumlah data per halaman (maks 100)"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      500 {object} response.Problem
+// @Router       /api/mg/pekerjaan/search [get]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) SearchPekerjaan(c *fiber.Ctx) error {
+	s, err := parseSearchQuery(c)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, mode, err := h.repo.WithContext(c.UserContext()).SearchPekerjaan(s.Q, s.Limit, s.Offset(), s.Mode == model.SearchModeBasic)
+	return searchResponse(c, s, mode, data, err)
+}
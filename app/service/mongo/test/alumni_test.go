@@ -2,23 +2,19 @@ package test
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"log"
 	"latihan2/middleware"
 	"latihan2/app/model"
 	mongoModel "latihan2/app/model/mongo"
+	"latihan2/app/repository/memory"
 	mongoService "latihan2/app/service/mongo" 
-	"latihan2/database"
 	"net/http/httptest"
 	"os"
 	"testing"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/assert"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -29,53 +25,44 @@ var (
 	testSeededUser mongoModel.User
 
 	testCreatedAlumniID string
-	testAlumniNIM       = "TEST-NIM-001"
+	testAlumniNIM       = "9990001001"
 
 	testSeededAlumniID     string 
 	testSeededAlumniForPekerjaan mongoModel.Alumni
-	testAlumniNIMForPekerjaan = "TEST-NIM-FOR-JOB-002"
+	testAlumniNIMForPekerjaan = "9990001002"
 
 	testSeededUsername = "alumni_tester_mongo"
 	testSeededPassword = "alumni_pass123"
 )
 
+// TestMain menyiapkan aplikasi dengan repository in-memory sehingga suite ini
+// tidak membutuhkan MongoDB sungguhan.
 func TestMain(m *testing.M) {
-	err := godotenv.Load("../../../../.env")
-	if err != nil {
-		log.Fatalf("Gagal memuat file .env dari root: %v", err)
-	}
 	os.Setenv("JWT_SECRET_KEY", "16824af3-6b8e-4c3d-9f1e-2c4b5e6f7g8h")
 
-	database.InitMongoDB()
-	if database.MongoDB == nil {
-		log.Fatal("Koneksi MongoDB nil setelah InitMongoDB")
+	uploadDir, err := os.MkdirTemp("", "uploads-test-")
+	if err != nil {
+		log.Fatalf("Gagal membuat folder upload tes: %v", err)
 	}
+	mongoService.UploadPath = uploadDir
 
-	collUser := database.MongoDB.Collection("user")
-	collAlumni := database.MongoDB.Collection("alumni")
-	collPekerjaan := database.MongoDB.Collection("pekerjaan")
-
-	_, _ = collUser.DeleteMany(context.Background(), bson.M{"username": testSeededUsername})
-	_, _ = collAlumni.DeleteMany(context.Background(), bson.M{"nim": bson.M{"$in": []string{testAlumniNIM, testAlumniNIMForPekerjaan}}})
-
+	store := memory.NewStore()
+	users := memory.NewUserRepository(store)
+	alumniRepo := memory.NewAlumniRepository(store)
 
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(testSeededPassword), bcrypt.DefaultCost)
-	testSeededUser = mongoModel.User{
-		ID:        primitive.NewObjectID(),
-		Username:  testSeededUsername,
-		Email:     testSeededUsername + "@example.com",
-		Password:  string(hashedPassword),
-		Role:      "admin",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	_, err = collUser.InsertOne(context.Background(), testSeededUser)
+	seededUser, err := users.CreateUser(&mongoModel.User{
+		Username: testSeededUsername,
+		Email:    testSeededUsername + "@example.com",
+		Password: string(hashedPassword),
+		Role:     "admin",
+	})
 	if err != nil {
 		log.Fatalf("Gagal seeding data user tes: %v", err)
 	}
+	testSeededUser = *seededUser
 
-	testSeededAlumniForPekerjaan = mongoModel.Alumni{
-		ID:         primitive.NewObjectID(),
+	seededAlumni, err := alumniRepo.CreateAlumni(&mongoModel.Alumni{
 		UserID:     testSeededUser.ID,
 		NIM:        testAlumniNIMForPekerjaan,
 		Nama:       "Alumni untuk Tes Pekerjaan",
@@ -83,45 +70,47 @@ func TestMain(m *testing.M) {
 		Angkatan:   2019,
 		TahunLulus: 2023,
 		Email:      "job-tester@alumni.com",
-		CreatedAt:  time.Now(),
-	}
-	_, err = collAlumni.InsertOne(context.Background(), testSeededAlumniForPekerjaan)
+	})
 	if err != nil {
 		log.Fatalf("Gagal seeding data alumni tes: %v", err)
 	}
-	testSeededAlumniID = testSeededAlumniForPekerjaan.ID.Hex() 
+	testSeededAlumniForPekerjaan = *seededAlumni
+	testSeededAlumniID = testSeededAlumniForPekerjaan.ID.Hex()
 
-	_, _ = collPekerjaan.DeleteMany(context.Background(), bson.M{"alumni_id": testSeededAlumniForPekerjaan.ID})
+	authHandler := mongoService.NewAuthHandler(users)
+	alumniHandler := mongoService.NewAlumniHandler(alumniRepo)
+	pekerjaanHandler := mongoService.NewPekerjaanHandler(memory.NewPekerjaanRepository(store))
+	fileHandler := mongoService.NewFileHandler(memory.NewFileRepository(store))
 
 	testApp = fiber.New()
 	api := testApp.Group("/api/mg")
-	api.Post("/login", mongoService.LoginMongo)
+	api.Post("/login", authHandler.LoginMongo)
 	protectedm := api.Group("", middleware.AuthRequiredMongo()) 
 
 	// Rute Alumni
 	alumnim := protectedm.Group("/alumni")
-	alumnim.Get("/", mongoService.GetAllAlumni)
-	alumnim.Get("/:id/", mongoService.GetAlumniByID)
-	alumnim.Post("/", mongoService.CreateAlumni)
-	alumnim.Put("/:id", mongoService.UpdateAlumni)
-	alumnim.Delete("/soft-delete/:id", mongoService.SoftDeleteAlumni)
+	alumnim.Get("/", alumniHandler.GetAllAlumni)
+	alumnim.Get("/:id/", alumniHandler.GetAlumniByID)
+	alumnim.Post("/", alumniHandler.CreateAlumni)
+	alumnim.Put("/:id", alumniHandler.UpdateAlumni)
+	alumnim.Delete("/soft-delete/:id", alumniHandler.SoftDeleteAlumni)
 
 	// Rute Pekerjaan
 	pekerjaanm := protectedm.Group("/pekerjaan")
-	pekerjaanm.Get("/", mongoService.GetAllPekerjaan)
-	pekerjaanm.Get("/:id", mongoService.GetPekerjaanByID)
-	pekerjaanm.Get("/alumni/:alumni_id", mongoService.GetPekerjaanByAlumniID)
-	pekerjaanm.Post("/", mongoService.CreatePekerjaan)
-	pekerjaanm.Put("/:id", mongoService.UpdatePekerjaan)
-	pekerjaanm.Delete("/soft-delete/:id", mongoService.SoftDeletePekerjaan)
+	pekerjaanm.Get("/", pekerjaanHandler.GetAllPekerjaan)
+	pekerjaanm.Get("/:id", pekerjaanHandler.GetPekerjaanByID)
+	pekerjaanm.Get("/alumni/:alumni_id", pekerjaanHandler.GetPekerjaanByAlumniID)
+	pekerjaanm.Post("/", pekerjaanHandler.CreatePekerjaan)
+	pekerjaanm.Put("/:id", pekerjaanHandler.UpdatePekerjaan)
+	pekerjaanm.Delete("/soft-delete/:id", pekerjaanHandler.SoftDeletePekerjaan)
 
 	// Rute File
 	filem := protectedm.Group("/files")
-	filem.Post("/upload", mongoService.UploadFile)
-	filem.Get("/", mongoService.GetAllFiles)
-	filem.Get("/:id", mongoService.GetFileByID)
-	filem.Get("/open/:id", mongoService.GetContentByID)
-	filem.Delete("/:id", mongoService.DeleteFile)
+	filem.Post("/upload", fileHandler.UploadFile)
+	filem.Get("/", fileHandler.GetAllFiles)
+	filem.Get("/:id", fileHandler.GetFileByID)
+	filem.Get("/open/:id", fileHandler.GetContentByID)
+	filem.Delete("/:id", fileHandler.DeleteFile)
 
 	loginBody := model.LoginRequest{Username: testSeededUsername, Password: testSeededPassword}
 	bodyBytes, _ := json.Marshal(loginBody)
@@ -145,14 +134,7 @@ func TestMain(m *testing.M) {
 	}
 	exitCode := m.Run()
 
-	_, _ = collUser.DeleteMany(context.Background(), bson.M{"username": testSeededUsername})
-	_, _ = collAlumni.DeleteMany(context.Background(), bson.M{"user_id": testSeededUser.ID})
-	_, _ = collPekerjaan.DeleteMany(context.Background(), bson.M{"alumni_id": testSeededAlumniForPekerjaan.ID})
-	
-	if database.MongoClient != nil {
-		database.MongoClient.Disconnect(context.Background())
-	}
-
+	os.RemoveAll(uploadDir)
 	os.Exit(exitCode)
 }
 
@@ -275,7 +257,7 @@ func TestAlumni_2_GetByID_Endpoint(t *testing.T) {
 func TestAlumni_3_GetAll_Endpoint(t *testing.T) {
 	// Skenario 1: Berhasil Mendapatkan Semua Alumni (dengan search)
 	t.Run("Positive - Get All With Search", func(t *testing.T) {
-		req := httptest.NewRequest("GET", "/api/mg/alumni?search=TEST-NIM", nil)
+		req := httptest.NewRequest("GET", "/api/mg/alumni?search=99900010", nil)
 		req.Header.Set("Authorization", "Bearer "+testAuthToken)
 
 		resp, err := testApp.Test(req, -1)
@@ -354,7 +336,7 @@ func TestAlumni_4_Update_Endpoint(t *testing.T) {
 	// Skenario 2: Gagal karena ID Tidak Ditemukan (Not Found)
 	t.Run("Negative - Not Found ID", func(t *testing.T) {
 		nonExistentID := primitive.NewObjectID().Hex()
-		reqBody := model.UpdateAlumniRequest{Nama: "Update Gagal"}
+		reqBody := model.UpdateAlumniRequest{Nama: "Update Gagal", Jurusan: "Sistem Informasi", Email: "update-gagal@alumni.com"}
 		bodyBytes, _ := json.Marshal(reqBody)
 
 		req := httptest.NewRequest("PUT", "/api/mg/alumni/"+nonExistentID, bytes.NewBuffer(bodyBytes))
@@ -368,7 +350,10 @@ func TestAlumni_4_Update_Endpoint(t *testing.T) {
 
 	// Skenario 3: Gagal karena Format ID Salah
 	t.Run("Negative - Invalid ID Format", func(t *testing.T) {
-		req := httptest.NewRequest("PUT", "/api/mg/alumni/invalid-id", bytes.NewBufferString(`{}`))
+		reqBody := model.UpdateAlumniRequest{Nama: "Update Gagal", Jurusan: "Sistem Informasi", Email: "update-gagal@alumni.com"}
+		bodyBytes, _ := json.Marshal(reqBody)
+
+		req := httptest.NewRequest("PUT", "/api/mg/alumni/invalid-id", bytes.NewBuffer(bodyBytes))
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("Authorization", "Bearer "+testAuthToken)
 		resp, err := testApp.Test(req, -1)
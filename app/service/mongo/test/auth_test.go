@@ -49,7 +49,7 @@ func TestLogin_Endpoint(t *testing.T) {
 		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode, "Status code seharusnya 401 Unauthorized")
 		var respBody map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&respBody)
-		assert.Contains(t, respBody["error"], "Username atau password salah", "Pesan error tidak sesuai")
+		assert.Contains(t, respBody["detail"], "Username atau password salah", "Pesan error tidak sesuai")
 	})
 
 	// --- Skenario 3: User Tidak Ditemukan ---
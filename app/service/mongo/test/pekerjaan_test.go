@@ -45,10 +45,11 @@ func TestPekerjaan_1_Create_Endpoint(t *testing.T) {
 
 		var respBody map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&respBody)
-		assert.Equal(t, "PT. Tester Jaya", respBody["nama_perusahaan"])
-		assert.Equal(t, testSeededAlumniID, respBody["alumni_id"], "AlumniID harus sama dengan yg di-seed")
+		data, _ := respBody["data"].(map[string]interface{})
+		assert.Equal(t, "PT. Tester Jaya", data["nama_perusahaan"])
+		assert.Equal(t, testSeededAlumniID, data["alumni_id"], "AlumniID harus sama dengan yg di-seed")
 
-		id, ok := respBody["id"].(string)
+		id, ok := data["id"].(string)
 		assert.True(t, ok)
 		assert.NotEmpty(t, id, "ID Pekerjaan yang baru dibuat tidak boleh kosong")
 		testCreatedPekerjaanID = id
@@ -91,8 +92,9 @@ func TestPekerjaan_2_GetByID_Endpoint(t *testing.T) {
 
 		var respBody map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&respBody)
-		assert.Equal(t, testCreatedPekerjaanID, respBody["id"])
-		assert.Equal(t, "PT. Tester Jaya", respBody["nama_perusahaan"])
+		data, _ := respBody["data"].(map[string]interface{})
+		assert.Equal(t, testCreatedPekerjaanID, data["id"])
+		assert.Equal(t, "PT. Tester Jaya", data["nama_perusahaan"])
 	})
 
 	// Skenario 2: Gagal karena ID Tidak Ditemukan (Not Found)
@@ -133,7 +135,8 @@ func TestPekerjaan_3_GetByAlumniID_Endpoint(t *testing.T) {
 		var respBody map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&respBody)
 		
-		assert.Equal(t, testSeededAlumniID, respBody["alumni_id"])
+		meta, _ := respBody["meta"].(map[string]interface{})
+		assert.Equal(t, testSeededAlumniID, meta["alumni_id"])
 		data, ok := respBody["data"].([]interface{})
 		assert.True(t, ok)
 		assert.Len(t, data, 1, "Seharusnya ada 1 pekerjaan untuk alumni ini")
@@ -196,6 +199,7 @@ func TestPekerjaan_5_Update_Endpoint(t *testing.T) {
 		req := httptest.NewRequest("PUT", "/api/mg/pekerjaan/"+testCreatedPekerjaanID, bytes.NewBuffer(bodyBytes))
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("Authorization", "Bearer "+testAuthToken)
+		req.Header.Set("If-Match", "*")
 
 		resp, err := testApp.Test(req, -1)
 		assert.NoError(t, err)
@@ -204,8 +208,9 @@ func TestPekerjaan_5_Update_Endpoint(t *testing.T) {
 		var respBody map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&respBody)
 		
-		assert.Equal(t, "PT. Tester Jaya (Updated)", respBody["nama_perusahaan"])
-		assert.Equal(t, "Senior QA", respBody["posisi_jabatan"])
+		data, _ := respBody["data"].(map[string]interface{})
+		assert.Equal(t, "PT. Tester Jaya (Updated)", data["nama_perusahaan"])
+		assert.Equal(t, "Senior QA", data["posisi_jabatan"])
 	})
 
 	// Skenario 2: Gagal karena ID Tidak Ditemukan (Not Found)
@@ -217,10 +222,11 @@ func TestPekerjaan_5_Update_Endpoint(t *testing.T) {
 		req := httptest.NewRequest("PUT", "/api/mg/pekerjaan/"+nonExistentID, bytes.NewBuffer(bodyBytes))
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("Authorization", "Bearer "+testAuthToken)
+		req.Header.Set("If-Match", "*")
 
 		resp, err := testApp.Test(req, -1)
 		assert.NoError(t, err)
-		assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
 	})
 }
 
@@ -1,8 +1,11 @@
 package mongo
 
 import (
+	"latihan2/app/model"
 	// Beri alias 'mongoRepo' untuk repository
 	mongoRepo "latihan2/app/repository/mongo"
+	"latihan2/response"
+	"latihan2/utils"
 	"strconv"
 	"strings"
 
@@ -10,8 +13,17 @@ import (
 	mongodriver "go.mongodb.org/mongo-driver/mongo"
 )
 
+// UserHandler menangani endpoint /api/mg/users.
+type UserHandler struct {
+	repo mongoRepo.UserRepository
+}
+
+func NewUserHandler(repo mongoRepo.UserRepository) *UserHandler {
+	return &UserHandler{repo: repo}
+}
+
 // GetAllUsers adalah handler untuk (GET /users-m/mongo/)
-func GetAllUsers(c *fiber.Ctx) error {
+func (h *UserHandler) GetAllUsers(c *fiber.Ctx) error {
 	// Parsing query params
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
@@ -20,25 +32,28 @@ func GetAllUsers(c *fiber.Ctx) error {
 	search := c.Query("search", "")
 	offset := (page - 1) * limit
 
+	filter, err := utils.ParseFilter(c.Query("filter"), model.UserFilterFields)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if utils.CursorRequested(c.Query("pagination"), c.Query("cursor")) {
+		return h.getUsersCursor(c, search, sortBy, order, limit, filter)
+	}
+
 	// Panggil Repository
-	data, err := mongoRepo.GetUsersRepo(search, sortBy, order, limit, offset)
+	data, err := h.repo.WithContext(c.UserContext()).GetUsersRepo(search, sortBy, order, limit, offset, filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	total, err := mongoRepo.CountUsersRepo(search)
+	total, err := h.repo.WithContext(c.UserContext()).CountUsersRepo(search, filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
 	// Format response
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": data,
-		"meta": fiber.Map{
+	return response.List(c, data, fiber.Map{
 			"page":   page,
 			"limit":  limit,
 			"total":  total,
@@ -46,29 +61,62 @@ func GetAllUsers(c *fiber.Ctx) error {
 			"sortBy": sortBy,
 			"order":  order,
 			"search": search,
-		},
-	})
+			"filter": c.Query("filter"),
+		})
+}
+
+var usersCursorSortable = map[string]bool{
+	"_id":        true,
+	"username":   true,
+	"email":      true,
+	"created_at": true,
+}
+
+// getUsersCursor melayani GetAllUsers pada mode pagination cursor.
+func (h *UserHandler) getUsersCursor(c *fiber.Ctx, search, sortBy, order string, limit int, filter model.Filter) error {
+	page, err := utils.ParseCursorPage(c.Query("cursor"), sortBy, order, limit, usersCursorSortable)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	data, err := h.repo.WithContext(c.UserContext()).GetUsersCursorRepo(search, page, filter)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	data, next, prev := utils.CursorResult(data, page)
+
+	meta := model.CursorMetaInfo{
+		Limit:      page.Limit,
+		SortBy:     page.SortBy,
+		Order:      page.Order,
+		Search:     search,
+		Filter:     c.Query("filter"),
+		NextCursor: next,
+		PrevCursor: prev,
+	}
+	if c.QueryBool("with_total") {
+		total, err := h.repo.WithContext(c.UserContext()).CountUsersRepo(search, filter)
+		if err != nil {
+			return response.Error(c, err)
+		}
+		meta.Total = &total
+	}
+
+	return response.List(c, data, meta)
 }
 
 // GetUsersByID adalah handler untuk (GET /users-m/mongo/:id/)
-func GetUsersByID(c *fiber.Ctx) error {
+func (h *UserHandler) GetUsersByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 
 	// Panggil Repository
-	data, err := mongoRepo.GetUserByID(id)
+	data, err := h.repo.WithContext(c.UserContext()).GetUserByID(id)
 	if err != nil {
 		if err == mongodriver.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "User tidak ditemukan",
-			})
+			return response.Fail(c, fiber.StatusNotFound, "User tidak ditemukan")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"success": true,
-		"data":    data,
-	})
+	return response.OK(c, data)
 }
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"latihan2/app/model"
 	"latihan2/app/repository"
-	"log"
+	"latihan2/middleware"
+	"latihan2/response"
+	"latihan2/utils"
+	"latihan2/validation"
 
 	// "os"
 	"strconv"
@@ -16,7 +19,16 @@ import (
 	// "github.com/golang-jwt/jwt/v5"
 )
 
-func GetPekerjaanService(c *fiber.Ctx) error {
+// PekerjaanHandler menangani endpoint /api/pg/pekerjaan.
+type PekerjaanHandler struct {
+	repo repository.PekerjaanRepository
+}
+
+func NewPekerjaanHandler(repo repository.PekerjaanRepository) *PekerjaanHandler {
+	return &PekerjaanHandler{repo: repo}
+}
+
+func (h *PekerjaanHandler) GetPekerjaanService(c *fiber.Ctx) error {
 	// AMBIL ROLE DAN USER ID
     userID, _ := c.Locals("user_id").(int)
     role, _ := c.Locals("role").(string)
@@ -28,6 +40,16 @@ func GetPekerjaanService(c *fiber.Ctx) error {
 	search := c.Query("search", "")
 	offset := (page - 1) * limit
 
+	gaji, err := utils.ParseGajiFilter(c.Query("gaji_min"), c.Query("gaji_max"), c.Query("mata_uang"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	filter, err := utils.ParseFilter(c.Query("filter"), model.PekerjaanFilterFields)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
 	sortByWhitelist := map[string]bool{
 		"id":                    true,
 		"alumni_id":             true,
@@ -36,6 +58,8 @@ func GetPekerjaanService(c *fiber.Ctx) error {
 		"bidang_industri":       true,
 		"lokasi_kerja":          true,
 		"gaji_range":            true,
+		"gaji_min":              true,
+		"gaji_max":              true,
 		"tanggal_mulai_kerja":   true,
 		"tanggal_selesai_kerja": true,
 		"status_pekerjaan":      true,
@@ -49,44 +73,86 @@ func GetPekerjaanService(c *fiber.Ctx) error {
 		order = "asc"
 	}
 
-	pekerjaan, err := repository.GetPekerjaanRepo(search, sortBy, order, limit, offset, role, userID)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error":   "Failed to fetch alumni",
-			"details": err.Error(),
-			"query":   fmt.Sprintf("search=%s, sortBy=%s, order=%s, limit=%d, offset=%d, role=%s", search, sortBy, order, limit, offset),
-		})
+	if utils.CursorRequested(c.Query("pagination"), c.Query("cursor")) {
+		return h.getPekerjaanCursor(c, search, sortBy, order, limit, role, userID, gaji, filter)
 	}
 
-	total, err := repository.CountPekerjaanRepo(search, role, userID)
+	pekerjaan, err := h.repo.WithContext(c.UserContext()).GetPekerjaanRepo(search, sortBy, order, limit, offset, role, userID, gaji, filter)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to count pekerjaan alumni"})
+		return response.Error(c, err)
 	}
 
-	response := model.PekerjaanResponse{
-		Data: pekerjaan,
-		Meta: model.MetaInfo{
-			Page:   page,
-			Limit:  limit,
-			Total:  total,
-			Pages:  (total + limit - 1) / limit,
-			SortBy: sortBy,
-			Order:  order,
-			Search: search,
-		},
+	total, err := h.repo.WithContext(c.UserContext()).CountPekerjaanRepo(search, role, userID, gaji, filter)
+	if err != nil {
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to count pekerjaan alumni")
 	}
 
-	return c.JSON(response)
+	return response.List(c, pekerjaan, model.MetaInfo{
+		Page:   page,
+		Limit:  limit,
+		Total:  total,
+		Pages:  (total + limit - 1) / limit,
+		SortBy: sortBy,
+		Order:  order,
+		Search: search,
+		Filter: c.Query("filter"),
+	})
+}
+
+// pekerjaanCursorSortable adalah kolom sort yang NOT NULL; kolom gaji dan
+// tanggal_selesai_kerja boleh kosong sehingga tidak bisa dipakai sebagai keyset.
+var pekerjaanCursorSortable = map[string]bool{
+	"id":                  true,
+	"alumni_id":           true,
+	"nama_perusahaan":     true,
+	"posisi_jabatan":      true,
+	"bidang_industri":     true,
+	"lokasi_kerja":        true,
+	"tanggal_mulai_kerja": true,
+	"status_pekerjaan":    true,
+	"created_at":          true,
+	"updated_at":          true,
 }
 
-func GetPekerjaanByID(c *fiber.Ctx) error {
-	log.Printf("[DEBUG] Locals: role=%v, user_id=%v", c.Locals("role"), c.Locals("user_id"))
+// getPekerjaanCursor melayani GetPekerjaanService pada mode pagination cursor.
+func (h *PekerjaanHandler) getPekerjaanCursor(c *fiber.Ctx, search, sortBy, order string, limit int, role string, userID int, gaji model.GajiFilter, filter model.Filter) error {
+	page, err := utils.ParseCursorPage(c.Query("cursor"), sortBy, order, limit, pekerjaanCursorSortable)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	pekerjaan, err := h.repo.WithContext(c.UserContext()).GetPekerjaanCursorRepo(search, page, role, userID, gaji, filter)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	pekerjaan, next, prev := utils.CursorResult(pekerjaan, page)
+
+	meta := model.CursorMetaInfo{
+		Limit:      page.Limit,
+		SortBy:     page.SortBy,
+		Order:      page.Order,
+		Search:     search,
+		Filter:     c.Query("filter"),
+		NextCursor: next,
+		PrevCursor: prev,
+	}
+	if c.QueryBool("with_total") {
+		total, err := h.repo.WithContext(c.UserContext()).CountPekerjaanRepo(search, role, userID, gaji, filter)
+		if err != nil {
+			return response.Fail(c, fiber.StatusInternalServerError, "Failed to count pekerjaan alumni")
+		}
+		meta.Total = &total
+	}
 
+	return response.List(c, pekerjaan, meta)
+}
+
+func (h *PekerjaanHandler) GetPekerjaanByID(c *fiber.Ctx) error {
 	// Ambil role dan userID
 	role, okRole := c.Locals("role").(string)
 	userID, okUserID := c.Locals("user_id").(int)
 	if !okRole || !okUserID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Informasi user tidak valid"})
+		return response.Fail(c, fiber.StatusUnauthorized, "Informasi user tidak valid")
 	}
     
     // BLOK 'if role == "user"' YANG SALAH SUDAH DIHAPUS
@@ -94,198 +160,215 @@ func GetPekerjaanByID(c *fiber.Ctx) error {
 	// ambil id dari parameter URL
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid pekerjaan ID"})
+		return response.Fail(c, fiber.StatusBadRequest, "Invalid pekerjaan ID")
 	}
 
     // Teruskan role dan userID ke repository untuk pengecekan keamanan
-	pekerjaan, err := repository.GetPekerjaanByIDRepo(id, userID, role)
+	pekerjaan, err := h.repo.WithContext(c.UserContext()).GetPekerjaanByIDRepo(id, userID, role)
 	if err != nil {
 		if err == sql.ErrNoRows {
             // Ini adalah tempat yang benar untuk 404
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Pekerjaan not found"})
+			return response.Fail(c, fiber.StatusNotFound, "Pekerjaan not found")
 		}
-		log.Printf("[ERROR] Gagal GetPekerjaanByIDRepo: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch pekerjaan"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to fetch pekerjaan")
 	}
 
-	return c.JSON(fiber.Map{"data": pekerjaan, "success": true})
+	c.Set(fiber.HeaderETag, utils.ETag(pekerjaan.Version))
+	return response.OK(c, pekerjaan)
 }
 
-func GetPekerjaanByAlumniID(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) GetPekerjaanByAlumniID(c *fiber.Ctx) error {
 	alumniID, err := strconv.Atoi(c.Params("alumni_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID alumni tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "ID alumni tidak valid")
 	}
 
-	pekerjaanList, err := repository.GetPekerjaanByAlumniID(alumniID)
+	pekerjaanList, err := h.repo.WithContext(c.UserContext()).GetPekerjaanByAlumniID(alumniID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal mengambil data pekerjaan"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal mengambil data pekerjaan")
 	}
 
-	return c.JSON(fiber.Map{"success": true, "data": pekerjaanList})
+	return response.OK(c, pekerjaanList)
 }
 
-func CreatePekerjaan(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) CreatePekerjaan(c *fiber.Ctx) error {
 	var req model.CreatePekerjaanRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Request body tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
 	}
 
 	// userID := c.Locals("user_id").(int) // ambil dari JWT
 
-	newPekerjaan, err := repository.CreatePekerjaan(req)
+	gaji, gajiRange, err := utils.ResolveGaji(req.GajiRange, req.RentangGaji)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal menambah data pekerjaan. Pastikan alumni_id valid."})
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
 	}
+	req.RentangGaji, req.GajiRange = gaji, gajiRange
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"success": true,
-		"data":    newPekerjaan,
-		"message": "Pekerjaan berhasil ditambahkan",
+	newPekerjaan, err := h.repo.WithContext(c.UserContext()).CreatePekerjaan(req)
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplikat) {
+			return response.Error(c, err)
+		}
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal menambah data pekerjaan. Pastikan alumni_id valid.")
+	}
+
+	return response.Send(c, fiber.StatusCreated, response.Envelope{
+		Data:    newPekerjaan,
+		Message: "Pekerjaan berhasil ditambahkan",
 	})
 }
 
-func UpdatePekerjaan(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) UpdatePekerjaan(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "ID tidak valid")
 	}
 
 	var req model.UpdatePekerjaanRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Request body tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
+	}
+
+	gaji, gajiRange, err := utils.ResolveGaji(req.GajiRange, req.RentangGaji)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
 	}
+	req.RentangGaji, req.GajiRange = gaji, gajiRange
 
 	userID := c.Locals("user_id").(int)
 	role := c.Locals("role").(string)
 
-	updatedPekerjaan, err := repository.UpdatePekerjaan(id, userID, role, req)
+	updatedPekerjaan, err := h.repo.WithContext(c.UserContext()).UpdatePekerjaan(id, userID, role, middleware.IfMatchVersion(c), req)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Pekerjaan tidak ditemukan untuk diupdate"})
+			return response.Fail(c, fiber.StatusNotFound, "Pekerjaan tidak ditemukan untuk diupdate")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal mengupdate data pekerjaan"})
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return response.Fail(c, fiber.StatusPreconditionFailed, "Pekerjaan sudah diubah, ambil ulang data terbaru")
+		}
+		if errors.Is(err, repository.ErrDuplikat) {
+			return response.Error(c, err)
+		}
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal mengupdate data pekerjaan")
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    updatedPekerjaan,
-		"message": "Pekerjaan berhasil diupdate",
+	c.Set(fiber.HeaderETag, utils.ETag(updatedPekerjaan.Version))
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    updatedPekerjaan,
+		Message: "Pekerjaan berhasil diupdate",
 	})
 }
 
-func SoftDeletePekerjaan(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) SoftDeletePekerjaan(c *fiber.Ctx) error {
 	pekerjaanID, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID pekerjaan tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "ID pekerjaan tidak valid")
 	}
 
 	userID, okUserID := c.Locals("user_id").(int)
 	role, okRole := c.Locals("role").(string)
 
 	if !okUserID || !okRole {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Akses tidak sah, informasi pengguna tidak ditemukan"})
+		return response.Fail(c, fiber.StatusUnauthorized, "Akses tidak sah, informasi pengguna tidak ditemukan")
 	}
 
-	err = repository.SoftDeletePekerjaan(pekerjaanID, userID, role)
+	err = h.repo.WithContext(c.UserContext()).SoftDeletePekerjaan(pekerjaanID, userID, role)
 	if err != nil {
-		log.Println("--- DEBUG: Terjadi error di repository ---", err)
 		switch err {
 		case repository.ErrPekerjaanNotFound:
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Data pekerjaan tidak ditemukan"})
+			return response.Fail(c, fiber.StatusNotFound, "Data pekerjaan tidak ditemukan")
 		case repository.ErrForbidden:
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Anda tidak diizinkan untuk menghapus data ini"})
+			return response.Fail(c, fiber.StatusForbidden, "Anda tidak diizinkan untuk menghapus data ini")
 		default:
 
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Terjadi kesalahan pada server"})
+			return response.Fail(c, fiber.StatusInternalServerError, "Terjadi kesalahan pada server")
 		}
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"success": true,
-		"message": "Data pekerjaan berhasil dihapus",
-	})
+	return response.Message(c, "Data pekerjaan berhasil dihapus")
 }
 
-func RestorePekerjaanService(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) RestorePekerjaanService(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	pekerjaanID, err := strconv.Atoi(idParam)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "ID pekerjaan tidak valid",
-		})
+		return response.Fail(c, fiber.StatusBadRequest, "ID pekerjaan tidak valid")
 	}
 
 	userID := c.Locals("user_id").(int)
 	role := c.Locals("role").(string)
 
-	err = repository.RestorePekerjaan(pekerjaanID, userID, role)
+	err = h.repo.WithContext(c.UserContext()).RestorePekerjaan(pekerjaanID, userID, role)
 	if err != nil {
 		if errors.Is(err, repository.ErrPekerjaanNotFound) {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Data pekerjaan tidak ditemukan atau belum dihapus",
-			})
+			return response.Fail(c, fiber.StatusNotFound, "Data pekerjaan tidak ditemukan atau belum dihapus")
 		}
 		if errors.Is(err, repository.ErrForbidden) {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error": "Kamu tidak punya izin untuk mengembalikan data ini",
-			})
+			return response.Fail(c, fiber.StatusForbidden, "Kamu tidak punya izin untuk mengembalikan data ini")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Gagal melakukan restore pekerjaan",
-			"details": err.Error(),
-		})
+		return response.Error(c, err)
 	}
 
-	return c.JSON(fiber.Map{
-		"message": "Data pekerjaan berhasil direstore",
-	})
+	return response.Message(c, "Data pekerjaan berhasil direstore")
 }
 
-func GetTrashPekerjaanByIDService(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) GetTrashPekerjaanByIDService(c *fiber.Ctx) error {
 	pekerjaanID, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID pekerjaan tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "ID pekerjaan tidak valid")
 	}
 
 	userID := c.Locals("user_id").(int)
 	role := c.Locals("role").(string)
 
-	pekerjaan, err := repository.GetTrashPekerjaanByID(pekerjaanID, userID, role)
+	pekerjaan, err := h.repo.WithContext(c.UserContext()).GetTrashPekerjaanByID(pekerjaanID, userID, role)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Data pekerjaan di trash tidak ditemukan"})
+			return response.Fail(c, fiber.StatusNotFound, "Data pekerjaan di trash tidak ditemukan")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal mengambil data dari trash"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal mengambil data dari trash")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"success": true,
-		"data":    pekerjaan,
-	})
+	return response.OK(c, pekerjaan)
 }
 
-func HardDeletePekerjaanService(c *fiber.Ctx) error {
+func (h *PekerjaanHandler) HardDeletePekerjaanService(c *fiber.Ctx) error {
 	pekerjaanID, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID pekerjaan tidak valid"})
+		return response.Fail(c, fiber.StatusBadRequest, "ID pekerjaan tidak valid")
 	}
 
 	userID := c.Locals("user_id").(int)
 	role := c.Locals("role").(string)
 
-	err = repository.HardDeletePekerjaan(pekerjaanID, userID, role)
+	err = h.repo.WithContext(c.UserContext()).HardDeletePekerjaan(pekerjaanID, userID, role)
 	if err != nil {
 		if err == repository.ErrPekerjaanNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Data pekerjaan tidak ditemukan, belum di-soft delete, atau Anda tidak memiliki akses",
-			})
+			return response.Fail(c, fiber.StatusNotFound, "Data pekerjaan tidak ditemukan, belum di-soft delete, atau Anda tidak memiliki akses")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Terjadi kesalahan pada server"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Terjadi kesalahan pada server")
+	}
+
+	return response.Message(c, "Data pekerjaan berhasil dihapus secara permanen")
+}
+
+func (h *PekerjaanHandler) MigrateGajiService(c *fiber.Ctx) error {
+	dryRun := c.QueryBool("dry_run", false)
+
+	report, err := h.repo.WithContext(c.UserContext()).MigrateGajiRange(dryRun)
+	if err != nil {
+		return response.Error(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"success": true,
-		"message": "Data pekerjaan berhasil dihapus secara permanen",
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    report,
+		Message: fmt.Sprintf("%d dari %d gaji_range berhasil diparse, %d gagal", report.Berhasil, report.Total, len(report.Gagal)),
 	})
 }
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+	"latihan2/app/model"
+	"latihan2/response"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func parseSearchQuery(c *fiber.Ctx) (model.SearchQuery, error) {
+	s := model.SearchQuery{Page: 1, Limit: 10}
+	if err := c.QueryParser(&s); err != nil {
+		return s, errors.New("Parameter pencarian tidak valid")
+	}
+	s.Q = strings.TrimSpace(s.Q)
+	if s.Q == "" {
+		return s, errors.New("Parameter q wajib diisi")
+	}
+	if s.Page < 1 {
+		s.Page = 1
+	}
+	if s.Limit < 1 || s.Limit > 100 {
+		s.Limit = 10
+	}
+	return s, nil
+}
+
+func searchResponse(c *fiber.Ctx, s model.SearchQuery, mode string, data interface{}, err error) error {
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.List(c, data, fiber.Map{
+			"q":     s.Q,
+			"mode":  mode,
+			"page":  s.Page,
+			"limit": s.Limit,
+		})
+}
+
+func (h *AlumniHandler) SearchAlumniService(c *fiber.Ctx) error {
+	s, err := parseSearchQuery(c)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, mode, err := h.repo.WithContext(c.UserContext()).SearchAlumni(s.Q, s.Limit, s.Offset(), s.Mode == model.SearchModeBasic)
+	return searchResponse(c, s, mode, data, err)
+}
+
+func (h *PekerjaanHandler) SearchPekerjaanService(c *fiber.Ctx) error {
+	s, err := parseSearchQuery(c)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	userID, _ := c.Locals("user_id").(int)
+	role, _ := c.Locals("role").(string)
+	data, mode, err := h.repo.WithContext(c.UserContext()).SearchPekerjaan(s.Q, s.Limit, s.Offset(), role, userID, s.Mode == model.SearchModeBasic)
+	return searchResponse(c, s, mode, data, err)
+}
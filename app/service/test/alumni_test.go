@@ -0,0 +1,113 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"latihan2/app/model"
+	"latihan2/app/repository"
+	"latihan2/app/service"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAlumniRepo hanya mengimplementasikan method yang dipakai tes; method lain
+// dari interface yang di-embed akan panic jika terpanggil.
+type fakeAlumniRepo struct {
+	repository.AlumniRepository
+	data      map[int]*model.Alumni
+	createErr error
+	created   []model.CreateAlumniRequest
+}
+
+func (f *fakeAlumniRepo) WithContext(context.Context) repository.AlumniRepository {
+	return f
+}
+
+func (f *fakeAlumniRepo) GetAlumniByID(id int, role string) (*model.Alumni, error) {
+	a, ok := f.data[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return a, nil
+}
+
+func (f *fakeAlumniRepo) CreateAlumni(req model.CreateAlumniRequest) (*model.Alumni, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.created = append(f.created, req)
+	return &model.Alumni{ID: strconv.Itoa(len(f.created)), NIM: req.NIM, Nama: req.Nama, Jurusan: req.Jurusan, Email: req.Email}, nil
+}
+
+func newAlumniApp(repo repository.AlumniRepository) *fiber.App {
+	h := service.NewAlumniHandler(repo)
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("role", "admin")
+		return c.Next()
+	})
+	app.Get("/alumni/:id", h.GetAlumniByID)
+	app.Post("/alumni", h.CreateAlumni)
+	return app
+}
+
+func TestGetAlumniByID(t *testing.T) {
+	repo := &fakeAlumniRepo{data: map[int]*model.Alumni{1: {ID: "1", NIM: "2019123", Nama: "Budi"}}}
+	app := newAlumniApp(repo)
+
+	t.Run("ditemukan", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/alumni/1", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data model.Alumni `json:"data"`
+		}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, "Budi", body.Data.Nama)
+	})
+
+	t.Run("tidak ditemukan", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/alumni/2", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("id tidak valid", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/alumni/abc", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCreateAlumni(t *testing.T) {
+	post := func(app *fiber.App, req model.CreateAlumniRequest) int {
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest("POST", "/alumni", bytes.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(r)
+		assert.NoError(t, err)
+		return resp.StatusCode
+	}
+	valid := model.CreateAlumniRequest{NIM: "2019123", Nama: "Budi", Jurusan: "TI", Email: "budi@example.com"}
+
+	repo := &fakeAlumniRepo{}
+	assert.Equal(t, fiber.StatusCreated, post(newAlumniApp(repo), valid))
+	assert.Len(t, repo.created, 1)
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, post(newAlumniApp(repo), model.CreateAlumniRequest{NIM: "123"}))
+	assert.Len(t, repo.created, 1, "request tidak valid tidak boleh sampai ke repository")
+
+	failing := &fakeAlumniRepo{createErr: errors.New("db down")}
+	assert.Equal(t, fiber.StatusInternalServerError, post(newAlumniApp(failing), valid))
+
+	duplikat := &fakeAlumniRepo{createErr: &repository.DuplicateKeyError{Field: "nim"}}
+	assert.Equal(t, fiber.StatusConflict, post(newAlumniApp(duplikat), valid))
+}
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"latihan2/app/model"
+	"latihan2/app/repository"
+	"latihan2/app/service"
+	"latihan2/response"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAnalyticsRepo mencatat filter terakhir yang diterima; method lain dari
+// interface yang di-embed akan panic jika terpanggil.
+type fakeAnalyticsRepo struct {
+	repository.AnalyticsRepository
+	filter  model.AnalyticsFilter
+	dimensi string
+	err     error
+}
+
+func (f *fakeAnalyticsRepo) WithContext(context.Context) repository.AnalyticsRepository {
+	return f
+}
+
+func (f *fakeAnalyticsRepo) GetEmploymentRate(filter model.AnalyticsFilter) ([]model.EmploymentRate, error) {
+	f.filter = filter
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []model.EmploymentRate{{TahunLulus: 2020, Jurusan: "TI", TotalAlumni: 2, AlumniBekerja: 1, Rate: 50}}, nil
+}
+
+func (f *fakeAnalyticsRepo) GetDistribusiPekerjaan(dimensi string, filter model.AnalyticsFilter) ([]model.DistributionItem, error) {
+	f.dimensi, f.filter = dimensi, filter
+	return []model.DistributionItem{}, nil
+}
+
+func newAnalyticsApp(repo repository.AnalyticsRepository) *fiber.App {
+	h := service.NewAnalyticsHandler(repo)
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Get("/analytics/employment-rate", h.GetEmploymentRateService)
+	app.Get("/analytics/distribusi/:dimensi", h.GetDistribusiPekerjaanService)
+	return app
+}
+
+func TestAnalyticsEmploymentRate(t *testing.T) {
+	repo := &fakeAnalyticsRepo{}
+	app := newAnalyticsApp(repo)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/analytics/employment-rate?tahun_mulai=2019&tahun_akhir=2022&jurusan=TI", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, model.AnalyticsFilter{TahunMulai: 2019, TahunAkhir: 2022, Jurusan: "TI"}, repo.filter)
+
+	var body struct {
+		Data []model.EmploymentRate `json:"data"`
+		Meta struct {
+			Filter model.AnalyticsFilter `json:"filter"`
+		} `json:"meta"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Data, 1)
+	assert.Equal(t, 50.0, body.Data[0].Rate)
+	assert.Equal(t, repo.filter, body.Meta.Filter)
+}
+
+func TestAnalyticsValidasi(t *testing.T) {
+	repo := &fakeAnalyticsRepo{}
+	app := newAnalyticsApp(repo)
+
+	tests := []struct {
+		name, path string
+		status     int
+	}{
+		{"tahun bukan angka", "/analytics/employment-rate?tahun_mulai=dua", fiber.StatusBadRequest},
+		{"rentang tahun terbalik", "/analytics/employment-rate?tahun_mulai=2023&tahun_akhir=2020", fiber.StatusBadRequest},
+		{"dimensi tidak dikenal", "/analytics/distribusi/posisi_jabatan", fiber.StatusBadRequest},
+		{"dimensi valid", "/analytics/distribusi/lokasi_kerja", fiber.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest("GET", tt.path, nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+	assert.Equal(t, "lokasi_kerja", repo.dimensi, "hanya dimensi valid yang sampai ke repository")
+}
+
+func TestAnalyticsRepositoryError(t *testing.T) {
+	app := newAnalyticsApp(&fakeAnalyticsRepo{err: errors.New("koneksi putus")})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/analytics/employment-rate", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
@@ -4,19 +4,35 @@ import (
 	"database/sql"
 	"latihan2/app/model"
 	"latihan2/app/repository"
+	"latihan2/response"
+	"latihan2/utils"
 	"strconv"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func GetUsersService(c *fiber.Ctx) error {
+// UserHandler menangani endpoint /api/pg/users.
+type UserHandler struct {
+	repo repository.UserRepository
+}
+
+func NewUserHandler(repo repository.UserRepository) *UserHandler {
+	return &UserHandler{repo: repo}
+}
+
+func (h *UserHandler) GetUsersService(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	sortBy := c.Query("sortBy", "id")
 	order := c.Query("order", "asc")
 	search := c.Query("search", "")
 	offset := (page - 1) * limit
+
+	filter, err := utils.ParseFilter(c.Query("filter"), model.UserFilterFields)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
 	
 	sortByWhitelist := map[string]bool{
 		"id": true, 
@@ -29,67 +45,105 @@ func GetUsersService(c *fiber.Ctx) error {
 	if strings.ToLower(order) != "desc" {
 		order = "asc"
 	}
-	users, err := repository.GetUsersRepo(search, sortBy, order, c.Locals("role").(string), limit, offset)
+	if utils.CursorRequested(c.Query("pagination"), c.Query("cursor")) {
+		return h.getUsersCursor(c, search, sortBy, order, limit, filter)
+	}
+	users, err := h.repo.WithContext(c.UserContext()).GetUsersRepo(search, sortBy, order, c.Locals("role").(string), limit, offset, filter)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch users"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to fetch users")
 	}
-	total, err := repository.CountUsersRepo(search)
+	total, err := h.repo.WithContext(c.UserContext()).CountUsersRepo(search, filter)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to count users"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to count users")
+	}
+	return response.List(c, users, model.MetaInfo{
+		Page:   page,
+		Limit:  limit,
+		Total:  total,
+		Pages:  (total + limit - 1) / limit,
+		SortBy: sortBy,
+		Order:  order,
+		Search: search,
+		Filter: c.Query("filter"),
+	})
+}
+
+var usersCursorSortable = map[string]bool{
+	"id":         true,
+	"username":   true,
+	"email":      true,
+	"created_at": true,
+}
+
+// getUsersCursor melayani GetUsersService pada mode pagination cursor.
+func (h *UserHandler) getUsersCursor(c *fiber.Ctx, search, sortBy, order string, limit int, filter model.Filter) error {
+	page, err := utils.ParseCursorPage(c.Query("cursor"), sortBy, order, limit, usersCursorSortable)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	users, err := h.repo.WithContext(c.UserContext()).GetUsersCursorRepo(search, page, c.Locals("role").(string), filter)
+	if err != nil {
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to fetch users")
+	}
+	users, next, prev := utils.CursorResult(users, page)
+
+	meta := model.CursorMetaInfo{
+		Limit:      page.Limit,
+		SortBy:     page.SortBy,
+		Order:      page.Order,
+		Search:     search,
+		Filter:     c.Query("filter"),
+		NextCursor: next,
+		PrevCursor: prev,
 	}
-	response := model.UserResponse{
-		Data: users,
-		Meta: model.MetaInfo{
-			Page:   page,
-			Limit:  limit,
-			Total:  total,
-			Pages:  (total + limit - 1) / limit,
-			SortBy: sortBy,
-			Order:  order,
-			Search: search,
-		},
+	if c.QueryBool("with_total") {
+		total, err := h.repo.WithContext(c.UserContext()).CountUsersRepo(search, filter)
+		if err != nil {
+			return response.Fail(c, fiber.StatusInternalServerError, "Failed to count users")
+		}
+		meta.Total = &total
 	}
-	return c.JSON(response)
+	return response.List(c, users, meta)
 }
 
-func SoftDeleteUserService(c *fiber.Ctx) error {
+func (h *UserHandler) SoftDeleteUserService(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-	return c.Status(400).JSON(fiber.Map{"error": "Invalid user ID"})
+	return response.Fail(c, fiber.StatusBadRequest, "Invalid user ID")
 	}
 
 	role := c.Locals("role")
 	if role != "admin" {
-		return c.Status(403).JSON(fiber.Map{"error": "Forbidden: only admin can delete"})
+		return response.Fail(c, fiber.StatusForbidden, "Forbidden: only admin can delete")
 	}
 
-	err = repository.SoftDeleteUserRepo(id)
+	err = h.repo.WithContext(c.UserContext()).SoftDeleteUserRepo(id)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete user"})
+		return response.Fail(c, fiber.StatusInternalServerError, "Failed to delete user")
 	}
 
-	return c.JSON(fiber.Map{"message": "User soft deleted successfully"})
+	return response.Message(c, "User soft deleted successfully")
 }
 
-func GetUserByIDService(c *fiber.Ctx) error {
+func (h *UserHandler) GetUserByIDService(c *fiber.Ctx) error {
     id, err := strconv.Atoi(c.Params("id"))
     if err != nil {
-        return c.Status(400).JSON(fiber.Map{"error": "Invalid user ID"})
+        return response.Fail(c, fiber.StatusBadRequest, "Invalid user ID")
     }
 
     role := c.Locals("role").(string)
 
-    user, err := repository.GetUserByID(id, role)
+    user, err := h.repo.WithContext(c.UserContext()).GetUserByID(id, role)
     if err != nil {
         if err == sql.ErrNoRows {
-            return c.Status(404).JSON(fiber.Map{"error": "User not found"})
+            return response.Fail(c, fiber.StatusNotFound, "User not found")
         }
-        return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch user"})
+        return response.Fail(c, fiber.StatusInternalServerError, "Failed to fetch user")
     }
 
-    return c.JSON(fiber.Map{
-        "success": true,
-        "data":    user,
-        "message": "User fetched successfully",
+    return response.Send(c, fiber.StatusOK, response.Envelope{
+        Data:    user,
+        Message: "User fetched successfully",
     })
 }
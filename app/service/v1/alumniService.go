@@ -0,0 +1,253 @@
+package v1
+
+import (
+	"latihan2/app/model"
+	v1Model "latihan2/app/model/v1"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/middleware"
+	"latihan2/response"
+	"latihan2/utils"
+	"latihan2/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AlumniHandler menangani endpoint /api/v1/alumni.
+type AlumniHandler struct {
+	repo v1Repo.AlumniRepository
+}
+
+func NewAlumniHandler(repo v1Repo.AlumniRepository) *AlumniHandler {
+	return &AlumniHandler{repo: repo}
+}
+
+// alumniSortable berisi field NOT NULL di Postgres dan selalu ada di Mongo,
+// sehingga juga bisa dipakai sebagai keyset pagination cursor.
+var alumniSortable = map[string]bool{
+	"id":          true,
+	"nim":         true,
+	"nama":        true,
+	"jurusan":     true,
+	"angkatan":    true,
+	"tahun_lulus": true,
+	"email":       true,
+	"created_at":  true,
+	"updated_at":  true,
+}
+
+// GetAlumni godoc
+// @Summary      Daftar alumni
+// @Description  Pagination page/limit atau cursor, sorting, search dan filter. Alumni yang sudah dihapus tidak ditampilkan.
+// @Tags         v1
+// @Produce      json
+// @Param        page       query  int     false  "Halaman"
+// @Param        limit      query  int     false  "Jumlah data per halaman (maks 100)"
+// @Param        sortBy     query  string  false  "id, nim, nama, jurusan, angkatan, tahun_lulus, email, created_at, updated_at"
+// @Param        order      query  string  false  "asc/desc"
+// @Param        search     query  string  false  "Kata kunci nim, nama atau email"
+// @Param        filter     query  string  false  "Filter field:operator:nilai dipisah koma, mis. tahun_lulus:gte:2020"
+// @Param        pagination query  string  false  "Isi 'cursor' untuk pagination keyset"
+// @Param        cursor     query  string  false  "next_cursor atau prev_cursor dari response sebelumnya"
+// @Param        with_total query  bool    false  "Hitung total data pada mode cursor"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Router       /api/v1/alumni [get]
+// @Security     BearerAuth
+func (h *AlumniHandler) GetAlumni(c *fiber.Ctx) error {
+	p := parseList(c, "id", alumniSortable)
+	filter, err := utils.ParseFilter(p.filter, model.AlumniFilterFields)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	q := v1Repo.ListQuery{Search: p.search, Filter: filter}
+	repo := h.repo.WithContext(c.UserContext())
+
+	return respondList(c, p, listSource[v1Model.Alumni]{
+		offset:         func(op v1Repo.OffsetPage) ([]v1Model.Alumni, error) { return repo.GetAlumni(q, op) },
+		cursor:         func(cp model.CursorPage) ([]v1Model.Alumni, error) { return repo.GetAlumniCursor(q, cp) },
+		count:          func() (int, error) { return repo.CountAlumni(q) },
+		cursorSortable: alumniSortable,
+	})
+}
+
+// SearchAlumni godoc
+// @Summary      Full-text search alumni
+// @Tags         v1
+// @Produce      json
+// @Param        q      query  string  true   "Kata kunci"
+// @Param        mode   query  string  false  "Isi 'basic' untuk pencarian substring tanpa ranking"
+// @Param        page   query  int     false  "Halaman"
+// @Param        limit  query  int     false  "Jumlah data per halaman (maks 100)"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Router       /api/v1/alumni/search [get]
+// @Security     BearerAuth
+func (h *AlumniHandler) SearchAlumni(c *fiber.Ctx) error {
+	s, err := parseSearchQuery(c)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, mode, err := h.repo.WithContext(c.UserContext()).SearchAlumni(s.Q, s.Limit, s.Offset(), s.Mode == model.SearchModeBasic)
+	return searchResponse(c, s, mode, data, err)
+}
+
+// GetAlumniByID godoc
+// @Summary      Detail alumni
+// @Tags         v1
+// @Produce      json
+// @Param        id  path  string  true  "ID alumni"
+// @Success      200 {object} response.Envelope
+// @Header       200 {string} ETag "Version data untuk If-Match"
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/alumni/{id} [get]
+// @Security     BearerAuth
+func (h *AlumniHandler) GetAlumniByID(c *fiber.Ctx) error {
+	alumni, err := h.repo.WithContext(c.UserContext()).GetAlumniByID(c.Params("id"))
+	if err != nil {
+		return failRepo(c, err, "Alumni tidak ditemukan")
+	}
+	c.Set(fiber.HeaderETag, utils.ETag(alumni.Version))
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    alumni,
+		Message: "Data alumni berhasil diambil",
+	})
+}
+
+// CreateAlumni godoc
+// @Summary      Tambah alumni
+// @Description  Hanya admin
+// @Tags         v1
+// @Accept       json
+// @Produce      json
+// @Param        body  body  model.CreateAlumniRequest  true  "Data alumni"
+// @Success      201 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      422 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Router       /api/v1/alumni [post]
+// @Security     BearerAuth
+func (h *AlumniHandler) CreateAlumni(c *fiber.Ctx) error {
+	var req model.CreateAlumniRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
+	}
+
+	alumni, err := h.repo.WithContext(c.UserContext()).CreateAlumni(req)
+	if err != nil {
+		return failRepo(c, err, "User tidak ditemukan")
+	}
+	return response.Send(c, fiber.StatusCreated, response.Envelope{
+		Data:    alumni,
+		Message: "Alumni berhasil ditambahkan",
+	})
+}
+
+// UpdateAlumni godoc
+// @Summary      Ubah alumni
+// @Description  Hanya admin. Semua field diganti dengan isi request; field yang tidak dikirim ikut dikosongkan. Gunakan PATCH untuk mengubah sebagian.
+// @Tags         v1
+// @Accept       json
+// @Produce      json
+// @Param        id        path    string                     true  "ID alumni"
+// @Param        If-Match  header  string                     true  "ETag dari GET alumni, atau *"
+// @Param        body      body    model.UpdateAlumniRequest  true  "Data alumni"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      422 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      412 {object} response.Problem
+// @Failure      428 {object} response.Problem
+// @Router       /api/v1/alumni/{id} [put]
+// @Security     BearerAuth
+func (h *AlumniHandler) UpdateAlumni(c *fiber.Ctx) error {
+	var req model.UpdateAlumniRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
+	}
+
+	alumni, err := h.repo.WithContext(c.UserContext()).UpdateAlumni(c.Params("id"), middleware.IfMatchVersion(c), req)
+	if err != nil {
+		return failRepo(c, err, "Alumni tidak ditemukan")
+	}
+	c.Set(fiber.HeaderETag, utils.ETag(alumni.Version))
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    alumni,
+		Message: "Alumni berhasil diupdate",
+	})
+}
+
+// PatchAlumni godoc
+// @Summary      Ubah sebagian data alumni
+// @Description  Hanya admin. JSON Merge Patch (RFC 7396): field yang tidak dikirim tetap, null mengosongkan field.
+// @Tags         v1
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        id        path    string                     true  "ID alumni"
+// @Param        If-Match  header  string                     true  "ETag dari GET alumni, atau *"
+// @Param        body      body    model.UpdateAlumniRequest  true  "Field alumni yang diubah"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      422 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      412 {object} response.Problem
+// @Failure      415 {object} response.Problem
+// @Failure      428 {object} response.Problem
+// @Router       /api/v1/alumni/{id} [patch]
+// @Security     BearerAuth
+func (h *AlumniHandler) PatchAlumni(c *fiber.Ctx) error {
+	if !isMergePatch(c.Get(fiber.HeaderContentType)) {
+		return response.Fail(c, fiber.StatusUnsupportedMediaType, "Content-Type harus "+utils.MergePatchContentType)
+	}
+	current, err := h.repo.WithContext(c.UserContext()).GetAlumniByID(c.Params("id"))
+	if err != nil {
+		return failRepo(c, err, "Alumni tidak ditemukan")
+	}
+	version, err := patchVersion(middleware.IfMatchVersion(c), current.Version)
+	if err != nil {
+		return failRepo(c, err, "Alumni tidak ditemukan")
+	}
+
+	var req model.UpdateAlumniRequest
+	supplied, err := utils.ApplyMergePatch(alumniUpdateRequest(*current), c.Body(), &req)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	if err := validatePatch(req, supplied); err != nil {
+		return response.Error(c, err)
+	}
+
+	alumni, err := h.repo.WithContext(c.UserContext()).UpdateAlumni(current.ID, version, req)
+	if err != nil {
+		return failRepo(c, err, "Alumni tidak ditemukan")
+	}
+	c.Set(fiber.HeaderETag, utils.ETag(alumni.Version))
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    alumni,
+		Message: "Alumni berhasil diupdate",
+	})
+}
+
+// DeleteAlumni godoc
+// @Summary      Hapus alumni (soft delete)
+// @Description  Hanya admin
+// @Tags         v1
+// @Produce      json
+// @Param        id  path  string  true  "ID alumni"
+// @Success      200 {object} response.Envelope
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/alumni/{id} [delete]
+// @Security     BearerAuth
+func (h *AlumniHandler) DeleteAlumni(c *fiber.Ctx) error {
+	if err := h.repo.WithContext(c.UserContext()).SoftDeleteAlumni(c.Params("id")); err != nil {
+		return failRepo(c, err, "Alumni tidak ditemukan")
+	}
+	return response.Message(c, "Alumni berhasil dihapus")
+}
@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"latihan2/audit"
+	"latihan2/response"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuditHandler menangani endpoint /api/v1/admin/audit.
+type AuditHandler struct {
+	store audit.Store
+}
+
+func NewAuditHandler(store audit.Store) *AuditHandler {
+	return &AuditHandler{store: store}
+}
+
+// AuditMeta adalah meta GET /api/v1/admin/audit. NextBefore diisi jika masih
+// ada entri yang lebih lama; kirim sebagai parameter before untuk halaman
+// berikutnya.
+type AuditMeta struct {
+	Limit      int   `json:"limit"`
+	NextBefore int64 `json:"next_before,omitempty"`
+}
+
+// auditMaxLimit adalah limit terbesar satu halaman audit log.
+const auditMaxLimit = 200
+
+var auditActions = map[string]bool{
+	audit.ActionCreate:         true,
+	audit.ActionUpdate:         true,
+	audit.ActionDelete:         true,
+	audit.ActionRestore:        true,
+	audit.ActionHardDelete:     true,
+	audit.ActionPasswordChange: true,
+	audit.ActionMigrate:        true,
+}
+
+var auditEntities = map[string]bool{
+	audit.EntityAlumni:    true,
+	audit.EntityPekerjaan: true,
+	audit.EntityUser:      true,
+	audit.EntityFile:      true,
+}
+
+// GetAuditLog godoc
+// @Summary      Audit log perubahan data
+// @Description  Entri terbaru lebih dulu. Halaman berikutnya diambil dengan before=meta.next_before. Tanggal from/to berupa RFC3339 atau YYYY-MM-DD; to berupa tanggal berarti sampai akhir hari itu (UTC).
+// @Tags         v1
+// @Produce      json
+// @Param        actor_id   query  string  false  "ID user pelaku"
+// @Param        actor      query  string  false  "Username pelaku, mis. cli:deploy"
+// @Param        action     query  string  false  "create, update, delete, restore, hard_delete, password_change, migrate"
+// @Param        entity     query  string  false  "alumni, pekerjaan, user, file"
+// @Param        entity_id  query  string  false  "ID data yang diubah"
+// @Param        request_id query  string  false  "X-Request-ID request yang mengubah data"
+// @Param        from       query  string  false  "Waktu paling awal"
+// @Param        to         query  string  false  "Waktu paling akhir"
+// @Param        before     query  int     false  "Hanya entri dengan seq lebih kecil"
+// @Param        limit      query  int     false  "Jumlah entri (default 50, maks 200)"
+// @Success      200 {object} response.Envelope{data=[]audit.Entry,meta=AuditMeta}
+// @Failure      400 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Router       /api/v1/admin/audit [get]
+// @Security     BearerAuth
+func (h *AuditHandler) GetAuditLog(c *fiber.Ctx) error {
+	f := audit.Filter{
+		ActorID:   c.Query("actor_id"),
+		Actor:     c.Query("actor"),
+		Action:    c.Query("action"),
+		Entity:    c.Query("entity"),
+		EntityID:  c.Query("entity_id"),
+		RequestID: c.Query("request_id"),
+		Limit:     audit.DefaultLimit,
+	}
+	if f.Action != "" && !auditActions[f.Action] {
+		return response.Fail(c, fiber.StatusBadRequest, "Parameter action tidak valid")
+	}
+	if f.Entity != "" && !auditEntities[f.Entity] {
+		return response.Fail(c, fiber.StatusBadRequest, "Parameter entity tidak valid")
+	}
+	var err error
+	if f.From, err = parseAuditTime(c.Query("from"), false); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, "Parameter from harus RFC3339 atau YYYY-MM-DD")
+	}
+	if f.To, err = parseAuditTime(c.Query("to"), true); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, "Parameter to harus RFC3339 atau YYYY-MM-DD")
+	}
+	if v := c.Query("before"); v != "" {
+		if f.BeforeSeq, err = strconv.ParseInt(v, 10, 64); err != nil || f.BeforeSeq < 1 {
+			return response.Fail(c, fiber.StatusBadRequest, "Parameter before tidak valid")
+		}
+	}
+	if v := c.QueryInt("limit"); v > 0 && v <= auditMaxLimit {
+		f.Limit = v
+	}
+
+	entries, err := h.store.Query(c.UserContext(), f)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	meta := AuditMeta{Limit: f.Limit}
+	if len(entries) == f.Limit && entries[len(entries)-1].Seq > 1 {
+		meta.NextBefore = entries[len(entries)-1].Seq
+	}
+	return response.List(c, entries, meta)
+}
+
+// parseAuditTime menerima RFC3339 atau YYYY-MM-DD. Tanggal tanpa jam pada
+// batas akhir (endOfDay) digeser ke awal hari berikutnya karena Filter.To
+// eksklusif.
+func parseAuditTime(v string, endOfDay bool) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// VerifyAuditLog godoc
+// @Summary      Periksa keutuhan audit log
+// @Description  Membaca seluruh rantai dan memeriksa seq serta hash setiap entri. Simpan last_seq dan last_hash di luar server lalu bandingkan dengan hasil berikutnya untuk mendeteksi entri terakhir yang dihapus.
+// @Tags         v1
+// @Produce      json
+// @Success      200 {object} response.Envelope{data=audit.VerifyReport}
+// @Failure      403 {object} response.Problem
+// @Router       /api/v1/admin/audit/verify [get]
+// @Security     BearerAuth
+func (h *AuditHandler) VerifyAuditLog(c *fiber.Ctx) error {
+	report, err := audit.Verify(c.UserContext(), h.store)
+	if err != nil {
+		return response.Error(c, err)
+	}
+	return response.OK(c, report)
+}
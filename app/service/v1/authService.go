@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"errors"
+	"latihan2/app/model"
+	v1Model "latihan2/app/model/v1"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/metrics"
+	"latihan2/response"
+	"latihan2/utils"
+	"latihan2/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuthHandler menerbitkan token /api/v1 yang terikat pada driver storage aktif.
+type AuthHandler struct {
+	driver string
+	users  v1Repo.UserRepository
+}
+
+func NewAuthHandler(driver string, users v1Repo.UserRepository) *AuthHandler {
+	return &AuthHandler{driver: driver, users: users}
+}
+
+// Login godoc
+// @Summary      Login
+// @Description  Login dengan username (atau email untuk driver mongo/memory) dan password
+// @Tags         v1
+// @Accept       json
+// @Produce      json
+// @Param        body  body  model.LoginRequest  true  "Kredensial"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      422 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Router       /api/v1/login [post]
+func (h *AuthHandler) Login(c *fiber.Ctx) error {
+	var req model.LoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
+	}
+
+	user, hash, err := h.users.WithContext(c.UserContext()).GetUserByUsername(req.Username)
+	if err != nil {
+		if errors.Is(err, v1Repo.ErrNotFound) {
+			metrics.ObserveLogin("v1", metrics.LoginFailure)
+			return response.Fail(c, fiber.StatusUnauthorized, "Username atau password salah")
+		}
+		return failRepo(c, err, "")
+	}
+	if user.DeletedAt != nil || !utils.CheckPassword(req.Password, hash) {
+		metrics.ObserveLogin("v1", metrics.LoginFailure)
+		return response.Fail(c, fiber.StatusUnauthorized, "Username atau password salah")
+	}
+
+	token, err := utils.GenerateTokenV1(h.driver, user.ID, user.Username, user.Role)
+	if err != nil {
+		return response.Fail(c, fiber.StatusInternalServerError, "Gagal generate token")
+	}
+
+	metrics.ObserveLogin("v1", metrics.LoginSuccess)
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    v1Model.LoginResponse{User: *user, Token: token},
+		Message: "Login berhasil",
+	})
+}
+
+// GetProfile godoc
+// @Summary      Profil user yang login
+// @Tags         v1
+// @Produce      json
+// @Success      200 {object} response.Envelope
+// @Failure      401 {object} response.Problem
+// @Router       /api/v1/profile [get]
+// @Security     BearerAuth
+func (h *AuthHandler) GetProfile(c *fiber.Ctx) error {
+	a := actor(c)
+	username, _ := c.Locals("username").(string)
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data: fiber.Map{
+			"user_id":  a.UserID,
+			"username": username,
+			"role":     a.Role,
+			"driver":   h.driver,
+		},
+		Message: "Profile berhasil diambil",
+	})
+}
@@ -0,0 +1,162 @@
+// Package v1 berisi handler /api/v1. Handler hanya bergantung pada interface
+// di app/repository/v1 sehingga bentuk request dan response sama untuk semua
+// driver storage; bentuk response mengikuti package response.
+package v1
+
+import (
+	"errors"
+	"latihan2/app/model"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/response"
+	"latihan2/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// failRepo menulis error repository lewat response.Error. notFound adalah
+// pesan untuk ErrNotFound, mis. "Alumni tidak ditemukan".
+func failRepo(c *fiber.Ctx, err error, notFound string) error {
+	if errors.Is(err, v1Repo.ErrNotFound) {
+		return response.Fail(c, fiber.StatusNotFound, notFound)
+	}
+	return response.Error(c, err)
+}
+
+// actor membaca user yang login dari Locals yang diisi middleware.AuthRequiredV1.
+func actor(c *fiber.Ctx) v1Repo.Actor {
+	userID, _ := c.Locals("userID").(string)
+	role, _ := c.Locals("role").(string)
+	return v1Repo.Actor{UserID: userID, Role: role}
+}
+
+// listParams adalah query pagination yang sama untuk semua endpoint list.
+type listParams struct {
+	page   int
+	limit  int
+	sortBy string
+	order  string
+	search string
+	filter string
+}
+
+func parseList(c *fiber.Ctx, defaultSort string, sortable map[string]bool) listParams {
+	p := listParams{
+		sortBy: c.Query("sortBy", defaultSort),
+		order:  strings.ToLower(c.Query("order", "asc")),
+		search: c.Query("search", ""),
+		filter: c.Query("filter"),
+	}
+	p.page, _ = strconv.Atoi(c.Query("page", "1"))
+	p.limit, _ = strconv.Atoi(c.Query("limit", "10"))
+	if p.page < 1 {
+		p.page = 1
+	}
+	if p.limit < 1 || p.limit > 100 {
+		p.limit = 10
+	}
+	if !sortable[p.sortBy] {
+		p.sortBy = defaultSort
+	}
+	if p.order != "desc" {
+		p.order = "asc"
+	}
+	return p
+}
+
+// listSource adalah fungsi repository yang dipakai respondList untuk satu resource.
+type listSource[T any] struct {
+	offset func(v1Repo.OffsetPage) ([]T, error)
+	cursor func(model.CursorPage) ([]T, error)
+	count  func() (int, error)
+	// cursorSortable hanya berisi field yang selalu terisi di kedua database.
+	cursorSortable map[string]bool
+}
+
+// respondList melayani pagination page/limit maupun cursor dengan bentuk
+// response yang sama untuk semua resource.
+func respondList[T any](c *fiber.Ctx, p listParams, src listSource[T]) error {
+	if utils.CursorRequested(c.Query("pagination"), c.Query("cursor")) {
+		return respondCursor(c, p, src)
+	}
+
+	items, err := src.offset(v1Repo.OffsetPage{SortBy: p.sortBy, Order: p.order, Limit: p.limit, Offset: (p.page - 1) * p.limit})
+	if err != nil {
+		return failRepo(c, err, "Data tidak ditemukan")
+	}
+	total, err := src.count()
+	if err != nil {
+		return failRepo(c, err, "Data tidak ditemukan")
+	}
+	return response.List(c, items, model.MetaInfo{
+		Page:   p.page,
+		Limit:  p.limit,
+		Total:  total,
+		Pages:  (total + p.limit - 1) / p.limit,
+		SortBy: p.sortBy,
+		Order:  p.order,
+		Search: p.search,
+		Filter: p.filter,
+	})
+}
+
+// respondCursor melayani pagination cursor; src.offset tidak dipakai.
+func respondCursor[T any](c *fiber.Ctx, p listParams, src listSource[T]) error {
+	page, err := utils.ParseCursorPage(c.Query("cursor"), p.sortBy, p.order, p.limit, src.cursorSortable)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	items, err := src.cursor(page)
+	if err != nil {
+		return failRepo(c, err, "Data tidak ditemukan")
+	}
+	items, next, prev := utils.CursorResult(items, page)
+	meta := model.CursorMetaInfo{
+		Limit:      page.Limit,
+		SortBy:     page.SortBy,
+		Order:      page.Order,
+		Search:     p.search,
+		Filter:     p.filter,
+		NextCursor: next,
+		PrevCursor: prev,
+	}
+	if c.QueryBool("with_total") {
+		total, err := src.count()
+		if err != nil {
+			return failRepo(c, err, "Data tidak ditemukan")
+		}
+		meta.Total = &total
+	}
+	return response.List(c, items, meta)
+}
+
+func parseSearchQuery(c *fiber.Ctx) (model.SearchQuery, error) {
+	s := model.SearchQuery{Page: 1, Limit: 10}
+	if err := c.QueryParser(&s); err != nil {
+		return s, errors.New("Parameter pencarian tidak valid")
+	}
+	s.Q = strings.TrimSpace(s.Q)
+	if s.Q == "" {
+		return s, errors.New("Parameter q wajib diisi")
+	}
+	if s.Page < 1 {
+		s.Page = 1
+	}
+	if s.Limit < 1 || s.Limit > 100 {
+		s.Limit = 10
+	}
+	return s, nil
+}
+
+func searchResponse(c *fiber.Ctx, s model.SearchQuery, mode string, data interface{}, err error) error {
+	if err != nil {
+		return failRepo(c, err, "Data tidak ditemukan")
+	}
+	return response.List(c, data, fiber.Map{
+		"q":     s.Q,
+		"mode":  mode,
+		"page":  s.Page,
+		"limit": s.Limit,
+	})
+}
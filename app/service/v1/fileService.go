@@ -0,0 +1,228 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"latihan2/app/model"
+	v1Model "latihan2/app/model/v1"
+	v1Repo "latihan2/app/repository/v1"
+	mongoService "latihan2/app/service/mongo"
+	"latihan2/logging"
+	"latihan2/metrics"
+	"latihan2/response"
+	"latihan2/tracing"
+	"latihan2/utils"
+	"os"
+	"path/filepath"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// FileHandler menangani endpoint /api/v1/files. Folder dan batas ukuran
+// upload sama dengan /api/mg/files. Role "user" hanya boleh membuka dan
+// menghapus file miliknya.
+type FileHandler struct {
+	repo  v1Repo.FileRepository
+	users v1Repo.UserRepository
+}
+
+func NewFileHandler(repo v1Repo.FileRepository, users v1Repo.UserRepository) *FileHandler {
+	return &FileHandler{repo: repo, users: users}
+}
+
+// fileCursorSortable berisi field yang selalu terisi di kedua database.
+var fileCursorSortable = map[string]bool{
+	"id":            true,
+	"uploaded_at":   true,
+	"file_name":     true,
+	"original_name": true,
+	"file_size":     true,
+}
+
+// UploadFile godoc
+// @Summary      Upload file
+// @Description  Gambar (jpeg/png) atau PDF. Admin wajib mengisi target_user_id sebagai pemilik file; user selalu menjadi pemilik file yang diunggahnya.
+// @Tags         v1
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file            formData  file    true   "File yang diupload"
+// @Param        target_user_id  formData  string  false  "ID user pemilik file (hanya admin)"
+// @Success      201 {object} response.Envelope{data=v1.File}
+// @Failure      400 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Router       /api/v1/files/upload [post]
+// @Security     BearerAuth
+func (h *FileHandler) UploadFile(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, "File wajib diupload")
+	}
+	a := actor(c)
+	ownerID := a.UserID
+	target := c.FormValue("target_user_id")
+	switch {
+	case a.IsAdmin() && target == "":
+		return response.Fail(c, fiber.StatusBadRequest, "Admin harus menyertakan target_user_id")
+	case a.IsAdmin():
+		if _, err := h.users.WithContext(c.UserContext()).GetUserByID(target, a); err != nil {
+			if errors.Is(err, v1Repo.ErrNotFound) {
+				return response.Fail(c, fiber.StatusBadRequest, "target_user_id tidak ditemukan")
+			}
+			return response.Error(c, err)
+		}
+		ownerID = target
+	case target != "":
+		return response.Fail(c, fiber.StatusForbidden, "User tidak diperbolehkan menentukan target_user_id")
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if err := mongoService.ValidateUpload(contentType, fileHeader.Size); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	fileName := uuid.New().String() + filepath.Ext(fileHeader.Filename)
+	filePath := filepath.Join(mongoService.UploadPath, fileName)
+	if err := os.MkdirAll(mongoService.UploadPath, os.ModePerm); err != nil {
+		return response.Error(c, err)
+	}
+	err = tracing.FileIO(c.UserContext(), "save", filePath, func() error {
+		return c.SaveFile(fileHeader, filePath)
+	})
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	f := &v1Model.File{
+		FileName:     fileName,
+		OriginalName: fileHeader.Filename,
+		FilePath:     filePath,
+		FileSize:     fileHeader.Size,
+		FileType:     contentType,
+		UploadedBy:   a.UserID,
+		OwnerID:      ownerID,
+	}
+	if err := h.repo.WithContext(c.UserContext()).CreateFile(f); err != nil {
+		tracing.FileIO(c.UserContext(), "remove", filePath, func() error { return os.Remove(filePath) })
+		return failRepo(c, err, "User tidak ditemukan")
+	}
+
+	metrics.ObserveUpload(contentType, fileHeader.Size)
+	logging.FromContext(c.UserContext()).Debug("file tersimpan", "file_id", f.ID, "owner_id", ownerID)
+	return response.Created(c, f)
+}
+
+// GetFiles godoc
+// @Summary      Daftar file
+// @Description  Semua metadata file. Dengan pagination=cursor, file diambil per halaman.
+// @Tags         v1
+// @Produce      json
+// @Param        pagination query  string  false  "Isi 'cursor' untuk pagination keyset"
+// @Param        cursor     query  string  false  "next_cursor atau prev_cursor dari response sebelumnya"
+// @Param        limit      query  int     false  "Jumlah data per halaman (maks 100)"
+// @Param        sortBy     query  string  false  "id, uploaded_at, file_name, original_name atau file_size (default: uploaded_at)"
+// @Param        order      query  string  false  "asc/desc"
+// @Param        with_total query  bool    false  "Hitung total data pada mode cursor"
+// @Success      200 {object} response.Envelope{data=[]v1.File}
+// @Failure      400 {object} response.Problem
+// @Router       /api/v1/files [get]
+// @Security     BearerAuth
+func (h *FileHandler) GetFiles(c *fiber.Ctx) error {
+	repo := h.repo.WithContext(c.UserContext())
+	if !utils.CursorRequested(c.Query("pagination"), c.Query("cursor")) {
+		files, err := repo.GetFiles()
+		if err != nil {
+			return failRepo(c, err, "File tidak ditemukan")
+		}
+		return response.OK(c, files)
+	}
+
+	return respondCursor(c, parseList(c, "uploaded_at", fileCursorSortable), listSource[v1Model.File]{
+		cursor:         func(cp model.CursorPage) ([]v1Model.File, error) { return repo.GetFilesCursor(cp) },
+		count:          repo.CountFiles,
+		cursorSortable: fileCursorSortable,
+	})
+}
+
+// GetFileByID godoc
+// @Summary      Metadata file
+// @Tags         v1
+// @Produce      json
+// @Param        id  path  string  true  "ID file"
+// @Success      200 {object} response.Envelope{data=v1.File}
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/files/{id} [get]
+// @Security     BearerAuth
+func (h *FileHandler) GetFileByID(c *fiber.Ctx) error {
+	f, err := h.repo.WithContext(c.UserContext()).GetFileByID(c.Params("id"))
+	if err != nil {
+		return failRepo(c, err, "File tidak ditemukan")
+	}
+	return response.OK(c, f)
+}
+
+// GetContentByID godoc
+// @Summary      Isi file
+// @Tags         v1
+// @Produce      application/octet-stream
+// @Param        id  path  string  true  "ID file"
+// @Success      200
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/files/open/{id} [get]
+// @Security     BearerAuth
+func (h *FileHandler) GetContentByID(c *fiber.Ctx) error {
+	f, err := h.ownedFile(c)
+	if err != nil {
+		return failRepo(c, err, "File tidak ditemukan")
+	}
+	if _, err := os.Stat(f.FilePath); os.IsNotExist(err) {
+		return response.Fail(c, fiber.StatusNotFound, "File fisik tidak ditemukan di server")
+	}
+
+	c.Set("Content-Type", f.FileType)
+	c.Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", f.OriginalName))
+	return tracing.FileIO(c.UserContext(), "send", f.FilePath, func() error {
+		return c.SendFile(f.FilePath)
+	})
+}
+
+// DeleteFile godoc
+// @Summary      Hapus file
+// @Description  Menghapus file dari disk beserta metadata-nya (hanya pemilik atau admin).
+// @Tags         v1
+// @Produce      json
+// @Param        id  path  string  true  "ID file"
+// @Success      200 {object} response.Envelope
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/files/{id} [delete]
+// @Security     BearerAuth
+func (h *FileHandler) DeleteFile(c *fiber.Ctx) error {
+	f, err := h.ownedFile(c)
+	if err != nil {
+		return failRepo(c, err, "File tidak ditemukan")
+	}
+
+	err = tracing.FileIO(c.UserContext(), "remove", f.FilePath, func() error { return os.Remove(f.FilePath) })
+	if err != nil {
+		logging.FromContext(c.UserContext()).Warn("gagal menghapus file dari storage", "path", f.FilePath, "error", err)
+	}
+	if err := h.repo.WithContext(c.UserContext()).DeleteFile(f.ID); err != nil {
+		return failRepo(c, err, "File tidak ditemukan")
+	}
+	return response.Message(c, "File berhasil dihapus")
+}
+
+// ownedFile mengambil file :id. Role "user" mendapat ErrForbidden untuk
+// file milik user lain.
+func (h *FileHandler) ownedFile(c *fiber.Ctx) (*v1Model.File, error) {
+	f, err := h.repo.WithContext(c.UserContext()).GetFileByID(c.Params("id"))
+	if err != nil {
+		return nil, err
+	}
+	if a := actor(c); !a.IsAdmin() && f.OwnerID != a.UserID {
+		return nil, v1Repo.ErrForbidden
+	}
+	return f, nil
+}
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"latihan2/logging"
+	"latihan2/response"
+	"latihan2/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LogLevelRequest adalah body PUT /api/v1/admin/log-level.
+type LogLevelRequest struct {
+	Level string `json:"level" validate:"required" example:"debug"`
+}
+
+// GetLogLevel godoc
+// @Summary      Level log aktif
+// @Tags         v1
+// @Produce      json
+// @Success      200 {object} response.Envelope
+// @Failure      403 {object} response.Problem
+// @Router       /api/v1/admin/log-level [get]
+// @Security     BearerAuth
+func GetLogLevel(c *fiber.Ctx) error {
+	return response.OK(c, fiber.Map{"level": logging.Level()})
+}
+
+// SetLogLevel godoc
+// @Summary      Ubah level log tanpa restart
+// @Description  Level: debug, info, warn atau error. Berlaku sampai proses restart; nilai awal dari LOG_LEVEL.
+// @Tags         v1
+// @Accept       json
+// @Produce      json
+// @Param        body  body  LogLevelRequest  true  "Level baru"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      422 {object} response.Problem
+// @Router       /api/v1/admin/log-level [put]
+// @Security     BearerAuth
+func SetLogLevel(c *fiber.Ctx) error {
+	var req LogLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
+	}
+	if err := logging.SetLevel(req.Level); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	logging.FromContext(c.UserContext()).Warn("level log diubah", "level", logging.Level(), "by", c.Locals("username"))
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    fiber.Map{"level": logging.Level()},
+		Message: "Level log diubah",
+	})
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"latihan2/app/model"
+	v1Model "latihan2/app/model/v1"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/utils"
+	"latihan2/validation"
+	"mime"
+)
+
+// PATCH memakai JSON Merge Patch (RFC 7396): field yang tidak dikirim tetap,
+// field bernilai null dikosongkan, dan hanya field yang dikirim yang divalidasi.
+// Patch diterapkan ke data saat ini lalu disimpan lewat update biasa dengan
+// version data tersebut, sehingga perubahan di antaranya tetap terdeteksi.
+
+// isMergePatch menerima application/merge-patch+json dan application/json.
+func isMergePatch(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == utils.MergePatchContentType || mediaType == "application/json"
+}
+
+// patchVersion memilih version untuk update. If-Match "*" memakai version data
+// yang dibaca; If-Match lain harus sama dengan version tersebut.
+func patchVersion(ifMatch, current int) (int, error) {
+	if ifMatch == v1Repo.AnyVersion {
+		return current, nil
+	}
+	if ifMatch != current {
+		return 0, v1Repo.ErrVersionConflict
+	}
+	return ifMatch, nil
+}
+
+// fieldTerkait memastikan aturan lintas field (tahun_lulus >= angkatan,
+// tanggal selesai setelah tanggal mulai) tetap diperiksa jika hanya pasangannya
+// yang dikirim.
+var fieldTerkait = map[string]string{
+	"angkatan":            "tahun_lulus",
+	"tanggal_mulai_kerja": "tanggal_selesai_kerja",
+}
+
+// validatePatch memvalidasi field yang dikirim di patch dengan tag validate milik req.
+func validatePatch(req interface{}, supplied map[string]bool) error {
+	fields := make(map[string]bool, len(supplied)+len(fieldTerkait))
+	for k := range supplied {
+		fields[k] = true
+		if pasangan, ok := fieldTerkait[k]; ok {
+			fields[pasangan] = true
+		}
+	}
+	return validation.Partial(req, fields)
+}
+
+func alumniUpdateRequest(a v1Model.Alumni) model.UpdateAlumniRequest {
+	req := model.UpdateAlumniRequest{
+		Nama:       a.Nama,
+		Jurusan:    a.Jurusan,
+		Angkatan:   a.Angkatan,
+		TahunLulus: a.TahunLulus,
+		Email:      a.Email,
+	}
+	if a.NoTelepon != nil {
+		req.NoTelepon = *a.NoTelepon
+	}
+	if a.Alamat != nil {
+		req.Alamat = *a.Alamat
+	}
+	return req
+}
+
+func pekerjaanUpdateRequest(p v1Model.Pekerjaan) model.UpdatePekerjaanRequest {
+	req := model.UpdatePekerjaanRequest{
+		NamaPerusahaan:     p.NamaPerusahaan,
+		PosisiJabatan:      p.PosisiJabatan,
+		BidangIndustri:     p.BidangIndustri,
+		LokasiKerja:        p.LokasiKerja,
+		GajiRange:          p.GajiRange,
+		StatusPekerjaan:    p.StatusPekerjaan,
+		DeskripsiPekerjaan: p.Deskripsi,
+		RentangGaji:        p.RentangGaji,
+	}
+	if !p.TanggalMulaiKerja.IsZero() {
+		req.TanggalMulaiKerja = p.TanggalMulaiKerja.Format("2006-01-02")
+	}
+	if p.TanggalSelesaiKerja != nil {
+		req.TanggalSelesaiKerja = p.TanggalSelesaiKerja.Format("2006-01-02")
+	}
+	return req
+}
+
+// gajiFields adalah field gaji terstruktur; gaji_range diturunkan darinya.
+var gajiFields = []string{"gaji_min", "gaji_max", "gaji_mata_uang", "gaji_periode"}
+
+// selaraskanGaji menyelaraskan gaji setelah patch: jika hanya gaji_range yang
+// dikirim, field terstruktur dihitung ulang darinya; jika field terstruktur
+// yang dikirim, gaji_range disusun ulang.
+func selaraskanGaji(req *model.UpdatePekerjaanRequest, supplied map[string]bool) error {
+	terstruktur := false
+	for _, f := range gajiFields {
+		terstruktur = terstruktur || supplied[f]
+	}
+	if !terstruktur && !supplied["gaji_range"] {
+		return nil
+	}
+	if !terstruktur {
+		req.RentangGaji = model.RentangGaji{}
+	}
+	gaji, gajiRange, err := utils.ResolveGaji(req.GajiRange, req.RentangGaji)
+	if err != nil {
+		return err
+	}
+	req.RentangGaji, req.GajiRange = gaji, gajiRange
+	return nil
+}
+
+func userUpdateRequest(u v1Model.User) model.UpdateUserRequest {
+	return model.UpdateUserRequest{Username: u.Username, Email: u.Email, Role: u.Role}
+}
@@ -0,0 +1,388 @@
+package v1
+
+import (
+	"errors"
+	"latihan2/app/model"
+	v1Model "latihan2/app/model/v1"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/middleware"
+	"latihan2/response"
+	"latihan2/utils"
+	"latihan2/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PekerjaanHandler menangani endpoint /api/v1/pekerjaan. Role "user" hanya
+// melihat dan menghapus pekerjaan milik alumni yang terhubung ke akunnya.
+type PekerjaanHandler struct {
+	repo   v1Repo.PekerjaanRepository
+	alumni v1Repo.AlumniRepository
+}
+
+func NewPekerjaanHandler(repo v1Repo.PekerjaanRepository, alumni v1Repo.AlumniRepository) *PekerjaanHandler {
+	return &PekerjaanHandler{repo: repo, alumni: alumni}
+}
+
+var pekerjaanSortable = map[string]bool{
+	"id":                    true,
+	"alumni_id":             true,
+	"nama_perusahaan":       true,
+	"posisi_jabatan":        true,
+	"bidang_industri":       true,
+	"lokasi_kerja":          true,
+	"gaji_range":            true,
+	"gaji_min":              true,
+	"gaji_max":              true,
+	"tanggal_mulai_kerja":   true,
+	"tanggal_selesai_kerja": true,
+	"status_pekerjaan":      true,
+	"created_at":            true,
+	"updated_at":            true,
+}
+
+// pekerjaanCursorSortable hanya berisi field yang selalu terisi di Mongo
+// (field lain memakai omitempty) sehingga aman dipakai sebagai keyset.
+var pekerjaanCursorSortable = map[string]bool{
+	"id":                  true,
+	"nama_perusahaan":     true,
+	"posisi_jabatan":      true,
+	"tanggal_mulai_kerja": true,
+	"created_at":          true,
+}
+
+// GetPekerjaan godoc
+// @Summary      Daftar pekerjaan
+// @Description  Pagination page/limit atau cursor, sorting, search, filter dan filter gaji. Data di trash tidak ditampilkan.
+// @Tags         v1
+// @Produce      json
+// @Param        page       query  int     false  "Halaman"
+// @Param        limit      query  int     false  "Jumlah data per halaman (maks 100)"
+// @Param        sortBy     query  string  false  "Kolom pengurutan (default: id)"
+// @Param        order      query  string  false  "asc/desc"
+// @Param        search     query  string  false  "Kata kunci perusahaan, posisi, bidang atau lokasi"
+// @Param        gaji_min   query  int     false  "Gaji minimum (gaji_min >= nilai)"
+// @Param        gaji_max   query  int     false  "Gaji maksimum (batas atas gaji <= nilai)"
+// @Param        mata_uang  query  string  false  "Kode mata uang gaji, mis. IDR"
+// @Param        filter     query  string  false  "Filter field:operator:nilai dipisah koma"
+// @Param        pagination query  string  false  "Isi 'cursor' untuk pagination keyset"
+// @Param        cursor     query  string  false  "next_cursor atau prev_cursor dari response sebelumnya"
+// @Param        with_total query  bool    false  "Hitung total data pada mode cursor"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Router       /api/v1/pekerjaan [get]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) GetPekerjaan(c *fiber.Ctx) error {
+	p := parseList(c, "id", pekerjaanSortable)
+	gaji, err := utils.ParseGajiFilter(c.Query("gaji_min"), c.Query("gaji_max"), c.Query("mata_uang"))
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	filter, err := utils.ParseFilter(p.filter, model.PekerjaanFilterFields)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	q, a := v1Repo.ListQuery{Search: p.search, Filter: filter, Gaji: gaji}, actor(c)
+	repo := h.repo.WithContext(c.UserContext())
+
+	return respondList(c, p, listSource[v1Model.Pekerjaan]{
+		offset:         func(op v1Repo.OffsetPage) ([]v1Model.Pekerjaan, error) { return repo.GetPekerjaan(q, op, a) },
+		cursor:         func(cp model.CursorPage) ([]v1Model.Pekerjaan, error) { return repo.GetPekerjaanCursor(q, cp, a) },
+		count:          func() (int, error) { return repo.CountPekerjaan(q, a) },
+		cursorSortable: pekerjaanCursorSortable,
+	})
+}
+
+// SearchPekerjaan godoc
+// @Summary      Full-text search pekerjaan
+// @Tags         v1
+// @Produce      json
+// @Param        q      query  string  true   "Kata kunci"
+// @Param        mode   query  string  false  "Isi 'basic' untuk pencarian substring tanpa ranking"
+// @Param        page   query  int     false  "Halaman"
+// @Param        limit  query  int     false  "Jumlah data per halaman (maks 100)"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Router       /api/v1/pekerjaan/search [get]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) SearchPekerjaan(c *fiber.Ctx) error {
+	s, err := parseSearchQuery(c)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	data, mode, err := h.repo.WithContext(c.UserContext()).SearchPekerjaan(s.Q, s.Limit, s.Offset(), s.Mode == model.SearchModeBasic, actor(c))
+	return searchResponse(c, s, mode, data, err)
+}
+
+// GetPekerjaanByID godoc
+// @Summary      Detail pekerjaan
+// @Tags         v1
+// @Produce      json
+// @Param        id  path  string  true  "ID pekerjaan"
+// @Success      200 {object} response.Envelope
+// @Header       200 {string} ETag "Version data untuk If-Match"
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/pekerjaan/{id} [get]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) GetPekerjaanByID(c *fiber.Ctx) error {
+	p, err := h.repo.WithContext(c.UserContext()).GetPekerjaanByID(c.Params("id"), actor(c))
+	if err != nil {
+		return failRepo(c, err, "Pekerjaan tidak ditemukan")
+	}
+	c.Set(fiber.HeaderETag, utils.ETag(p.Version))
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    p,
+		Message: "Data pekerjaan berhasil diambil",
+	})
+}
+
+// GetPekerjaanByAlumniID godoc
+// @Summary      Pekerjaan milik satu alumni
+// @Description  Hanya admin
+// @Tags         v1
+// @Produce      json
+// @Param        alumni_id  path  string  true  "ID alumni"
+// @Success      200 {object} response.Envelope
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/pekerjaan/alumni/{alumni_id} [get]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) GetPekerjaanByAlumniID(c *fiber.Ctx) error {
+	if _, err := h.alumni.WithContext(c.UserContext()).GetAlumniByID(c.Params("alumni_id")); err != nil {
+		return failRepo(c, err, "Alumni tidak ditemukan")
+	}
+	list, err := h.repo.WithContext(c.UserContext()).GetPekerjaanByAlumniID(c.Params("alumni_id"))
+	if err != nil {
+		return failRepo(c, err, "Alumni tidak ditemukan")
+	}
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    list,
+		Message: "Data pekerjaan alumni berhasil diambil",
+	})
+}
+
+// CreatePekerjaan godoc
+// @Summary      Tambah pekerjaan
+// @Description  Hanya admin. Gaji boleh dikirim sebagai gaji_range atau field gaji terstruktur.
+// @Tags         v1
+// @Accept       json
+// @Produce      json
+// @Param        body  body  v1.CreatePekerjaanRequest  true  "Data pekerjaan"
+// @Success      201 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      422 {object} response.Problem
+// @Router       /api/v1/pekerjaan [post]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) CreatePekerjaan(c *fiber.Ctx) error {
+	var req v1Model.CreatePekerjaanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
+	}
+	if _, err := h.alumni.WithContext(c.UserContext()).GetAlumniByID(req.AlumniID); err != nil {
+		if errors.Is(err, v1Repo.ErrNotFound) {
+			return response.Fail(c, fiber.StatusBadRequest, "alumni_id tidak ditemukan")
+		}
+		return failRepo(c, err, "")
+	}
+
+	gaji, gajiRange, err := utils.ResolveGaji(req.GajiRange, req.RentangGaji)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	req.RentangGaji, req.GajiRange = gaji, gajiRange
+
+	p, err := h.repo.WithContext(c.UserContext()).CreatePekerjaan(req)
+	if err != nil {
+		return failRepo(c, err, "Alumni tidak ditemukan")
+	}
+	return response.Send(c, fiber.StatusCreated, response.Envelope{
+		Data:    p,
+		Message: "Pekerjaan berhasil ditambahkan",
+	})
+}
+
+// UpdatePekerjaan godoc
+// @Summary      Ubah pekerjaan
+// @Description  Hanya admin
+// @Tags         v1
+// @Accept       json
+// @Produce      json
+// @Param        id        path    string                        true  "ID pekerjaan"
+// @Param        If-Match  header  string                        true  "ETag dari GET pekerjaan, atau *"
+// @Param        body      body    model.UpdatePekerjaanRequest  true  "Data pekerjaan"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      422 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      412 {object} response.Problem
+// @Failure      428 {object} response.Problem
+// @Router       /api/v1/pekerjaan/{id} [put]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) UpdatePekerjaan(c *fiber.Ctx) error {
+	var req model.UpdatePekerjaanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if err := validation.Struct(req); err != nil {
+		return response.Error(c, err)
+	}
+
+	gaji, gajiRange, err := utils.ResolveGaji(req.GajiRange, req.RentangGaji)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	req.RentangGaji, req.GajiRange = gaji, gajiRange
+
+	p, err := h.repo.WithContext(c.UserContext()).UpdatePekerjaan(c.Params("id"), middleware.IfMatchVersion(c), req)
+	if err != nil {
+		return failRepo(c, err, "Pekerjaan tidak ditemukan")
+	}
+	c.Set(fiber.HeaderETag, utils.ETag(p.Version))
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    p,
+		Message: "Pekerjaan berhasil diupdate",
+	})
+}
+
+// PatchPekerjaan godoc
+// @Summary      Ubah sebagian data pekerjaan
+// @Description  Hanya admin. JSON Merge Patch (RFC 7396): field yang tidak dikirim tetap, null mengosongkan field.
+// @Tags         v1
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        id        path    string                        true  "ID pekerjaan"
+// @Param        If-Match  header  string                        true  "ETag dari GET pekerjaan, atau *"
+// @Param        body      body    model.UpdatePekerjaanRequest  true  "Field pekerjaan yang diubah"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      422 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      412 {object} response.Problem
+// @Failure      415 {object} response.Problem
+// @Failure      428 {object} response.Problem
+// @Router       /api/v1/pekerjaan/{id} [patch]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) PatchPekerjaan(c *fiber.Ctx) error {
+	if !isMergePatch(c.Get(fiber.HeaderContentType)) {
+		return response.Fail(c, fiber.StatusUnsupportedMediaType, "Content-Type harus "+utils.MergePatchContentType)
+	}
+	current, err := h.repo.WithContext(c.UserContext()).GetPekerjaanByID(c.Params("id"), actor(c))
+	if err != nil {
+		return failRepo(c, err, "Pekerjaan tidak ditemukan")
+	}
+	version, err := patchVersion(middleware.IfMatchVersion(c), current.Version)
+	if err != nil {
+		return failRepo(c, err, "Pekerjaan tidak ditemukan")
+	}
+
+	var req model.UpdatePekerjaanRequest
+	supplied, err := utils.ApplyMergePatch(pekerjaanUpdateRequest(*current), c.Body(), &req)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	if err := validatePatch(req, supplied); err != nil {
+		return response.Error(c, err)
+	}
+	if err := selaraskanGaji(&req, supplied); err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	p, err := h.repo.WithContext(c.UserContext()).UpdatePekerjaan(current.ID, version, req)
+	if err != nil {
+		return failRepo(c, err, "Pekerjaan tidak ditemukan")
+	}
+	c.Set(fiber.HeaderETag, utils.ETag(p.Version))
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    p,
+		Message: "Pekerjaan berhasil diupdate",
+	})
+}
+
+// MigrateGaji godoc
+// @Summary      Migrasi gaji_range ke field gaji terstruktur
+// @Description  Hanya admin
+// @Tags         v1
+// @Produce      json
+// @Param        dry_run  query  bool  false  "Hanya laporkan hasil parse tanpa menyimpan"
+// @Success      200 {object} response.Envelope
+// @Router       /api/v1/pekerjaan/migrasi-gaji [post]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) MigrateGaji(c *fiber.Ctx) error {
+	report, err := h.repo.WithContext(c.UserContext()).MigrateGajiRange(c.QueryBool("dry_run", false))
+	if err != nil {
+		return failRepo(c, err, "")
+	}
+	return response.OK(c, report)
+}
+
+// DeletePekerjaan godoc
+// @Summary      Pindahkan pekerjaan ke trash (soft delete)
+// @Tags         v1
+// @Produce      json
+// @Param        id  path  string  true  "ID pekerjaan"
+// @Success      200 {object} response.Envelope
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/pekerjaan/{id} [delete]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) DeletePekerjaan(c *fiber.Ctx) error {
+	if err := h.repo.WithContext(c.UserContext()).SoftDeletePekerjaan(c.Params("id"), actor(c)); err != nil {
+		return failRepo(c, err, "Pekerjaan tidak ditemukan")
+	}
+	return response.Message(c, "Pekerjaan berhasil dipindahkan ke trash")
+}
+
+// GetTrashPekerjaanByID godoc
+// @Summary      Detail pekerjaan di trash
+// @Tags         v1
+// @Produce      json
+// @Param        id  path  string  true  "ID pekerjaan"
+// @Success      200 {object} response.Envelope
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/pekerjaan/trash/{id} [get]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) GetTrashPekerjaanByID(c *fiber.Ctx) error {
+	p, err := h.repo.WithContext(c.UserContext()).GetTrashPekerjaanByID(c.Params("id"), actor(c))
+	if err != nil {
+		return failRepo(c, err, "Pekerjaan tidak ditemukan di trash")
+	}
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    p,
+		Message: "Data trash pekerjaan berhasil diambil",
+	})
+}
+
+// RestorePekerjaan godoc
+// @Summary      Kembalikan pekerjaan dari trash
+// @Tags         v1
+// @Produce      json
+// @Param        id  path  string  true  "ID pekerjaan"
+// @Success      200 {object} response.Envelope
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/pekerjaan/trash/{id}/restore [post]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) RestorePekerjaan(c *fiber.Ctx) error {
+	if err := h.repo.WithContext(c.UserContext()).RestorePekerjaan(c.Params("id"), actor(c)); err != nil {
+		return failRepo(c, err, "Pekerjaan tidak ditemukan di trash")
+	}
+	return response.Message(c, "Pekerjaan berhasil dikembalikan")
+}
+
+// HardDeletePekerjaan godoc
+// @Summary      Hapus permanen pekerjaan dari trash
+// @Tags         v1
+// @Produce      json
+// @Param        id  path  string  true  "ID pekerjaan"
+// @Success      200 {object} response.Envelope
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/pekerjaan/trash/{id} [delete]
+// @Security     BearerAuth
+func (h *PekerjaanHandler) HardDeletePekerjaan(c *fiber.Ctx) error {
+	if err := h.repo.WithContext(c.UserContext()).HardDeletePekerjaan(c.Params("id"), actor(c)); err != nil {
+		return failRepo(c, err, "Pekerjaan tidak ditemukan di trash")
+	}
+	return response.Message(c, "Pekerjaan berhasil dihapus permanen")
+}
@@ -0,0 +1,564 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"latihan2/app/repository/memory"
+	v1Repo "latihan2/app/repository/v1"
+	mongoService "latihan2/app/service/mongo"
+	v1Service "latihan2/app/service/v1"
+	"latihan2/audit"
+	"latihan2/logging"
+	"latihan2/middleware"
+	"latihan2/response"
+	"latihan2/route"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newV1App(t *testing.T) *fiber.App {
+	return newV1AuditApp(t, nil)
+}
+
+// newV1AuditApp sama dengan newV1App dengan repository yang dibungkus
+// auditLog dan /api/v1/admin/audit terpasang jika auditLog tidak nil.
+func newV1AuditApp(t *testing.T, auditLog *audit.Log) *fiber.App {
+	t.Helper()
+	t.Setenv("JWT_SECRET_KEY", "rahasia-test")
+
+	store := memory.NewStore()
+	require.NoError(t, memory.Seed(store))
+	s := v1Repo.NewMongoStorage(v1Repo.DriverMemory,
+		audit.MongoUsers(auditLog, audit.BackendMemory, memory.NewUserRepository(store)),
+		audit.MongoAlumni(auditLog, audit.BackendMemory, memory.NewAlumniRepository(store)),
+		audit.MongoPekerjaan(auditLog, audit.BackendMemory, memory.NewPekerjaanRepository(store)),
+		audit.MongoFiles(auditLog, audit.BackendMemory, memory.NewFileRepository(store)))
+
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Use(middleware.RequestID())
+	h := route.V1Handlers{
+		Driver:    s.Driver,
+		Auth:      v1Service.NewAuthHandler(s.Driver, s.Users),
+		User:      v1Service.NewUserHandler(s.Users),
+		Alumni:    v1Service.NewAlumniHandler(s.Alumni),
+		Pekerjaan: v1Service.NewPekerjaanHandler(s.Pekerjaan, s.Alumni),
+		File:      v1Service.NewFileHandler(s.Files, s.Users),
+	}
+	if auditLog != nil {
+		h.Audit = v1Service.NewAuditHandler(auditLog.Store())
+	}
+	route.SetupRoutesV1(app, h)
+	return app
+}
+
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Code    string          `json:"code"`
+	Detail  string          `json:"detail"`
+	Errors  []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"errors"`
+	Meta struct {
+		Total int `json:"total"`
+	} `json:"meta"`
+}
+
+func call(t *testing.T, app *fiber.App, method, path, token string, body interface{}) (int, envelope) {
+	t.Helper()
+	status, _, env := callWithHeader(t, app, method, path, token, nil, body)
+	return status, env
+}
+
+// callWithHeader sama dengan call tetapi bisa mengirim header tambahan dan
+// mengembalikan header response, mis. untuk ETag dan If-Match.
+func callWithHeader(t *testing.T, app *fiber.App, method, path, token string, header map[string]string, body interface{}) (int, http.Header, envelope) {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	var env envelope
+	_ = json.NewDecoder(resp.Body).Decode(&env)
+	return resp.StatusCode, resp.Header, env
+}
+
+func login(t *testing.T, app *fiber.App, username, password string) string {
+	t.Helper()
+	status, env := call(t, app, "POST", "/api/v1/login", "", map[string]string{"username": username, "password": password})
+	require.Equal(t, fiber.StatusOK, status, env.Detail)
+	var data struct {
+		Token string `json:"token"`
+	}
+	require.NoError(t, json.Unmarshal(env.Data, &data))
+	return data.Token
+}
+
+func TestV1LoginDanAuth(t *testing.T) {
+	app := newV1App(t)
+
+	status, _ := call(t, app, "POST", "/api/v1/login", "", map[string]string{"username": memory.DemoAdminUsername, "password": "salah"})
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+
+	status, _ = call(t, app, "GET", "/api/v1/alumni", "", nil)
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+
+	token := login(t, app, memory.DemoAdminUsername, memory.DemoAdminPassword)
+	status, env := call(t, app, "GET", "/api/v1/alumni?sortBy=nama", token, nil)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.True(t, env.Success)
+	assert.Equal(t, 5, env.Meta.Total)
+}
+
+func TestV1PekerjaanKepemilikan(t *testing.T) {
+	app := newV1App(t)
+	admin := login(t, app, memory.DemoAdminUsername, memory.DemoAdminPassword)
+	user := login(t, app, memory.DemoUserUsername, memory.DemoUserPassword)
+
+	_, env := call(t, app, "GET", "/api/v1/pekerjaan", admin, nil)
+	assert.Equal(t, 5, env.Meta.Total, "data di trash tidak ikut di list")
+
+	_, env = call(t, app, "GET", "/api/v1/pekerjaan", user, nil)
+	require.Equal(t, 1, env.Meta.Total, "user hanya melihat pekerjaan miliknya")
+	var own []struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(env.Data, &own))
+
+	_, env = call(t, app, "GET", "/api/v1/pekerjaan?search=Bank", admin, nil)
+	var other []struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(env.Data, &other))
+	require.Len(t, other, 1)
+
+	status, _ := call(t, app, "GET", "/api/v1/pekerjaan/"+other[0].ID, user, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+	status, _ = call(t, app, "DELETE", "/api/v1/pekerjaan/"+other[0].ID, user, nil)
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	status, _ = call(t, app, "DELETE", "/api/v1/pekerjaan/"+own[0].ID, user, nil)
+	require.Equal(t, fiber.StatusOK, status)
+	status, _ = call(t, app, "GET", "/api/v1/pekerjaan/trash/"+own[0].ID, user, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	status, _ = call(t, app, "POST", "/api/v1/pekerjaan/trash/"+own[0].ID+"/restore", user, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, _ = call(t, app, "POST", "/api/v1/pekerjaan/migrasi-gaji", user, nil)
+	assert.Equal(t, fiber.StatusForbidden, status)
+}
+
+func TestV1AlumniDuplikatDanIDTidakValid(t *testing.T) {
+	app := newV1App(t)
+	admin := login(t, app, memory.DemoAdminUsername, memory.DemoAdminPassword)
+
+	status, header, env := callWithHeader(t, app, "POST", "/api/v1/alumni", admin, nil, map[string]interface{}{
+		"nim": "2019001", "nama": "Duplikat", "jurusan": "Teknik Informatika", "email": "dup@demo.local",
+	})
+	assert.Equal(t, fiber.StatusConflict, status)
+	assert.Equal(t, "application/problem+json", header.Get("Content-Type"))
+	assert.Equal(t, "duplicate", env.Code)
+	assert.Equal(t, "nim sudah terdaftar", env.Detail)
+	assert.False(t, env.Success)
+
+	status, env = call(t, app, "GET", "/api/v1/alumni/123", admin, nil)
+	assert.Equal(t, fiber.StatusNotFound, status, "ID Postgres pada driver memory dianggap tidak ada")
+	assert.Equal(t, "not_found", env.Code)
+}
+
+func TestV1ProblemDetails(t *testing.T) {
+	app := newV1App(t)
+
+	status, header, env := callWithHeader(t, app, "GET", "/tidak-ada", "", nil, nil)
+	assert.Equal(t, fiber.StatusNotFound, status, "route yang tidak ada ditangani ErrorHandler")
+	assert.Equal(t, "application/problem+json", header.Get("Content-Type"))
+	assert.Equal(t, "not_found", env.Code)
+
+	status, env = call(t, app, "GET", "/api/v1/alumni", "", nil)
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+	assert.Equal(t, "unauthorized", env.Code)
+	assert.Equal(t, "Token akses diperlukan", env.Detail)
+}
+
+func TestV1ValidasiBahasa(t *testing.T) {
+	app := newV1App(t)
+	admin := login(t, app, memory.DemoAdminUsername, memory.DemoAdminPassword)
+	body := map[string]interface{}{
+		"nim": "19-001", "nama": "Baru", "jurusan": "Teknik Informatika", "email": "baru",
+		"angkatan": 2020, "tahun_lulus": 2019,
+	}
+
+	status, header, env := callWithHeader(t, app, "POST", "/api/v1/alumni", admin, map[string]string{"Accept-Language": "en-US,en;q=0.9"}, body)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+	assert.Equal(t, "en", header.Get("Content-Language"))
+	assert.Equal(t, "Validation failed", env.Detail)
+	messages := map[string]string{}
+	for _, fe := range env.Errors {
+		messages[fe.Field] = fe.Message
+	}
+	assert.Equal(t, map[string]string{
+		"nim":         "nim must be 7 to 15 digits",
+		"email":       "email must be a valid email address",
+		"tahun_lulus": "tahun_lulus must not be earlier than angkatan",
+	}, messages)
+
+	status, header, env = callWithHeader(t, app, "POST", "/api/v1/alumni", admin, nil, body)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+	assert.Equal(t, "id", header.Get("Content-Language"))
+	assert.Equal(t, "Validasi gagal", env.Detail)
+	require.Len(t, env.Errors, 3)
+}
+
+func TestV1AlumniIfMatch(t *testing.T) {
+	app := newV1App(t)
+	admin := login(t, app, memory.DemoAdminUsername, memory.DemoAdminPassword)
+
+	_, env := call(t, app, "GET", "/api/v1/alumni?sortBy=nim", admin, nil)
+	var list []struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(env.Data, &list))
+	path := "/api/v1/alumni/" + list[0].ID
+
+	status, header, env := callWithHeader(t, app, "GET", path, admin, nil, nil)
+	require.Equal(t, fiber.StatusOK, status)
+	etag := header.Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	body := map[string]interface{}{
+		"nim": "2019001", "nama": "Nama Baru", "jurusan": "Teknik Informatika", "angkatan": 2019,
+		"tahun_lulus": 2023, "email": "baru@demo.local",
+	}
+	status, _, _ = callWithHeader(t, app, "PUT", path, admin, nil, body)
+	assert.Equal(t, fiber.StatusPreconditionRequired, status)
+	status, _, _ = callWithHeader(t, app, "PUT", path, admin, map[string]string{"If-Match": `"9"`}, body)
+	assert.Equal(t, fiber.StatusPreconditionFailed, status)
+
+	status, header, _ = callWithHeader(t, app, "PUT", path, admin, map[string]string{"If-Match": etag}, body)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, `"2"`, header.Get("ETag"))
+
+	// ETag lama sudah kedaluwarsa setelah update pertama.
+	status, _, env = callWithHeader(t, app, "PUT", path, admin, map[string]string{"If-Match": etag}, body)
+	assert.Equal(t, fiber.StatusPreconditionFailed, status)
+	assert.False(t, env.Success)
+	assert.Equal(t, "version_conflict", env.Code)
+
+	status, _, _ = callWithHeader(t, app, "PUT", path, admin, map[string]string{"If-Match": "*"}, body)
+	assert.Equal(t, fiber.StatusOK, status)
+}
+
+func TestV1UpdatePekerjaanTidakAda(t *testing.T) {
+	app := newV1App(t)
+	admin := login(t, app, memory.DemoAdminUsername, memory.DemoAdminPassword)
+
+	body := map[string]interface{}{
+		"nama_perusahaan": "PT Tidak Ada", "posisi_jabatan": "QA", "tanggal_mulai_kerja": "2023-01-02",
+		"status_pekerjaan": "aktif",
+	}
+	status, _, env := callWithHeader(t, app, "PUT", "/api/v1/pekerjaan/65f000000000000000000000", admin, map[string]string{"If-Match": "*"}, body)
+	assert.Equal(t, fiber.StatusNotFound, status, env.Detail)
+	assert.Equal(t, "not_found", env.Code)
+}
+
+func TestV1PatchMergePatch(t *testing.T) {
+	app := newV1App(t)
+	admin := login(t, app, memory.DemoAdminUsername, memory.DemoAdminPassword)
+	mergePatch := map[string]string{"Content-Type": "application/merge-patch+json", "If-Match": "*"}
+
+	_, env := call(t, app, "GET", "/api/v1/alumni?search=Budi", admin, nil)
+	var list []struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(env.Data, &list))
+	require.Len(t, list, 1)
+	path := "/api/v1/alumni/" + list[0].ID
+
+	status, _, _ := callWithHeader(t, app, "PATCH", path, admin, mergePatch, map[string]interface{}{"alamat": "Surabaya"})
+	require.Equal(t, fiber.StatusOK, status)
+
+	// Field yang tidak dikirim tetap, null mengosongkan field.
+	status, header, env := callWithHeader(t, app, "PATCH", path, admin, mergePatch,
+		map[string]interface{}{"angkatan": 2018, "alamat": nil})
+	require.Equal(t, fiber.StatusOK, status, env.Detail)
+	assert.Equal(t, `"3"`, header.Get("ETag"))
+	var alumni map[string]interface{}
+	require.NoError(t, json.Unmarshal(env.Data, &alumni))
+	assert.Equal(t, "Budi Santoso", alumni["nama"])
+	assert.Equal(t, "budi@demo.local", alumni["email"])
+	assert.EqualValues(t, 2018, alumni["angkatan"])
+	assert.Empty(t, alumni["alamat"])
+
+	for _, body := range []map[string]interface{}{
+		{"angkatan": "2018"},
+		{"nim": "2019999"},
+	} {
+		status, _, env = callWithHeader(t, app, "PATCH", path, admin, mergePatch, body)
+		assert.Equal(t, fiber.StatusBadRequest, status, "%v: %s", body, env.Detail)
+	}
+	for _, body := range []map[string]interface{}{
+		{"nama": nil},
+		{"email": "bukan-email"},
+		{"angkatan": 2030},
+	} {
+		status, _, env = callWithHeader(t, app, "PATCH", path, admin, mergePatch, body)
+		assert.Equal(t, fiber.StatusUnprocessableEntity, status, "%v: %s", body, env.Detail)
+	}
+
+	status, _, _ = callWithHeader(t, app, "PATCH", path, admin, map[string]string{"Content-Type": "text/plain", "If-Match": "*"}, map[string]interface{}{})
+	assert.Equal(t, fiber.StatusUnsupportedMediaType, status)
+	status, _, _ = callWithHeader(t, app, "PATCH", path, admin, map[string]string{"Content-Type": "application/merge-patch+json", "If-Match": `"1"`},
+		map[string]interface{}{"nama": "Budi"})
+	assert.Equal(t, fiber.StatusPreconditionFailed, status)
+}
+
+func TestV1PatchPekerjaanDanUser(t *testing.T) {
+	app := newV1App(t)
+	admin := login(t, app, memory.DemoAdminUsername, memory.DemoAdminPassword)
+	mergePatch := map[string]string{"Content-Type": "application/merge-patch+json", "If-Match": "*"}
+
+	_, env := call(t, app, "GET", "/api/v1/pekerjaan?search=Bank", admin, nil)
+	var list []struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(env.Data, &list))
+	require.Len(t, list, 1)
+	path := "/api/v1/pekerjaan/" + list[0].ID
+
+	status, _, env := callWithHeader(t, app, "PATCH", path, admin, mergePatch,
+		map[string]interface{}{"tanggal_selesai_kerja": "2024-12-31", "gaji_range": "12-15 juta"})
+	require.Equal(t, fiber.StatusOK, status, env.Detail)
+	var p map[string]interface{}
+	require.NoError(t, json.Unmarshal(env.Data, &p))
+	assert.Equal(t, "Bank Sejahtera", p["nama_perusahaan"])
+	assert.Contains(t, p["tanggal_selesai_kerja"], "2024-12-31")
+	assert.EqualValues(t, 12000000, p["gaji_min"], "field gaji terstruktur dihitung ulang dari gaji_range")
+
+	status, _, env = callWithHeader(t, app, "PATCH", path, admin, mergePatch, map[string]interface{}{"tanggal_selesai_kerja": nil})
+	require.Equal(t, fiber.StatusOK, status, env.Detail)
+	p = nil
+	require.NoError(t, json.Unmarshal(env.Data, &p))
+	assert.Nil(t, p["tanggal_selesai_kerja"])
+
+	status, _, _ = callWithHeader(t, app, "PATCH", path, admin, mergePatch, map[string]interface{}{"tanggal_mulai_kerja": "01-02-2023"})
+	assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+
+	_, env = call(t, app, "GET", "/api/v1/users?search="+memory.DemoUserUsername, admin, nil)
+	var users []struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(env.Data, &users))
+	require.NotEmpty(t, users)
+	userPath := "/api/v1/users/" + users[0].ID
+	header := map[string]string{"Content-Type": "application/merge-patch+json"}
+
+	status, _, _ = callWithHeader(t, app, "PATCH", userPath, admin, header, map[string]interface{}{"role": "superadmin"})
+	assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+	status, _, env = callWithHeader(t, app, "PATCH", userPath, admin, header, map[string]interface{}{"email": "baru@demo.local"})
+	require.Equal(t, fiber.StatusOK, status, env.Detail)
+	assert.Contains(t, string(env.Data), "baru@demo.local")
+}
+
+func TestV1LogLevelRuntime(t *testing.T) {
+	app := newV1App(t)
+	admin := login(t, app, memory.DemoAdminUsername, memory.DemoAdminPassword)
+	user := login(t, app, memory.DemoUserUsername, memory.DemoUserPassword)
+	t.Cleanup(func() { logging.SetLevel("info") })
+
+	status, _ := call(t, app, "PUT", "/api/v1/admin/log-level", user, map[string]string{"level": "debug"})
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	status, env := call(t, app, "PUT", "/api/v1/admin/log-level", admin, map[string]string{"level": "verbose"})
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Equal(t, "bad_request", env.Code)
+
+	status, _ = call(t, app, "PUT", "/api/v1/admin/log-level", admin, map[string]string{"level": "debug"})
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "DEBUG", logging.Level())
+
+	status, env = call(t, app, "GET", "/api/v1/admin/log-level", admin, nil)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.JSONEq(t, `{"level":"DEBUG"}`, string(env.Data))
+}
+
+func TestV1AuditLog(t *testing.T) {
+	app := newV1AuditApp(t, audit.New(audit.NewMemoryStore()))
+	admin := login(t, app, memory.DemoAdminUsername, memory.DemoAdminPassword)
+	user := login(t, app, memory.DemoUserUsername, memory.DemoUserPassword)
+
+	_, env := call(t, app, "GET", "/api/v1/alumni?sortBy=nim", admin, nil)
+	var list []struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(env.Data, &list))
+	body := map[string]interface{}{
+		"nim": "2019001", "nama": "Nama Baru", "jurusan": "Teknik Informatika", "angkatan": 2019,
+		"tahun_lulus": 2023, "email": "baru@demo.local",
+	}
+	status, _, _ := callWithHeader(t, app, "PUT", "/api/v1/alumni/"+list[0].ID, admin,
+		map[string]string{"If-Match": "*", middleware.HeaderRequestID: "req-audit-1"}, body)
+	require.Equal(t, fiber.StatusOK, status)
+	status, _ = call(t, app, "DELETE", "/api/v1/alumni/"+list[1].ID, admin, nil)
+	require.Equal(t, fiber.StatusOK, status)
+
+	status, _ = call(t, app, "GET", "/api/v1/admin/audit", user, nil)
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	status, env = call(t, app, "GET", "/api/v1/admin/audit?entity=alumni&action=update", admin, nil)
+	require.Equal(t, fiber.StatusOK, status)
+	var entries []audit.Entry
+	require.NoError(t, json.Unmarshal(env.Data, &entries))
+	require.Len(t, entries, 1)
+	e := entries[0]
+	assert.Equal(t, list[0].ID, e.EntityID)
+	assert.Equal(t, memory.DemoAdminUsername, e.Actor.Username)
+	assert.Equal(t, "admin", e.Actor.Role)
+	assert.NotEmpty(t, e.Actor.ID)
+	assert.Equal(t, "req-audit-1", e.RequestID)
+	assert.Equal(t, audit.BackendMemory, e.Backend)
+	assert.Contains(t, string(e.Changes), `"nama":{"before":`)
+	assert.Contains(t, string(e.Changes), `"after":"Nama Baru"`)
+
+	status, env = call(t, app, "GET", "/api/v1/admin/audit?limit=1", admin, nil)
+	require.Equal(t, fiber.StatusOK, status)
+	require.NoError(t, json.Unmarshal(env.Data, &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, audit.ActionDelete, entries[0].Action)
+	assert.Equal(t, list[1].ID, entries[0].EntityID)
+
+	status, env = call(t, app, "GET", "/api/v1/admin/audit?from=kemarin", admin, nil)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+
+	status, env = call(t, app, "GET", "/api/v1/admin/audit/verify", admin, nil)
+	require.Equal(t, fiber.StatusOK, status)
+	var report audit.VerifyReport
+	require.NoError(t, json.Unmarshal(env.Data, &report))
+	assert.True(t, report.Valid)
+	assert.Equal(t, 2, report.Checked)
+}
+
+// upload mengirim multipart POST /api/v1/files/upload berisi satu PDF kecil.
+func upload(t *testing.T, app *fiber.App, token, targetUserID string) (int, envelope) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", `form-data; name="file"; filename="cv.pdf"`)
+	h.Set("Content-Type", "application/pdf")
+	part, err := w.CreatePart(h)
+	require.NoError(t, err)
+	_, err = part.Write([]byte("%PDF-1.4 isi"))
+	require.NoError(t, err)
+	if targetUserID != "" {
+		require.NoError(t, w.WriteField("target_user_id", targetUserID))
+	}
+	require.NoError(t, w.Close())
+
+	req := httptest.NewRequest("POST", "/api/v1/files/upload", &buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	var env envelope
+	_ = json.NewDecoder(resp.Body).Decode(&env)
+	return resp.StatusCode, env
+}
+
+func TestV1Files(t *testing.T) {
+	uploadPath := mongoService.UploadPath
+	mongoService.UploadPath = t.TempDir()
+	t.Cleanup(func() { mongoService.UploadPath = uploadPath })
+	app := newV1App(t)
+	admin := login(t, app, memory.DemoAdminUsername, memory.DemoAdminPassword)
+	user := login(t, app, memory.DemoUserUsername, memory.DemoUserPassword)
+
+	var profile struct {
+		UserID string `json:"user_id"`
+	}
+	_, env := call(t, app, "GET", "/api/v1/profile", user, nil)
+	require.NoError(t, json.Unmarshal(env.Data, &profile))
+
+	status, _ := upload(t, app, admin, "")
+	assert.Equal(t, fiber.StatusBadRequest, status, "admin wajib mengisi target_user_id")
+	status, env = upload(t, app, admin, "000000000000000000000000")
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Equal(t, "target_user_id tidak ditemukan", env.Detail)
+	status, _ = upload(t, app, user, profile.UserID)
+	assert.Equal(t, fiber.StatusForbidden, status, "user tidak boleh menentukan pemilik")
+
+	status, env = upload(t, app, admin, profile.UserID)
+	require.Equal(t, fiber.StatusCreated, status, env.Detail)
+	var milikUser struct {
+		ID       string `json:"id"`
+		OwnerID  string `json:"owner_id"`
+		FileSize int64  `json:"file_size"`
+	}
+	require.NoError(t, json.Unmarshal(env.Data, &milikUser))
+	assert.Equal(t, profile.UserID, milikUser.OwnerID)
+	assert.Equal(t, int64(12), milikUser.FileSize)
+
+	status, env = upload(t, app, user, "")
+	require.Equal(t, fiber.StatusCreated, status, env.Detail)
+
+	_, env = call(t, app, "GET", "/api/v1/files", admin, nil)
+	var list []struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(env.Data, &list))
+	assert.Len(t, list, 2)
+
+	status, env = call(t, app, "GET", "/api/v1/files?pagination=cursor&limit=1&with_total=true", admin, nil)
+	require.Equal(t, fiber.StatusOK, status, env.Detail)
+	assert.Equal(t, 2, env.Meta.Total)
+
+	status, _ = call(t, app, "GET", "/api/v1/files/"+milikUser.ID, user, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	status, _ = call(t, app, "GET", "/api/v1/files/123", admin, nil)
+	assert.Equal(t, fiber.StatusNotFound, status, "ID Postgres pada driver memory dianggap tidak ada")
+
+	// File milik user lain tidak bisa dibuka atau dihapus oleh role user.
+	_, env = call(t, app, "GET", "/api/v1/profile", admin, nil)
+	require.NoError(t, json.Unmarshal(env.Data, &profile))
+	status, env = upload(t, app, admin, profile.UserID)
+	require.Equal(t, fiber.StatusCreated, status, env.Detail)
+	var milikAdmin struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(env.Data, &milikAdmin))
+	status, _ = call(t, app, "GET", "/api/v1/files/open/"+milikAdmin.ID, user, nil)
+	assert.Equal(t, fiber.StatusForbidden, status)
+	status, _ = call(t, app, "DELETE", "/api/v1/files/"+milikAdmin.ID, user, nil)
+	assert.Equal(t, fiber.StatusForbidden, status)
+
+	req := httptest.NewRequest("GET", "/api/v1/files/open/"+milikUser.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+user)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+
+	status, _ = call(t, app, "DELETE", "/api/v1/files/"+milikUser.ID, user, nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	status, _ = call(t, app, "GET", "/api/v1/files/"+milikUser.ID, admin, nil)
+	assert.Equal(t, fiber.StatusNotFound, status)
+}
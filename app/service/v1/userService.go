@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"latihan2/app/model"
+	v1Model "latihan2/app/model/v1"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/response"
+	"latihan2/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UserHandler menangani endpoint /api/v1/users.
+type UserHandler struct {
+	repo v1Repo.UserRepository
+}
+
+func NewUserHandler(repo v1Repo.UserRepository) *UserHandler {
+	return &UserHandler{repo: repo}
+}
+
+var usersSortable = map[string]bool{
+	"id":         true,
+	"username":   true,
+	"email":      true,
+	"role":       true,
+	"created_at": true,
+}
+
+var usersCursorSortable = map[string]bool{
+	"id":         true,
+	"username":   true,
+	"email":      true,
+	"created_at": true,
+}
+
+// GetUsers godoc
+// @Summary      Daftar user
+// @Description  Pagination page/limit atau cursor, sorting, search dan filter
+// @Tags         v1
+// @Produce      json
+// @Param        page       query  int     false  "Halaman"
+// @Param        limit      query  int     false  "Jumlah data per halaman (maks 100)"
+// @Param        sortBy     query  string  false  "id, username, email, role, created_at"
+// @Param        order      query  string  false  "asc/desc"
+// @Param        search     query  string  false  "Kata kunci username atau email"
+// @Param        filter     query  string  false  "Filter field:operator:nilai dipisah koma"
+// @Param        pagination query  string  false  "Isi 'cursor' untuk pagination keyset"
+// @Param        cursor     query  string  false  "next_cursor atau prev_cursor dari response sebelumnya"
+// @Param        with_total query  bool    false  "Hitung total data pada mode cursor"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Router       /api/v1/users [get]
+// @Security     BearerAuth
+func (h *UserHandler) GetUsers(c *fiber.Ctx) error {
+	p := parseList(c, "id", usersSortable)
+	filter, err := utils.ParseFilter(p.filter, model.UserFilterFields)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	q, a := v1Repo.ListQuery{Search: p.search, Filter: filter}, actor(c)
+	repo := h.repo.WithContext(c.UserContext())
+
+	return respondList(c, p, listSource[v1Model.User]{
+		offset:         func(op v1Repo.OffsetPage) ([]v1Model.User, error) { return repo.GetUsers(q, op, a) },
+		cursor:         func(cp model.CursorPage) ([]v1Model.User, error) { return repo.GetUsersCursor(q, cp, a) },
+		count:          func() (int, error) { return repo.CountUsers(q) },
+		cursorSortable: usersCursorSortable,
+	})
+}
+
+// GetUserByID godoc
+// @Summary      Detail user
+// @Tags         v1
+// @Produce      json
+// @Param        id  path  string  true  "ID user"
+// @Success      200 {object} response.Envelope
+// @Failure      404 {object} response.Problem
+// @Router       /api/v1/users/{id} [get]
+// @Security     BearerAuth
+func (h *UserHandler) GetUserByID(c *fiber.Ctx) error {
+	user, err := h.repo.WithContext(c.UserContext()).GetUserByID(c.Params("id"), actor(c))
+	if err != nil {
+		return failRepo(c, err, "User tidak ditemukan")
+	}
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    user,
+		Message: "Data user berhasil diambil",
+	})
+}
+
+// PatchUser godoc
+// @Summary      Ubah sebagian data user
+// @Description  Hanya admin. JSON Merge Patch (RFC 7396) untuk username, email dan role. User tidak punya version sehingga tidak memakai If-Match.
+// @Tags         v1
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        id    path  string                   true  "ID user"
+// @Param        body  body  model.UpdateUserRequest  true  "Field user yang diubah"
+// @Success      200 {object} response.Envelope
+// @Failure      400 {object} response.Problem
+// @Failure      422 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      409 {object} response.Problem
+// @Failure      415 {object} response.Problem
+// @Router       /api/v1/users/{id} [patch]
+// @Security     BearerAuth
+func (h *UserHandler) PatchUser(c *fiber.Ctx) error {
+	if !isMergePatch(c.Get(fiber.HeaderContentType)) {
+		return response.Fail(c, fiber.StatusUnsupportedMediaType, "Content-Type harus "+utils.MergePatchContentType)
+	}
+	current, err := h.repo.WithContext(c.UserContext()).GetUserByID(c.Params("id"), actor(c))
+	if err != nil {
+		return failRepo(c, err, "User tidak ditemukan")
+	}
+
+	var req model.UpdateUserRequest
+	supplied, err := utils.ApplyMergePatch(userUpdateRequest(*current), c.Body(), &req)
+	if err != nil {
+		return response.Fail(c, fiber.StatusBadRequest, err.Error())
+	}
+	if err := validatePatch(req, supplied); err != nil {
+		return response.Error(c, err)
+	}
+
+	user, err := h.repo.WithContext(c.UserContext()).UpdateUser(current.ID, req)
+	if err != nil {
+		return failRepo(c, err, "User tidak ditemukan")
+	}
+	return response.Send(c, fiber.StatusOK, response.Envelope{
+		Data:    user,
+		Message: "User berhasil diupdate",
+	})
+}
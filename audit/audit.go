@@ -0,0 +1,197 @@
+// Package audit mencatat setiap perubahan data (alumni, pekerjaan, user dan
+// file) ke log yang hanya bisa ditambah. Setiap entri menyimpan hash entri
+// sebelumnya sehingga entri yang diubah, dihapus atau disisipkan belakangan
+// terdeteksi oleh Verify.
+//
+// Entri ditulis oleh pembungkus repository (lihat PostgresAlumni, MongoAlumni
+// dan seterusnya) sehingga semua jalur penulisan, baik /api/v1, /api/pg,
+// /api/mg maupun CLI, tercatat tanpa mengubah handler. Siapa pelakunya
+// dibaca dari context (lihat WithActor dan WithRequest).
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Aksi yang dicatat.
+const (
+	ActionCreate         = "create"
+	ActionUpdate         = "update"
+	ActionDelete         = "delete"
+	ActionRestore        = "restore"
+	ActionHardDelete     = "hard_delete"
+	ActionPasswordChange = "password_change"
+	ActionMigrate        = "migrate"
+)
+
+// Entitas yang dicatat.
+const (
+	EntityAlumni    = "alumni"
+	EntityPekerjaan = "pekerjaan"
+	EntityUser      = "user"
+	EntityFile      = "file"
+)
+
+// Backend tempat perubahan terjadi.
+const (
+	BackendPostgres = "postgres"
+	BackendMongo    = "mongo"
+	BackendMemory   = "memory"
+)
+
+// Actor adalah pelaku perubahan. ID kosong berarti perubahan tidak berasal
+// dari user yang login, misalnya dari CLI ("cli:<user OS>") atau proses
+// internal ("system").
+type Actor struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// System adalah pelaku jika context tidak membawa Actor.
+var System = Actor{Username: "system"}
+
+// Change adalah nilai satu field sebelum dan sesudah perubahan dalam JSON.
+// Before kosong pada create, After kosong pada delete.
+type Change struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// Entry adalah satu baris audit log. Seq dimulai dari 1 dan selalu naik satu;
+// Hash adalah sha256 dari PrevHash dan isi entri (lihat computeHash).
+type Entry struct {
+	Seq      int64     `json:"seq"`
+	Time     time.Time `json:"time"`
+	Actor    Actor     `json:"actor"`
+	Action   string    `json:"action"`
+	Entity   string    `json:"entity"`
+	EntityID string    `json:"entity_id"`
+	Backend  string    `json:"backend"`
+	// Changes adalah map nama field ke Change, disimpan apa adanya agar
+	// hash tetap sama setelah dibaca ulang dari database.
+	Changes   json.RawMessage `json:"changes,omitempty" swaggertype:"object"`
+	RequestID string          `json:"request_id,omitempty"`
+	IP        string          `json:"ip,omitempty"`
+	PrevHash  string          `json:"prev_hash"`
+	Hash      string          `json:"hash"`
+}
+
+// seal menyambung e ke entri terakhir rantai (seq dan hash prev; 0 dan ""
+// untuk entri pertama).
+func (e *Entry) seal(prevSeq int64, prevHash string) {
+	e.Seq = prevSeq + 1
+	e.PrevHash = prevHash
+	e.Hash = e.computeHash()
+}
+
+func (e *Entry) computeHash() string {
+	changes := e.Changes
+	if len(changes) == 0 {
+		changes = nil
+	}
+	// Urutan field tetap karena memakai struct; waktu ditulis dalam UTC agar
+	// zona waktu driver database tidak mengubah hash.
+	data, _ := json.Marshal(struct {
+		Seq       int64           `json:"seq"`
+		Time      string          `json:"time"`
+		Actor     Actor           `json:"actor"`
+		Action    string          `json:"action"`
+		Entity    string          `json:"entity"`
+		EntityID  string          `json:"entity_id"`
+		Backend   string          `json:"backend"`
+		Changes   json.RawMessage `json:"changes"`
+		RequestID string          `json:"request_id"`
+		IP        string          `json:"ip"`
+	}{e.Seq, e.Time.UTC().Format(time.RFC3339Nano), e.Actor, e.Action, e.Entity, e.EntityID,
+		e.Backend, changes, e.RequestID, e.IP})
+	sum := sha256.Sum256(append([]byte(e.PrevHash), data...))
+	return hex.EncodeToString(sum[:])
+}
+
+// Diff membandingkan representasi JSON before dan after per field dan
+// mengembalikan field yang berbeda. before atau after nil berarti data baru
+// dibuat atau dihapus. Field dengan tag json:"-" (misalnya password) tidak
+// pernah ikut.
+func Diff(before, after any) (json.RawMessage, error) {
+	b, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+	changes := map[string]Change{}
+	for k, v := range b {
+		if !bytes.Equal(v, a[k]) {
+			changes[k] = Change{Before: v, After: a[k]}
+		}
+	}
+	for k, v := range a {
+		if _, ok := b[k]; !ok {
+			changes[k] = Change{After: v}
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	// Key map diurutkan encoding/json sehingga hasilnya selalu sama.
+	return json.Marshal(changes)
+}
+
+func fields(v any) (map[string]json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for k, raw := range m {
+		if string(raw) == "null" {
+			delete(m, k)
+		}
+	}
+	return m, nil
+}
+
+type actorKey struct{}
+
+type requestKey struct{}
+
+type requestInfo struct {
+	id, ip string
+}
+
+// WithActor menyimpan pelaku perubahan di ctx.
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFrom mengembalikan pelaku di ctx atau System.
+func ActorFrom(ctx context.Context) Actor {
+	if a, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return a
+	}
+	return System
+}
+
+// WithRequest menyimpan ID dan IP request yang menyebabkan perubahan.
+func WithRequest(ctx context.Context, requestID, ip string) context.Context {
+	return context.WithValue(ctx, requestKey{}, requestInfo{id: requestID, ip: ip})
+}
+
+func requestFrom(ctx context.Context) requestInfo {
+	r, _ := ctx.Value(requestKey{}).(requestInfo)
+	return r
+}
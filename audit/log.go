@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+	"latihan2/logging"
+	"latihan2/metrics"
+	"strings"
+	"time"
+)
+
+// Event adalah satu perubahan yang akan dicatat. Before dan After adalah
+// data sebelum dan sesudah perubahan dalam bentuk model repository.
+type Event struct {
+	Action   string
+	Entity   string
+	EntityID string
+	Backend  string
+	Before   any
+	After    any
+}
+
+// Log menulis Event ke Store. Log nil tidak mencatat apa pun, sehingga
+// pembungkus repository bisa dipasang tanpa memeriksa apakah audit aktif.
+type Log struct {
+	store Store
+	// Now bisa diganti di test. Waktu dibulatkan ke milidetik, presisi
+	// terkecil yang disimpan semua Store.
+	Now func() time.Time
+}
+
+func New(store Store) *Log {
+	return &Log{store: store, Now: time.Now}
+}
+
+// Store mengembalikan store tempat entri disimpan, dipakai API query.
+func (l *Log) Store() Store {
+	return l.store
+}
+
+// Record mencatat ev dengan pelaku dan request dari ctx. Perubahan datanya
+// sudah terjadi saat Record dipanggil, jadi kegagalan menulis audit log tidak
+// membatalkan request: error dicatat di log aplikasi dan metrik
+// audit_entries_total{result="error"} agar bisa dipasangi alert.
+func (l *Log) Record(ctx context.Context, ev Event) {
+	if l == nil {
+		return
+	}
+	err := l.record(ctx, ev)
+	metrics.ObserveAudit(err)
+	if err != nil {
+		logging.FromContext(ctx).Error("gagal menulis audit log", "action", ev.Action,
+			"entity", ev.Entity, "entity_id", ev.EntityID, "error", err)
+	}
+}
+
+func (l *Log) record(ctx context.Context, ev Event) error {
+	changes, err := Diff(ev.Before, ev.After)
+	if err != nil {
+		return err
+	}
+	req := requestFrom(ctx)
+	actor := ActorFrom(ctx)
+	// String dari Fiber (parameter path, header, IP) menunjuk ke buffer yang
+	// dipakai ulang setelah request selesai, jadi disalin sebelum disimpan.
+	e := &Entry{
+		Time:      l.Now().UTC().Truncate(time.Millisecond),
+		Actor:     Actor{ID: strings.Clone(actor.ID), Username: strings.Clone(actor.Username), Role: strings.Clone(actor.Role)},
+		Action:    ev.Action,
+		Entity:    ev.Entity,
+		EntityID:  strings.Clone(ev.EntityID),
+		Backend:   ev.Backend,
+		Changes:   changes,
+		RequestID: strings.Clone(req.id),
+		IP:        strings.Clone(req.ip),
+	}
+	// Request yang sudah melewati batas waktu tetap harus tercatat.
+	return l.store.Append(context.WithoutCancel(ctx), e)
+}
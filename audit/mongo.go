@@ -0,0 +1,140 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CollectionName adalah koleksi audit log di MongoDB.
+const CollectionName = "audit_log"
+
+// appendRetries adalah jumlah percobaan Append jika instance lain menulis
+// Seq yang sama lebih dulu.
+const appendRetries = 5
+
+// MongoStore menyimpan entri di koleksi audit_log. Index unik seq (migrasi
+// Mongo 6) membuat Append dari instance lain yang bersamaan gagal lalu
+// diulang dengan entri terakhir yang baru, sehingga rantai tidak bercabang.
+type MongoStore struct {
+	coll *mongo.Collection
+	// mu menghindari tabrakan antar-Append di instance yang sama.
+	mu sync.Mutex
+}
+
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{coll: db.Collection(CollectionName)}
+}
+
+// mongoEntry adalah bentuk dokumen Entry. Changes disimpan sebagai string
+// agar byte-nya sama persis dengan saat hash dihitung.
+type mongoEntry struct {
+	Seq       int64     `bson:"seq"`
+	Time      time.Time `bson:"time"`
+	ActorID   string    `bson:"actor_id"`
+	Actor     string    `bson:"actor_username"`
+	Role      string    `bson:"actor_role"`
+	Action    string    `bson:"action"`
+	Entity    string    `bson:"entity"`
+	EntityID  string    `bson:"entity_id"`
+	Backend   string    `bson:"backend"`
+	Changes   string    `bson:"changes,omitempty"`
+	RequestID string    `bson:"request_id"`
+	IP        string    `bson:"ip"`
+	PrevHash  string    `bson:"prev_hash"`
+	Hash      string    `bson:"hash"`
+}
+
+func (s *MongoStore) Append(ctx context.Context, e *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for attempt := 0; attempt < appendRetries; attempt++ {
+		var last mongoEntry
+		err := s.coll.FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}})).Decode(&last)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+		e.seal(last.Seq, last.Hash)
+
+		_, err = s.coll.InsertOne(ctx, mongoEntry{
+			Seq: e.Seq, Time: e.Time, ActorID: e.Actor.ID, Actor: e.Actor.Username, Role: e.Actor.Role,
+			Action: e.Action, Entity: e.Entity, EntityID: e.EntityID, Backend: e.Backend,
+			Changes: string(e.Changes), RequestID: e.RequestID, IP: e.IP, PrevHash: e.PrevHash, Hash: e.Hash,
+		})
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("audit log: seq terus bertabrakan setelah %d percobaan", appendRetries)
+}
+
+func (s *MongoStore) Query(ctx context.Context, f Filter) ([]Entry, error) {
+	filter := bson.M{}
+	for key, value := range map[string]string{
+		"actor_id":       f.ActorID,
+		"actor_username": f.Actor,
+		"action":         f.Action,
+		"entity":         f.Entity,
+		"entity_id":      f.EntityID,
+		"request_id":     f.RequestID,
+	} {
+		if value != "" {
+			filter[key] = value
+		}
+	}
+	timeRange := bson.M{}
+	if !f.From.IsZero() {
+		timeRange["$gte"] = f.From
+	}
+	if !f.To.IsZero() {
+		timeRange["$lt"] = f.To
+	}
+	if len(timeRange) > 0 {
+		filter["time"] = timeRange
+	}
+	seqRange := bson.M{}
+	if f.BeforeSeq > 0 {
+		seqRange["$lt"] = f.BeforeSeq
+	}
+	if f.AfterSeq > 0 {
+		seqRange["$gt"] = f.AfterSeq
+	}
+	if len(seqRange) > 0 {
+		filter["seq"] = seqRange
+	}
+
+	order := -1
+	if f.Ascending {
+		order = 1
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: order}}).SetLimit(int64(f.limit()))
+	cursor, err := s.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []mongoEntry
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(docs))
+	for _, d := range docs {
+		e := Entry{
+			Seq: d.Seq, Time: d.Time.UTC(), Actor: Actor{ID: d.ActorID, Username: d.Actor, Role: d.Role},
+			Action: d.Action, Entity: d.Entity, EntityID: d.EntityID, Backend: d.Backend,
+			RequestID: d.RequestID, IP: d.IP, PrevHash: d.PrevHash, Hash: d.Hash,
+		}
+		if d.Changes != "" {
+			e.Changes = []byte(d.Changes)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// appendLockKey membuat Append dari semua instance server berjalan satu per
+// satu sehingga rantai tidak bercabang.
+const appendLockKey = 72070050
+
+// PostgresStore menyimpan entri di tabel audit_log (migrasi 0008). Trigger di
+// tabel itu menolak UPDATE, DELETE dan TRUNCATE.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+const auditColumns = `seq, time, actor_id, actor_username, actor_role, action, entity, entity_id,
+	backend, COALESCE(changes, ''), request_id, ip, prev_hash, hash`
+
+func (s *PostgresStore) Append(ctx context.Context, e *Entry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, appendLockKey); err != nil {
+		return err
+	}
+	var prevSeq int64
+	var prevHash string
+	err = tx.QueryRowContext(ctx, `SELECT seq, hash FROM audit_log ORDER BY seq DESC LIMIT 1`).Scan(&prevSeq, &prevHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	e.seal(prevSeq, prevHash)
+
+	var changes sql.NullString
+	if len(e.Changes) > 0 {
+		changes = sql.NullString{String: string(e.Changes), Valid: true}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO audit_log (seq, time, actor_id, actor_username, actor_role, action, entity, entity_id,
+			backend, changes, request_id, ip, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		e.Seq, e.Time, e.Actor.ID, e.Actor.Username, e.Actor.Role, e.Action, e.Entity, e.EntityID,
+		e.Backend, changes, e.RequestID, e.IP, e.PrevHash, e.Hash); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *PostgresStore) Query(ctx context.Context, f Filter) ([]Entry, error) {
+	var where []string
+	var args []interface{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	for _, c := range []struct {
+		column, value string
+	}{
+		{"actor_id", f.ActorID},
+		{"actor_username", f.Actor},
+		{"action", f.Action},
+		{"entity", f.Entity},
+		{"entity_id", f.EntityID},
+		{"request_id", f.RequestID},
+	} {
+		if c.value != "" {
+			add(c.column+" = $%d", c.value)
+		}
+	}
+	if !f.From.IsZero() {
+		add("time >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("time < $%d", f.To)
+	}
+	if f.BeforeSeq > 0 {
+		add("seq < $%d", f.BeforeSeq)
+	}
+	if f.AfterSeq > 0 {
+		add("seq > $%d", f.AfterSeq)
+	}
+
+	query := "SELECT " + auditColumns + " FROM audit_log"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	order := "DESC"
+	if f.Ascending {
+		order = "ASC"
+	}
+	args = append(args, f.limit())
+	query += fmt.Sprintf(" ORDER BY seq %s LIMIT $%d", order, len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+	for rows.Next() {
+		var e Entry
+		var changes string
+		if err := rows.Scan(&e.Seq, &e.Time, &e.Actor.ID, &e.Actor.Username, &e.Actor.Role, &e.Action,
+			&e.Entity, &e.EntityID, &e.Backend, &changes, &e.RequestID, &e.IP, &e.PrevHash, &e.Hash); err != nil {
+			return nil, err
+		}
+		if changes != "" {
+			e.Changes = []byte(changes)
+		}
+		e.Time = e.Time.UTC()
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
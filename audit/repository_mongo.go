@@ -0,0 +1,221 @@
+package audit
+
+import (
+	"context"
+	appModel "latihan2/app/model"
+	"latihan2/app/model/mongo"
+	mongoRepo "latihan2/app/repository/mongo"
+)
+
+// MongoAlumni, MongoPekerjaan, MongoUsers dan MongoFiles membungkus
+// repository dengan interface Mongo (dipakai juga oleh repository memori)
+// agar setiap perubahan yang berhasil dicatat ke l. backend adalah
+// BackendMongo atau BackendMemory. l nil mengembalikan repo apa adanya.
+func MongoAlumni(l *Log, backend string, repo mongoRepo.AlumniRepository) mongoRepo.AlumniRepository {
+	if l == nil {
+		return repo
+	}
+	return &mgAlumni{AlumniRepository: repo, rec: recorder{log: l, backend: backend, entity: EntityAlumni, ctx: context.Background()}}
+}
+
+func MongoPekerjaan(l *Log, backend string, repo mongoRepo.PekerjaanRepository) mongoRepo.PekerjaanRepository {
+	if l == nil {
+		return repo
+	}
+	return &mgPekerjaan{PekerjaanRepository: repo, rec: recorder{log: l, backend: backend, entity: EntityPekerjaan, ctx: context.Background()}}
+}
+
+func MongoUsers(l *Log, backend string, repo mongoRepo.UserRepository) mongoRepo.UserRepository {
+	if l == nil {
+		return repo
+	}
+	return &mgUsers{UserRepository: repo, rec: recorder{log: l, backend: backend, entity: EntityUser, ctx: context.Background()}}
+}
+
+func MongoFiles(l *Log, backend string, repo mongoRepo.FileRepository) mongoRepo.FileRepository {
+	if l == nil {
+		return repo
+	}
+	return &mgFiles{FileRepository: repo, rec: recorder{log: l, backend: backend, entity: EntityFile, ctx: context.Background()}}
+}
+
+// recorder menyimpan bagian Event yang sama untuk satu pembungkus.
+type recorder struct {
+	log     *Log
+	backend string
+	entity  string
+	ctx     context.Context
+}
+
+func (r recorder) with(ctx context.Context) recorder {
+	r.ctx = ctx
+	return r
+}
+
+func (r recorder) record(action, id string, before, after any) {
+	r.log.Record(r.ctx, Event{Action: action, Entity: r.entity, EntityID: id,
+		Backend: r.backend, Before: before, After: after})
+}
+
+type mgAlumni struct {
+	mongoRepo.AlumniRepository
+	rec recorder
+}
+
+func (r *mgAlumni) WithContext(ctx context.Context) mongoRepo.AlumniRepository {
+	return &mgAlumni{AlumniRepository: r.AlumniRepository.WithContext(ctx), rec: r.rec.with(ctx)}
+}
+
+func (r *mgAlumni) CreateAlumni(alumni *mongo.Alumni) (*mongo.Alumni, error) {
+	a, err := r.AlumniRepository.CreateAlumni(alumni)
+	if err == nil {
+		r.rec.record(ActionCreate, a.ID.Hex(), nil, a)
+	}
+	return a, err
+}
+
+func (r *mgAlumni) UpdateAlumni(id string, version int, req appModel.UpdateAlumniRequest) (*mongo.Alumni, error) {
+	before, _ := r.AlumniRepository.GetAlumniByID(id)
+	a, err := r.AlumniRepository.UpdateAlumni(id, version, req)
+	if err == nil {
+		r.rec.record(ActionUpdate, id, before, a)
+	}
+	return a, err
+}
+
+func (r *mgAlumni) SoftDeleteAlumni(id string) error {
+	before, _ := r.AlumniRepository.GetAlumniByID(id)
+	err := r.AlumniRepository.SoftDeleteAlumni(id)
+	if err == nil {
+		r.rec.record(ActionDelete, id, before, nil)
+	}
+	return err
+}
+
+type mgPekerjaan struct {
+	mongoRepo.PekerjaanRepository
+	rec recorder
+}
+
+func (r *mgPekerjaan) WithContext(ctx context.Context) mongoRepo.PekerjaanRepository {
+	return &mgPekerjaan{PekerjaanRepository: r.PekerjaanRepository.WithContext(ctx), rec: r.rec.with(ctx)}
+}
+
+func (r *mgPekerjaan) CreatePekerjaan(p *mongo.Pekerjaan) (*mongo.Pekerjaan, error) {
+	created, err := r.PekerjaanRepository.CreatePekerjaan(p)
+	if err == nil {
+		r.rec.record(ActionCreate, created.ID.Hex(), nil, created)
+	}
+	return created, err
+}
+
+func (r *mgPekerjaan) UpdatePekerjaan(id string, version int, req mongo.UpdatePekerjaanRequest) (*mongo.Pekerjaan, error) {
+	before, _ := r.PekerjaanRepository.GetPekerjaanByIDRepo(id)
+	p, err := r.PekerjaanRepository.UpdatePekerjaan(id, version, req)
+	if err == nil {
+		r.rec.record(ActionUpdate, id, before, p)
+	}
+	return p, err
+}
+
+func (r *mgPekerjaan) SoftDeletePekerjaan(pekerjaanID, userID, role string) error {
+	before, _ := r.PekerjaanRepository.GetPekerjaanByIDRepo(pekerjaanID)
+	err := r.PekerjaanRepository.SoftDeletePekerjaan(pekerjaanID, userID, role)
+	if err == nil {
+		r.rec.record(ActionDelete, pekerjaanID, before, nil)
+	}
+	return err
+}
+
+func (r *mgPekerjaan) RestorePekerjaan(pekerjaanID, userID, role string) error {
+	err := r.PekerjaanRepository.RestorePekerjaan(pekerjaanID, userID, role)
+	if err == nil {
+		after, _ := r.PekerjaanRepository.GetPekerjaanByIDRepo(pekerjaanID)
+		r.rec.record(ActionRestore, pekerjaanID, nil, after)
+	}
+	return err
+}
+
+func (r *mgPekerjaan) HardDeletePekerjaan(pekerjaanID, userID, role string) error {
+	var before *mongo.Pekerjaan
+	if trash, err := r.PekerjaanRepository.GetTrashPekerjaan(pekerjaanID, "admin"); err == nil && len(trash) > 0 {
+		before = &trash[0]
+	}
+	err := r.PekerjaanRepository.HardDeletePekerjaan(pekerjaanID, userID, role)
+	if err == nil {
+		r.rec.record(ActionHardDelete, pekerjaanID, before, nil)
+	}
+	return err
+}
+
+// MigrateGajiRange dicatat sebagai satu entri berisi laporan migrasi, bukan
+// satu entri per dokumen. Dry run tidak mengubah data sehingga tidak dicatat.
+func (r *mgPekerjaan) MigrateGajiRange(dryRun bool) (*appModel.GajiMigrationReport, error) {
+	report, err := r.PekerjaanRepository.MigrateGajiRange(dryRun)
+	if err == nil && !dryRun && report.Berhasil > 0 {
+		r.rec.record(ActionMigrate, "", nil, report)
+	}
+	return report, err
+}
+
+type mgUsers struct {
+	mongoRepo.UserRepository
+	rec recorder
+}
+
+func (r *mgUsers) WithContext(ctx context.Context) mongoRepo.UserRepository {
+	return &mgUsers{UserRepository: r.UserRepository.WithContext(ctx), rec: r.rec.with(ctx)}
+}
+
+func (r *mgUsers) UpdateUser(id string, req appModel.UpdateUserRequest) (*mongo.User, error) {
+	before, _ := r.UserRepository.GetUserByID(id)
+	u, err := r.UserRepository.UpdateUser(id, req)
+	if err == nil {
+		r.rec.record(ActionUpdate, id, before, u)
+	}
+	return u, err
+}
+
+func (r *mgUsers) CreateUser(user *mongo.User) (*mongo.User, error) {
+	u, err := r.UserRepository.CreateUser(user)
+	if err == nil {
+		r.rec.record(ActionCreate, u.ID.Hex(), nil, u)
+	}
+	return u, err
+}
+
+// UpdatePassword hanya mencatat bahwa password diganti; hash-nya tidak pernah
+// masuk audit log.
+func (r *mgUsers) UpdatePassword(id string, passwordHash string) error {
+	err := r.UserRepository.UpdatePassword(id, passwordHash)
+	if err == nil {
+		r.rec.record(ActionPasswordChange, id, nil, nil)
+	}
+	return err
+}
+
+type mgFiles struct {
+	mongoRepo.FileRepository
+	rec recorder
+}
+
+func (r *mgFiles) WithContext(ctx context.Context) mongoRepo.FileRepository {
+	return &mgFiles{FileRepository: r.FileRepository.WithContext(ctx), rec: r.rec.with(ctx)}
+}
+
+func (r *mgFiles) CreateFile(file *mongo.File) error {
+	err := r.FileRepository.CreateFile(file)
+	if err == nil {
+		r.rec.record(ActionCreate, file.ID.Hex(), nil, file)
+	}
+	return err
+}
+
+func (r *mgFiles) DeleteFile(id string) error {
+	before, _ := r.FileRepository.FindFileByID(id)
+	err := r.FileRepository.DeleteFile(id)
+	if err == nil {
+		r.rec.record(ActionHardDelete, id, before, nil)
+	}
+	return err
+}
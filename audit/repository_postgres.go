@@ -0,0 +1,221 @@
+package audit
+
+import (
+	"context"
+	"latihan2/app/model"
+	"latihan2/app/repository"
+	"strconv"
+)
+
+// PostgresAlumni, PostgresPekerjaan, PostgresUsers dan PostgresFiles membungkus repository
+// Postgres agar setiap perubahan yang berhasil dicatat ke l. l nil
+// mengembalikan repo apa adanya. Data sebelum perubahan dibaca lebih dulu
+// dengan query terpisah.
+func PostgresAlumni(l *Log, repo repository.AlumniRepository) repository.AlumniRepository {
+	if l == nil {
+		return repo
+	}
+	return &pgAlumni{AlumniRepository: repo, rec: recorder{log: l, backend: BackendPostgres, entity: EntityAlumni, ctx: context.Background()}}
+}
+
+func PostgresPekerjaan(l *Log, repo repository.PekerjaanRepository) repository.PekerjaanRepository {
+	if l == nil {
+		return repo
+	}
+	return &pgPekerjaan{PekerjaanRepository: repo, rec: recorder{log: l, backend: BackendPostgres, entity: EntityPekerjaan, ctx: context.Background()}}
+}
+
+func PostgresUsers(l *Log, repo repository.UserRepository) repository.UserRepository {
+	if l == nil {
+		return repo
+	}
+	return &pgUsers{UserRepository: repo, rec: recorder{log: l, backend: BackendPostgres, entity: EntityUser, ctx: context.Background()}}
+}
+
+func PostgresFiles(l *Log, repo repository.FileRepository) repository.FileRepository {
+	if l == nil {
+		return repo
+	}
+	return &pgFiles{FileRepository: repo, rec: recorder{log: l, backend: BackendPostgres, entity: EntityFile, ctx: context.Background()}}
+}
+
+type pgAlumni struct {
+	repository.AlumniRepository
+	rec recorder
+}
+
+func (r *pgAlumni) WithContext(ctx context.Context) repository.AlumniRepository {
+	return &pgAlumni{AlumniRepository: r.AlumniRepository.WithContext(ctx), rec: r.rec.with(ctx)}
+}
+
+func (r *pgAlumni) CreateAlumni(req model.CreateAlumniRequest) (*model.Alumni, error) {
+	a, err := r.AlumniRepository.CreateAlumni(req)
+	if err == nil {
+		r.rec.record(ActionCreate, a.ID, nil, a)
+	}
+	return a, err
+}
+
+func (r *pgAlumni) UpdateAlumni(id int, version int, req model.UpdateAlumniRequest) (*model.Alumni, error) {
+	before, _ := r.AlumniRepository.GetAlumniByID(id, "admin")
+	a, err := r.AlumniRepository.UpdateAlumni(id, version, req)
+	if err == nil {
+		r.rec.record(ActionUpdate, strconv.Itoa(id), before, a)
+	}
+	return a, err
+}
+
+func (r *pgAlumni) DeleteAlumni(id int) error {
+	before, _ := r.AlumniRepository.GetAlumniByID(id, "admin")
+	err := r.AlumniRepository.DeleteAlumni(id)
+	if err == nil {
+		r.rec.record(ActionHardDelete, strconv.Itoa(id), before, nil)
+	}
+	return err
+}
+
+func (r *pgAlumni) SoftDeleteAlumniRepo(id int) error {
+	before, _ := r.AlumniRepository.GetAlumniByID(id, "admin")
+	err := r.AlumniRepository.SoftDeleteAlumniRepo(id)
+	if err == nil {
+		r.rec.record(ActionDelete, strconv.Itoa(id), before, nil)
+	}
+	return err
+}
+
+type pgPekerjaan struct {
+	repository.PekerjaanRepository
+	rec recorder
+}
+
+func (r *pgPekerjaan) WithContext(ctx context.Context) repository.PekerjaanRepository {
+	return &pgPekerjaan{PekerjaanRepository: r.PekerjaanRepository.WithContext(ctx), rec: r.rec.with(ctx)}
+}
+
+func (r *pgPekerjaan) CreatePekerjaan(req model.CreatePekerjaanRequest) (*model.Pekerjaan, error) {
+	p, err := r.PekerjaanRepository.CreatePekerjaan(req)
+	if err == nil {
+		r.rec.record(ActionCreate, strconv.Itoa(p.ID), nil, p)
+	}
+	return p, err
+}
+
+func (r *pgPekerjaan) UpdatePekerjaan(id int, userID int, role string, version int, req model.UpdatePekerjaanRequest) (*model.Pekerjaan, error) {
+	before, _ := r.PekerjaanRepository.GetPekerjaanByIDRepo(id, userID, role)
+	p, err := r.PekerjaanRepository.UpdatePekerjaan(id, userID, role, version, req)
+	if err == nil {
+		r.rec.record(ActionUpdate, strconv.Itoa(id), before, p)
+	}
+	return p, err
+}
+
+func (r *pgPekerjaan) SoftDeletePekerjaan(pekerjaanID int, userID int, role string) error {
+	before, _ := r.PekerjaanRepository.GetPekerjaanByIDRepo(pekerjaanID, userID, role)
+	err := r.PekerjaanRepository.SoftDeletePekerjaan(pekerjaanID, userID, role)
+	if err == nil {
+		r.rec.record(ActionDelete, strconv.Itoa(pekerjaanID), before, nil)
+	}
+	return err
+}
+
+func (r *pgPekerjaan) RestorePekerjaan(pekerjaanID int, userID int, role string) error {
+	err := r.PekerjaanRepository.RestorePekerjaan(pekerjaanID, userID, role)
+	if err == nil {
+		after, _ := r.PekerjaanRepository.GetPekerjaanByIDRepo(pekerjaanID, userID, role)
+		r.rec.record(ActionRestore, strconv.Itoa(pekerjaanID), nil, after)
+	}
+	return err
+}
+
+func (r *pgPekerjaan) HardDeletePekerjaan(pekerjaanID int, userID int, role string) error {
+	var before *model.Pekerjaan
+	if trash, err := r.PekerjaanRepository.GetTrashPekerjaanByID(pekerjaanID, userID, role); err == nil {
+		before = &trash.Pekerjaan
+	}
+	err := r.PekerjaanRepository.HardDeletePekerjaan(pekerjaanID, userID, role)
+	if err == nil {
+		r.rec.record(ActionHardDelete, strconv.Itoa(pekerjaanID), before, nil)
+	}
+	return err
+}
+
+// MigrateGajiRange dicatat sebagai satu entri berisi laporan migrasi, bukan
+// satu entri per baris. Dry run tidak mengubah data sehingga tidak dicatat.
+func (r *pgPekerjaan) MigrateGajiRange(dryRun bool) (*model.GajiMigrationReport, error) {
+	report, err := r.PekerjaanRepository.MigrateGajiRange(dryRun)
+	if err == nil && !dryRun && report.Berhasil > 0 {
+		r.rec.record(ActionMigrate, "", nil, report)
+	}
+	return report, err
+}
+
+type pgUsers struct {
+	repository.UserRepository
+	rec recorder
+}
+
+func (r *pgUsers) WithContext(ctx context.Context) repository.UserRepository {
+	return &pgUsers{UserRepository: r.UserRepository.WithContext(ctx), rec: r.rec.with(ctx)}
+}
+
+func (r *pgUsers) SoftDeleteUserRepo(id int) error {
+	before, _ := r.UserRepository.GetUserByID(id, "admin")
+	err := r.UserRepository.SoftDeleteUserRepo(id)
+	if err == nil {
+		r.rec.record(ActionDelete, strconv.Itoa(id), before, nil)
+	}
+	return err
+}
+
+func (r *pgUsers) UpdateUser(id int, req model.UpdateUserRequest) (*model.User, error) {
+	before, _ := r.UserRepository.GetUserByID(id, "admin")
+	u, err := r.UserRepository.UpdateUser(id, req)
+	if err == nil {
+		r.rec.record(ActionUpdate, strconv.Itoa(id), before, u)
+	}
+	return u, err
+}
+
+func (r *pgUsers) CreateUser(user model.User, passwordHash string) (*model.User, error) {
+	u, err := r.UserRepository.CreateUser(user, passwordHash)
+	if err == nil {
+		r.rec.record(ActionCreate, strconv.Itoa(u.ID), nil, u)
+	}
+	return u, err
+}
+
+// UpdatePassword hanya mencatat bahwa password diganti; hash-nya tidak pernah
+// masuk audit log.
+func (r *pgUsers) UpdatePassword(id int, passwordHash string) error {
+	err := r.UserRepository.UpdatePassword(id, passwordHash)
+	if err == nil {
+		r.rec.record(ActionPasswordChange, strconv.Itoa(id), nil, nil)
+	}
+	return err
+}
+
+type pgFiles struct {
+	repository.FileRepository
+	rec recorder
+}
+
+func (r *pgFiles) WithContext(ctx context.Context) repository.FileRepository {
+	return &pgFiles{FileRepository: r.FileRepository.WithContext(ctx), rec: r.rec.with(ctx)}
+}
+
+func (r *pgFiles) CreateFile(file *model.File) error {
+	err := r.FileRepository.CreateFile(file)
+	if err == nil {
+		r.rec.record(ActionCreate, strconv.Itoa(file.ID), nil, file)
+	}
+	return err
+}
+
+func (r *pgFiles) DeleteFile(id int) error {
+	before, _ := r.FileRepository.FindFileByID(id)
+	err := r.FileRepository.DeleteFile(id)
+	if err == nil {
+		r.rec.record(ActionHardDelete, strconv.Itoa(id), before, nil)
+	}
+	return err
+}
@@ -0,0 +1,155 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store menyimpan rantai entri. Append harus atomik terhadap Append lain
+// (termasuk dari instance lain untuk store database): membaca entri terakhir,
+// memanggil seal lalu menyimpan, tanpa entri lain menyela.
+type Store interface {
+	Append(ctx context.Context, e *Entry) error
+	Query(ctx context.Context, f Filter) ([]Entry, error)
+}
+
+// Filter adalah kriteria Query. Field kosong tidak membatasi. Hasil diurutkan
+// dari Seq terbaru kecuali Ascending.
+type Filter struct {
+	ActorID   string
+	Actor     string // username
+	Action    string
+	Entity    string
+	EntityID  string
+	RequestID string
+	From, To  time.Time
+	// BeforeSeq dan AfterSeq adalah cursor: hanya entri dengan Seq lebih kecil
+	// atau lebih besar dari nilai ini.
+	BeforeSeq int64
+	AfterSeq  int64
+	Limit     int
+	Ascending bool
+}
+
+// DefaultLimit dipakai jika Filter.Limit tidak diisi.
+const DefaultLimit = 50
+
+func (f Filter) limit() int {
+	if f.Limit <= 0 {
+		return DefaultLimit
+	}
+	return f.Limit
+}
+
+func (f Filter) match(e *Entry) bool {
+	switch {
+	case f.ActorID != "" && e.Actor.ID != f.ActorID,
+		f.Actor != "" && e.Actor.Username != f.Actor,
+		f.Action != "" && e.Action != f.Action,
+		f.Entity != "" && e.Entity != f.Entity,
+		f.EntityID != "" && e.EntityID != f.EntityID,
+		f.RequestID != "" && e.RequestID != f.RequestID,
+		!f.From.IsZero() && e.Time.Before(f.From),
+		!f.To.IsZero() && !e.Time.Before(f.To),
+		f.BeforeSeq > 0 && e.Seq >= f.BeforeSeq,
+		f.AfterSeq > 0 && e.Seq <= f.AfterSeq:
+		return false
+	}
+	return true
+}
+
+// MemoryStore menyimpan entri di memori untuk mode demo dan test.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Append(_ context.Context, e *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var prevSeq int64
+	var prevHash string
+	if n := len(s.entries); n > 0 {
+		prevSeq, prevHash = s.entries[n-1].Seq, s.entries[n-1].Hash
+	}
+	e.seal(prevSeq, prevHash)
+	s.entries = append(s.entries, *e)
+	return nil
+}
+
+func (s *MemoryStore) Query(_ context.Context, f Filter) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := []Entry{}
+	for i := range s.entries {
+		if f.match(&s.entries[i]) {
+			result = append(result, s.entries[i])
+		}
+	}
+	if !f.Ascending {
+		sort.Slice(result, func(i, j int) bool { return result[i].Seq > result[j].Seq })
+	}
+	if len(result) > f.limit() {
+		result = result[:f.limit()]
+	}
+	return result, nil
+}
+
+// VerifyReport adalah hasil Verify. Jika rantai rusak, BrokenAt adalah Seq
+// entri pertama yang tidak cocok dan Reason menjelaskan sebabnya.
+type VerifyReport struct {
+	Valid    bool   `json:"valid"`
+	Checked  int    `json:"checked"`
+	LastSeq  int64  `json:"last_seq"`
+	LastHash string `json:"last_hash,omitempty"`
+	BrokenAt int64  `json:"broken_at,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// verifyPage adalah jumlah entri yang dibaca per Query saat Verify.
+const verifyPage = 500
+
+// Verify membaca seluruh rantai dari awal dan memeriksa bahwa Seq
+// berurutan tanpa celah, PrevHash sama dengan Hash entri sebelumnya dan Hash
+// sesuai dengan isi entri. Entri terakhir yang dihapus tidak meninggalkan
+// celah, jadi simpan LastSeq dan LastHash dari laporan sebelumnya di luar
+// database dan bandingkan dengan laporan berikutnya.
+func Verify(ctx context.Context, s Store) (*VerifyReport, error) {
+	r := &VerifyReport{Valid: true}
+	for {
+		entries, err := s.Query(ctx, Filter{AfterSeq: r.LastSeq, Limit: verifyPage, Ascending: true})
+		if err != nil {
+			return nil, err
+		}
+		for i := range entries {
+			e := &entries[i]
+			switch {
+			case e.Seq != r.LastSeq+1:
+				r.fail(r.LastSeq+1, fmt.Sprintf("entri %d tidak ada, berikutnya %d", r.LastSeq+1, e.Seq))
+			case e.PrevHash != r.LastHash:
+				r.fail(e.Seq, "prev_hash tidak sama dengan hash entri sebelumnya")
+			case e.computeHash() != e.Hash:
+				r.fail(e.Seq, "isi entri tidak sesuai dengan hash-nya")
+			}
+			if !r.Valid {
+				return r, nil
+			}
+			r.Checked++
+			r.LastSeq, r.LastHash = e.Seq, e.Hash
+		}
+		if len(entries) < verifyPage {
+			return r, nil
+		}
+	}
+}
+
+func (r *VerifyReport) fail(seq int64, reason string) {
+	r.Valid, r.BrokenAt, r.Reason = false, seq, reason
+}
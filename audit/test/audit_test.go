@@ -0,0 +1,245 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"latihan2/app/model"
+	"latihan2/app/repository/memory"
+	"latihan2/audit"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLog membuat audit log di memori dengan jam yang maju satu menit setiap
+// entri, mulai 1 Januari 2026.
+func newLog() (*audit.Log, *audit.MemoryStore) {
+	store := audit.NewMemoryStore()
+	l := audit.New(store)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.Now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+	return l, store
+}
+
+func TestRecordChain(t *testing.T) {
+	l, store := newLog()
+	ctx := audit.WithActor(context.Background(), audit.Actor{ID: "7", Username: "budi", Role: "admin"})
+	ctx = audit.WithRequest(ctx, "req-1", "10.0.0.1")
+
+	l.Record(ctx, audit.Event{Action: audit.ActionCreate, Entity: audit.EntityAlumni, EntityID: "1",
+		Backend: audit.BackendPostgres, After: map[string]any{"nama": "Budi"}})
+	l.Record(context.Background(), audit.Event{Action: audit.ActionDelete, Entity: audit.EntityAlumni, EntityID: "1",
+		Backend: audit.BackendPostgres, Before: map[string]any{"nama": "Budi"}})
+
+	entries, err := store.Query(context.Background(), audit.Filter{Ascending: true})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	first, second := entries[0], entries[1]
+	assert.Equal(t, int64(1), first.Seq)
+	assert.Empty(t, first.PrevHash)
+	assert.Equal(t, audit.Actor{ID: "7", Username: "budi", Role: "admin"}, first.Actor)
+	assert.Equal(t, "req-1", first.RequestID)
+	assert.Equal(t, "10.0.0.1", first.IP)
+	assert.JSONEq(t, `{"nama":{"after":"Budi"}}`, string(first.Changes))
+
+	assert.Equal(t, int64(2), second.Seq)
+	assert.Equal(t, first.Hash, second.PrevHash)
+	assert.Equal(t, audit.System, second.Actor, "tanpa actor di context dicatat sebagai system")
+	assert.JSONEq(t, `{"nama":{"before":"Budi"}}`, string(second.Changes))
+
+	report, err := audit.Verify(context.Background(), store)
+	require.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Equal(t, 2, report.Checked)
+	assert.Equal(t, second.Hash, report.LastHash)
+}
+
+func TestRecordNilLog(t *testing.T) {
+	var l *audit.Log
+	assert.NotPanics(t, func() {
+		l.Record(context.Background(), audit.Event{Action: audit.ActionCreate})
+	})
+}
+
+type failingStore struct{ audit.Store }
+
+func (failingStore) Append(context.Context, *audit.Entry) error {
+	return errors.New("database mati")
+}
+
+// Gagal menulis audit log tidak boleh menggagalkan perubahan yang sudah terjadi.
+func TestRecordStoreError(t *testing.T) {
+	l := audit.New(failingStore{audit.NewMemoryStore()})
+	assert.NotPanics(t, func() {
+		l.Record(context.Background(), audit.Event{Action: audit.ActionCreate, Entity: audit.EntityUser})
+	})
+}
+
+func TestDiff(t *testing.T) {
+	type alumni struct {
+		Nama     string  `json:"nama"`
+		Email    string  `json:"email"`
+		Alamat   *string `json:"alamat"`
+		Password string  `json:"-"`
+	}
+	alamat := "Jakarta"
+	before := alumni{Nama: "Budi", Email: "budi@x.id", Password: "lama"}
+	after := alumni{Nama: "Budi", Email: "budi@y.id", Alamat: &alamat, Password: "baru"}
+
+	changes, err := audit.Diff(before, after)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"email":{"before":"budi@x.id","after":"budi@y.id"},"alamat":{"after":"Jakarta"}}`, string(changes))
+
+	changes, err = audit.Diff(before, before)
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+
+	var none *alumni
+	changes, err = audit.Diff(none, after)
+	require.NoError(t, err)
+	assert.NotContains(t, string(changes), "before")
+}
+
+func TestMemoryStoreFilter(t *testing.T) {
+	l, store := newLog()
+	budi := audit.WithActor(context.Background(), audit.Actor{ID: "1", Username: "budi"})
+	ani := audit.WithActor(context.Background(), audit.Actor{ID: "2", Username: "ani"})
+	for i, ctx := range []context.Context{budi, ani, budi, ani, budi} {
+		l.Record(ctx, audit.Event{Action: audit.ActionUpdate, Entity: audit.EntityPekerjaan,
+			EntityID: string(rune('a' + i)), Backend: audit.BackendMongo})
+	}
+	ctx := context.Background()
+
+	entries, err := store.Query(ctx, audit.Filter{ActorID: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5, 3, 1}, seqs(entries), "terbaru lebih dulu")
+
+	entries, err = store.Query(ctx, audit.Filter{Actor: "ani", Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4}, seqs(entries))
+
+	entries, err = store.Query(ctx, audit.Filter{BeforeSeq: 4, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 2}, seqs(entries))
+
+	// Entri ke-n dicatat pada 00:0n.
+	entries, err = store.Query(ctx, audit.Filter{
+		From: time.Date(2026, 1, 1, 0, 2, 0, 0, time.UTC),
+		To:   time.Date(2026, 1, 1, 0, 4, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 2}, seqs(entries))
+
+	entries, err = store.Query(ctx, audit.Filter{EntityID: "c", Entity: audit.EntityPekerjaan})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, seqs(entries))
+
+	entries, err = store.Query(ctx, audit.Filter{Entity: audit.EntityAlumni})
+	require.NoError(t, err)
+	assert.NotNil(t, entries)
+	assert.Empty(t, entries)
+}
+
+func seqs(entries []audit.Entry) []int64 {
+	result := []int64{}
+	for _, e := range entries {
+		result = append(result, e.Seq)
+	}
+	return result
+}
+
+// tamperedStore mengubah entri dari store aslinya saat dibaca, seperti
+// seseorang yang mengubah baris langsung di database.
+type tamperedStore struct {
+	audit.Store
+	tamper func(entries []audit.Entry) []audit.Entry
+}
+
+func (s tamperedStore) Query(ctx context.Context, f audit.Filter) ([]audit.Entry, error) {
+	entries, err := s.Store.Query(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	return s.tamper(entries), nil
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	l, store := newLog()
+	for i := 0; i < 4; i++ {
+		l.Record(context.Background(), audit.Event{Action: audit.ActionUpdate, Entity: audit.EntityUser,
+			EntityID: "1", Backend: audit.BackendPostgres, After: map[string]int{"n": i}})
+	}
+
+	tests := []struct {
+		name     string
+		tamper   func([]audit.Entry) []audit.Entry
+		brokenAt int64
+	}{
+		{"isi diubah", func(e []audit.Entry) []audit.Entry {
+			e[1].Actor.Username = "orang lain"
+			return e
+		}, 2},
+		{"changes diubah", func(e []audit.Entry) []audit.Entry {
+			e[2].Changes = json.RawMessage(`{"n":{"after":99}}`)
+			return e
+		}, 3},
+		{"entri dihapus", func(e []audit.Entry) []audit.Entry {
+			return append(e[:1], e[2:]...)
+		}, 2},
+		{"prev_hash diubah", func(e []audit.Entry) []audit.Entry {
+			e[2].PrevHash = ""
+			return e
+		}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := audit.Verify(context.Background(), tamperedStore{Store: store, tamper: tt.tamper})
+			require.NoError(t, err)
+			assert.False(t, report.Valid)
+			assert.Equal(t, tt.brokenAt, report.BrokenAt)
+			assert.NotEmpty(t, report.Reason)
+		})
+	}
+}
+
+// Pembungkus repository mencatat pelaku dari context yang diteruskan lewat
+// WithContext, dan tidak pernah mencatat hash password.
+func TestMongoRepositoryWrapper(t *testing.T) {
+	l, store := newLog()
+	data := memory.NewStore()
+	require.NoError(t, memory.Seed(data))
+	users := audit.MongoUsers(l, audit.BackendMemory, memory.NewUserRepository(data))
+
+	ctx := audit.WithActor(context.Background(), audit.Actor{Username: "cli:ops", Role: "admin"})
+	admin, err := users.WithContext(ctx).GetUserByUsername(memory.DemoAdminUsername)
+	require.NoError(t, err)
+	require.NoError(t, users.WithContext(ctx).UpdatePassword(admin.ID.Hex(), "$2a$10$hashrahasia"))
+	_, err = users.WithContext(ctx).UpdateUser(admin.ID.Hex(), model.UpdateUserRequest{
+		Username: admin.Username, Email: "admin-baru@demo.local", Role: admin.Role,
+	})
+	require.NoError(t, err)
+
+	// Perubahan yang gagal tidak dicatat.
+	_, err = users.UpdateUser("000000000000000000000000", model.UpdateUserRequest{Username: "x", Email: "x@x.id", Role: "user"})
+	require.Error(t, err)
+
+	entries, err := store.Query(context.Background(), audit.Filter{Ascending: true})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, audit.ActionPasswordChange, entries[0].Action)
+	assert.Empty(t, entries[0].Changes)
+	assert.Equal(t, audit.ActionUpdate, entries[1].Action)
+	assert.Equal(t, admin.ID.Hex(), entries[1].EntityID)
+	assert.Equal(t, "cli:ops", entries[1].Actor.Username)
+	assert.Contains(t, string(entries[1].Changes), "admin-baru@demo.local")
+	for _, e := range entries {
+		assert.NotContains(t, string(e.Changes), "hashrahasia")
+	}
+}
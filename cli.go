@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/audit"
+	"latihan2/config"
+	"latihan2/database"
+	"latihan2/validation"
+	"os"
+	"os/user"
+)
+
+const usage = `Pemakaian: latihan2 [perintah] [flag]
+
+Perintah:
+  serve             jalankan server HTTP (default jika perintah tidak diisi)
+  migrate           jalankan migrasi database
+  sync              salin data antara Postgres dan MongoDB
+  seed              isi database dengan data contoh
+  user              buat user, jadikan admin, atau reset password
+  token issue       buat token JWT untuk debugging
+  files reconcile   bandingkan metadata file dengan folder upload
+  config show       tampilkan konfigurasi yang dipakai
+
+Semua perintah membaca konfigurasi yang sama dengan server (--config, env,
+flag). Jalankan "latihan2 <perintah> -h" untuk flag setiap perintah.
+`
+
+type command func(args []string) int
+
+var commands = map[string]command{
+	"serve":   runServe,
+	"migrate": runMigrate,
+	"sync":    runSync,
+	"seed":    runSeed,
+	"user":    runUser,
+	"token":   runToken,
+	"files":   runFiles,
+	"config":  runConfig,
+}
+
+// run menjalankan perintah name dan mengembalikan exit code: 0 berhasil,
+// 1 gagal, 2 pemakaian salah.
+func run(name string, args []string) int {
+	if name == "help" {
+		fmt.Print(usage)
+		return 0
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "perintah tidak dikenal: %s\n\n%s", name, usage)
+		return 2
+	}
+	return cmd(args)
+}
+
+// loadConfig membaca dan memvalidasi konfigurasi dengan cara yang sama seperti
+// server, lalu menerapkannya. cfg nil berarti perintah harus berhenti dengan
+// exit code code.
+func loadConfig(fs *flag.FlagSet, args []string) (cfg *config.Config, code int) {
+	cfg, err := config.Load(fs, args)
+	if err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return nil, 2
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil, 1
+	}
+	cfg.Apply()
+	return cfg, 0
+}
+
+// openStorage membuka storage /api/v1 untuk driver postgres atau mongo
+// dengan inisialisasi database yang sama seperti server. Driver memory tidak
+// didukung karena datanya hilang begitu perintah selesai.
+//
+// Perubahan dicatat ke audit log dengan pelaku dari cliContext. Seperti server,
+// audit log ditulis ke database storage.driver agar tetap satu rantai, jadi
+// database itu ikut dibuka jika berbeda dari driver.
+func openStorage(cfg *config.Config, driver string) (v1Repo.Storage, func(), error) {
+	if driver != v1Repo.DriverPostgres && driver != v1Repo.DriverMongo {
+		return v1Repo.Storage{}, nil, fmt.Errorf("--db tidak valid: %q (pilih postgres atau mongo)", driver)
+	}
+	auditDriver := cfg.Storage.Driver
+	if auditDriver == v1Repo.DriverMemory {
+		auditDriver = driver
+	}
+
+	var closers []func()
+	if driver == v1Repo.DriverPostgres || (cfg.Audit.Enabled && auditDriver == v1Repo.DriverPostgres) {
+		database.InitPostgresDB(cfg.Postgres.DSN)
+		closers = append(closers, func() { database.DB.Close() })
+	}
+	if driver == v1Repo.DriverMongo || (cfg.Audit.Enabled && auditDriver == v1Repo.DriverMongo) {
+		database.InitMongoDB(cfg.Mongo.URI, cfg.Mongo.Database)
+		closers = append(closers, func() { database.MongoClient.Disconnect(context.Background()) })
+	}
+	closeAll := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+
+	auditLog := newAuditLog(cfg, auditDriver, database.DB, database.MongoDB)
+	s := postgresStorage(database.DB, auditLog)
+	if driver == v1Repo.DriverMongo {
+		s = mongoStorage(database.MongoDB, auditLog)
+	}
+	return s.WithContext(cliContext()), closeAll, nil
+}
+
+// cliContext menandai perubahan dari CLI di audit log dengan pelaku
+// "cli:<user OS>" ber-role admin, karena perintah CLI tidak dibatasi role.
+func cliContext() context.Context {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	return audit.WithActor(context.Background(), audit.Actor{Username: "cli:" + name, Role: "admin"})
+}
+
+// dbFlag mendaftarkan --db yang default-nya storage.driver dari konfigurasi.
+// Nilai kosong diganti setelah konfigurasi dibaca lewat driverOf.
+func dbFlag(fs *flag.FlagSet) *string {
+	return fs.String("db", "", "database yang dipakai: postgres atau mongo (default storage.driver)")
+}
+
+func driverOf(cfg *config.Config, db string) string {
+	if db == "" {
+		return cfg.Storage.Driver
+	}
+	return db
+}
+
+// printInvalid mencetak error validasi, satu field per baris.
+func printInvalid(err error) {
+	for _, fe := range validation.Translate(err, validation.LangID) {
+		fmt.Fprintln(os.Stderr, fe.Message)
+	}
+}
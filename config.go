@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"latihan2/config"
+	"os"
+)
+
+const configUsage = `Pemakaian: latihan2 config show [--config=file] [flag konfigurasi lain]
+
+Menampilkan konfigurasi akhir dalam format YAML setelah digabung dari default,
+file (--config atau CONFIG_FILE), env lalu flag. Secret disamarkan dan setiap
+baris diberi komentar asal nilainya. Output bisa disimpan sebagai file --config.
+
+Exit code 0 jika konfigurasi valid, 1 jika tidak valid.
+`
+
+// runConfig menjalankan subcommand "config" dan mengembalikan exit code.
+func runConfig(args []string) int {
+	fs := flag.NewFlagSet("config show", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), configUsage)
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 || args[0] != "show" {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := config.Load(fs, args[1:])
+	if err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return 2
+	}
+	if err := cfg.Show(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
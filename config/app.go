@@ -1,26 +1,151 @@
 package config
 
 import (
+	"strings"
+	"time"
+
+	"latihan2/health"
+	"latihan2/metrics"
 	"latihan2/middleware"
+	"latihan2/ratelimit"
+	"latihan2/response"
 	"latihan2/route"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/helmet"
 )
 
-func NewApp() *fiber.App {
+// NewApp merakit aplikasi Fiber. pg nil berarti route /api/pg tidak dipasang (mode demo).
+// /api/v1 selalu dipasang; /api/pg dan /api/mg tetap ada sebagai alias usang.
+// ready adalah check /readyz untuk dependensi yang benar-benar dipakai.
+// limits dipasang di semua API (lihat NewLimits). Batas waktu, ukuran body,
+// CORS dan header keamanan diambil dari cfg.
+func NewApp(cfg *Config, pg *route.PostgresHandlers, mg route.MongoHandlers, v1 route.V1Handlers, ready []health.Check, limits route.Limits) *fiber.App {
+	upload := UploadBodyLimit(cfg.Upload)
+	body := int(cfg.HTTP.MaxBodyKB << 10)
+	proxies := cfg.HTTP.Proxies()
 	app := fiber.New(fiber.Config{
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
-		},
+		ErrorHandler: response.ErrorHandler,
+		// Fiber membaca body sebelum routing, jadi batas globalnya harus
+		// muat upload; route lain dibatasi middleware.BodyLimit.
+		BodyLimit:               max(upload, body),
+		ReadTimeout:             cfg.HTTP.ReadTimeout,
+		WriteTimeout:            cfg.HTTP.WriteTimeout,
+		IdleTimeout:             cfg.HTTP.IdleTimeout,
+		EnableTrustedProxyCheck: len(proxies) > 0,
+		TrustedProxies:          proxies,
+		ProxyHeader:             proxyHeader(proxies),
 	})
 
-	app.Use(cors.New())
-
+	app.Use(middleware.Tracing())
+	app.Use(middleware.RequestID())
+	app.Use(middleware.Metrics())
 	app.Use(middleware.LoggerMiddleware)
 
-	route.SetupRoutesPostgres(app)
-	route.SetupRoutesMongo(app)
+	for _, h := range securityHeaders(cfg.Security) {
+		app.Use(h)
+	}
+	if c, ok := corsConfig(cfg.CORS); ok {
+		app.Use(cors.New(c))
+	}
+	app.Use(middleware.BodyLimit(body, map[string]int{
+		"/api/v1/files/upload": upload,
+		"/api/mg/files/upload": upload,
+	}))
+	app.Use(middleware.Timeout(cfg.HTTP.RequestTimeout))
+
+	app.Get("/metrics", metrics.Handler())
+	app.Get("/healthz", health.Liveness)
+	app.Get("/readyz", health.Readiness(ready...))
+
+	v1.Limits, mg.Limits = limits, limits
+	route.SetupRoutesV1(app, v1)
+	if pg != nil {
+		pg.Limits = limits
+		route.SetupRoutesPostgres(app, *pg)
+	}
+	route.SetupRoutesMongo(app, mg)
 
 	return app
 }
+
+// UploadBodyLimit adalah ukuran body maksimal endpoint upload: file terbesar
+// yang diizinkan ditambah ruang untuk boundary dan field multipart lainnya.
+func UploadBodyLimit(c UploadConfig) int {
+	return int(max(c.MaxImageMB, c.MaxPDFMB)<<20) + 64<<10
+}
+
+// proxyHeader memakai X-Forwarded-For untuk c.IP() (rate limit per IP dan
+// log) hanya jika ada proxy tepercaya.
+func proxyHeader(proxies []string) string {
+	if len(proxies) == 0 {
+		return ""
+	}
+	return fiber.HeaderXForwardedFor
+}
+
+// docsCSP melonggarkan CSP untuk Swagger UI yang memakai script dan style inline.
+const docsCSP = "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'"
+
+// securityHeaders memasang X-Content-Type-Options, X-Frame-Options, CSP,
+// Referrer-Policy dan Strict-Transport-Security. HSTS hanya dikirim untuk
+// request HTTPS, termasuk lewat proxy tepercaya dengan X-Forwarded-Proto.
+// /swagger memakai CSP yang lebih longgar.
+func securityHeaders(c SecurityConfig) []fiber.Handler {
+	base := helmet.Config{
+		XFrameOptions:         strings.ToUpper(c.FrameOptions),
+		HSTSMaxAge:            int(c.HSTSMaxAge / time.Second),
+		HSTSExcludeSubdomains: !c.HSTSIncludeSubdomains,
+		ContentSecurityPolicy: c.CSP,
+		ReferrerPolicy:        "no-referrer",
+	}
+	isDocs := func(ctx *fiber.Ctx) bool { return strings.HasPrefix(ctx.Path(), "/swagger") }
+
+	api := base
+	api.Next = isDocs
+	docs := base
+	docs.Next = func(ctx *fiber.Ctx) bool { return !isDocs(ctx) }
+	docs.ContentSecurityPolicy = docsCSP
+	docs.CrossOriginEmbedderPolicy = "unsafe-none"
+	return []fiber.Handler{helmet.New(api), helmet.New(docs)}
+}
+
+// corsConfig mengembalikan false jika tidak ada origin yang diizinkan; tanpa
+// middleware CORS browser menolak semua panggilan dari origin lain.
+func corsConfig(c CORSConfig) (cors.Config, bool) {
+	origins := c.Origins()
+	if len(origins) == 0 {
+		return cors.Config{}, false
+	}
+	return cors.Config{
+		AllowOrigins:     strings.Join(origins, ","),
+		AllowMethods:     "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders:     "Authorization,Content-Type,If-Match,If-None-Match,Accept-Language,X-Request-ID",
+		ExposeHeaders:    "ETag,Location,Link,Deprecation,Retry-After,X-Request-ID,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,RateLimit-Policy",
+		AllowCredentials: c.AllowCredentials,
+		MaxAge:           int(c.MaxAge / time.Second),
+	}, true
+}
+
+// NewLimits membuat limiter route dari rate_limit. Semua limiter memakai store
+// yang sama; setiap kelompok tetap punya bucket sendiri karena nama limiter
+// menjadi bagian key. Konfigurasi sudah diperiksa Validate.
+func NewLimits(c RateLimitConfig, store ratelimit.Store) route.Limits {
+	if !c.Enabled {
+		return route.Limits{}
+	}
+	limiter := func(name, spec string) fiber.Handler {
+		l, _ := ratelimit.ParseLimit(spec)
+		if l.Unlimited() {
+			return nil
+		}
+		return middleware.RateLimit(name, l, store)
+	}
+	return route.Limits{
+		IP:     limiter("ip", c.IP),
+		User:   limiter("user", c.User),
+		Login:  limiter("login", c.Login),
+		Upload: limiter("upload", c.Upload),
+	}
+}
@@ -0,0 +1,633 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	v1Repo "latihan2/app/repository/v1"
+	mongoService "latihan2/app/service/mongo"
+	"latihan2/logging"
+	"latihan2/ratelimit"
+	"latihan2/utils"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Config adalah seluruh konfigurasi aplikasi. Setiap field punya kunci file
+// (tag key, digabung dengan kunci bagiannya, misalnya server.addr), nama env
+// (tag env) dan flag (kunci dengan "-" menggantikan "_", atau tag flag).
+type Config struct {
+	Server    ServerConfig    `key:"server"`
+	Postgres  PostgresConfig  `key:"postgres"`
+	Mongo     MongoConfig     `key:"mongo"`
+	Storage   StorageConfig   `key:"storage"`
+	Auth      AuthConfig      `key:"auth"`
+	Upload    UploadConfig    `key:"upload"`
+	Log       LogConfig       `key:"log"`
+	Health    HealthConfig    `key:"health"`
+	RateLimit RateLimitConfig `key:"rate_limit"`
+	HTTP      HTTPConfig      `key:"http"`
+	CORS      CORSConfig      `key:"cors"`
+	Security  SecurityConfig  `key:"security"`
+	Audit     AuditConfig     `key:"audit"`
+
+	// sources mencatat asal nilai setiap kunci untuk config show.
+	sources map[string]string
+}
+
+type ServerConfig struct {
+	Addr            string        `key:"addr" env:"SERVER_ADDR" usage:"alamat listen HTTP"`
+	ShutdownTimeout time.Duration `key:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" usage:"batas waktu menunggu request berjalan dan menutup koneksi saat berhenti"`
+	AutoMigrate     bool          `key:"auto_migrate" env:"AUTO_MIGRATE" flag:"auto-migrate" usage:"jalankan migrasi database yang belum dijalankan sebelum server start"`
+	Demo            bool          `key:"demo" env:"DEMO" flag:"demo" usage:"jalankan server tanpa database memakai repository in-memory berisi data contoh"`
+}
+
+type PostgresConfig struct {
+	DSN string `key:"dsn" env:"DB_DSN" secret:"url" usage:"DSN Postgres"`
+}
+
+type MongoConfig struct {
+	URI      string `key:"uri" env:"MONGODB_URI" secret:"url" usage:"URI MongoDB"`
+	Database string `key:"database" env:"DATABASE_NAME" usage:"nama database MongoDB"`
+}
+
+type StorageConfig struct {
+	Driver string `key:"driver" env:"STORAGE_DRIVER" usage:"database di belakang /api/v1: postgres, mongo atau memory"`
+}
+
+type AuthConfig struct {
+	JWTSecret     string        `key:"jwt_secret" env:"JWT_SECRET_KEY" secret:"true" usage:"secret penandatangan token"`
+	TokenTTL      time.Duration `key:"token_ttl" env:"TOKEN_TTL" usage:"masa berlaku token /api/v1 dan /api/pg"`
+	MongoTokenTTL time.Duration `key:"mongo_token_ttl" env:"MONGO_TOKEN_TTL" usage:"masa berlaku token /api/mg"`
+}
+
+type UploadConfig struct {
+	Dir        string `key:"dir" env:"UPLOAD_DIR" usage:"folder penyimpanan file upload"`
+	MaxImageMB int64  `key:"max_image_mb" env:"UPLOAD_MAX_IMAGE_MB" usage:"ukuran maksimal upload gambar dalam MB"`
+	MaxPDFMB   int64  `key:"max_pdf_mb" env:"UPLOAD_MAX_PDF_MB" usage:"ukuran maksimal upload PDF dalam MB"`
+}
+
+type LogConfig struct {
+	Level string `key:"level" env:"LOG_LEVEL" usage:"level log awal: debug, info, warn atau error"`
+}
+
+// RateLimitConfig memakai format ratelimit.ParseLimit, misalnya "10/1m" atau
+// "100/1h,burst=20"; "off" mematikan satu kelompok.
+type RateLimitConfig struct {
+	Enabled bool   `key:"enabled" env:"RATE_LIMIT_ENABLED" usage:"aktifkan rate limit"`
+	IP      string `key:"ip" env:"RATE_LIMIT_IP" usage:"batas semua request API per IP, dihitung sebelum auth"`
+	User    string `key:"user" env:"RATE_LIMIT_USER" usage:"batas request API per user yang sudah login"`
+	Login   string `key:"login" env:"RATE_LIMIT_LOGIN" usage:"batas percobaan login per IP"`
+	Upload  string `key:"upload" env:"RATE_LIMIT_UPLOAD" usage:"batas upload file per user"`
+}
+
+// HTTPConfig mengatur batas waktu dan ukuran request. Batas body upload
+// diturunkan dari upload.max_image_mb dan upload.max_pdf_mb.
+type HTTPConfig struct {
+	ReadTimeout    time.Duration `key:"read_timeout" env:"HTTP_READ_TIMEOUT" usage:"batas waktu membaca seluruh request termasuk body upload"`
+	WriteTimeout   time.Duration `key:"write_timeout" env:"HTTP_WRITE_TIMEOUT" usage:"batas waktu menulis response"`
+	IdleTimeout    time.Duration `key:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" usage:"batas waktu koneksi keep-alive tanpa request"`
+	RequestTimeout time.Duration `key:"request_timeout" env:"HTTP_REQUEST_TIMEOUT" usage:"batas waktu handler termasuk query database, setelah itu 503"`
+	MaxBodyKB      int64         `key:"max_body_kb" env:"HTTP_MAX_BODY_KB" usage:"ukuran body maksimal dalam KB untuk route selain upload"`
+	TrustedProxies string        `key:"trusted_proxies" env:"HTTP_TRUSTED_PROXIES" usage:"IP atau CIDR reverse proxy dipisah koma; X-Forwarded-For dan X-Forwarded-Proto hanya dipercaya dari sini"`
+}
+
+// Proxies mengembalikan daftar proxy dari TrustedProxies.
+func (c HTTPConfig) Proxies() []string {
+	return splitList(c.TrustedProxies)
+}
+
+// CORSConfig kosong berarti API hanya untuk origin yang sama: tidak ada header
+// CORS yang dikirim. Setiap environment mengisi daftar origin frontend-nya
+// sendiri lewat file konfigurasi atau env.
+type CORSConfig struct {
+	AllowOrigins     string        `key:"allow_origins" env:"CORS_ALLOW_ORIGINS" usage:"origin yang boleh memanggil API dipisah koma, misalnya https://app.example.com"`
+	AllowCredentials bool          `key:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" usage:"izinkan cookie dan header Authorization dari origin lain"`
+	MaxAge           time.Duration `key:"max_age" env:"CORS_MAX_AGE" usage:"lama browser menyimpan hasil preflight"`
+}
+
+// Origins mengembalikan daftar origin dari AllowOrigins.
+func (c CORSConfig) Origins() []string {
+	return splitList(c.AllowOrigins)
+}
+
+// splitList memecah daftar dipisah koma dan membuang elemen kosong.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+type SecurityConfig struct {
+	HSTSMaxAge            time.Duration `key:"hsts_max_age" env:"SECURITY_HSTS_MAX_AGE" usage:"max-age Strict-Transport-Security untuk request HTTPS, 0 mematikan"`
+	HSTSIncludeSubdomains bool          `key:"hsts_include_subdomains" env:"SECURITY_HSTS_INCLUDE_SUBDOMAINS" usage:"tambahkan includeSubDomains ke Strict-Transport-Security"`
+	CSP                   string        `key:"csp" env:"SECURITY_CSP" usage:"Content-Security-Policy untuk response API"`
+	FrameOptions          string        `key:"frame_options" env:"SECURITY_FRAME_OPTIONS" usage:"X-Frame-Options: DENY atau SAMEORIGIN"`
+}
+
+// AuditConfig mengatur audit log perubahan data. Entri disimpan di database
+// storage.driver (memori untuk mode demo), termasuk perubahan lewat /api/pg
+// dan /api/mg.
+type AuditConfig struct {
+	Enabled bool `key:"enabled" env:"AUDIT_ENABLED" usage:"catat setiap perubahan data ke audit log"`
+}
+
+type HealthConfig struct {
+	CheckTimeout  time.Duration `key:"check_timeout" env:"HEALTH_CHECK_TIMEOUT" usage:"batas waktu setiap check /readyz"`
+	MinFreeDiskMB uint64        `key:"min_free_disk_mb" env:"HEALTH_MIN_FREE_DISK_MB" usage:"sisa disk minimal di folder upload sebelum /readyz degraded"`
+}
+
+// Asal nilai konfigurasi, ditampilkan config show.
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+// Default mengembalikan konfigurasi bawaan. Koneksi database dan secret
+// tidak punya default.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:            ":3000",
+			ShutdownTimeout: 15 * time.Second,
+		},
+		Storage: StorageConfig{Driver: v1Repo.DriverPostgres},
+		Auth: AuthConfig{
+			TokenTTL:      24 * time.Hour,
+			MongoTokenTTL: 72 * time.Hour,
+		},
+		Upload: UploadConfig{
+			Dir:        "./uploads",
+			MaxImageMB: 1,
+			MaxPDFMB:   2,
+		},
+		Log:    LogConfig{Level: "info"},
+		Health: HealthConfig{CheckTimeout: 2 * time.Second, MinFreeDiskMB: 100},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			IP:      "300/1m",
+			User:    "120/1m",
+			Login:   "10/1m",
+			Upload:  "30/1h,burst=10",
+		},
+		HTTP: HTTPConfig{
+			ReadTimeout:    time.Minute,
+			WriteTimeout:   time.Minute,
+			IdleTimeout:    2 * time.Minute,
+			RequestTimeout: 30 * time.Second,
+			MaxBodyKB:      256,
+		},
+		CORS: CORSConfig{MaxAge: 10 * time.Minute},
+		Security: SecurityConfig{
+			HSTSMaxAge:   180 * 24 * time.Hour,
+			CSP:          "default-src 'none'; frame-ancestors 'none'",
+			FrameOptions: "DENY",
+		},
+		Audit: AuditConfig{Enabled: true},
+	}
+}
+
+// field adalah satu nilai konfigurasi hasil refleksi struct Config.
+type field struct {
+	key, env, flag, usage, secret string
+	value                         reflect.Value
+}
+
+func (c *Config) fields() []field {
+	var fields []field
+	root := reflect.ValueOf(c).Elem()
+	for i := 0; i < root.NumField(); i++ {
+		section := root.Type().Field(i)
+		prefix := section.Tag.Get("key")
+		if prefix == "" {
+			continue
+		}
+		for j := 0; j < section.Type.NumField(); j++ {
+			sf := section.Type.Field(j)
+			key := prefix + "." + sf.Tag.Get("key")
+			name := sf.Tag.Get("flag")
+			if name == "" {
+				name = strings.ReplaceAll(key, "_", "-")
+			}
+			fields = append(fields, field{
+				key:    key,
+				env:    sf.Tag.Get("env"),
+				flag:   name,
+				usage:  sf.Tag.Get("usage"),
+				secret: sf.Tag.Get("secret"),
+				value:  root.Field(i).Field(j),
+			})
+		}
+	}
+	return fields
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// set mengisi field dari string. Semua sumber (file, env, flag) lewat sini
+// sehingga format nilainya sama: durasi seperti "15s", bool seperti "true".
+func (f field) set(raw string) error {
+	v := f.value
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("durasi %q tidak valid, contoh: 30s, 5m, 24h", raw)
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(raw)
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("nilai %q bukan true/false", raw)
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("nilai %q bukan bilangan bulat", raw)
+		}
+		v.SetInt(n)
+	case v.Kind() == reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("nilai %q bukan bilangan bulat positif", raw)
+		}
+		v.SetUint(n)
+	default:
+		return fmt.Errorf("tipe %s tidak didukung", v.Type())
+	}
+	return nil
+}
+
+func (f field) String() string {
+	if f.value.Type() == durationType {
+		return time.Duration(f.value.Int()).String()
+	}
+	return fmt.Sprint(f.value.Interface())
+}
+
+// flagValue menampung nilai flag mentah; nilainya baru dipasang setelah env
+// agar flag selalu menang.
+type flagValue struct {
+	field *field
+	raw   *string
+}
+
+func (v flagValue) String() string {
+	if v.field == nil {
+		return ""
+	}
+	return v.field.String()
+}
+
+func (v flagValue) Set(s string) error {
+	*v.raw = s
+	return nil
+}
+
+func (v flagValue) IsBoolFlag() bool {
+	return v.field != nil && v.field.value.Kind() == reflect.Bool
+}
+
+// Load membaca konfigurasi dari, berurutan dan yang belakangan menang:
+// nilai default, file YAML/TOML (flag --config atau env CONFIG_FILE), env
+// (termasuk .env jika ada) lalu flag. fs boleh nil jika tidak ada flag.
+// Hasilnya belum divalidasi; panggil Validate.
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	cfg := Default()
+	fields := cfg.fields()
+	cfg.sources = make(map[string]string, len(fields))
+	for _, f := range fields {
+		cfg.sources[f.key] = SourceDefault
+	}
+
+	var file string
+	raw := make(map[string]*string, len(fields))
+	if fs != nil {
+		fs.StringVar(&file, "config", "", "file konfigurasi YAML atau TOML (env CONFIG_FILE)")
+		for i := range fields {
+			f := &fields[i]
+			raw[f.flag] = new(string)
+			fs.Var(flagValue{field: f, raw: raw[f.flag]}, f.flag, fmt.Sprintf("%s (env %s)", f.usage, f.env))
+		}
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+	}
+
+	// .env tidak wajib: di container nilai biasanya langsung dari environment.
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("gagal membaca .env: %w", err)
+	}
+
+	if file == "" {
+		file = os.Getenv("CONFIG_FILE")
+	}
+	if file != "" {
+		values, err := readFile(file)
+		if err != nil {
+			return nil, err
+		}
+		byKey := make(map[string]field, len(fields))
+		for _, f := range fields {
+			byKey[f.key] = f
+		}
+		for key, val := range values {
+			f, ok := byKey[key]
+			if !ok {
+				return nil, fmt.Errorf("%s: kunci %q tidak dikenal", file, key)
+			}
+			if err := f.set(val); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", file, key, err)
+			}
+			cfg.sources[key] = SourceFile
+		}
+	}
+
+	for _, f := range fields {
+		val, ok := os.LookupEnv(f.env)
+		if !ok {
+			continue
+		}
+		if err := f.set(val); err != nil {
+			return nil, fmt.Errorf("env %s: %w", f.env, err)
+		}
+		cfg.sources[f.key] = SourceEnv
+	}
+
+	if fs != nil {
+		var err error
+		fs.Visit(func(fl *flag.Flag) {
+			val, ok := raw[fl.Name]
+			if !ok || err != nil {
+				return
+			}
+			for _, f := range fields {
+				if f.flag != fl.Name {
+					continue
+				}
+				if serr := f.set(*val); serr != nil {
+					err = fmt.Errorf("flag --%s: %w", fl.Name, serr)
+					return
+				}
+				cfg.sources[f.key] = SourceFlag
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// readFile membaca file YAML atau TOML (dari ekstensinya) menjadi pasangan
+// kunci bertitik dan nilai string, misalnya "server.addr" -> ":3000".
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca file konfigurasi: %w", err)
+	}
+	var doc map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	case ".toml":
+		err = toml.Unmarshal(data, &doc)
+	default:
+		return nil, fmt.Errorf("%s: format file konfigurasi harus .yaml, .yml atau .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	values := map[string]string{}
+	if err := flatten("", doc, values); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+func flatten(prefix string, doc map[string]any, out map[string]string) error {
+	for k, v := range doc {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			if err := flatten(key, val, out); err != nil {
+				return err
+			}
+		case []any:
+			return fmt.Errorf("%s: daftar tidak didukung", key)
+		case nil:
+			// Kunci tanpa nilai dianggap tidak diisi.
+		default:
+			out[key] = fmt.Sprint(val)
+		}
+	}
+	return nil
+}
+
+// DemoMode melaporkan apakah server berjalan tanpa database (--demo atau
+// storage.driver memory).
+func (c *Config) DemoMode() bool {
+	return c.Server.Demo || c.Storage.Driver == v1Repo.DriverMemory
+}
+
+// Validate memeriksa semua nilai dan mengembalikan satu error berisi semua
+// masalah sekaligus, masing-masing menyebut kunci, env dan flag-nya.
+func (c *Config) Validate() error {
+	byKey := map[string]field{}
+	for _, f := range c.fields() {
+		byKey[f.key] = f
+	}
+	var errs []error
+	fail := func(key, format string, args ...any) {
+		f := byKey[key]
+		errs = append(errs, fmt.Errorf("%s (env %s, flag --%s): %s", key, f.env, f.flag, fmt.Sprintf(format, args...)))
+	}
+	positive := func(key string, d time.Duration) {
+		if d <= 0 {
+			fail(key, "harus lebih dari 0")
+		}
+	}
+
+	if _, _, err := net.SplitHostPort(c.Server.Addr); err != nil {
+		fail("server.addr", "alamat %q tidak valid, contoh :3000 atau 127.0.0.1:8080", c.Server.Addr)
+	}
+	positive("server.shutdown_timeout", c.Server.ShutdownTimeout)
+	if !v1Repo.ValidDriver(c.Storage.Driver) {
+		fail("storage.driver", "%q tidak dikenal, pilih %s, %s atau %s", c.Storage.Driver,
+			v1Repo.DriverPostgres, v1Repo.DriverMongo, v1Repo.DriverMemory)
+	}
+	if !c.DemoMode() {
+		if c.Postgres.DSN == "" {
+			fail("postgres.dsn", "wajib diisi")
+		}
+		if c.Mongo.URI == "" {
+			fail("mongo.uri", "wajib diisi")
+		}
+		if c.Mongo.Database == "" {
+			fail("mongo.database", "wajib diisi")
+		}
+		if strings.TrimSpace(c.Auth.JWTSecret) == "" {
+			fail("auth.jwt_secret", "wajib diisi")
+		}
+	}
+	positive("auth.token_ttl", c.Auth.TokenTTL)
+	positive("auth.mongo_token_ttl", c.Auth.MongoTokenTTL)
+	if c.Upload.Dir == "" {
+		fail("upload.dir", "wajib diisi")
+	}
+	if c.Upload.MaxImageMB <= 0 {
+		fail("upload.max_image_mb", "harus lebih dari 0")
+	}
+	if c.Upload.MaxPDFMB <= 0 {
+		fail("upload.max_pdf_mb", "harus lebih dari 0")
+	}
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		fail("log.level", "%v", err)
+	}
+	positive("health.check_timeout", c.Health.CheckTimeout)
+	for _, l := range []struct{ key, spec string }{
+		{"rate_limit.ip", c.RateLimit.IP},
+		{"rate_limit.user", c.RateLimit.User},
+		{"rate_limit.login", c.RateLimit.Login},
+		{"rate_limit.upload", c.RateLimit.Upload},
+	} {
+		if _, err := ratelimit.ParseLimit(l.spec); err != nil {
+			fail(l.key, "%v", err)
+		}
+	}
+	positive("http.read_timeout", c.HTTP.ReadTimeout)
+	positive("http.write_timeout", c.HTTP.WriteTimeout)
+	positive("http.idle_timeout", c.HTTP.IdleTimeout)
+	positive("http.request_timeout", c.HTTP.RequestTimeout)
+	if c.HTTP.MaxBodyKB <= 0 {
+		fail("http.max_body_kb", "harus lebih dari 0")
+	}
+	for _, p := range c.HTTP.Proxies() {
+		if net.ParseIP(p) == nil {
+			if _, _, err := net.ParseCIDR(p); err != nil {
+				fail("http.trusted_proxies", "%q bukan IP atau CIDR", p)
+			}
+		}
+	}
+	for _, o := range c.CORS.Origins() {
+		if o == "*" {
+			if c.CORS.AllowCredentials {
+				fail("cors.allow_origins", "\"*\" tidak boleh dipakai bersama cors.allow_credentials")
+			}
+			continue
+		}
+		if u, err := url.Parse(o); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.Path != "" || u.RawQuery != "" || u.User != nil {
+			fail("cors.allow_origins", "origin %q tidak valid, contoh https://app.example.com", o)
+		}
+	}
+	if c.CORS.MaxAge < 0 {
+		fail("cors.max_age", "tidak boleh negatif")
+	}
+	if c.Security.HSTSMaxAge < 0 {
+		fail("security.hsts_max_age", "tidak boleh negatif")
+	}
+	if fo := strings.ToUpper(c.Security.FrameOptions); fo != "DENY" && fo != "SAMEORIGIN" {
+		fail("security.frame_options", "%q tidak dikenal, pilih DENY atau SAMEORIGIN", c.Security.FrameOptions)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("konfigurasi tidak valid:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Apply memasang konfigurasi ke package yang masih memakai nilai global:
+// level log, secret dan masa berlaku token, serta lokasi dan batas upload.
+func (c *Config) Apply() {
+	if err := logging.SetLevel(c.Log.Level); err != nil {
+		slog.Warn("log.level diabaikan", "error", err)
+	}
+	utils.SetJWTSecret(c.Auth.JWTSecret)
+	utils.TokenTTL = c.Auth.TokenTTL
+	utils.MongoTokenTTL = c.Auth.MongoTokenTTL
+	mongoService.UploadPath = c.Upload.Dir
+	mongoService.MaxImageSize = c.Upload.MaxImageMB << 20
+	mongoService.MaxPDFSize = c.Upload.MaxPDFMB << 20
+}
+
+// Show menulis konfigurasi sebagai YAML ke w. Secret disamarkan dan setiap
+// baris diberi komentar asal nilainya (default, file, env atau flag).
+func (c *Config) Show(w io.Writer) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	var section *yaml.Node
+	var current string
+	for _, f := range c.fields() {
+		name, leaf, _ := strings.Cut(f.key, ".")
+		if name != current {
+			current = name
+			section = &yaml.Node{Kind: yaml.MappingNode}
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, section)
+		}
+		value := &yaml.Node{Kind: yaml.ScalarNode, Value: mask(f.secret, f.String())}
+		if f.value.Kind() == reflect.String || f.value.Type() == durationType {
+			value.Tag = "!!str"
+		}
+		if source := c.sources[f.key]; source != "" {
+			value.LineComment = source
+		}
+		section.Content = append(section.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: leaf}, value)
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Masked menggantikan nilai secret di config show.
+const Masked = "******"
+
+// mask menyamarkan nilai secret. Untuk kind "url" hanya password di URL
+// (menjadi xxxxx) atau DSN key=value yang disamarkan agar host dan nama
+// database tetap terlihat.
+func mask(kind, value string) string {
+	if kind == "" || value == "" {
+		return value
+	}
+	if kind == "url" {
+		if dsnPassword.MatchString(value) {
+			return dsnPassword.ReplaceAllString(value, "${1}"+Masked)
+		}
+		if u, err := url.Parse(value); err == nil {
+			return u.Redacted()
+		}
+	}
+	return Masked
+}
+
+// dsnPassword mencocokkan password di DSN Postgres bentuk key=value atau
+// query string URL.
+var dsnPassword = regexp.MustCompile(`(password=)('[^']*'|[^\s&]+)`)
@@ -1,22 +1,41 @@
 package config
 
 import (
+	"io"
+	"latihan2/logging"
 	"log"
+	"log/slog"
 	"os"
 
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+var logFile *lumberjack.Logger
+
+// InitLogger memasang logger JSON sebagai slog.Default. Output ditulis ke
+// stdout dan ke logs/app.log (dirotasi lumberjack). Pemanggilan log.Printf
+// yang tersisa ikut diteruskan ke logger ini dengan level INFO.
 func InitLogger() {
 	if _, err := os.Stat("logs"); os.IsNotExist(err) {
 		os.Mkdir("logs", os.ModePerm)
 	}
-	log.SetOutput(&lumberjack.Logger{
+	logFile = &lumberjack.Logger{
 		Filename:   "logs/app.log",
 		MaxSize:    5,
 		MaxBackups: 10,
 		MaxAge:     30,
 		Compress:   true,
-	})
-	log.Println("File logger berhasil diinisialisasi.")
-}
\ No newline at end of file
+	}
+	slog.SetDefault(logging.New(io.MultiWriter(os.Stdout, logFile)))
+	log.SetFlags(0)
+	slog.Debug("File logger berhasil diinisialisasi.")
+}
+
+// CloseLogger menutup file log. Dipanggil paling akhir saat proses berhenti;
+// log yang ditulis setelahnya membuka file itu lagi.
+func CloseLogger() error {
+	if logFile == nil {
+		return nil
+	}
+	return logFile.Close()
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Closer adalah resource yang ditutup Serve setelah server berhenti.
+type Closer struct {
+	Name  string
+	Close func(ctx context.Context) error
+}
+
+// Serve menjalankan app di ln sampai ctx selesai (biasanya karena SIGINT atau
+// SIGTERM). Setelah itu listener ditutup sehingga koneksi baru ditolak,
+// request yang sedang berjalan (misalnya upload) ditunggu sampai selesai, lalu
+// closers ditutup sesuai urutan. Seluruh proses dibatasi timeout; lewat dari
+// itu koneksi yang tersisa diputus dan closers menerima context yang sudah
+// kedaluwarsa. Closer yang gagal tidak menghentikan closer berikutnya.
+func Serve(ctx context.Context, app *fiber.App, ln net.Listener, timeout time.Duration, closers ...Closer) error {
+	served := make(chan error, 1)
+	go func() { served <- app.Listener(ln) }()
+
+	var errs []error
+	select {
+	case err := <-served:
+		// Server berhenti sendiri tanpa sinyal; tetap tutup resource.
+		errs = append(errs, err)
+		ln = nil
+	case <-ctx.Done():
+		slog.Info("server berhenti, menunggu request yang sedang berjalan", "timeout", timeout.String())
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if ln != nil {
+		start := time.Now()
+		if err := app.ShutdownWithContext(shutdownCtx); err != nil {
+			slog.Warn("batas waktu shutdown terlewati, koneksi yang tersisa diputus", "error", err)
+		} else {
+			slog.Info("semua request selesai", "duration_ms", time.Since(start).Milliseconds())
+		}
+		if err := <-served; err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, c := range closers {
+		// Dicatat sebelum Close karena closer terakhir biasanya file log.
+		slog.Info("menutup resource", "name", c.Name)
+		if err := c.Close(shutdownCtx); err != nil {
+			slog.Error("gagal menutup resource", "name", c.Name, "error", err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
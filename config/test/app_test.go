@@ -0,0 +1,110 @@
+package test
+
+import (
+	"bytes"
+	"io"
+	"latihan2/config"
+	"latihan2/route"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newApp merakit aplikasi tanpa database; hanya middleware dan route yang
+// tidak memanggil handler yang diuji di sini.
+func newApp(t *testing.T, args ...string) *fiber.App {
+	t.Helper()
+	cfg, err := load(t, append([]string{"--demo"}, args...)...)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	app := config.NewApp(cfg, nil, route.MongoHandlers{}, route.V1Handlers{}, nil, route.Limits{})
+	app.Get("/swagger/*", func(c *fiber.Ctx) error { return c.SendString("docs") })
+	return app
+}
+
+func TestHeaderKeamanan(t *testing.T) {
+	app := newApp(t)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/healthz", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "nosniff", resp.Header.Get(fiber.HeaderXContentTypeOptions))
+	assert.Equal(t, "DENY", resp.Header.Get(fiber.HeaderXFrameOptions))
+	assert.Equal(t, "default-src 'none'; frame-ancestors 'none'", resp.Header.Get(fiber.HeaderContentSecurityPolicy))
+	assert.Equal(t, "no-referrer", resp.Header.Get(fiber.HeaderReferrerPolicy))
+	assert.Empty(t, resp.Header.Get(fiber.HeaderStrictTransportSecurity), "HSTS hanya untuk HTTPS")
+
+	// X-Forwarded-Proto hanya dipercaya dari proxy terdaftar. app.Test
+	// selalu memakai alamat 0.0.0.0.
+	https := func() *http.Request {
+		req := httptest.NewRequest("GET", "/healthz", nil)
+		req.Header.Set(fiber.HeaderXForwardedProto, "https")
+		return req
+	}
+	resp, err = newApp(t, "--http.trusted-proxies=10.0.0.1").Test(https())
+	require.NoError(t, err)
+	assert.Empty(t, resp.Header.Get(fiber.HeaderStrictTransportSecurity))
+
+	resp, err = newApp(t, "--http.trusted-proxies=0.0.0.0", "--security.hsts-include-subdomains").Test(https())
+	require.NoError(t, err)
+	assert.Equal(t, "max-age=15552000; includeSubDomains", resp.Header.Get(fiber.HeaderStrictTransportSecurity))
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/swagger/index.html", nil))
+	require.NoError(t, err)
+	assert.Contains(t, resp.Header.Get(fiber.HeaderContentSecurityPolicy), "script-src 'self' 'unsafe-inline'")
+	assert.Equal(t, "nosniff", resp.Header.Get(fiber.HeaderXContentTypeOptions))
+}
+
+func preflight(t *testing.T, app *fiber.App, origin string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest("OPTIONS", "/api/v1/alumni", nil)
+	req.Header.Set(fiber.HeaderOrigin, origin)
+	req.Header.Set(fiber.HeaderAccessControlRequestMethod, "POST")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestCORSHanyaOriginTerdaftar(t *testing.T) {
+	app := newApp(t, "--cors.allow-origins=https://app.example.com, https://admin.example.com")
+
+	resp := preflight(t, app, "https://app.example.com")
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://app.example.com", resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Contains(t, resp.Header.Get(fiber.HeaderAccessControlAllowHeaders), "Authorization")
+	assert.Equal(t, "600", resp.Header.Get(fiber.HeaderAccessControlMaxAge))
+
+	resp = preflight(t, app, "https://evil.example.net")
+	assert.Empty(t, resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+
+	// Tanpa daftar origin tidak ada header CORS sama sekali.
+	resp = preflight(t, newApp(t), "https://app.example.com")
+	assert.Empty(t, resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+}
+
+func post(t *testing.T, app *fiber.App, path string, size int) int {
+	t.Helper()
+	req := httptest.NewRequest("POST", path, bytes.NewReader(bytes.Repeat([]byte("a"), size)))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	if resp.StatusCode == fiber.StatusRequestEntityTooLarge {
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), `"code":"payload_too_large"`)
+	}
+	return resp.StatusCode
+}
+
+func TestBatasBodyPerRoute(t *testing.T) {
+	app := newApp(t, "--http.max-body-kb=1")
+
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, post(t, app, "/api/v1/auth/login", 2<<10))
+	assert.NotEqual(t, fiber.StatusRequestEntityTooLarge, post(t, app, "/api/v1/auth/login", 512))
+
+	// Upload boleh sebesar file PDF maksimal (default 2 MB).
+	assert.NotEqual(t, fiber.StatusRequestEntityTooLarge, post(t, app, "/api/v1/files/upload", 2<<20))
+	assert.NotEqual(t, fiber.StatusRequestEntityTooLarge, post(t, app, "/api/mg/files/upload/", 2<<20))
+}
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"latihan2/config"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// start menjalankan config.Serve di port acak. Request ke /lambat baru
+// selesai setelah delay; started ditutup saat handler mulai berjalan.
+func start(t *testing.T, delay, timeout time.Duration, closers ...config.Closer) (url string, started chan struct{}, cancel context.CancelFunc, done chan error) {
+	t.Helper()
+	started = make(chan struct{})
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/lambat", func(c *fiber.Ctx) error {
+		close(started)
+		time.Sleep(delay)
+		return c.SendString("selesai")
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done = make(chan error, 1)
+	go func() { done <- config.Serve(ctx, app, ln, timeout, closers...) }()
+	return "http://" + ln.Addr().String(), started, cancel, done
+}
+
+func TestServeMenungguRequestBerjalan(t *testing.T) {
+	var order []string
+	closer := func(name string, err error) config.Closer {
+		return config.Closer{Name: name, Close: func(ctx context.Context) error {
+			order = append(order, name)
+			return err
+		}}
+	}
+	url, started, cancel, done := start(t, 200*time.Millisecond, 5*time.Second,
+		closer("postgres", nil), closer("mongo", errors.New("disconnect gagal")), closer("log", nil))
+
+	type result struct {
+		body string
+		err  error
+	}
+	res := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url + "/lambat")
+		if err != nil {
+			res <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		res <- result{body: string(b)}
+	}()
+
+	<-started
+	cancel()
+
+	r := <-res
+	require.NoError(t, r.err, "request yang sedang berjalan tidak boleh diputus")
+	assert.Equal(t, "selesai", r.body)
+
+	err := <-done
+	assert.ErrorContains(t, err, "disconnect gagal")
+	assert.Equal(t, []string{"postgres", "mongo", "log"}, order, "closer gagal tidak menghentikan closer berikutnya")
+
+	_, err = http.Get(url + "/lambat")
+	assert.Error(t, err, "koneksi baru harus ditolak setelah shutdown")
+}
+
+func TestServeBatasWaktu(t *testing.T) {
+	var expired bool
+	url, started, cancel, done := start(t, 3*time.Second, 100*time.Millisecond, config.Closer{
+		Name: "postgres",
+		Close: func(ctx context.Context) error {
+			expired = ctx.Err() != nil
+			return nil
+		},
+	})
+	go http.Get(url + "/lambat")
+
+	<-started
+	begin := time.Now()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Serve harus berhenti setelah batas waktu shutdown")
+	}
+	assert.Less(t, time.Since(begin), time.Second)
+	assert.True(t, expired, "closer menerima context yang sudah kedaluwarsa")
+}
@@ -0,0 +1,417 @@
+// Package datasync menyalin users, alumni dan pekerjaan antara Postgres dan
+// MongoDB, ke arah mana pun. Postgres memakai ID integer dan Mongo memakai
+// ObjectID, jadi pasangan ID setiap record dicatat di Mapping dan foreign key
+// (alumni.user_id, pekerjaan.alumni_id, pekerjaan.delete_by) diterjemahkan
+// lewat tabel itu sebelum ditulis ke tujuan.
+//
+// Sinkronisasi bersifat incremental: hanya record yang dibuat, diubah atau
+// dihapus (soft delete) sejak sinkronisasi terakhir ke arah yang sama yang
+// disalin. Hard delete tidak ikut disalin.
+package datasync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"latihan2/app/model"
+	"time"
+)
+
+// Side adalah salah satu database yang disinkronkan.
+type Side string
+
+const (
+	SidePostgres Side = "postgres"
+	SideMongo    Side = "mongo"
+)
+
+// Entity adalah jenis record yang disinkronkan, dalam urutan penyalinan.
+type Entity string
+
+const (
+	EntityUser      Entity = "users"
+	EntityAlumni    Entity = "alumni"
+	EntityPekerjaan Entity = "pekerjaan"
+)
+
+// ErrDuplikat dikembalikan Store jika penulisan melanggar constraint unik di tujuan.
+var ErrDuplikat = errors.New("data duplikat di database tujuan")
+
+// User, Alumni dan Pekerjaan adalah bentuk netral record kedua database.
+// Semua ID berupa string: angka untuk Postgres, hex ObjectID untuk Mongo.
+// Referensi kosong ("") berarti NULL di Postgres atau ObjectID nol di Mongo.
+type User struct {
+	ID           string
+	Username     string
+	Email        string
+	PasswordHash string
+	Role         string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    *time.Time
+}
+
+type Alumni struct {
+	ID         string
+	UserID     string
+	NIM        string
+	Nama       string
+	Jurusan    string
+	Angkatan   int
+	TahunLulus int
+	Email      string
+	NoTelepon  *string
+	Alamat     *string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  *time.Time
+}
+
+type Pekerjaan struct {
+	ID                  string
+	AlumniID            string
+	NamaPerusahaan      string
+	PosisiJabatan       string
+	BidangIndustri      string
+	LokasiKerja         string
+	GajiRange           string
+	TanggalMulaiKerja   time.Time
+	TanggalSelesaiKerja *time.Time
+	StatusPekerjaan     string
+	DeskripsiPekerjaan  string
+	IsDelete            bool
+	DeleteBy            string
+	DeletedAt           *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	model.RentangGaji
+}
+
+// Store membaca dan menulis record di satu database.
+type Store interface {
+	Side() Side
+	// Users, Alumni dan Pekerjaan mengembalikan record yang berubah setelah since
+	// (lihat Changed). since nol berarti semua record.
+	Users(ctx context.Context, since time.Time) ([]User, error)
+	Alumni(ctx context.Context, since time.Time) ([]Alumni, error)
+	Pekerjaan(ctx context.Context, since time.Time) ([]Pekerjaan, error)
+	// Changed mengembalikan waktu perubahan terakhir record id; ok false jika record tidak ada.
+	Changed(ctx context.Context, entity Entity, id string) (t time.Time, ok bool, err error)
+	// FindByKey mencari record berdasarkan kunci alami (username untuk users, nim
+	// untuk alumni). Pekerjaan tidak punya kunci alami dan selalu ok false.
+	FindByKey(ctx context.Context, entity Entity, key string) (id string, ok bool, err error)
+	// SaveUser, SaveAlumni dan SavePekerjaan menimpa record id, atau membuat record
+	// baru jika id kosong, lalu mengembalikan ID-nya. Referensi di record sudah
+	// berupa ID tujuan. Pelanggaran constraint unik dikembalikan sebagai ErrDuplikat.
+	SaveUser(ctx context.Context, id string, u User) (string, error)
+	SaveAlumni(ctx context.Context, id string, a Alumni) (string, error)
+	SavePekerjaan(ctx context.Context, id string, p Pekerjaan) (string, error)
+}
+
+// Mapping menyimpan pasangan ID Postgres dan Mongo serta waktu sinkronisasi terakhir per arah.
+type Mapping interface {
+	// Lookup mengembalikan ID pasangan dari id yang berasal dari sisi from.
+	Lookup(ctx context.Context, entity Entity, from Side, id string) (string, bool, error)
+	Save(ctx context.Context, entity Entity, postgresID, mongoID string) error
+	LastSync(ctx context.Context, direction string) (time.Time, error)
+	SetLastSync(ctx context.Context, direction string, t time.Time) error
+}
+
+// Options mengatur satu kali sinkronisasi.
+type Options struct {
+	// Full mengabaikan waktu sinkronisasi terakhir dan membandingkan semua record.
+	Full bool
+	// DryRun hanya menyusun laporan tanpa menulis apa pun.
+	DryRun bool
+	// Overwrite menimpa record tujuan walaupun record itu juga berubah (konflik).
+	Overwrite bool
+}
+
+// Stats adalah jumlah record per entitas.
+type Stats struct {
+	Entity  Entity
+	Dibuat  int
+	Diubah  int
+	Sama    int
+	Konflik int
+}
+
+// Conflict adalah record yang tidak disalin beserta alasannya.
+type Conflict struct {
+	Entity   Entity
+	SourceID string
+	TargetID string
+	Alasan   string
+}
+
+// Report adalah hasil satu kali sinkronisasi.
+type Report struct {
+	Direction string
+	Since     time.Time
+	DryRun    bool
+	Stats     []Stats
+	Conflicts []Conflict
+}
+
+// Direction adalah kunci arah sinkronisasi di Mapping, misalnya "postgres->mongo".
+func Direction(src, dst Side) string {
+	return fmt.Sprintf("%s->%s", src, dst)
+}
+
+// Run menyalin record yang berubah dari src ke dst. Record yang juga berubah di
+// dst sejak sinkronisasi terakhir, yang referensinya belum tersinkron, atau yang
+// melanggar constraint unik dilaporkan sebagai konflik dan dilewati. Waktu
+// sinkronisasi terakhir hanya dimajukan jika tidak ada konflik, sehingga record
+// yang dilewati ikut dibandingkan lagi pada run berikutnya.
+func Run(ctx context.Context, src, dst Store, mapping Mapping, opts Options) (*Report, error) {
+	if src.Side() == dst.Side() {
+		return nil, fmt.Errorf("sumber dan tujuan sinkronisasi sama: %s", src.Side())
+	}
+	direction := Direction(src.Side(), dst.Side())
+
+	var since time.Time
+	if !opts.Full {
+		t, err := mapping.LastSync(ctx, direction)
+		if err != nil {
+			return nil, fmt.Errorf("baca waktu sinkronisasi terakhir: %w", err)
+		}
+		since = t
+	}
+	started := time.Now()
+
+	s := &syncer{
+		src: src, dst: dst, mapping: mapping, opts: opts, since: since,
+		report:  &Report{Direction: direction, Since: since, DryRun: opts.DryRun},
+		pending: map[Entity]map[string]string{},
+	}
+	for _, step := range []func(context.Context) error{s.users, s.alumni, s.pekerjaan} {
+		if err := step(ctx); err != nil {
+			return s.report, err
+		}
+	}
+
+	if !opts.DryRun && len(s.report.Conflicts) == 0 {
+		if err := mapping.SetLastSync(ctx, direction, started); err != nil {
+			return s.report, fmt.Errorf("simpan waktu sinkronisasi: %w", err)
+		}
+	}
+	return s.report, nil
+}
+
+type syncer struct {
+	src, dst Store
+	mapping  Mapping
+	opts     Options
+	since    time.Time
+	report   *Report
+	// pending berisi ID sementara record yang akan dibuat pada dry run, agar
+	// record anaknya tidak dilaporkan sebagai referensi yang belum tersinkron.
+	pending map[Entity]map[string]string
+}
+
+func (s *syncer) users(ctx context.Context) error {
+	users, err := s.src.Users(ctx, s.since)
+	if err != nil {
+		return fmt.Errorf("baca users: %w", err)
+	}
+	stats := &Stats{Entity: EntityUser}
+	for _, u := range users {
+		err := s.copy(ctx, stats, EntityUser, u.ID, u.Username, changedAt(u.CreatedAt, u.UpdatedAt, u.DeletedAt),
+			func(id string) (string, error) { return s.dst.SaveUser(ctx, id, u) })
+		if err != nil {
+			return err
+		}
+	}
+	s.report.Stats = append(s.report.Stats, *stats)
+	return nil
+}
+
+func (s *syncer) alumni(ctx context.Context) error {
+	alumni, err := s.src.Alumni(ctx, s.since)
+	if err != nil {
+		return fmt.Errorf("baca alumni: %w", err)
+	}
+	stats := &Stats{Entity: EntityAlumni}
+	for _, a := range alumni {
+		if a.UserID != "" {
+			userID, ok, err := s.resolve(ctx, EntityUser, a.UserID)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				s.conflict(stats, EntityAlumni, a.ID, "", fmt.Sprintf("user %s belum tersinkron", a.UserID))
+				continue
+			}
+			a.UserID = userID
+		}
+		err := s.copy(ctx, stats, EntityAlumni, a.ID, a.NIM, changedAt(a.CreatedAt, a.UpdatedAt, a.DeletedAt),
+			func(id string) (string, error) { return s.dst.SaveAlumni(ctx, id, a) })
+		if err != nil {
+			return err
+		}
+	}
+	s.report.Stats = append(s.report.Stats, *stats)
+	return nil
+}
+
+func (s *syncer) pekerjaan(ctx context.Context) error {
+	pekerjaan, err := s.src.Pekerjaan(ctx, s.since)
+	if err != nil {
+		return fmt.Errorf("baca pekerjaan: %w", err)
+	}
+	stats := &Stats{Entity: EntityPekerjaan}
+	for _, p := range pekerjaan {
+		alumniID, ok, err := s.resolve(ctx, EntityAlumni, p.AlumniID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			s.conflict(stats, EntityPekerjaan, p.ID, "", fmt.Sprintf("alumni %s belum tersinkron", p.AlumniID))
+			continue
+		}
+		p.AlumniID = alumniID
+
+		// delete_by hanya catatan audit; user yang belum tersinkron tidak menghalangi penyalinan.
+		if p.DeleteBy != "" {
+			deleteBy, ok, err := s.resolve(ctx, EntityUser, p.DeleteBy)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				deleteBy = ""
+			}
+			p.DeleteBy = deleteBy
+		}
+
+		err = s.copy(ctx, stats, EntityPekerjaan, p.ID, "", changedAt(p.CreatedAt, p.UpdatedAt, p.DeletedAt),
+			func(id string) (string, error) { return s.dst.SavePekerjaan(ctx, id, p) })
+		if err != nil {
+			return err
+		}
+	}
+	s.report.Stats = append(s.report.Stats, *stats)
+	return nil
+}
+
+// copy menulis satu record ke tujuan. Record tujuan dicari lewat Mapping, lalu
+// lewat kunci alami agar data yang sudah ada di kedua database tidak digandakan
+// pada sinkronisasi pertama.
+func (s *syncer) copy(ctx context.Context, stats *Stats, entity Entity, srcID, key string, srcChanged time.Time, save func(id string) (string, error)) error {
+	target, ok, err := s.resolve(ctx, entity, srcID)
+	if err != nil {
+		return err
+	}
+	if !ok && key != "" {
+		if target, ok, err = s.dst.FindByKey(ctx, entity, key); err != nil {
+			return fmt.Errorf("cari %s %s di tujuan: %w", entity, key, err)
+		}
+	}
+
+	if ok {
+		dstChanged, exists, err := s.dst.Changed(ctx, entity, target)
+		if err != nil {
+			return fmt.Errorf("baca %s %s di tujuan: %w", entity, target, err)
+		}
+		switch {
+		case !exists:
+			// Record tujuan sudah di-hard delete; buat ulang.
+			target, ok = "", false
+		case sameInstant(dstChanged, srcChanged):
+			stats.Sama++
+			return s.link(ctx, entity, srcID, target)
+		case s.conflicting(srcChanged, dstChanged) && !s.opts.Overwrite:
+			s.conflict(stats, entity, srcID, target, fmt.Sprintf(
+				"record tujuan juga berubah (%s)", dstChanged.UTC().Format(time.RFC3339)))
+			return nil
+		}
+	}
+
+	if s.opts.DryRun {
+		if ok {
+			stats.Diubah++
+		} else {
+			stats.Dibuat++
+			if s.pending[entity] == nil {
+				s.pending[entity] = map[string]string{}
+			}
+			s.pending[entity][srcID] = "baru:" + srcID
+		}
+		return nil
+	}
+
+	saved, err := save(target)
+	if errors.Is(err, ErrDuplikat) {
+		s.conflict(stats, entity, srcID, target, err.Error())
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("tulis %s %s: %w", entity, srcID, err)
+	}
+	if ok {
+		stats.Diubah++
+	} else {
+		stats.Dibuat++
+	}
+	return s.link(ctx, entity, srcID, saved)
+}
+
+// conflicting menentukan apakah record tujuan berubah sendiri. Pada run penuh
+// (tanpa since) tujuan dianggap konflik jika lebih baru dari sumber.
+func (s *syncer) conflicting(srcChanged, dstChanged time.Time) bool {
+	if s.since.IsZero() {
+		return dstChanged.After(srcChanged)
+	}
+	return dstChanged.After(s.since)
+}
+
+// resolve menerjemahkan ID sumber ke ID tujuan.
+func (s *syncer) resolve(ctx context.Context, entity Entity, srcID string) (string, bool, error) {
+	if id, ok := s.pending[entity][srcID]; ok {
+		return id, true, nil
+	}
+	id, ok, err := s.mapping.Lookup(ctx, entity, s.src.Side(), srcID)
+	if err != nil {
+		return "", false, fmt.Errorf("baca id map %s %s: %w", entity, srcID, err)
+	}
+	return id, ok, nil
+}
+
+func (s *syncer) link(ctx context.Context, entity Entity, srcID, dstID string) error {
+	if s.opts.DryRun {
+		return nil
+	}
+	pgID, mgID := srcID, dstID
+	if s.src.Side() == SideMongo {
+		pgID, mgID = dstID, srcID
+	}
+	if err := s.mapping.Save(ctx, entity, pgID, mgID); err != nil {
+		return fmt.Errorf("simpan id map %s %s: %w", entity, srcID, err)
+	}
+	return nil
+}
+
+func (s *syncer) conflict(stats *Stats, entity Entity, srcID, dstID, alasan string) {
+	stats.Konflik++
+	s.report.Conflicts = append(s.report.Conflicts, Conflict{Entity: entity, SourceID: srcID, TargetID: dstID, Alasan: alasan})
+}
+
+// changedAt adalah waktu perubahan terakhir sebuah record: yang paling akhir
+// dari created_at, updated_at dan deleted_at. Soft delete di Mongo tidak selalu
+// mengubah updated_at, jadi deleted_at ikut dihitung.
+func changedAt(created, updated time.Time, deleted *time.Time) time.Time {
+	t := created
+	if updated.After(t) {
+		t = updated
+	}
+	if deleted != nil && deleted.After(t) {
+		t = *deleted
+	}
+	return t
+}
+
+// sameInstant membandingkan sampai milidetik, presisi tanggal di Mongo.
+// Postgres menyimpan mikrodetik, jadi record yang baru disalin dari Postgres
+// ke Mongo tetap dianggap sama.
+func sameInstant(a, b time.Time) bool {
+	return a.Truncate(time.Millisecond).Equal(b.Truncate(time.Millisecond))
+}
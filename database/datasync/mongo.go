@@ -0,0 +1,299 @@
+package datasync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mongoModel "latihan2/app/model/mongo"
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var mgCollections = map[Entity]string{
+	EntityUser:      "user",
+	EntityAlumni:    "alumni",
+	EntityPekerjaan: "pekerjaan",
+}
+
+type mongoStore struct {
+	db *mongo.Database
+}
+
+// NewMongoStore membaca dan menulis koleksi user, alumni dan pekerjaan.
+func NewMongoStore(db *mongo.Database) Store {
+	return &mongoStore{db: db}
+}
+
+func (s *mongoStore) Side() Side { return SideMongo }
+
+// changedFilter memilih dokumen yang salah satu created_at, updated_at atau
+// deleted_at-nya setelah since, sama dengan changedAt.
+func changedFilter(since time.Time) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"created_at": bson.M{"$gt": since}},
+		bson.M{"updated_at": bson.M{"$gt": since}},
+		bson.M{"deleted_at": bson.M{"$gt": since}},
+	}}
+}
+
+func (s *mongoStore) find(ctx context.Context, entity Entity, since time.Time, result interface{}) error {
+	cursor, err := s.db.Collection(mgCollections[entity]).Find(ctx, changedFilter(since),
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, result)
+}
+
+func (s *mongoStore) Users(ctx context.Context, since time.Time) ([]User, error) {
+	var docs []mongoModel.User
+	if err := s.find(ctx, EntityUser, since, &docs); err != nil {
+		return nil, err
+	}
+	users := make([]User, 0, len(docs))
+	for _, d := range docs {
+		users = append(users, User{
+			ID: d.ID.Hex(), Username: d.Username, Email: d.Email, PasswordHash: d.Password, Role: d.Role,
+			CreatedAt: d.CreatedAt, UpdatedAt: d.UpdatedAt, DeletedAt: d.DeletedAt,
+		})
+	}
+	return users, nil
+}
+
+func (s *mongoStore) Alumni(ctx context.Context, since time.Time) ([]Alumni, error) {
+	var docs []mongoModel.Alumni
+	if err := s.find(ctx, EntityAlumni, since, &docs); err != nil {
+		return nil, err
+	}
+	alumni := make([]Alumni, 0, len(docs))
+	for _, d := range docs {
+		alumni = append(alumni, Alumni{
+			ID: d.ID.Hex(), UserID: hexRef(d.UserID), NIM: d.NIM, Nama: d.Nama, Jurusan: d.Jurusan,
+			Angkatan: d.Angkatan, TahunLulus: d.TahunLulus, Email: d.Email, NoTelepon: d.NoTelepon, Alamat: d.Alamat,
+			CreatedAt: d.CreatedAt, UpdatedAt: d.UpdatedAt, DeletedAt: d.DeletedAt,
+		})
+	}
+	return alumni, nil
+}
+
+func (s *mongoStore) Pekerjaan(ctx context.Context, since time.Time) ([]Pekerjaan, error) {
+	var docs []mongoModel.Pekerjaan
+	if err := s.find(ctx, EntityPekerjaan, since, &docs); err != nil {
+		return nil, err
+	}
+	pekerjaan := make([]Pekerjaan, 0, len(docs))
+	for _, d := range docs {
+		// updated_at di koleksi pekerjaan boleh kosong; anggap sama dengan created_at.
+		updated := d.CreatedAt
+		if d.UpdatedAt != nil {
+			updated = *d.UpdatedAt
+		}
+		pekerjaan = append(pekerjaan, Pekerjaan{
+			ID: d.ID.Hex(), AlumniID: hexRef(d.AlumniID), NamaPerusahaan: d.NamaPerusahaan,
+			PosisiJabatan: d.PosisiJabatan, BidangIndustri: d.BidangIndustri, LokasiKerja: d.LokasiKerja,
+			GajiRange: d.GajiRange, TanggalMulaiKerja: d.TanggalMulaiKerja, TanggalSelesaiKerja: d.TanggalSelesaiKerja,
+			StatusPekerjaan: d.StatusPekerjaan, DeskripsiPekerjaan: d.Deskripsi, IsDelete: d.IsDelete,
+			DeleteBy: d.DeleteBy, DeletedAt: d.DeletedAt, CreatedAt: d.CreatedAt, UpdatedAt: updated,
+			RentangGaji: d.RentangGaji,
+		})
+	}
+	return pekerjaan, nil
+}
+
+func (s *mongoStore) Changed(ctx context.Context, entity Entity, id string) (time.Time, bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return time.Time{}, false, nil
+	}
+	var doc struct {
+		CreatedAt time.Time  `bson:"created_at"`
+		UpdatedAt *time.Time `bson:"updated_at"`
+		DeletedAt *time.Time `bson:"deleted_at"`
+	}
+	err = s.db.Collection(mgCollections[entity]).FindOne(ctx, bson.M{"_id": objID},
+		options.FindOne().SetProjection(bson.M{"created_at": 1, "updated_at": 1, "deleted_at": 1})).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	updated := doc.CreatedAt
+	if doc.UpdatedAt != nil {
+		updated = *doc.UpdatedAt
+	}
+	return changedAt(doc.CreatedAt, updated, doc.DeletedAt), true, nil
+}
+
+func (s *mongoStore) FindByKey(ctx context.Context, entity Entity, key string) (string, bool, error) {
+	var filter bson.M
+	switch entity {
+	case EntityUser:
+		filter = bson.M{"username": key}
+	case EntityAlumni:
+		filter = bson.M{"nim": key}
+	default:
+		return "", false, nil
+	}
+	var doc struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	err := s.db.Collection(mgCollections[entity]).FindOne(ctx, filter,
+		options.FindOne().SetProjection(bson.M{"_id": 1})).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return doc.ID.Hex(), true, nil
+}
+
+func (s *mongoStore) SaveUser(ctx context.Context, id string, u User) (string, error) {
+	return s.save(ctx, EntityUser, id, bson.M{
+		"username":   u.Username,
+		"email":      u.Email,
+		"password":   u.PasswordHash,
+		"role":       u.Role,
+		"created_at": u.CreatedAt,
+		"updated_at": u.UpdatedAt,
+		"deleted_at": u.DeletedAt,
+	})
+}
+
+func (s *mongoStore) SaveAlumni(ctx context.Context, id string, a Alumni) (string, error) {
+	userID, err := mgRef(a.UserID)
+	if err != nil {
+		return "", err
+	}
+	return s.save(ctx, EntityAlumni, id, bson.M{
+		"user_id":     userID,
+		"nim":         a.NIM,
+		"nama":        a.Nama,
+		"jurusan":     a.Jurusan,
+		"angkatan":    a.Angkatan,
+		"tahun_lulus": a.TahunLulus,
+		"email":       a.Email,
+		"no_telepon":  a.NoTelepon,
+		"alamat":      a.Alamat,
+		"created_at":  a.CreatedAt,
+		"updated_at":  a.UpdatedAt,
+		"deleted_at":  a.DeletedAt,
+	})
+}
+
+func (s *mongoStore) SavePekerjaan(ctx context.Context, id string, p Pekerjaan) (string, error) {
+	alumniID, err := mgRef(p.AlumniID)
+	if err != nil {
+		return "", err
+	}
+	var deleteBy interface{}
+	if p.DeleteBy != "" {
+		deleteBy = p.DeleteBy
+	}
+	return s.save(ctx, EntityPekerjaan, id, bson.M{
+		"alumni_id":             alumniID,
+		"nama_perusahaan":       p.NamaPerusahaan,
+		"posisi_jabatan":        p.PosisiJabatan,
+		"bidang_industri":       p.BidangIndustri,
+		"lokasi_kerja":          p.LokasiKerja,
+		"gaji_range":            p.GajiRange,
+		"tanggal_mulai_kerja":   p.TanggalMulaiKerja,
+		"tanggal_selesai_kerja": p.TanggalSelesaiKerja,
+		"status_pekerjaan":      p.StatusPekerjaan,
+		"deskripsi_pekerjaan":   p.DeskripsiPekerjaan,
+		"is_delete":             p.IsDelete,
+		"delete_by":             deleteBy,
+		"deleted_at":            p.DeletedAt,
+		"created_at":            p.CreatedAt,
+		"updated_at":            p.UpdatedAt,
+		"gaji_min":              p.GajiMin,
+		"gaji_max":              p.GajiMax,
+		"gaji_mata_uang":        p.GajiMataUang,
+		"gaji_periode":          p.GajiPeriode,
+	})
+}
+
+// save memakai $set agar field lain di dokumen tujuan tidak ikut terhapus. Field
+// bernilai nil tidak ditulis (atau di-$unset), sama dengan omitempty di model
+// Mongo, karena repository mencari dokumen aktif dengan deleted_at $exists false.
+// Alumni dan pekerjaan punya version; setiap penulisan menaikkannya agar ETag
+// yang dipegang klien ikut kedaluwarsa.
+func (s *mongoStore) save(ctx context.Context, entity Entity, id string, doc bson.M) (string, error) {
+	set, unset := bson.M{}, bson.M{}
+	for k, v := range doc {
+		if isNil(v) {
+			unset[k] = ""
+		} else {
+			set[k] = v
+		}
+	}
+
+	versioned := entity != EntityUser
+	collection := s.db.Collection(mgCollections[entity])
+	if id == "" {
+		if versioned {
+			set["version"] = 1
+		}
+		res, err := collection.InsertOne(ctx, set)
+		if err != nil {
+			return "", mgDuplicate(err)
+		}
+		return res.InsertedID.(primitive.ObjectID).Hex(), nil
+	}
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return "", fmt.Errorf("id mongo tidak valid: %s", id)
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	if versioned {
+		update["$inc"] = bson.M{"version": 1}
+	}
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": objID}, update); err != nil {
+		return "", mgDuplicate(err)
+	}
+	return id, nil
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+func mgDuplicate(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%w: %v", ErrDuplikat, err)
+	}
+	return err
+}
+
+// mgRef mengubah referensi kosong menjadi ObjectID nol, seperti alumni tanpa user di data Mongo.
+func mgRef(id string) (primitive.ObjectID, error) {
+	if id == "" {
+		return primitive.NilObjectID, nil
+	}
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, fmt.Errorf("id mongo tidak valid: %s", id)
+	}
+	return objID, nil
+}
+
+func hexRef(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.Hex()
+}
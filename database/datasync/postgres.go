@@ -0,0 +1,340 @@
+package datasync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Kolom timestamp di Postgres tidak menyimpan zona waktu, jadi semua waktu
+// ditulis dan dibandingkan dalam UTC.
+
+// changedColumn menghitung waktu perubahan terakhir, sama dengan changedAt.
+// GREATEST di Postgres mengabaikan NULL.
+const changedColumn = "GREATEST(created_at, updated_at, deleted_at)"
+
+var pgTables = map[Entity]string{
+	EntityUser:      "users",
+	EntityAlumni:    "alumni",
+	EntityPekerjaan: "pekerjaan_alumni",
+}
+
+type postgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore membaca dan menulis tabel users, alumni dan pekerjaan_alumni.
+func NewPostgresStore(db *sql.DB) Store {
+	return &postgresStore{db: db}
+}
+
+func (s *postgresStore) Side() Side { return SidePostgres }
+
+func (s *postgresStore) Users(ctx context.Context, since time.Time) ([]User, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, username, email, password_hash, role, created_at, updated_at, deleted_at
+		FROM users WHERE `+changedColumn+` > $1 ORDER BY id`, since.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		var u User
+		var id int
+		var deletedAt sql.NullTime
+		if err := rows.Scan(&id, &u.Username, &u.Email, &u.PasswordHash, &u.Role, &u.CreatedAt, &u.UpdatedAt, &deletedAt); err != nil {
+			return nil, err
+		}
+		u.ID, u.DeletedAt = strconv.Itoa(id), nullTime(deletedAt)
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func (s *postgresStore) Alumni(ctx context.Context, since time.Time) ([]Alumni, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, user_id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat,
+		       created_at, updated_at, deleted_at
+		FROM alumni WHERE `+changedColumn+` > $1 ORDER BY id`, since.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alumni []Alumni
+	for rows.Next() {
+		var a Alumni
+		var id int
+		var userID sql.NullInt64
+		var noTelepon, alamat sql.NullString
+		var deletedAt sql.NullTime
+		if err := rows.Scan(&id, &userID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email,
+			&noTelepon, &alamat, &a.CreatedAt, &a.UpdatedAt, &deletedAt); err != nil {
+			return nil, err
+		}
+		a.ID, a.UserID, a.DeletedAt = strconv.Itoa(id), nullID(userID), nullTime(deletedAt)
+		a.NoTelepon, a.Alamat = nullString(noTelepon), nullString(alamat)
+		alumni = append(alumni, a)
+	}
+	return alumni, rows.Err()
+}
+
+func (s *postgresStore) Pekerjaan(ctx context.Context, since time.Time) ([]Pekerjaan, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan,
+		       is_delete, delete_by, deleted_at, created_at, updated_at,
+		       gaji_min, gaji_max, COALESCE(gaji_mata_uang, ''), COALESCE(gaji_periode, '')
+		FROM pekerjaan_alumni WHERE `+changedColumn+` > $1 ORDER BY id`, since.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pekerjaan []Pekerjaan
+	for rows.Next() {
+		var p Pekerjaan
+		var id, alumniID int
+		var deleteBy sql.NullInt64
+		var selesai, deletedAt sql.NullTime
+		if err := rows.Scan(&id, &alumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja,
+			&p.GajiRange, &p.TanggalMulaiKerja, &selesai, &p.StatusPekerjaan, &p.DeskripsiPekerjaan,
+			&p.IsDelete, &deleteBy, &deletedAt, &p.CreatedAt, &p.UpdatedAt,
+			&p.GajiMin, &p.GajiMax, &p.GajiMataUang, &p.GajiPeriode); err != nil {
+			return nil, err
+		}
+		p.ID, p.AlumniID, p.DeleteBy = strconv.Itoa(id), strconv.Itoa(alumniID), nullID(deleteBy)
+		p.TanggalSelesaiKerja, p.DeletedAt = nullTime(selesai), nullTime(deletedAt)
+		pekerjaan = append(pekerjaan, p)
+	}
+	return pekerjaan, rows.Err()
+}
+
+func (s *postgresStore) Changed(ctx context.Context, entity Entity, id string) (time.Time, bool, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return time.Time{}, false, nil
+	}
+	var t time.Time
+	err = s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT %s FROM %s WHERE id = $1", changedColumn, pgTables[entity]), n).Scan(&t)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	return t, err == nil, err
+}
+
+func (s *postgresStore) FindByKey(ctx context.Context, entity Entity, key string) (string, bool, error) {
+	var query string
+	switch entity {
+	case EntityUser:
+		query = "SELECT id FROM users WHERE username = $1"
+	case EntityAlumni:
+		query = "SELECT id FROM alumni WHERE nim = $1"
+	default:
+		return "", false, nil
+	}
+	var id int
+	err := s.db.QueryRowContext(ctx, query, key).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return strconv.Itoa(id), true, nil
+}
+
+func (s *postgresStore) SaveUser(ctx context.Context, id string, u User) (string, error) {
+	args := []interface{}{u.Username, u.Email, u.PasswordHash, u.Role, u.CreatedAt.UTC(), u.UpdatedAt.UTC(), utcPtr(u.DeletedAt)}
+	return s.save(ctx, id, args,
+		`INSERT INTO users (username, email, password_hash, role, created_at, updated_at, deleted_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		`UPDATE users SET username = $1, email = $2, password_hash = $3, role = $4,
+		 created_at = $5, updated_at = $6, deleted_at = $7 WHERE id = $8`)
+}
+
+func (s *postgresStore) SaveAlumni(ctx context.Context, id string, a Alumni) (string, error) {
+	userID, err := pgRef(a.UserID)
+	if err != nil {
+		return "", err
+	}
+	args := []interface{}{userID, a.NIM, a.Nama, a.Jurusan, a.Angkatan, a.TahunLulus, a.Email, a.NoTelepon, a.Alamat,
+		a.CreatedAt.UTC(), a.UpdatedAt.UTC(), utcPtr(a.DeletedAt)}
+	return s.save(ctx, id, args,
+		`INSERT INTO alumni (user_id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat,
+		 created_at, updated_at, deleted_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`,
+		`UPDATE alumni SET user_id = $1, nim = $2, nama = $3, jurusan = $4, angkatan = $5, tahun_lulus = $6,
+		 email = $7, no_telepon = $8, alamat = $9, created_at = $10, updated_at = $11, deleted_at = $12,
+		 version = version + 1 WHERE id = $13`)
+}
+
+func (s *postgresStore) SavePekerjaan(ctx context.Context, id string, p Pekerjaan) (string, error) {
+	alumniID, err := pgRef(p.AlumniID)
+	if err != nil {
+		return "", err
+	}
+	deleteBy, err := pgRef(p.DeleteBy)
+	if err != nil {
+		return "", err
+	}
+	args := []interface{}{alumniID, p.NamaPerusahaan, p.PosisiJabatan, p.BidangIndustri, p.LokasiKerja, p.GajiRange,
+		p.TanggalMulaiKerja, p.TanggalSelesaiKerja, p.StatusPekerjaan, p.DeskripsiPekerjaan,
+		p.IsDelete, deleteBy, utcPtr(p.DeletedAt), p.CreatedAt.UTC(), p.UpdatedAt.UTC(),
+		p.GajiMin, p.GajiMax, nullIfEmpty(p.GajiMataUang), nullIfEmpty(p.GajiPeriode)}
+	return s.save(ctx, id, args,
+		`INSERT INTO pekerjaan_alumni (alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja,
+		 gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan,
+		 is_delete, delete_by, deleted_at, created_at, updated_at, gaji_min, gaji_max, gaji_mata_uang, gaji_periode)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19) RETURNING id`,
+		`UPDATE pekerjaan_alumni SET alumni_id = $1, nama_perusahaan = $2, posisi_jabatan = $3, bidang_industri = $4,
+		 lokasi_kerja = $5, gaji_range = $6, tanggal_mulai_kerja = $7, tanggal_selesai_kerja = $8,
+		 status_pekerjaan = $9, deskripsi_pekerjaan = $10, is_delete = $11, delete_by = $12, deleted_at = $13,
+		 created_at = $14, updated_at = $15, gaji_min = $16, gaji_max = $17, gaji_mata_uang = $18, gaji_periode = $19,
+		 version = version + 1 WHERE id = $20`)
+}
+
+// save menjalankan insert jika id kosong, atau update dengan id sebagai argumen terakhir.
+func (s *postgresStore) save(ctx context.Context, id string, args []interface{}, insert, update string) (string, error) {
+	if id == "" {
+		var newID int
+		if err := s.db.QueryRowContext(ctx, insert, args...).Scan(&newID); err != nil {
+			return "", pgDuplicate(err)
+		}
+		return strconv.Itoa(newID), nil
+	}
+
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return "", fmt.Errorf("id postgres tidak valid: %s", id)
+	}
+	if _, err := s.db.ExecContext(ctx, update, append(args, n)...); err != nil {
+		return "", pgDuplicate(err)
+	}
+	return id, nil
+}
+
+func pgDuplicate(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return fmt.Errorf("%w: %s", ErrDuplikat, pqErr.Constraint)
+	}
+	return err
+}
+
+// pgRef mengubah referensi kosong menjadi NULL.
+func pgRef(id string) (interface{}, error) {
+	if id == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("id postgres tidak valid: %s", id)
+	}
+	return n, nil
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func nullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func nullID(n sql.NullInt64) string {
+	if !n.Valid {
+		return ""
+	}
+	return strconv.FormatInt(n.Int64, 10)
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func utcPtr(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+type postgresMapping struct {
+	db *sql.DB
+}
+
+// NewPostgresMapping menyimpan id map di tabel sync_id_map dan waktu
+// sinkronisasi di sync_state (migrasi 0006).
+func NewPostgresMapping(db *sql.DB) Mapping {
+	return &postgresMapping{db: db}
+}
+
+func (m *postgresMapping) Lookup(ctx context.Context, entity Entity, from Side, id string) (string, bool, error) {
+	var query string
+	var arg interface{} = id
+	if from == SidePostgres {
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			return "", false, nil
+		}
+		query, arg = "SELECT mongo_id FROM sync_id_map WHERE entity = $1 AND postgres_id = $2", n
+	} else {
+		query = "SELECT postgres_id::text FROM sync_id_map WHERE entity = $1 AND mongo_id = $2"
+	}
+
+	var other string
+	err := m.db.QueryRowContext(ctx, query, string(entity), arg).Scan(&other)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return other, true, nil
+}
+
+func (m *postgresMapping) Save(ctx context.Context, entity Entity, postgresID, mongoID string) error {
+	n, err := strconv.Atoi(postgresID)
+	if err != nil {
+		return fmt.Errorf("id postgres tidak valid: %s", postgresID)
+	}
+	_, err = m.db.ExecContext(ctx, `
+		INSERT INTO sync_id_map (entity, postgres_id, mongo_id) VALUES ($1, $2, $3)
+		ON CONFLICT (entity, postgres_id) DO UPDATE SET mongo_id = EXCLUDED.mongo_id, synced_at = NOW()`,
+		string(entity), n, mongoID)
+	return err
+}
+
+func (m *postgresMapping) LastSync(ctx context.Context, direction string) (time.Time, error) {
+	var t time.Time
+	err := m.db.QueryRowContext(ctx, "SELECT last_synced_at FROM sync_state WHERE direction = $1", direction).Scan(&t)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	return t, err
+}
+
+func (m *postgresMapping) SetLastSync(ctx context.Context, direction string, t time.Time) error {
+	_, err := m.db.ExecContext(ctx, `
+		INSERT INTO sync_state (direction, last_synced_at) VALUES ($1, $2)
+		ON CONFLICT (direction) DO UPDATE SET last_synced_at = EXCLUDED.last_synced_at`,
+		direction, t.UTC())
+	return err
+}
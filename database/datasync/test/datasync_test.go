@@ -0,0 +1,286 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"latihan2/database/datasync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore menyimpan record di map; ID dibuat dengan prefix sisi agar
+// ID sumber dan tujuan tidak mungkin tertukar tanpa ketahuan.
+type fakeStore struct {
+	side      datasync.Side
+	next      int
+	users     map[string]datasync.User
+	alumni    map[string]datasync.Alumni
+	pekerjaan map[string]datasync.Pekerjaan
+	writes    int
+}
+
+func newFakeStore(side datasync.Side) *fakeStore {
+	return &fakeStore{side: side, users: map[string]datasync.User{},
+		alumni: map[string]datasync.Alumni{}, pekerjaan: map[string]datasync.Pekerjaan{}}
+}
+
+func (s *fakeStore) newID() string {
+	s.next++
+	return fmt.Sprintf("%s-%d", s.side, s.next)
+}
+
+func changed(created, updated time.Time, deleted *time.Time) time.Time {
+	t := created
+	if updated.After(t) {
+		t = updated
+	}
+	if deleted != nil && deleted.After(t) {
+		t = *deleted
+	}
+	return t
+}
+
+func (s *fakeStore) Side() datasync.Side { return s.side }
+
+func (s *fakeStore) Users(_ context.Context, since time.Time) ([]datasync.User, error) {
+	var out []datasync.User
+	for _, u := range s.users {
+		if changed(u.CreatedAt, u.UpdatedAt, u.DeletedAt).After(since) {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeStore) Alumni(_ context.Context, since time.Time) ([]datasync.Alumni, error) {
+	var out []datasync.Alumni
+	for _, a := range s.alumni {
+		if changed(a.CreatedAt, a.UpdatedAt, a.DeletedAt).After(since) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeStore) Pekerjaan(_ context.Context, since time.Time) ([]datasync.Pekerjaan, error) {
+	var out []datasync.Pekerjaan
+	for _, p := range s.pekerjaan {
+		if changed(p.CreatedAt, p.UpdatedAt, p.DeletedAt).After(since) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeStore) Changed(_ context.Context, entity datasync.Entity, id string) (time.Time, bool, error) {
+	switch entity {
+	case datasync.EntityUser:
+		u, ok := s.users[id]
+		return changed(u.CreatedAt, u.UpdatedAt, u.DeletedAt), ok, nil
+	case datasync.EntityAlumni:
+		a, ok := s.alumni[id]
+		return changed(a.CreatedAt, a.UpdatedAt, a.DeletedAt), ok, nil
+	}
+	p, ok := s.pekerjaan[id]
+	return changed(p.CreatedAt, p.UpdatedAt, p.DeletedAt), ok, nil
+}
+
+func (s *fakeStore) FindByKey(_ context.Context, entity datasync.Entity, key string) (string, bool, error) {
+	switch entity {
+	case datasync.EntityUser:
+		for id, u := range s.users {
+			if u.Username == key {
+				return id, true, nil
+			}
+		}
+	case datasync.EntityAlumni:
+		for id, a := range s.alumni {
+			if a.NIM == key {
+				return id, true, nil
+			}
+		}
+	}
+	return "", false, nil
+}
+
+func (s *fakeStore) SaveUser(_ context.Context, id string, u datasync.User) (string, error) {
+	for otherID, other := range s.users {
+		if otherID != id && other.Email == u.Email {
+			return "", fmt.Errorf("%w: users_email_key", datasync.ErrDuplikat)
+		}
+	}
+	if id == "" {
+		id = s.newID()
+	}
+	u.ID = id
+	s.users[id] = u
+	s.writes++
+	return id, nil
+}
+
+func (s *fakeStore) SaveAlumni(_ context.Context, id string, a datasync.Alumni) (string, error) {
+	if id == "" {
+		id = s.newID()
+	}
+	a.ID = id
+	s.alumni[id] = a
+	s.writes++
+	return id, nil
+}
+
+func (s *fakeStore) SavePekerjaan(_ context.Context, id string, p datasync.Pekerjaan) (string, error) {
+	if id == "" {
+		id = s.newID()
+	}
+	p.ID = id
+	s.pekerjaan[id] = p
+	s.writes++
+	return id, nil
+}
+
+type fakeMapping struct {
+	pg, mg   map[string]string
+	lastSync map[string]time.Time
+}
+
+func newFakeMapping() *fakeMapping {
+	return &fakeMapping{pg: map[string]string{}, mg: map[string]string{}, lastSync: map[string]time.Time{}}
+}
+
+func (m *fakeMapping) Lookup(_ context.Context, entity datasync.Entity, from datasync.Side, id string) (string, bool, error) {
+	ids := m.pg
+	if from == datasync.SideMongo {
+		ids = m.mg
+	}
+	other, ok := ids[string(entity)+"/"+id]
+	return other, ok, nil
+}
+
+func (m *fakeMapping) Save(_ context.Context, entity datasync.Entity, postgresID, mongoID string) error {
+	m.pg[string(entity)+"/"+postgresID] = mongoID
+	m.mg[string(entity)+"/"+mongoID] = postgresID
+	return nil
+}
+
+func (m *fakeMapping) LastSync(_ context.Context, direction string) (time.Time, error) {
+	return m.lastSync[direction], nil
+}
+
+func (m *fakeMapping) SetLastSync(_ context.Context, direction string, t time.Time) error {
+	m.lastSync[direction] = t
+	return nil
+}
+
+// seedPostgres mengisi satu user, satu alumni miliknya dan satu pekerjaan.
+func seedPostgres(t *testing.T, pg *fakeStore) (userID, alumniID, pekerjaanID string) {
+	t.Helper()
+	lalu := time.Now().Add(-time.Hour)
+	ctx := context.Background()
+
+	userID, err := pg.SaveUser(ctx, "", datasync.User{Username: "budi", Email: "budi@mail.com", Role: "user", CreatedAt: lalu, UpdatedAt: lalu})
+	require.NoError(t, err)
+	alumniID, err = pg.SaveAlumni(ctx, "", datasync.Alumni{UserID: userID, NIM: "2019001", Nama: "Budi", CreatedAt: lalu, UpdatedAt: lalu})
+	require.NoError(t, err)
+	pekerjaanID, err = pg.SavePekerjaan(ctx, "", datasync.Pekerjaan{AlumniID: alumniID, NamaPerusahaan: "Tokopedia",
+		IsDelete: true, DeleteBy: userID, CreatedAt: lalu, UpdatedAt: lalu})
+	require.NoError(t, err)
+	return userID, alumniID, pekerjaanID
+}
+
+func stats(r *datasync.Report, entity datasync.Entity) datasync.Stats {
+	for _, s := range r.Stats {
+		if s.Entity == entity {
+			return s
+		}
+	}
+	return datasync.Stats{}
+}
+
+func TestSyncMenerjemahkanForeignKey(t *testing.T) {
+	ctx := context.Background()
+	pg, mg, mapping := newFakeStore(datasync.SidePostgres), newFakeStore(datasync.SideMongo), newFakeMapping()
+	userID, alumniID, pekerjaanID := seedPostgres(t, pg)
+
+	report, err := datasync.Run(ctx, pg, mg, mapping, datasync.Options{})
+	require.NoError(t, err)
+	assert.Empty(t, report.Conflicts)
+	assert.Equal(t, 1, stats(report, datasync.EntityPekerjaan).Dibuat)
+
+	mgUser, _, _ := mapping.Lookup(ctx, datasync.EntityUser, datasync.SidePostgres, userID)
+	mgAlumni, _, _ := mapping.Lookup(ctx, datasync.EntityAlumni, datasync.SidePostgres, alumniID)
+	mgPekerjaan, ok, _ := mapping.Lookup(ctx, datasync.EntityPekerjaan, datasync.SidePostgres, pekerjaanID)
+	require.True(t, ok)
+	assert.Equal(t, mgUser, mg.alumni[mgAlumni].UserID)
+	assert.Equal(t, mgAlumni, mg.pekerjaan[mgPekerjaan].AlumniID)
+	assert.Equal(t, mgUser, mg.pekerjaan[mgPekerjaan].DeleteBy)
+
+	// Run berikutnya incremental: tanpa perubahan tidak ada yang ditulis.
+	writes := mg.writes
+	report, err = datasync.Run(ctx, pg, mg, mapping, datasync.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, writes, mg.writes)
+	assert.Equal(t, 0, stats(report, datasync.EntityAlumni).Diubah)
+
+	// Arah sebaliknya memakai id map yang sama, jadi tidak ada record yang digandakan.
+	report, err = datasync.Run(ctx, mg, pg, mapping, datasync.Options{})
+	require.NoError(t, err)
+	assert.Len(t, pg.alumni, 1)
+	assert.Equal(t, 1, stats(report, datasync.EntityAlumni).Sama)
+}
+
+func TestSyncKonflik(t *testing.T) {
+	ctx := context.Background()
+	pg, mg, mapping := newFakeStore(datasync.SidePostgres), newFakeStore(datasync.SideMongo), newFakeMapping()
+	_, alumniID, _ := seedPostgres(t, pg)
+	_, err := datasync.Run(ctx, pg, mg, mapping, datasync.Options{})
+	require.NoError(t, err)
+	since := mapping.lastSync[datasync.Direction(datasync.SidePostgres, datasync.SideMongo)]
+
+	// Alumni yang sama diubah di kedua database setelah sinkronisasi.
+	mgAlumni, _, _ := mapping.Lookup(ctx, datasync.EntityAlumni, datasync.SidePostgres, alumniID)
+	a := pg.alumni[alumniID]
+	a.Nama, a.UpdatedAt = "Budi (pg)", since.Add(time.Second)
+	pg.alumni[alumniID] = a
+	b := mg.alumni[mgAlumni]
+	b.Nama, b.UpdatedAt = "Budi (mongo)", since.Add(2*time.Second)
+	mg.alumni[mgAlumni] = b
+
+	report, err := datasync.Run(ctx, pg, mg, mapping, datasync.Options{})
+	require.NoError(t, err)
+	require.Len(t, report.Conflicts, 1)
+	assert.Equal(t, datasync.EntityAlumni, report.Conflicts[0].Entity)
+	assert.Equal(t, "Budi (mongo)", mg.alumni[mgAlumni].Nama)
+	assert.Equal(t, since, mapping.lastSync[datasync.Direction(datasync.SidePostgres, datasync.SideMongo)],
+		"waktu sinkronisasi tidak dimajukan jika ada konflik")
+
+	report, err = datasync.Run(ctx, pg, mg, mapping, datasync.Options{Overwrite: true})
+	require.NoError(t, err)
+	assert.Empty(t, report.Conflicts)
+	assert.Equal(t, "Budi (pg)", mg.alumni[mgAlumni].Nama)
+}
+
+func TestSyncDuplikatDanDryRun(t *testing.T) {
+	ctx := context.Background()
+	pg, mg, mapping := newFakeStore(datasync.SidePostgres), newFakeStore(datasync.SideMongo), newFakeMapping()
+	seedPostgres(t, pg)
+
+	report, err := datasync.Run(ctx, pg, mg, mapping, datasync.Options{DryRun: true})
+	require.NoError(t, err)
+	assert.Empty(t, report.Conflicts, "anak dari record baru tidak dianggap belum tersinkron")
+	assert.Equal(t, 1, stats(report, datasync.EntityPekerjaan).Dibuat)
+	assert.Zero(t, mg.writes)
+	assert.Empty(t, mapping.lastSync)
+
+	// Email yang sama sudah dipakai user lain di tujuan: user dan semua turunannya jadi konflik.
+	_, err = mg.SaveUser(ctx, "", datasync.User{Username: "lain", Email: "budi@mail.com", CreatedAt: time.Now()})
+	require.NoError(t, err)
+	report, err = datasync.Run(ctx, pg, mg, mapping, datasync.Options{})
+	require.NoError(t, err)
+	require.Len(t, report.Conflicts, 3)
+	assert.Contains(t, report.Conflicts[0].Alasan, datasync.ErrDuplikat.Error())
+	assert.Equal(t, datasync.EntityUser, report.Conflicts[0].Entity)
+	assert.Len(t, mg.alumni, 0)
+}
@@ -0,0 +1,112 @@
+// Package migration menjalankan migrasi skema yang berversi untuk Postgres dan MongoDB.
+// Migrasi Postgres adalah file SQL yang di-embed (postgres/NNNN_nama.up.sql dan
+// .down.sql); migrasi Mongo didefinisikan di kode (mongo.go). Versi yang sudah
+// dijalankan dicatat di tabel/koleksi schema_migrations.
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const table = "schema_migrations"
+
+// Status adalah keadaan satu migrasi di database.
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// Migrator diimplementasikan oleh runner Postgres dan Mongo.
+type Migrator interface {
+	// Up menjalankan semua migrasi yang belum dijalankan.
+	Up(ctx context.Context) error
+	// Down membatalkan steps migrasi terakhir.
+	Down(ctx context.Context, steps int) error
+	// To menaikkan atau menurunkan skema sampai tepat di version. Version 0 berarti kosong.
+	To(ctx context.Context, version int64) error
+	Status(ctx context.Context) ([]Status, error)
+}
+
+// Step adalah satu langkah yang akan dijalankan runner: naik ke Version atau turun dari Version.
+type Step struct {
+	Version int64
+	Up      bool
+}
+
+// Plan menghitung langkah dari keadaan applied menuju target. Migrasi yang
+// belum jalan di bawah target ikut dijalankan, sehingga celah versi tertutup.
+func Plan(versions []int64, applied map[int64]bool, target int64) []Step {
+	sorted := append([]int64(nil), versions...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var steps []Step
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if v := sorted[i]; v > target && applied[v] {
+			steps = append(steps, Step{Version: v, Up: false})
+		}
+	}
+	for _, v := range sorted {
+		if v <= target && !applied[v] {
+			steps = append(steps, Step{Version: v, Up: true})
+		}
+	}
+	return steps
+}
+
+// latest mengembalikan versi tertinggi yang tersedia.
+func latest(versions []int64) int64 {
+	var max int64
+	for _, v := range versions {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// DownTarget mengembalikan versi tujuan setelah membatalkan steps migrasi terakhir yang sudah jalan.
+func DownTarget(versions []int64, applied map[int64]bool, steps int) (int64, error) {
+	if steps < 1 {
+		return 0, fmt.Errorf("jumlah langkah down harus >= 1")
+	}
+	var done []int64
+	for _, v := range versions {
+		if applied[v] {
+			done = append(done, v)
+		}
+	}
+	sort.Slice(done, func(i, j int) bool { return done[i] < done[j] })
+	if steps >= len(done) {
+		return 0, nil
+	}
+	return done[len(done)-steps-1], nil
+}
+
+func buildStatus(versions []int64, names map[int64]string, applied map[int64]time.Time) []Status {
+	sorted := append([]int64(nil), versions...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	result := make([]Status, 0, len(sorted))
+	for _, v := range sorted {
+		s := Status{Version: v, Name: names[v]}
+		if at, ok := applied[v]; ok {
+			at := at
+			s.Applied, s.AppliedAt = true, &at
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+func appliedSet(applied map[int64]time.Time) map[int64]bool {
+	set := make(map[int64]bool, len(applied))
+	for v := range applied {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,318 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoMigration adalah satu migrasi Mongo. Up dan Down harus idempoten karena
+// Mongo tidak punya transaksi DDL: jika pencatatan versi gagal, migrasi bisa diulang.
+type MongoMigration struct {
+	Version int64
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+	Down    func(ctx context.Context, db *mongo.Database) error
+}
+
+// MongoMigrations adalah daftar migrasi Mongo, urut menurut Version.
+var MongoMigrations = []MongoMigration{
+	{
+		Version: 1,
+		Name:    "create_collections",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, name := range []string{"user", "alumni", "pekerjaan", "files"} {
+				if err := createCollection(ctx, db, name, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, name := range []string{"files", "pekerjaan", "alumni", "user"} {
+				if err := db.Collection(name).Drop(ctx); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// Satu koleksi hanya boleh punya satu text index, jadi semua field yang dicari ada di index yang sama.
+		Version: 2,
+		Name:    "text_indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := createIndexes(ctx, db, "alumni", mongo.IndexModel{
+				Keys:    bson.D{{Key: "nama", Value: "text"}, {Key: "jurusan", Value: "text"}},
+				Options: options.Index().SetName("alumni_text").SetWeights(bson.M{"nama": 3, "jurusan": 2}).SetDefaultLanguage("none"),
+			}); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, "pekerjaan", mongo.IndexModel{
+				Keys: bson.D{
+					{Key: "nama_perusahaan", Value: "text"},
+					{Key: "posisi_jabatan", Value: "text"},
+					{Key: "deskripsi_pekerjaan", Value: "text"},
+				},
+				Options: options.Index().SetName("pekerjaan_text").
+					SetWeights(bson.M{"nama_perusahaan": 3, "posisi_jabatan": 3, "deskripsi_pekerjaan": 1}).
+					SetDefaultLanguage("none"),
+			})
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if err := dropIndex(ctx, db, "alumni", "alumni_text"); err != nil {
+				return err
+			}
+			return dropIndex(ctx, db, "pekerjaan", "pekerjaan_text")
+		},
+	},
+	{
+		Version: 3,
+		Name:    "json_schema_validators",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for name, validator := range mongoValidators {
+				if err := createCollection(ctx, db, name, validator); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for name := range mongoValidators {
+				if err := createCollection(ctx, db, name, bson.M{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// Index unik gagal dibuat jika data lama sudah punya duplikat; bersihkan dulu lalu ulangi.
+		Version: 4,
+		Name:    "unique_and_query_indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for collection, indexes := range mongoIndexes {
+				if err := createIndexes(ctx, db, collection, indexes...); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for collection, indexes := range mongoIndexes {
+				for _, name := range indexNames(indexes) {
+					if err := dropIndex(ctx, db, collection, name); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// Sama dengan migrasi Postgres 0007: dokumen lama mulai dari version 1.
+		Version: 5,
+		Name:    "version_field",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, name := range []string{"alumni", "pekerjaan"} {
+				if _, err := db.Collection(name).UpdateMany(ctx,
+					bson.M{"version": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"version": 1}}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, name := range []string{"alumni", "pekerjaan"} {
+				if _, err := db.Collection(name).UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"version": ""}}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// Sama dengan migrasi Postgres 0008. Index unik seq membuat dua
+		// instance yang menambah entri bersamaan tidak bisa mencabangkan rantai.
+		Version: 6,
+		Name:    "audit_log",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := createCollection(ctx, db, "audit_log", nil); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, "audit_log",
+				mongo.IndexModel{Keys: bson.D{{Key: "seq", Value: 1}}, Options: options.Index().SetName("seq_unique").SetUnique(true)},
+				mongo.IndexModel{Keys: bson.D{{Key: "time", Value: 1}}, Options: options.Index().SetName("time")},
+				mongo.IndexModel{Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "seq", Value: -1}}, Options: options.Index().SetName("actor_seq")},
+				mongo.IndexModel{Keys: bson.D{{Key: "entity", Value: 1}, {Key: "entity_id", Value: 1}, {Key: "seq", Value: -1}}, Options: options.Index().SetName("entity_seq")},
+				mongo.IndexModel{Keys: bson.D{{Key: "request_id", Value: 1}}, Options: options.Index().SetName("request_id")},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return db.Collection("audit_log").Drop(ctx)
+		},
+	},
+}
+
+// Mongo menjalankan MongoMigrations dan mencatat versinya di koleksi schema_migrations.
+type Mongo struct {
+	db         *mongo.Database
+	migrations map[int64]MongoMigration
+}
+
+func NewMongo(db *mongo.Database) (*Mongo, error) {
+	migrations := map[int64]MongoMigration{}
+	for _, m := range MongoMigrations {
+		if _, ok := migrations[m.Version]; ok {
+			return nil, fmt.Errorf("versi migrasi mongo %d terdaftar dua kali", m.Version)
+		}
+		if m.Up == nil || m.Down == nil {
+			return nil, fmt.Errorf("migrasi mongo %04d_%s harus punya Up dan Down", m.Version, m.Name)
+		}
+		migrations[m.Version] = m
+	}
+	return &Mongo{db: db, migrations: migrations}, nil
+}
+
+func (m *Mongo) versions() []int64 {
+	versions := make([]int64, 0, len(m.migrations))
+	for v := range m.migrations {
+		versions = append(versions, v)
+	}
+	return versions
+}
+
+func (m *Mongo) Up(ctx context.Context) error {
+	return m.To(ctx, latest(m.versions()))
+}
+
+func (m *Mongo) Down(ctx context.Context, steps int) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	target, err := DownTarget(m.versions(), appliedSet(applied), steps)
+	if err != nil {
+		return err
+	}
+	return m.migrate(ctx, applied, target)
+}
+
+func (m *Mongo) To(ctx context.Context, version int64) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	return m.migrate(ctx, applied, version)
+}
+
+func (m *Mongo) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := map[int64]string{}
+	for v, mig := range m.migrations {
+		names[v] = mig.Name
+	}
+	return buildStatus(m.versions(), names, applied), nil
+}
+
+func (m *Mongo) migrate(ctx context.Context, applied map[int64]time.Time, target int64) error {
+	records := m.db.Collection(table)
+	for _, s := range Plan(m.versions(), appliedSet(applied), target) {
+		mig := m.migrations[s.Version]
+
+		var err error
+		if s.Up {
+			if err = mig.Up(ctx, m.db); err == nil {
+				_, err = records.InsertOne(ctx, bson.M{"_id": mig.Version, "name": mig.Name, "applied_at": time.Now()})
+			}
+		} else {
+			if err = mig.Down(ctx, m.db); err == nil {
+				_, err = records.DeleteOne(ctx, bson.M{"_id": mig.Version})
+			}
+		}
+
+		arah := "down"
+		if s.Up {
+			arah = "up"
+		}
+		if err != nil {
+			return fmt.Errorf("migrasi mongo %04d_%s (%s): %w", mig.Version, mig.Name, arah, err)
+		}
+		log.Printf("migrasi mongo %04d_%s: %s", mig.Version, mig.Name, arah)
+	}
+	return nil
+}
+
+func (m *Mongo) applied(ctx context.Context) (map[int64]time.Time, error) {
+	cursor, err := m.db.Collection(table).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []struct {
+		Version   int64     `bson:"_id"`
+		AppliedAt time.Time `bson:"applied_at"`
+	}
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]time.Time, len(records))
+	for _, r := range records {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+// createCollection membuat koleksi jika belum ada. Validator (boleh nil) dipasang
+// lewat collMod jika koleksinya sudah ada; validator kosong menghapus validasi.
+// Level "moderate" membuat dokumen lama yang belum valid tetap bisa diupdate.
+func createCollection(ctx context.Context, db *mongo.Database, name string, validator bson.M) error {
+	names, err := db.ListCollectionNames(ctx, bson.M{"name": name})
+	if err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		if validator == nil {
+			return nil
+		}
+		return db.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: name},
+			{Key: "validator", Value: validator},
+			{Key: "validationLevel", Value: "moderate"},
+		}).Err()
+	}
+
+	opts := options.CreateCollection()
+	if validator != nil {
+		opts.SetValidator(validator).SetValidationLevel("moderate")
+	}
+	return db.CreateCollection(ctx, name, opts)
+}
+
+func createIndexes(ctx context.Context, db *mongo.Database, collection string, indexes ...mongo.IndexModel) error {
+	_, err := db.Collection(collection).Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// dropIndex menghapus index berdasarkan nama dan mengabaikan index yang memang belum ada.
+func dropIndex(ctx context.Context, db *mongo.Database, collection, name string) error {
+	_, err := db.Collection(collection).Indexes().DropOne(ctx, name)
+	if se, ok := err.(mongo.ServerError); ok && se.HasErrorCode(errCodeIndexNotFound) {
+		return nil
+	}
+	return err
+}
+
+// Kode error Mongo "IndexNotFound".
+const errCodeIndexNotFound = 27
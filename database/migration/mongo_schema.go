@@ -0,0 +1,125 @@
+package migration
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Validator $jsonSchema untuk setiap koleksi, mengikuti struct di app/model/mongo.
+// Field yang di struct memakai omitempty atau pointer tidak diwajibkan dan boleh null.
+var mongoValidators = map[string]bson.M{
+	"user": jsonSchema(
+		[]string{"username", "email", "password", "role"},
+		bson.M{
+			"username":   bson.M{"bsonType": "string", "minLength": 1},
+			"email":      bson.M{"bsonType": "string"},
+			"password":   bson.M{"bsonType": "string", "minLength": 1},
+			"role":       bson.M{"enum": bson.A{"admin", "user"}},
+			"created_at": bson.M{"bsonType": "date"},
+			"updated_at": bson.M{"bsonType": "date"},
+			"deleted_at": bson.M{"bsonType": bson.A{"date", "null"}},
+		},
+	),
+	"alumni": jsonSchema(
+		[]string{"user_id", "nim", "nama", "jurusan", "email"},
+		bson.M{
+			"user_id":     bson.M{"bsonType": "objectId"},
+			"nim":         bson.M{"bsonType": "string", "minLength": 1},
+			"nama":        bson.M{"bsonType": "string", "minLength": 1},
+			"jurusan":     bson.M{"bsonType": "string", "minLength": 1},
+			"angkatan":    bson.M{"bsonType": bson.A{"int", "long"}},
+			"tahun_lulus": bson.M{"bsonType": bson.A{"int", "long"}},
+			"email":       bson.M{"bsonType": "string"},
+			"no_telepon":  bson.M{"bsonType": bson.A{"string", "null"}},
+			"alamat":      bson.M{"bsonType": bson.A{"string", "null"}},
+			"created_at":  bson.M{"bsonType": "date"},
+			"updated_at":  bson.M{"bsonType": "date"},
+			"deleted_at":  bson.M{"bsonType": bson.A{"date", "null"}},
+		},
+	),
+	"pekerjaan": jsonSchema(
+		[]string{"alumni_id"},
+		bson.M{
+			"alumni_id":             bson.M{"bsonType": "objectId"},
+			"nama_perusahaan":       bson.M{"bsonType": "string"},
+			"posisi_jabatan":        bson.M{"bsonType": "string"},
+			"bidang_industri":       bson.M{"bsonType": "string"},
+			"lokasi_kerja":          bson.M{"bsonType": "string"},
+			"gaji_range":            bson.M{"bsonType": "string"},
+			"tanggal_mulai_kerja":   bson.M{"bsonType": bson.A{"date", "null"}},
+			"tanggal_selesai_kerja": bson.M{"bsonType": bson.A{"date", "null"}},
+			"status_pekerjaan":      bson.M{"bsonType": "string"},
+			"deskripsi_pekerjaan":   bson.M{"bsonType": "string"},
+			"is_delete":             bson.M{"bsonType": "bool"},
+			"delete_by":             bson.M{"bsonType": bson.A{"string", "null"}},
+			"deleted_at":            bson.M{"bsonType": bson.A{"date", "null"}},
+			"created_at":            bson.M{"bsonType": "date"},
+			"updated_at":            bson.M{"bsonType": bson.A{"date", "null"}},
+			"gaji_min":              bson.M{"bsonType": bson.A{"int", "long", "null"}, "minimum": 0},
+			"gaji_max":              bson.M{"bsonType": bson.A{"int", "long", "null"}, "minimum": 0},
+			"gaji_mata_uang":        bson.M{"bsonType": "string", "maxLength": 3},
+			"gaji_periode":          bson.M{"bsonType": "string"},
+		},
+	),
+	"files": jsonSchema(
+		[]string{"file_name", "file_path", "file_size", "file_type", "uploaded_at", "uploaded_by", "owner_id"},
+		bson.M{
+			"file_name":     bson.M{"bsonType": "string", "minLength": 1},
+			"original_name": bson.M{"bsonType": "string"},
+			"file_path":     bson.M{"bsonType": "string", "minLength": 1},
+			"file_size":     bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 0},
+			"file_type":     bson.M{"bsonType": "string"},
+			"uploaded_at":   bson.M{"bsonType": "date"},
+			"uploaded_by":   bson.M{"bsonType": "objectId"},
+			"owner_id":      bson.M{"bsonType": "objectId"},
+		},
+	),
+}
+
+func jsonSchema(required []string, properties bson.M) bson.M {
+	return bson.M{"$jsonSchema": bson.M{
+		"bsonType":   "object",
+		"required":   required,
+		"properties": properties,
+	}}
+}
+
+// Email boleh kosong di beberapa data lama, jadi unique hanya berlaku untuk email yang terisi.
+var emailTerisi = bson.M{"email": bson.M{"$gt": ""}}
+
+// mongoIndexes berisi index unik dan index pendukung untuk setiap filter di app/repository/mongo.
+var mongoIndexes = map[string][]mongo.IndexModel{
+	"user": {
+		{Keys: bson.D{{Key: "username", Value: 1}}, Options: options.Index().SetName("user_username_unique").SetUnique(true)},
+		{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetName("user_email_unique").SetUnique(true).SetPartialFilterExpression(emailTerisi)},
+		{Keys: bson.D{{Key: "deleted_at", Value: 1}}, Options: options.Index().SetName("user_deleted_at")},
+	},
+	"alumni": {
+		{Keys: bson.D{{Key: "nim", Value: 1}}, Options: options.Index().SetName("alumni_nim_unique").SetUnique(true)},
+		{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetName("alumni_email_unique").SetUnique(true).SetPartialFilterExpression(emailTerisi)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetName("alumni_user_id")},
+		{Keys: bson.D{{Key: "deleted_at", Value: 1}}, Options: options.Index().SetName("alumni_deleted_at")},
+		{Keys: bson.D{{Key: "tahun_lulus", Value: 1}, {Key: "jurusan", Value: 1}}, Options: options.Index().SetName("alumni_tahun_lulus_jurusan")},
+	},
+	"pekerjaan": {
+		// Dipakai GetPekerjaanByAlumniID dan $lookup pekerjaan pertama di analytics.
+		{Keys: bson.D{{Key: "alumni_id", Value: 1}, {Key: "tanggal_mulai_kerja", Value: 1}}, Options: options.Index().SetName("pekerjaan_alumni_id_tanggal_mulai")},
+		{Keys: bson.D{{Key: "is_delete", Value: 1}}, Options: options.Index().SetName("pekerjaan_is_delete")},
+		{Keys: bson.D{{Key: "deleted_at", Value: 1}}, Options: options.Index().SetName("pekerjaan_deleted_at")},
+		{Keys: bson.D{{Key: "gaji_min", Value: 1}}, Options: options.Index().SetName("pekerjaan_gaji_min")},
+	},
+	"files": {
+		{Keys: bson.D{{Key: "owner_id", Value: 1}}, Options: options.Index().SetName("files_owner_id")},
+		{Keys: bson.D{{Key: "uploaded_by", Value: 1}}, Options: options.Index().SetName("files_uploaded_by")},
+		{Keys: bson.D{{Key: "uploaded_at", Value: 1}}, Options: options.Index().SetName("files_uploaded_at")},
+	},
+}
+
+func indexNames(indexes []mongo.IndexModel) []string {
+	names := make([]string, 0, len(indexes))
+	for _, idx := range indexes {
+		names = append(names, *idx.Options.Name)
+	}
+	return names
+}
@@ -0,0 +1,222 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+//go:embed postgres/*.sql
+var postgresFiles embed.FS
+
+var sqlFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// advisoryLockKey mencegah dua instance menjalankan migrasi bersamaan.
+const advisoryLockKey = 72070031
+
+type sqlMigration struct {
+	version  int64
+	name     string
+	up, down string
+}
+
+// Postgres menjalankan migrasi SQL yang di-embed dari folder postgres/.
+type Postgres struct {
+	db         *sql.DB
+	migrations map[int64]sqlMigration
+}
+
+func NewPostgres(db *sql.DB) (*Postgres, error) {
+	migrations, err := loadSQLMigrations(postgresFiles, "postgres")
+	if err != nil {
+		return nil, err
+	}
+	return &Postgres{db: db, migrations: migrations}, nil
+}
+
+func loadSQLMigrations(fsys fs.FS, dir string) (map[int64]sqlMigration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	migrations := map[int64]sqlMigration{}
+	for _, e := range entries {
+		m := sqlFilePattern.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("nama file migrasi tidak valid: %s", e.Name())
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		body, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		mig := migrations[version]
+		if mig.name != "" && mig.name != m[2] {
+			return nil, fmt.Errorf("versi migrasi %d dipakai dua nama: %s dan %s", version, mig.name, m[2])
+		}
+		mig.version, mig.name = version, m[2]
+		if m[3] == "up" {
+			mig.up = string(body)
+		} else {
+			mig.down = string(body)
+		}
+		migrations[version] = mig
+	}
+	for v, mig := range migrations {
+		if mig.up == "" || mig.down == "" {
+			return nil, fmt.Errorf("migrasi %04d_%s harus punya file up dan down", v, mig.name)
+		}
+	}
+	return migrations, nil
+}
+
+func (p *Postgres) versions() []int64 {
+	versions := make([]int64, 0, len(p.migrations))
+	for v := range p.migrations {
+		versions = append(versions, v)
+	}
+	return versions
+}
+
+func (p *Postgres) Up(ctx context.Context) error {
+	return p.To(ctx, latest(p.versions()))
+}
+
+func (p *Postgres) Down(ctx context.Context, steps int) error {
+	return p.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := p.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		target, err := DownTarget(p.versions(), appliedSet(applied), steps)
+		if err != nil {
+			return err
+		}
+		return p.migrate(ctx, conn, applied, target)
+	})
+}
+
+func (p *Postgres) To(ctx context.Context, version int64) error {
+	return p.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := p.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		return p.migrate(ctx, conn, applied, version)
+	})
+}
+
+func (p *Postgres) Status(ctx context.Context) ([]Status, error) {
+	conn, err := p.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	applied, err := p.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	names := map[int64]string{}
+	for v, m := range p.migrations {
+		names[v] = m.name
+	}
+	return buildStatus(p.versions(), names, applied), nil
+}
+
+func (p *Postgres) migrate(ctx context.Context, conn *sql.Conn, applied map[int64]time.Time, target int64) error {
+	for _, s := range Plan(p.versions(), appliedSet(applied), target) {
+		m := p.migrations[s.Version]
+		if err := p.run(ctx, conn, m, s.Up); err != nil {
+			arah := "down"
+			if s.Up {
+				arah = "up"
+			}
+			return fmt.Errorf("migrasi postgres %04d_%s (%s): %w", m.version, m.name, arah, err)
+		}
+	}
+	return nil
+}
+
+// run menjalankan satu migrasi dan mencatat versinya dalam satu transaksi.
+func (p *Postgres) run(ctx context.Context, conn *sql.Conn, m sqlMigration, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	body, record, args := m.down, `DELETE FROM `+table+` WHERE version = $1`, []interface{}{m.version}
+	if up {
+		body, record, args = m.up, `INSERT INTO `+table+` (version, name) VALUES ($1, $2)`, []interface{}{m.version, m.name}
+	}
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if up {
+		log.Printf("migrasi postgres %04d_%s: up", m.version, m.name)
+	} else {
+		log.Printf("migrasi postgres %04d_%s: down", m.version, m.name)
+	}
+	return nil
+}
+
+func (p *Postgres) applied(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS `+table+` (
+			version    BIGINT PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`); err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM `+table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var v int64
+		var at time.Time
+		if err := rows.Scan(&v, &at); err != nil {
+			return nil, err
+		}
+		applied[v] = at
+	}
+	return applied, rows.Err()
+}
+
+// withLock memegang advisory lock di satu koneksi selama fn berjalan.
+func (p *Postgres) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := p.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockKey); err != nil {
+		return fmt.Errorf("gagal mengambil lock migrasi: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockKey)
+
+	return fn(conn)
+}
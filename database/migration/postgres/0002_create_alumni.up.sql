@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS alumni (
+    id          SERIAL PRIMARY KEY,
+    user_id     INT          REFERENCES users (id) ON DELETE SET NULL,
+    nim         VARCHAR(20)  NOT NULL UNIQUE,
+    nama        VARCHAR(100) NOT NULL,
+    jurusan     VARCHAR(100) NOT NULL,
+    angkatan    INT          NOT NULL,
+    tahun_lulus INT          NOT NULL,
+    email       VARCHAR(100) NOT NULL UNIQUE,
+    no_telepon  VARCHAR(20),
+    alamat      TEXT,
+    created_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+    deleted_at  TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_alumni_user_id ON alumni (user_id);
+CREATE INDEX IF NOT EXISTS idx_alumni_tahun_lulus_jurusan ON alumni (tahun_lulus, jurusan);
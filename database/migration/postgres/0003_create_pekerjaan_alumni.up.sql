@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS pekerjaan_alumni (
+    id                    SERIAL PRIMARY KEY,
+    alumni_id             INT          NOT NULL REFERENCES alumni (id) ON DELETE CASCADE,
+    nama_perusahaan       VARCHAR(100) NOT NULL,
+    posisi_jabatan        VARCHAR(100) NOT NULL,
+    bidang_industri       VARCHAR(50)  NOT NULL DEFAULT '',
+    lokasi_kerja          VARCHAR(100) NOT NULL DEFAULT '',
+    gaji_range            VARCHAR(50)  NOT NULL DEFAULT '',
+    tanggal_mulai_kerja   DATE         NOT NULL,
+    tanggal_selesai_kerja DATE,
+    status_pekerjaan      VARCHAR(20)  NOT NULL,
+    deskripsi_pekerjaan   TEXT         NOT NULL DEFAULT '',
+    is_delete             BOOLEAN      NOT NULL DEFAULT FALSE,
+    delete_by             INT          REFERENCES users (id) ON DELETE SET NULL,
+    deleted_at            TIMESTAMP,
+    created_at            TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at            TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_alumni_id ON pekerjaan_alumni (alumni_id) WHERE is_delete = FALSE;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_pekerjaan_alumni_gaji_min;
+
+ALTER TABLE pekerjaan_alumni
+    DROP COLUMN IF EXISTS gaji_min,
+    DROP COLUMN IF EXISTS gaji_max,
+    DROP COLUMN IF EXISTS gaji_mata_uang,
+    DROP COLUMN IF EXISTS gaji_periode;
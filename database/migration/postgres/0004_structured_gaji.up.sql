@@ -0,0 +1,7 @@
+ALTER TABLE pekerjaan_alumni
+    ADD COLUMN IF NOT EXISTS gaji_min       BIGINT,
+    ADD COLUMN IF NOT EXISTS gaji_max       BIGINT,
+    ADD COLUMN IF NOT EXISTS gaji_mata_uang VARCHAR(3),
+    ADD COLUMN IF NOT EXISTS gaji_periode   VARCHAR(10);
+
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_gaji_min ON pekerjaan_alumni (gaji_min);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_pekerjaan_search_vector;
+ALTER TABLE pekerjaan_alumni DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_alumni_search_vector;
+ALTER TABLE alumni DROP COLUMN IF EXISTS search_vector;
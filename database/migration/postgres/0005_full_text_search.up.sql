@@ -0,0 +1,16 @@
+-- Konfigurasi 'simple' dipakai karena Postgres tidak punya kamus bahasa Indonesia;
+-- kata tidak di-stem, hanya di-lowercase.
+ALTER TABLE alumni ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', COALESCE(nama, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(jurusan, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_alumni_search_vector ON alumni USING GIN (search_vector);
+
+ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', COALESCE(nama_perusahaan, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(posisi_jabatan, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(deskripsi_pekerjaan, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_search_vector ON pekerjaan_alumni USING GIN (search_vector);
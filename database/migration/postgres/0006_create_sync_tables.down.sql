@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sync_state;
+DROP TABLE IF EXISTS sync_id_map;
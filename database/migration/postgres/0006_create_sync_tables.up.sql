@@ -0,0 +1,17 @@
+-- Dipakai oleh perintah "sync". Pasangan ID dicatat per entitas sehingga foreign key
+-- (alumni.user_id, pekerjaan_alumni.alumni_id, delete_by) bisa diterjemahkan ke ID
+-- di database tujuan, ke arah mana pun sinkronisasi dijalankan.
+CREATE TABLE IF NOT EXISTS sync_id_map (
+    entity      VARCHAR(20) NOT NULL,
+    postgres_id INT         NOT NULL,
+    mongo_id    VARCHAR(24) NOT NULL,
+    synced_at   TIMESTAMP   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (entity, postgres_id),
+    UNIQUE (entity, mongo_id)
+);
+
+-- Waktu mulai sinkronisasi terakhir yang selesai tanpa konflik, per arah.
+CREATE TABLE IF NOT EXISTS sync_state (
+    direction      VARCHAR(30) PRIMARY KEY,
+    last_synced_at TIMESTAMP   NOT NULL
+);
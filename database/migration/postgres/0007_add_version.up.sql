@@ -0,0 +1,4 @@
+-- Dipakai untuk optimistic concurrency: setiap update menaikkan version dan
+-- hanya berhasil jika version di If-Match masih sama dengan di database.
+ALTER TABLE alumni ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,43 @@
+-- Audit log perubahan data (package audit). Setiap baris menyimpan hash baris
+-- sebelumnya; seq diisi aplikasi di bawah advisory lock agar rantai tidak
+-- bercabang.
+CREATE TABLE IF NOT EXISTS audit_log (
+    seq            BIGINT       PRIMARY KEY,
+    time           TIMESTAMPTZ  NOT NULL,
+    actor_id       VARCHAR(64)  NOT NULL DEFAULT '',
+    actor_username VARCHAR(100) NOT NULL DEFAULT '',
+    actor_role     VARCHAR(20)  NOT NULL DEFAULT '',
+    action         VARCHAR(30)  NOT NULL,
+    entity         VARCHAR(30)  NOT NULL,
+    entity_id      VARCHAR(64)  NOT NULL DEFAULT '',
+    backend        VARCHAR(20)  NOT NULL,
+    changes        TEXT,
+    request_id     VARCHAR(128) NOT NULL DEFAULT '',
+    ip             VARCHAR(64)  NOT NULL DEFAULT '',
+    prev_hash      VARCHAR(64)  NOT NULL,
+    hash           VARCHAR(64)  NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_time ON audit_log (time);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor_id, seq);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity, entity_id, seq);
+CREATE INDEX IF NOT EXISTS idx_audit_log_request ON audit_log (request_id);
+
+-- Audit log hanya boleh ditambah. Trigger ini tidak menghentikan superuser
+-- yang mematikan trigger, tapi perubahan seperti itu tetap terdeteksi oleh
+-- GET /api/v1/admin/audit/verify.
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log hanya boleh ditambah (%)', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_no_update ON audit_log;
+CREATE TRIGGER audit_log_no_update
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
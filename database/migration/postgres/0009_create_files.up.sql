@@ -0,0 +1,15 @@
+-- Metadata file upload /api/v1/files untuk storage.driver postgres, sama
+-- dengan koleksi files di MongoDB. Isi file tetap di folder upload.
+CREATE TABLE IF NOT EXISTS files (
+    id            SERIAL       PRIMARY KEY,
+    file_name     VARCHAR(255) NOT NULL,
+    original_name VARCHAR(255) NOT NULL,
+    file_path     TEXT         NOT NULL,
+    file_size     BIGINT       NOT NULL,
+    file_type     VARCHAR(100) NOT NULL,
+    uploaded_at   TIMESTAMP    NOT NULL DEFAULT NOW(),
+    uploaded_by   INT          NOT NULL REFERENCES users(id),
+    owner_id      INT          NOT NULL REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_files_owner ON files (owner_id);
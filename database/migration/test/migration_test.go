@@ -0,0 +1,93 @@
+package test
+
+import (
+	"latihan2/database/migration"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// File SQL yang di-embed harus lengkap (up dan down) dan bernama sesuai pola.
+func TestNewPostgresLoadsEmbeddedMigrations(t *testing.T) {
+	_, err := migration.NewPostgres(nil)
+	assert.NoError(t, err)
+}
+
+func TestMongoMigrationsOrdered(t *testing.T) {
+	_, err := migration.NewMongo(nil)
+	assert.NoError(t, err)
+
+	for i := 1; i < len(migration.MongoMigrations); i++ {
+		assert.Greater(t, migration.MongoMigrations[i].Version, migration.MongoMigrations[i-1].Version)
+	}
+}
+
+func applied(versions ...int64) map[int64]bool {
+	set := map[int64]bool{}
+	for _, v := range versions {
+		set[v] = true
+	}
+	return set
+}
+
+func TestPlan(t *testing.T) {
+	versions := []int64{3, 1, 5, 2, 4}
+	up := func(v int64) migration.Step { return migration.Step{Version: v, Up: true} }
+	down := func(v int64) migration.Step { return migration.Step{Version: v, Up: false} }
+
+	tests := []struct {
+		name    string
+		applied map[int64]bool
+		target  int64
+		want    []migration.Step
+	}{
+		{"kosong ke terbaru", applied(), 5, []migration.Step{up(1), up(2), up(3), up(4), up(5)}},
+		{"sudah terbaru", applied(1, 2, 3, 4, 5), 5, nil},
+		{"sebagian ke terbaru", applied(1, 2), 5, []migration.Step{up(3), up(4), up(5)}},
+		// 2 dan 4 tertinggal karena migrasi digabung dari branch lain.
+		{"diterapkan tidak berurutan", applied(1, 3, 5), 5, []migration.Step{up(2), up(4)}},
+		{"celah di bawah target", applied(1, 3, 5), 3, []migration.Step{down(5), up(2)}},
+		{"target di bawah versi sekarang", applied(1, 2, 3, 4, 5), 2, []migration.Step{down(5), down(4), down(3)}},
+		{"target 0 membatalkan semua", applied(1, 2, 3), 0, []migration.Step{down(3), down(2), down(1)}},
+		{"versi tak dikenal diabaikan", applied(1, 9), 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, migration.Plan(versions, tt.applied, tt.target))
+		})
+	}
+}
+
+func TestDownTarget(t *testing.T) {
+	versions := []int64{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name    string
+		applied map[int64]bool
+		steps   int
+		want    int64
+		err     bool
+	}{
+		{"satu langkah", applied(1, 2, 3), 1, 2, false},
+		{"dua langkah", applied(1, 2, 3, 4, 5), 2, 3, false},
+		// Langkah dihitung dari migrasi yang sudah jalan, bukan dari nomor versi.
+		{"diterapkan tidak berurutan", applied(1, 3, 5), 1, 3, false},
+		{"diterapkan tidak berurutan dua langkah", applied(1, 3, 5), 2, 1, false},
+		{"langkah sama dengan jumlah applied", applied(1, 2), 2, 0, false},
+		{"langkah melebihi jumlah applied", applied(1, 2), 10, 0, false},
+		{"belum ada yang jalan", applied(), 1, 0, false},
+		{"langkah nol", applied(1), 0, 0, true},
+		{"langkah negatif", applied(1), -1, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := migration.DownTarget(versions, tt.applied, tt.steps)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
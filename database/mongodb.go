@@ -2,11 +2,13 @@ package database
 
 import (
 	"context"
-	"fmt"
+	"latihan2/metrics"
+	"latihan2/tracing"
 	"log"
-	"os"
+	"log/slog"
 	"time"
 
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -14,19 +16,12 @@ import (
 var MongoClient *mongo.Client
 var MongoDB *mongo.Database
 
-func InitMongoDB() {
-	mongoURI := os.Getenv("MONGODB_URI")
-	dbName := os.Getenv("DATABASE_NAME")
-	// fmt.Println(">> [DEBUG] Koneksi ke DB:", dbName)
-	if mongoURI == "" {
-		log.Fatal("Error: MONGODB_URI tidak ditemukan di file .env. Pastikan file .env sudah disimpan.")
-	}
-	if dbName == "" {
-		log.Fatal("Error: DATABASE_NAME tidak ditemukan di file .env. Pastikan file .env sudah disimpan.")
-	}
+// InitMongoDB membuka koneksi MongoDB dari mongo.uri dan memilih database
+// dbName. Kedua nilai sudah diperiksa config.Validate.
+func InitMongoDB(mongoURI, dbName string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetMonitor(combineMonitors(metrics.MongoMonitor(), tracing.MongoMonitor())))
 	if err != nil {
 		log.Fatalf("Gagal koneksi ke MongoDB: %v", err)
 	}
@@ -38,11 +33,37 @@ func InitMongoDB() {
 	MongoClient = client
 	MongoDB = client.Database(dbName)
 
-	fmt.Println("Berhasil tersambung ke MongoDB!")
 	collections, err := MongoDB.ListCollectionNames(ctx, map[string]interface{}{})
 	if err != nil {
 		log.Fatalf("Gagal mendapatkan list koleksi: %v", err)
 	}
-	fmt.Println("Koleksi yang ada:", collections)
-	log.Println("Berhasil tersambung ke MongoDB!")
-}
\ No newline at end of file
+	slog.Info("Berhasil tersambung ke MongoDB!", "database", dbName, "collections", collections)
+}
+
+// combineMonitors menggabungkan beberapa CommandMonitor karena driver Mongo
+// hanya menerima satu monitor per client.
+func combineMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				if m.Started != nil {
+					m.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				if m.Failed != nil {
+					m.Failed(ctx, e)
+				}
+			}
+		},
+	}
+}
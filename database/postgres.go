@@ -2,18 +2,20 @@ package database
 
 import (
 	"database/sql"
+	"latihan2/metrics"
+	"latihan2/tracing"
 	"log"
-	"os"
 
 	_ "github.com/lib/pq"
 )
 
 var DB *sql.DB
 
-func InitPostgresDB() {
-	dsn := os.Getenv("DB_DSN")
+// InitPostgresDB membuka koneksi Postgres dari postgres.dsn dan memastikan
+// database bisa dijangkau.
+func InitPostgresDB(dsn string) {
 	var err error
-	DB, err = sql.Open("postgres", dsn)
+	DB, err = tracing.OpenDB("postgres", dsn)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
@@ -22,5 +24,9 @@ func InitPostgresDB() {
 		log.Fatal("Failed to ping database:", err)
 	}
 
+	if err := metrics.RegisterDB(DB, "postgres"); err != nil {
+		log.Println("Gagal mendaftarkan metrik pool Postgres:", err)
+	}
+
 	log.Println("Database connection established")
 }
\ No newline at end of file
@@ -0,0 +1,159 @@
+// Package reconcile membandingkan metadata file (koleksi files di MongoDB dan
+// tabel files di Postgres) dengan isi folder upload. Keduanya bisa tidak
+// sinkron jika proses mati di tengah upload (file sudah ditulis, metadata
+// belum) atau file dihapus manual dari disk.
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	v1 "latihan2/app/model/v1"
+	v1Repo "latihan2/app/repository/v1"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Options mengatur tindakan perbaikan. Tanpa opsi apa pun Files hanya membuat laporan.
+type Options struct {
+	// DeleteOrphans menghapus file di disk yang tidak punya metadata.
+	DeleteOrphans bool
+	// PruneMissing menghapus metadata yang file-nya tidak ada di disk.
+	PruneMissing bool
+	// MinAge melindungi upload yang sedang berjalan: file yang lebih baru dari
+	// MinAge tidak dianggap orphan karena metadata-nya mungkin belum ditulis.
+	MinAge time.Duration
+	// Now dipakai untuk menghitung umur file; nil berarti time.Now.
+	Now func() time.Time
+}
+
+// Orphan adalah file di folder upload tanpa metadata.
+type Orphan struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+	Deleted bool
+}
+
+// Missing adalah metadata yang file-nya tidak ada di disk.
+type Missing struct {
+	File   v1.File
+	Pruned bool
+}
+
+// SizeMismatch adalah file yang ukurannya berbeda dengan file_size di metadata.
+type SizeMismatch struct {
+	File       v1.File
+	ActualSize int64
+}
+
+// Report adalah hasil Files. Checked adalah jumlah metadata yang diperiksa.
+type Report struct {
+	Checked      int
+	Orphans      []Orphan
+	Missing      []Missing
+	SizeMismatch []SizeMismatch
+	// Recent adalah file tanpa metadata yang dilewati karena lebih baru dari MinAge.
+	Recent int
+}
+
+// Clean melaporkan apakah metadata dan disk sudah sinkron.
+func (r *Report) Clean() bool {
+	return len(r.Orphans) == 0 && len(r.Missing) == 0 && len(r.SizeMismatch) == 0
+}
+
+// Files membandingkan metadata di semua repos dengan file langsung di dalam
+// dir (tidak rekursif). Semua database yang menulis ke dir harus diberikan;
+// file yang metadata-nya hanya ada di database lain dianggap orphan. File
+// tersembunyi, misalnya file sementara check /readyz, diabaikan.
+func Files(ctx context.Context, repos []v1Repo.FileRepository, dir string, opts Options) (*Report, error) {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	report := &Report{}
+	known := map[string]bool{}
+	for _, repo := range repos {
+		if err := checkMetadata(repo.WithContext(ctx), opts, report, known); err != nil {
+			return report, err
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		// Belum pernah ada upload; semua metadata sudah tercatat sebagai Missing.
+		return report, nil
+	}
+	if err != nil {
+		return report, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		path, err := filepath.Abs(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return report, err
+		}
+		if known[path] {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return report, err
+		}
+		if now().Sub(info.ModTime()) < opts.MinAge {
+			report.Recent++
+			continue
+		}
+		o := Orphan{Path: filepath.Join(dir, e.Name()), Size: info.Size(), ModTime: info.ModTime()}
+		if opts.DeleteOrphans {
+			if err := os.Remove(path); err != nil {
+				return report, fmt.Errorf("gagal menghapus %s: %w", o.Path, err)
+			}
+			o.Deleted = true
+		}
+		report.Orphans = append(report.Orphans, o)
+	}
+	sort.Slice(report.Orphans, func(i, j int) bool { return report.Orphans[i].Path < report.Orphans[j].Path })
+	return report, nil
+}
+
+// checkMetadata memeriksa setiap metadata di repo terhadap disk dan mencatat
+// path-nya di known.
+func checkMetadata(repo v1Repo.FileRepository, opts Options, report *Report, known map[string]bool) error {
+	files, err := repo.GetFiles()
+	if err != nil {
+		return fmt.Errorf("gagal membaca metadata file: %w", err)
+	}
+
+	report.Checked += len(files)
+	for _, f := range files {
+		path, err := filepath.Abs(f.FilePath)
+		if err != nil {
+			return err
+		}
+		known[path] = true
+
+		info, err := os.Stat(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			m := Missing{File: f}
+			if opts.PruneMissing {
+				if err := repo.DeleteFile(f.ID); err != nil {
+					return fmt.Errorf("gagal menghapus metadata %s: %w", f.ID, err)
+				}
+				m.Pruned = true
+			}
+			report.Missing = append(report.Missing, m)
+		case err != nil:
+			return err
+		case info.Size() != f.FileSize:
+			report.SizeMismatch = append(report.SizeMismatch, SizeMismatch{File: f, ActualSize: info.Size()})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"context"
+	mongoModel "latihan2/app/model/mongo"
+	"latihan2/app/repository/memory"
+	mongoRepo "latihan2/app/repository/mongo"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/database/reconcile"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setup membuat folder upload berisi:
+//   - ok.pdf: ada metadata, ukuran cocok
+//   - beda.pdf: ada metadata, ukuran berbeda
+//   - orphan.pdf: tanpa metadata, lama
+//   - baru.pdf: tanpa metadata, baru saja ditulis
+//   - .readyz-123: file tersembunyi
+//
+// dan metadata hilang.pdf yang file-nya tidak ada.
+func setup(t *testing.T) (mongoRepo.FileRepository, string) {
+	t.Helper()
+	dir := t.TempDir()
+	repo := memory.NewFileRepository(memory.NewStore())
+	old := time.Now().Add(-2 * time.Hour)
+
+	write := func(name, content string, modTime time.Time) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+		return path
+	}
+	meta := func(name string, size int64) {
+		require.NoError(t, repo.CreateFile(&mongoModel.File{
+			FileName: name, FilePath: filepath.Join(dir, name), FileSize: size, FileType: "application/pdf",
+		}))
+	}
+
+	write("ok.pdf", "isi", old)
+	meta("ok.pdf", 3)
+	write("beda.pdf", "isi lebih panjang", old)
+	meta("beda.pdf", 3)
+	meta("hilang.pdf", 10)
+	write("orphan.pdf", "yatim", old)
+	write("baru.pdf", "sedang diupload", time.Now())
+	write(".readyz-123", "", old)
+	return repo, dir
+}
+
+func repos(list ...mongoRepo.FileRepository) []v1Repo.FileRepository {
+	result := make([]v1Repo.FileRepository, 0, len(list))
+	for _, r := range list {
+		result = append(result, v1Repo.NewMongoFiles(r))
+	}
+	return result
+}
+
+func TestFilesLaporan(t *testing.T) {
+	repo, dir := setup(t)
+
+	report, err := reconcile.Files(context.Background(), repos(repo), dir, reconcile.Options{MinAge: time.Hour})
+	require.NoError(t, err)
+	assert.False(t, report.Clean())
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, 1, report.Recent)
+
+	require.Len(t, report.Orphans, 1)
+	assert.Equal(t, filepath.Join(dir, "orphan.pdf"), report.Orphans[0].Path)
+	assert.False(t, report.Orphans[0].Deleted)
+	require.Len(t, report.Missing, 1)
+	assert.Equal(t, "hilang.pdf", report.Missing[0].File.FileName)
+	require.Len(t, report.SizeMismatch, 1)
+	assert.Equal(t, int64(17), report.SizeMismatch[0].ActualSize)
+
+	// Tanpa opsi perbaikan tidak ada yang berubah.
+	assert.FileExists(t, filepath.Join(dir, "orphan.pdf"))
+	n, err := repo.CountFiles()
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
+
+func TestFilesPerbaikan(t *testing.T) {
+	repo, dir := setup(t)
+	opts := reconcile.Options{DeleteOrphans: true, PruneMissing: true, MinAge: time.Hour}
+
+	report, err := reconcile.Files(context.Background(), repos(repo), dir, opts)
+	require.NoError(t, err)
+	assert.True(t, report.Orphans[0].Deleted)
+	assert.True(t, report.Missing[0].Pruned)
+	assert.NoFileExists(t, filepath.Join(dir, "orphan.pdf"))
+	assert.FileExists(t, filepath.Join(dir, "baru.pdf"), "upload yang masih baru tidak boleh dihapus")
+
+	// Ukuran berbeda hanya dilaporkan, tidak pernah diperbaiki otomatis.
+	report, err = reconcile.Files(context.Background(), repos(repo), dir, opts)
+	require.NoError(t, err)
+	assert.Empty(t, report.Orphans)
+	assert.Empty(t, report.Missing)
+	assert.Len(t, report.SizeMismatch, 1)
+}
+
+func TestFilesFolderBelumAda(t *testing.T) {
+	repo := memory.NewFileRepository(memory.NewStore())
+	report, err := reconcile.Files(context.Background(), repos(repo), filepath.Join(t.TempDir(), "uploads"), reconcile.Options{})
+	require.NoError(t, err)
+	assert.True(t, report.Clean())
+}
+
+// File yang metadata-nya ada di database lain (mis. /api/v1/files di
+// Postgres) bukan orphan.
+func TestFilesBeberapaDatabase(t *testing.T) {
+	repo, dir := setup(t)
+	other := memory.NewFileRepository(memory.NewStore())
+	require.NoError(t, other.CreateFile(&mongoModel.File{
+		FileName: "orphan.pdf", FilePath: filepath.Join(dir, "orphan.pdf"), FileSize: 5, FileType: "application/pdf",
+	}))
+
+	report, err := reconcile.Files(context.Background(), repos(repo, other), dir,
+		reconcile.Options{DeleteOrphans: true, MinAge: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Checked)
+	assert.Empty(t, report.Orphans)
+	assert.FileExists(t, filepath.Join(dir, "orphan.pdf"))
+}
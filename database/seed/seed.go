@@ -0,0 +1,251 @@
+// Package seed mengisi database dengan data contoh yang terlihat nyata: user,
+// alumni (sebagian terhubung ke user) dan riwayat pekerjaan. Data ditulis
+// lewat storage /api/v1 sehingga cara kerjanya sama untuk Postgres, MongoDB
+// maupun memory, dan memakai validasi yang sama dengan API.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"latihan2/app/model"
+	v1 "latihan2/app/model/v1"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/utils"
+	"latihan2/validation"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Options mengatur jumlah data. Seed yang sama menghasilkan data yang sama,
+// berguna untuk membuat ulang dataset pengujian.
+type Options struct {
+	Users        int
+	Alumni       int
+	MaxPekerjaan int
+	// Password untuk semua user yang dibuat.
+	Password string
+	Seed     int64
+}
+
+// Report menghitung data yang dibuat. Data yang bentrok dengan data lama
+// (NIM, username atau email sudah terdaftar) dilewati, bukan dianggap gagal,
+// agar seed bisa dijalankan berulang kali.
+type Report struct {
+	Users, Alumni, Pekerjaan int
+	Skipped                  int
+}
+
+// Run membuat data contoh di s sesuai opts.
+func Run(ctx context.Context, s v1Repo.Storage, opts Options) (*Report, error) {
+	if opts.Users > opts.Alumni {
+		return nil, fmt.Errorf("jumlah user (%d) tidak boleh lebih dari jumlah alumni (%d)", opts.Users, opts.Alumni)
+	}
+	if opts.Password == "" {
+		return nil, errors.New("password user seed wajib diisi")
+	}
+	hash, err := utils.HashPassword(opts.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{r: rand.New(rand.NewSource(opts.Seed))}
+	users := s.Users.WithContext(ctx)
+	alumniRepo := s.Alumni.WithContext(ctx)
+	pekerjaanRepo := s.Pekerjaan.WithContext(ctx)
+	report := &Report{}
+
+	for i := 0; i < opts.Alumni; i++ {
+		// Semua data satu alumni dibuat sebelum ditulis agar urutan angka
+		// acak tidak bergeser saat ada data yang dilewati.
+		p := g.person()
+		req := g.alumni(p)
+		jobs := g.career(req.TahunLulus, opts.MaxPekerjaan)
+
+		// User pertama sebanyak opts.Users menjadi pemilik alumni-nya.
+		if i < opts.Users {
+			u, err := users.CreateUser(p.username, req.Email, "user", hash)
+			if errors.Is(err, v1Repo.ErrDuplikat) {
+				report.Skipped++
+				continue
+			}
+			if err != nil {
+				return report, fmt.Errorf("seed user %s: %w", p.username, err)
+			}
+			report.Users++
+			req.UserID = u.ID
+		}
+
+		if err := validation.Struct(req); err != nil {
+			return report, fmt.Errorf("seed alumni %s: %w", req.NIM, err)
+		}
+		a, err := alumniRepo.CreateAlumni(req)
+		if errors.Is(err, v1Repo.ErrDuplikat) {
+			report.Skipped++
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("seed alumni %s: %w", req.NIM, err)
+		}
+		report.Alumni++
+
+		for _, job := range jobs {
+			job.AlumniID = a.ID
+			if err := validation.Struct(job); err != nil {
+				return report, fmt.Errorf("seed pekerjaan alumni %s: %w", a.NIM, err)
+			}
+			if _, err := pekerjaanRepo.CreatePekerjaan(job); err != nil {
+				return report, fmt.Errorf("seed pekerjaan alumni %s: %w", a.NIM, err)
+			}
+			report.Pekerjaan++
+		}
+	}
+	return report, nil
+}
+
+var (
+	namaDepan = []string{
+		"Budi", "Siti", "Andi", "Dewi", "Rizky", "Putri", "Agus", "Rina", "Dimas", "Ayu",
+		"Fajar", "Intan", "Hendra", "Nur", "Yoga", "Maya", "Arif", "Lestari", "Bayu", "Fitri",
+		"Eko", "Wulan", "Galih", "Sari", "Reza", "Nadia", "Joko", "Anisa", "Teguh", "Citra",
+	}
+	namaBelakang = []string{
+		"Santoso", "Rahmawati", "Pratama", "Lestari", "Hidayat", "Wijaya", "Saputra", "Kurniawan",
+		"Nugroho", "Permata", "Setiawan", "Utami", "Gunawan", "Maharani", "Firmansyah", "Anggraini",
+		"Susanto", "Puspita", "Wibowo", "Halim", "Siregar", "Nasution", "Simanjuntak", "Tanjung",
+	}
+	jurusan = []string{
+		"Teknik Informatika", "Sistem Informasi", "Manajemen Informatika", "Teknik Komputer",
+		"Ilmu Komputer", "Sains Data",
+	}
+	kota = []string{
+		"Jakarta", "Bandung", "Surabaya", "Yogyakarta", "Semarang", "Medan", "Makassar",
+		"Denpasar", "Malang", "Balikpapan", "Palembang", "Tangerang",
+	}
+	jalan = []string{"Merdeka", "Sudirman", "Diponegoro", "Gajah Mada", "Ahmad Yani", "Pahlawan", "Melati", "Kenanga"}
+
+	perusahaan = []struct{ nama, bidang string }{
+		{"PT Teknologi Nusantara", "Teknologi"},
+		{"PT Solusi Digital Indonesia", "Teknologi"},
+		{"Bank Sejahtera", "Perbankan"},
+		{"Bank Mandiri Sentosa", "Perbankan"},
+		{"PT Telekomunikasi Raya", "Telekomunikasi"},
+		{"Startup Edukasi", "Pendidikan"},
+		{"PT Logistik Cepat", "Logistik"},
+		{"Konsultan Data Indonesia", "Konsultasi"},
+		{"PT E-Commerce Makmur", "E-Commerce"},
+		{"Rumah Sakit Harapan", "Kesehatan"},
+		{"Kementerian Komunikasi", "Pemerintahan"},
+		{"PT Energi Terbarukan", "Energi"},
+	}
+	posisi = []struct {
+		nama     string
+		gajiJuta int64 // gaji bawah awal karier, dalam juta rupiah per bulan
+	}{
+		{"Backend Engineer", 8}, {"Frontend Developer", 7}, {"Mobile Developer", 8},
+		{"Data Analyst", 7}, {"Data Engineer", 10}, {"QA Engineer", 6},
+		{"DevOps Engineer", 11}, {"Business Analyst", 8}, {"IT Support", 5},
+		{"Product Manager", 14}, {"UI/UX Designer", 7}, {"System Administrator", 7},
+	}
+	deskripsi = []string{
+		"Mengembangkan dan memelihara layanan internal",
+		"Bekerja dalam tim agile dengan sprint dua mingguan",
+		"Menangani integrasi sistem dengan mitra",
+		"Membangun dashboard dan laporan untuk manajemen",
+		"Meningkatkan performa dan keandalan aplikasi",
+	}
+)
+
+type generator struct {
+	r *rand.Rand
+}
+
+type person struct {
+	nama, username string
+}
+
+func (g *generator) pick(list []string) string {
+	return list[g.r.Intn(len(list))]
+}
+
+// person membuat nama lengkap dan username. Angka acak di username membuat
+// seed berulang dengan Seed berbeda jarang bentrok.
+func (g *generator) person() person {
+	depan, belakang := g.pick(namaDepan), g.pick(namaBelakang)
+	username := fmt.Sprintf("%s.%s%d", strings.ToLower(depan), strings.ToLower(belakang), g.r.Intn(900)+100)
+	return person{nama: depan + " " + belakang, username: username}
+}
+
+func (g *generator) alumni(p person) model.CreateAlumniRequest {
+	// Angkatan 2012-2019 agar semua alumni sudah lulus dan punya riwayat kerja.
+	angkatan := 2012 + g.r.Intn(8)
+	return model.CreateAlumniRequest{
+		// NIM: tahun angkatan diikuti 6 digit, 10 digit total.
+		NIM:        fmt.Sprintf("%d%06d", angkatan, g.r.Intn(1000000)),
+		Nama:       p.nama,
+		Jurusan:    g.pick(jurusan),
+		Angkatan:   angkatan,
+		TahunLulus: angkatan + 4 + g.r.Intn(2),
+		Email:      p.username + "@example.com",
+		NoTelepon:  fmt.Sprintf("08%d%08d", 11+g.r.Intn(9), g.r.Intn(100000000)),
+		Alamat:     fmt.Sprintf("Jl. %s No. %d, %s", g.pick(jalan), 1+g.r.Intn(200), g.pick(kota)),
+	}
+}
+
+// career membuat 0 sampai max pekerjaan berurutan sejak tahun lulus; hanya
+// pekerjaan terakhir yang masih aktif.
+// AlumniID diisi pemanggil setelah alumni dibuat.
+func (g *generator) career(tahunLulus, max int) []v1.CreatePekerjaanRequest {
+	if max <= 0 {
+		return nil
+	}
+	n := g.r.Intn(max + 1)
+	mulai := time.Date(tahunLulus, time.Month(1+g.r.Intn(12)), 1, 0, 0, 0, 0, time.UTC)
+	var jobs []v1.CreatePekerjaanRequest
+	for i := 0; i < n; i++ {
+		c := perusahaan[g.r.Intn(len(perusahaan))]
+		pos := posisi[g.r.Intn(len(posisi))]
+		min := (pos.gajiJuta + int64(i)*2) * 1_000_000
+		max := min + int64(2+g.r.Intn(4))*1_000_000
+		job := v1.CreatePekerjaanRequest{
+			NamaPerusahaan:     c.nama,
+			PosisiJabatan:      pos.nama,
+			BidangIndustri:     c.bidang,
+			LokasiKerja:        g.pick(kota),
+			GajiRange:          fmt.Sprintf("Rp %s - %s", rupiah(min), rupiah(max)),
+			TanggalMulaiKerja:  mulai.Format("2006-01-02"),
+			StatusPekerjaan:    "aktif",
+			DeskripsiPekerjaan: g.pick(deskripsi),
+			RentangGaji: model.RentangGaji{
+				GajiMin: &min, GajiMax: &max, GajiMataUang: "IDR", GajiPeriode: "bulanan",
+			},
+		}
+		if i < n-1 {
+			selesai := mulai.AddDate(1+g.r.Intn(3), g.r.Intn(12), 0)
+			if selesai.After(time.Now()) {
+				// Tidak ada lagi ruang di masa lalu untuk pekerjaan berikutnya.
+				jobs = append(jobs, job)
+				break
+			}
+			job.StatusPekerjaan = "selesai"
+			job.TanggalSelesaiKerja = selesai.Format("2006-01-02")
+			mulai = selesai.AddDate(0, 1, 0)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// rupiah memformat 8500000 menjadi "8.500.000".
+func rupiah(n int64) string {
+	s := fmt.Sprint(n)
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"context"
+	"latihan2/app/repository/memory"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/database/seed"
+	"latihan2/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func memoryStorage() v1Repo.Storage {
+	store := memory.NewStore()
+	return v1Repo.NewMongoStorage(v1Repo.DriverMemory, memory.NewUserRepository(store),
+		memory.NewAlumniRepository(store), memory.NewPekerjaanRepository(store), memory.NewFileRepository(store))
+}
+
+var admin = v1Repo.Actor{Role: "admin"}
+
+func TestRunMengisiStorage(t *testing.T) {
+	s := memoryStorage()
+	opts := seed.Options{Users: 4, Alumni: 10, MaxPekerjaan: 3, Password: "rahasia123", Seed: 7}
+
+	report, err := seed.Run(context.Background(), s, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Users)
+	assert.Equal(t, 10, report.Alumni)
+	assert.Zero(t, report.Skipped)
+
+	n, err := s.Alumni.CountAlumni(v1Repo.ListQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+	n, err = s.Pekerjaan.CountPekerjaan(v1Repo.ListQuery{}, admin)
+	require.NoError(t, err)
+	assert.Equal(t, report.Pekerjaan, n)
+
+	users, err := s.Users.GetUsers(v1Repo.ListQuery{}, v1Repo.OffsetPage{Limit: 10}, admin)
+	require.NoError(t, err)
+	require.Len(t, users, 4)
+	u, hash, err := s.Users.GetUserByUsername(users[0].Username)
+	require.NoError(t, err)
+	assert.Equal(t, "user", u.Role)
+	assert.True(t, utils.CheckPassword(opts.Password, hash), "semua user seed memakai Password")
+
+	jobs, err := s.Pekerjaan.GetPekerjaan(v1Repo.ListQuery{}, v1Repo.OffsetPage{Limit: 100}, admin)
+	require.NoError(t, err)
+	for _, job := range jobs {
+		require.NotNil(t, job.GajiMin)
+		require.NotNil(t, job.GajiMax)
+		assert.Less(t, *job.GajiMin, *job.GajiMax)
+		assert.Equal(t, "IDR", job.GajiMataUang)
+	}
+}
+
+func TestRunBisaDiulang(t *testing.T) {
+	s := memoryStorage()
+	opts := seed.Options{Users: 3, Alumni: 5, MaxPekerjaan: 2, Password: "rahasia123", Seed: 1}
+	_, err := seed.Run(context.Background(), s, opts)
+	require.NoError(t, err)
+
+	// Seed yang sama menghasilkan data yang sama sehingga semuanya bentrok.
+	report, err := seed.Run(context.Background(), s, opts)
+	require.NoError(t, err)
+	assert.Equal(t, seed.Report{Skipped: 5}, *report)
+
+	opts.Seed = 2
+	report, err = seed.Run(context.Background(), s, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.Alumni+report.Skipped)
+	assert.Positive(t, report.Alumni)
+}
+
+func TestRunOpsiTidakValid(t *testing.T) {
+	_, err := seed.Run(context.Background(), memoryStorage(), seed.Options{Users: 5, Alumni: 2, Password: "x"})
+	assert.ErrorContains(t, err, "tidak boleh lebih dari")
+
+	_, err = seed.Run(context.Background(), memoryStorage(), seed.Options{Alumni: 2})
+	assert.ErrorContains(t, err, "password")
+}
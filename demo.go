@@ -0,0 +1,69 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"latihan2/app/repository/memory"
+	v1Repo "latihan2/app/repository/v1"
+	mongoService "latihan2/app/service/mongo"
+	"latihan2/audit"
+	"latihan2/config"
+	"latihan2/ratelimit"
+	"latihan2/route"
+	"latihan2/utils"
+
+	"github.com/gofiber/swagger"
+)
+
+// runDemo menjalankan server dengan /api/v1 (driver memory) dan /api/mg di
+// atas repository in-memory yang sama, sudah diisi data contoh. Tidak ada
+// koneksi Postgres maupun MongoDB, dan semua perubahan hilang saat server
+// berhenti, termasuk audit log.
+func runDemo(cfg *config.Config, closers ...config.Closer) error {
+	store := memory.NewStore()
+	if err := memory.Seed(store); err != nil {
+		return fmt.Errorf("gagal mengisi data demo: %w", err)
+	}
+	// Token /api/v1 butuh auth.jwt_secret; mode demo boleh jalan tanpa konfigurasi.
+	if cfg.Auth.JWTSecret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return err
+		}
+		utils.SetJWTSecret(hex.EncodeToString(secret))
+	}
+
+	var auditLog *audit.Log
+	if cfg.Audit.Enabled {
+		auditLog = audit.New(audit.NewMemoryStore())
+	}
+	mg := demoHandlers(store, auditLog)
+	storage := v1Repo.NewMongoStorage(v1Repo.DriverMemory,
+		audit.MongoUsers(auditLog, audit.BackendMemory, memory.NewUserRepository(store)),
+		audit.MongoAlumni(auditLog, audit.BackendMemory, memory.NewAlumniRepository(store)),
+		audit.MongoPekerjaan(auditLog, audit.BackendMemory, memory.NewPekerjaanRepository(store)),
+		mg.FileRepo)
+	app := config.NewApp(cfg, nil, mg, v1Handlers(storage, route.MongoAnalytics(mg.Analytics), auditLog),
+		storageChecks(cfg), config.NewLimits(cfg.RateLimit, ratelimit.NewMemoryStore()))
+	app.Get("/swagger/*", swagger.HandlerDefault)
+
+	fmt.Println("Mode demo: data in-memory, /api/v1 dan /api/mg yang aktif")
+	fmt.Printf("Login admin: %s / %s\n", memory.DemoAdminUsername, memory.DemoAdminPassword)
+	fmt.Printf("Login user:  %s / %s\n", memory.DemoUserUsername, memory.DemoUserPassword)
+	return serve(cfg, app, closers...)
+}
+
+func demoHandlers(store *memory.Store, auditLog *audit.Log) route.MongoHandlers {
+	users := audit.MongoUsers(auditLog, audit.BackendMemory, memory.NewUserRepository(store))
+	files := audit.MongoFiles(auditLog, audit.BackendMemory, memory.NewFileRepository(store))
+	return route.MongoHandlers{
+		Auth:      mongoService.NewAuthHandler(users),
+		User:      mongoService.NewUserHandler(users),
+		File:      mongoService.NewFileHandler(files),
+		Alumni:    mongoService.NewAlumniHandler(audit.MongoAlumni(auditLog, audit.BackendMemory, memory.NewAlumniRepository(store))),
+		Pekerjaan: mongoService.NewPekerjaanHandler(audit.MongoPekerjaan(auditLog, audit.BackendMemory, memory.NewPekerjaanRepository(store))),
+		FileRepo:  files,
+		Analytics: mongoService.NewAnalyticsHandler(memory.NewAnalyticsRepository(store)),
+	}
+}
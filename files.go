@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"latihan2/app/repository"
+	mongoRepo "latihan2/app/repository/mongo"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/database"
+	"latihan2/database/reconcile"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+const filesUsage = `Pemakaian: latihan2 files reconcile [--delete-orphans] [--prune-missing] [--min-age=1h]
+
+Membandingkan metadata file di MongoDB (koleksi files) dan Postgres (tabel
+files, dipakai /api/v1 dengan storage.driver postgres) dengan isi upload.dir
+dan melaporkan:
+  orphan    file di disk tanpa metadata
+  missing   metadata yang file-nya tidak ada di disk
+  size      ukuran file berbeda dengan file_size di metadata
+
+Tanpa --delete-orphans atau --prune-missing tidak ada yang diubah.
+
+Exit code 0 jika sinkron (atau semua sudah diperbaiki), 1 jika gagal, 3 jika
+masih ada selisih.
+`
+
+// runFiles menjalankan subcommand "files" dan mengembalikan exit code.
+func runFiles(args []string) int {
+	if len(args) == 0 || args[0] != "reconcile" {
+		fmt.Fprint(os.Stderr, filesUsage)
+		return 2
+	}
+	fs := flag.NewFlagSet("files reconcile", flag.ContinueOnError)
+	deleteOrphans := fs.Bool("delete-orphans", false, "hapus file di disk yang tidak punya metadata")
+	pruneMissing := fs.Bool("prune-missing", false, "hapus metadata yang file-nya tidak ada")
+	minAge := fs.Duration("min-age", time.Hour, "file tanpa metadata yang lebih baru dari ini dianggap upload yang sedang berjalan")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), filesUsage)
+		fs.PrintDefaults()
+	}
+	cfg, code := loadConfig(fs, args[1:])
+	if cfg == nil {
+		return code
+	}
+
+	database.InitPostgresDB(cfg.Postgres.DSN)
+	defer database.DB.Close()
+	database.InitMongoDB(cfg.Mongo.URI, cfg.Mongo.Database)
+	defer database.MongoClient.Disconnect(context.Background())
+
+	// Kedua database selalu diperiksa: /api/mg/files menulis ke MongoDB dan
+	// /api/v1/files ke database storage.driver, di folder upload yang sama.
+	repos := []v1Repo.FileRepository{
+		v1Repo.NewMongoFiles(mongoRepo.NewFileRepository(database.MongoDB)),
+		v1Repo.NewPostgresFiles(repository.NewFileRepository(database.DB)),
+	}
+	report, err := reconcile.Files(context.Background(), repos, cfg.Upload.Dir,
+		reconcile.Options{DeleteOrphans: *deleteOrphans, PruneMissing: *pruneMissing, MinAge: *minAge})
+	if report != nil {
+		printReconcileReport(cfg.Upload.Dir, report)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reconcile gagal: %v\n", err)
+		return 1
+	}
+
+	// Selisih yang sudah diperbaiki tidak dihitung lagi.
+	remaining := len(report.SizeMismatch)
+	if !*deleteOrphans {
+		remaining += len(report.Orphans)
+	}
+	if !*pruneMissing {
+		remaining += len(report.Missing)
+	}
+	if remaining > 0 {
+		return 3
+	}
+	return 0
+}
+
+func printReconcileReport(dir string, r *reconcile.Report) {
+	fmt.Printf("%d metadata diperiksa terhadap %s\n", r.Checked, dir)
+	if r.Recent > 0 {
+		fmt.Printf("%d file baru tanpa metadata dilewati (mungkin upload yang sedang berjalan)\n", r.Recent)
+	}
+	if r.Clean() {
+		fmt.Println("metadata dan disk sudah sinkron")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "JENIS\tID\tPATH\tKETERANGAN")
+	for _, o := range r.Orphans {
+		note := fmt.Sprintf("%d byte, diubah %s", o.Size, o.ModTime.Format("2006-01-02 15:04"))
+		if o.Deleted {
+			note += ", dihapus"
+		}
+		fmt.Fprintf(w, "orphan\t-\t%s\t%s\n", o.Path, note)
+	}
+	for _, m := range r.Missing {
+		note := "file tidak ada"
+		if m.Pruned {
+			note += ", metadata dihapus"
+		}
+		fmt.Fprintf(w, "missing\t%s\t%s\t%s\n", m.File.ID, m.File.FilePath, note)
+	}
+	for _, s := range r.SizeMismatch {
+		fmt.Fprintf(w, "size\t%s\t%s\tmetadata %d byte, disk %d byte\n", s.File.ID, s.File.FilePath, s.File.FileSize, s.ActualSize)
+	}
+	w.Flush()
+}
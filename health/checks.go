@@ -0,0 +1,90 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// Postgres memeriksa koneksi Postgres dengan PingContext.
+func Postgres(db *sql.DB, timeout time.Duration) Check {
+	return Check{Name: "postgres", Timeout: timeout, Run: func(ctx context.Context) (map[string]any, error) {
+		if err := db.PingContext(ctx); err != nil {
+			return nil, err
+		}
+		stats := db.Stats()
+		return map[string]any{"open_connections": stats.OpenConnections, "in_use": stats.InUse}, nil
+	}}
+}
+
+// Mongo memeriksa koneksi MongoDB dengan ping ke primary.
+func Mongo(client *mongo.Client, timeout time.Duration) Check {
+	return Check{Name: "mongo", Timeout: timeout, Run: func(ctx context.Context) (map[string]any, error) {
+		return nil, client.Ping(ctx, readpref.Primary())
+	}}
+}
+
+// UploadDir memeriksa bahwa dir ada (dibuat jika belum) dan bisa ditulisi
+// dengan membuat lalu menghapus file sementara.
+func UploadDir(dir string, timeout time.Duration) Check {
+	return Check{Name: "upload_dir", Timeout: timeout, Run: func(ctx context.Context) (map[string]any, error) {
+		details := map[string]any{"path": dir}
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return details, err
+		}
+		f, err := os.CreateTemp(dir, ".readyz-*")
+		if err != nil {
+			return details, err
+		}
+		name := f.Name()
+		_, err = f.WriteString("ok")
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if rerr := os.Remove(name); err == nil {
+			err = rerr
+		}
+		return details, err
+	}}
+}
+
+// DiskSpace gagal jika sisa ruang disk pada filesystem yang memuat path
+// kurang dari minFree byte. Jika path belum ada, folder induk terdekat yang
+// sudah ada yang diukur.
+func DiskSpace(path string, minFree uint64, timeout time.Duration) Check {
+	return Check{Name: "disk", Timeout: timeout, Run: func(ctx context.Context) (map[string]any, error) {
+		free, total, err := diskUsage(existingParent(path))
+		if err != nil {
+			return map[string]any{"path": path}, err
+		}
+		details := map[string]any{
+			"path":           path,
+			"free_bytes":     free,
+			"total_bytes":    total,
+			"min_free_bytes": minFree,
+		}
+		if free < minFree {
+			return details, fmt.Errorf("sisa disk %d byte di bawah batas %d byte", free, minFree)
+		}
+		return details, nil
+	}}
+}
+
+func existingParent(path string) string {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
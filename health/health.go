@@ -0,0 +1,145 @@
+// Package health menyediakan endpoint liveness (/healthz) dan readiness
+// (/readyz) untuk orchestrator. Liveness hanya menandakan proses masih hidup;
+// readiness menjalankan setiap Check secara paralel dengan batas waktu
+// masing-masing dan membalas 503 jika ada yang gagal.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Status keseluruhan dan status per check.
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusUp       = "up"
+	StatusDown     = "down"
+)
+
+// DefaultTimeout dipakai Check yang Timeout-nya nol.
+const DefaultTimeout = 2 * time.Second
+
+// Check adalah satu pemeriksaan readiness. Run boleh mengembalikan detail
+// (misalnya sisa disk) walaupun gagal; detail ikut ditampilkan di respons.
+type Check struct {
+	Name    string
+	Timeout time.Duration
+	Run     func(ctx context.Context) (details map[string]any, err error)
+}
+
+// Result adalah hasil satu Check.
+type Result struct {
+	Status     string         `json:"status" example:"up"`
+	DurationMs float64        `json:"duration_ms" example:"1.25"`
+	Error      string         `json:"error,omitempty"`
+	Details    map[string]any `json:"details,omitempty"`
+}
+
+// Report adalah body respons /readyz.
+type Report struct {
+	Status string            `json:"status" example:"ok"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Run menjalankan semua check secara paralel. Check yang tidak selesai dalam
+// Timeout dianggap gagal walaupun fungsinya mengabaikan ctx (misalnya Statfs
+// pada mount yang macet); goroutine-nya dibiarkan selesai sendiri.
+func Run(ctx context.Context, checks []Check) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			res := runOne(ctx, check)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = res
+			if res.Status != StatusUp {
+				report.Status = StatusDegraded
+			}
+		}(check)
+	}
+	wg.Wait()
+	return report
+}
+
+type outcome struct {
+	details map[string]any
+	err     error
+}
+
+func runOne(ctx context.Context, check Check) Result {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan outcome, 1)
+	go func() {
+		details, err := check.Run(ctx)
+		done <- outcome{details, err}
+	}()
+
+	var out outcome
+	select {
+	case out = <-done:
+	case <-ctx.Done():
+		out.err = ctx.Err()
+	}
+
+	res := Result{
+		Status:     StatusUp,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+		Details:    out.details,
+	}
+	if out.err != nil {
+		res.Status = StatusDown
+		res.Error = out.err.Error()
+		if errors.Is(out.err, context.DeadlineExceeded) {
+			res.Error = "timeout setelah " + timeout.String()
+		}
+	}
+	return res
+}
+
+// Liveness godoc
+// @Summary      Liveness probe
+// @Description  Selalu 200 selama proses masih bisa melayani request. Tidak memeriksa dependensi.
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} health.Report
+// @Router       /healthz [get]
+func Liveness(c *fiber.Ctx) error {
+	return c.JSON(Report{Status: StatusOK, Checks: map[string]Result{}})
+}
+
+// Readiness mengembalikan handler /readyz yang menjalankan checks.
+//
+// @Summary      Readiness probe
+// @Description  Memeriksa Postgres, MongoDB, folder upload dan sisa disk. 503 jika ada yang gagal.
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} health.Report
+// @Failure      503 {object} health.Report
+// @Router       /readyz [get]
+func Readiness(checks ...Check) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		report := Run(c.UserContext(), checks)
+		status := fiber.StatusOK
+		if report.Status != StatusOK {
+			status = fiber.StatusServiceUnavailable
+		}
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return c.Status(status).JSON(report)
+	}
+}
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"latihan2/health"
+	"math"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func check(name string, err error) health.Check {
+	return health.Check{Name: name, Run: func(context.Context) (map[string]any, error) {
+		return nil, err
+	}}
+}
+
+func readyz(t *testing.T, checks ...health.Check) (int, health.Report) {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/readyz", health.Readiness(checks...))
+	resp, err := app.Test(httptest.NewRequest("GET", "/readyz", nil), 5000)
+	require.NoError(t, err)
+	var report health.Report
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	return resp.StatusCode, report
+}
+
+func TestLiveness(t *testing.T) {
+	app := fiber.New()
+	app.Get("/healthz", health.Liveness)
+	resp, err := app.Test(httptest.NewRequest("GET", "/healthz", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestReadinessSemuaSehat(t *testing.T) {
+	status, report := readyz(t, check("a", nil), check("b", nil))
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, health.StatusOK, report.Status)
+	assert.Equal(t, health.StatusUp, report.Checks["a"].Status)
+	assert.Equal(t, health.StatusUp, report.Checks["b"].Status)
+}
+
+func TestReadinessDegraded(t *testing.T) {
+	status, report := readyz(t, check("postgres", nil), check("mongo", errors.New("server selection timeout")))
+	assert.Equal(t, fiber.StatusServiceUnavailable, status)
+	assert.Equal(t, health.StatusDegraded, report.Status)
+	assert.Equal(t, health.StatusUp, report.Checks["postgres"].Status)
+	assert.Equal(t, health.StatusDown, report.Checks["mongo"].Status)
+	assert.Equal(t, "server selection timeout", report.Checks["mongo"].Error)
+}
+
+func TestReadinessTimeout(t *testing.T) {
+	// Check yang mengabaikan ctx tetap dihentikan oleh batas waktunya.
+	lambat := health.Check{Name: "lambat", Timeout: 50 * time.Millisecond, Run: func(context.Context) (map[string]any, error) {
+		time.Sleep(time.Second)
+		return nil, nil
+	}}
+	start := time.Now()
+	status, report := readyz(t, lambat, check("cepat", nil))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, fiber.StatusServiceUnavailable, status)
+	assert.Equal(t, health.StatusDown, report.Checks["lambat"].Status)
+	assert.Equal(t, "timeout setelah 50ms", report.Checks["lambat"].Error)
+	assert.Equal(t, health.StatusUp, report.Checks["cepat"].Status)
+}
+
+func TestPostgresCheck(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectPing()
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+	c := health.Postgres(db, time.Second)
+	report := health.Run(context.Background(), []health.Check{c})
+	assert.Equal(t, health.StatusUp, report.Checks["postgres"].Status)
+
+	report = health.Run(context.Background(), []health.Check{c})
+	assert.Equal(t, health.StatusDown, report.Checks["postgres"].Status)
+	assert.Equal(t, "connection refused", report.Checks["postgres"].Error)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUploadDirDanDisk(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "uploads")
+	report := health.Run(context.Background(), []health.Check{
+		health.UploadDir(dir, time.Second),
+		health.DiskSpace(dir, 1, time.Second),
+	})
+	assert.Equal(t, health.StatusOK, report.Status)
+	assert.DirExists(t, dir)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "file percobaan harus dihapus")
+	assert.Contains(t, report.Checks["disk"].Details, "free_bytes")
+
+	report = health.Run(context.Background(), []health.Check{health.DiskSpace(dir, math.MaxUint64, time.Second)})
+	assert.Equal(t, health.StatusDegraded, report.Status)
+	assert.Contains(t, report.Checks["disk"].Error, "di bawah batas")
+}
+
+func TestUploadDirTidakBisaDitulis(t *testing.T) {
+	// Path di bawah sebuah file biasa tidak mungkin dibuat sebagai folder.
+	file := filepath.Join(t.TempDir(), "bukan-folder")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	report := health.Run(context.Background(), []health.Check{health.UploadDir(filepath.Join(file, "uploads"), time.Second)})
+	assert.Equal(t, health.StatusDegraded, report.Status)
+	assert.Equal(t, health.StatusDown, report.Checks["upload_dir"].Status)
+}
@@ -0,0 +1,88 @@
+// Package logging menyediakan logger terstruktur (log/slog, output JSON) untuk
+// seluruh aplikasi. Level bisa diubah saat runtime lewat SetLevel, atribut yang
+// berisi rahasia (password, token, secret, ...) selalu disamarkan, dan logger
+// per request (berisi request_id) dibawa lewat context.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Redacted adalah pengganti nilai atribut yang disamarkan.
+const Redacted = "[REDACTED]"
+
+var level = new(slog.LevelVar)
+
+// sensitiveKeys adalah potongan nama atribut yang nilainya tidak boleh masuk log.
+var sensitiveKeys = []string{"password", "secret", "token", "authorization", "cookie", "api_key"}
+
+// New membuat logger JSON ke w dengan level global dan redaksi atribut sensitif.
+func New(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	}))
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if Sensitive(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	return a
+}
+
+// Sensitive melaporkan apakah nilai atribut bernama key harus disamarkan.
+func Sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLevel menerima debug, info, warn atau error (tidak peka huruf besar).
+func ParseLevel(s string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("level log %q tidak dikenal, gunakan debug, info, warn atau error", s)
+	}
+	return l, nil
+}
+
+// SetLevel mengubah level semua logger dari New tanpa restart.
+func SetLevel(s string) error {
+	l, err := ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	level.Set(l)
+	return nil
+}
+
+// Level mengembalikan level yang sedang aktif, mis. "INFO".
+func Level() string {
+	return level.Level().String()
+}
+
+type ctxKey struct{}
+
+// WithContext menyimpan logger di ctx.
+func WithContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext mengembalikan logger request di ctx, atau slog.Default jika tidak ada.
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+			return l
+		}
+	}
+	return slog.Default()
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"latihan2/logging"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var m map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &m), line)
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestRedaksiAtributSensitif(t *testing.T) {
+	var buf bytes.Buffer
+	l := logging.New(&buf)
+	l.Info("login", "username", "admin", "password", "rahasia", "JWT_SECRET_KEY", "abc",
+		slog.Group("req", "Authorization", "Bearer xyz", "path", "/api/v1/login"))
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "admin", lines[0]["username"])
+	assert.Equal(t, logging.Redacted, lines[0]["password"])
+	assert.Equal(t, logging.Redacted, lines[0]["JWT_SECRET_KEY"])
+	req := lines[0]["req"].(map[string]interface{})
+	assert.Equal(t, logging.Redacted, req["Authorization"])
+	assert.Equal(t, "/api/v1/login", req["path"])
+	assert.NotContains(t, buf.String(), "rahasia")
+}
+
+func TestSetLevelBerlakuTanpaMembuatUlangLogger(t *testing.T) {
+	t.Cleanup(func() { logging.SetLevel("info") })
+	var buf bytes.Buffer
+	l := logging.New(&buf)
+
+	require.NoError(t, logging.SetLevel("warn"))
+	l.Info("tidak tercatat")
+	l.Warn("tercatat")
+	require.NoError(t, logging.SetLevel("DEBUG"))
+	l.Debug("debug tercatat")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "tercatat", lines[0]["msg"])
+	assert.Equal(t, "debug tercatat", lines[1]["msg"])
+	assert.Equal(t, "DEBUG", logging.Level())
+
+	assert.Error(t, logging.SetLevel("verbose"))
+	assert.Equal(t, "DEBUG", logging.Level())
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Same(t, slog.Default(), logging.FromContext(context.Background()))
+
+	var buf bytes.Buffer
+	l := logging.New(&buf).With("request_id", "abc")
+	logging.FromContext(logging.WithContext(context.Background(), l)).Info("halo")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "abc", lines[0]["request_id"])
+}
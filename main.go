@@ -2,17 +2,35 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"flag"
 	"fmt"
+	"latihan2/app/repository"
+	mongoRepo "latihan2/app/repository/mongo"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/app/service"
+	mongoService "latihan2/app/service/mongo"
+	v1Service "latihan2/app/service/v1"
+	"latihan2/audit"
 	"latihan2/config"
 	"latihan2/database"
+	"latihan2/health"
+	"latihan2/ratelimit"
+	"latihan2/route"
+	"latihan2/tracing"
 	"log"
+	"net"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
-	_ "github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2"
 
 	_ "latihan2/docs"
 
 	"github.com/gofiber/swagger"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
 	// "github.com/swaggo/files"
 	// "github.com/swaggo/gin-swagger"
 )
@@ -42,20 +60,193 @@ import (
 
 func main() {
 	config.InitLogger()
-	config.LoadEnv()
 
-	fmt.Println("DEBUG: JWT_SECRET_KEY yang terbaca adalah ->", os.Getenv("JWT_SECRET_KEY"))
-
-	database.InitPostgresDB()
-	database.InitMongoDB()
-
-	defer database.DB.Close()
-	defer database.MongoClient.Disconnect(context.Background())
+	// Tanpa perintah, atau langsung dengan flag, berarti serve agar cara
+	// menjalankan server yang lama tetap berlaku.
+	name, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	os.Exit(run(name, args))
+}
 
-	app := config.NewApp()
+// runServe menjalankan subcommand "serve" dan mengembalikan exit code.
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage+"\nFlag serve:\n")
+		fs.PrintDefaults()
+	}
+	cfg, code := loadConfig(fs, args)
+	if cfg == nil {
+		return code
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background())
+	if err != nil {
+		log.Print("Gagal memasang tracing: ", err)
+		return 1
+	}
+	// Ditutup paling akhir agar span dan log dari proses shutdown ikut terkirim.
+	last := []config.Closer{
+		{Name: "tracing", Close: shutdownTracing},
+		{Name: "log", Close: func(context.Context) error { return config.CloseLogger() }},
+	}
+
+	// Driver memory tidak butuh database sama sekali, sama dengan --demo.
+	if cfg.DemoMode() {
+		if err := runDemo(cfg, last...); err != nil {
+			log.Print(err)
+			return 1
+		}
+		return 0
+	}
+
+	database.InitPostgresDB(cfg.Postgres.DSN)
+	database.InitMongoDB(cfg.Mongo.URI, cfg.Mongo.Database)
+
+	if cfg.Server.AutoMigrate {
+		if err := autoMigrate(context.Background()); err != nil {
+			log.Print("Gagal menjalankan migrasi database: ", err)
+			return 1
+		}
+	}
+
+	auditLog := newAuditLog(cfg, cfg.Storage.Driver, database.DB, database.MongoDB)
+	pg := postgresHandlers(database.DB, auditLog)
+	app := config.NewApp(cfg, &pg, mongoHandlers(database.MongoDB, auditLog), storageHandlers(cfg.Storage.Driver, database.DB, database.MongoDB, auditLog),
+		readinessChecks(cfg, database.DB, database.MongoClient), config.NewLimits(cfg.RateLimit, ratelimit.NewMemoryStore()))
 
 	// swagger gin
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
-	log.Fatal(app.Listen(":3000"))
+	closers := append([]config.Closer{
+		{Name: "postgres", Close: func(context.Context) error { return database.DB.Close() }},
+		{Name: "mongo", Close: database.MongoClient.Disconnect},
+	}, last...)
+	if err := serve(cfg, app, closers...); err != nil {
+		log.Print(err)
+		return 1
+	}
+	return 0
+}
+
+// serve menjalankan app di server.addr sampai SIGINT atau SIGTERM, lalu
+// mematikannya dengan rapi lewat config.Serve dengan batas waktu
+// server.shutdown_timeout.
+func serve(cfg *config.Config, app *fiber.App, closers ...config.Closer) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ln, err := net.Listen("tcp", cfg.Server.Addr)
+	if err != nil {
+		return err
+	}
+	return config.Serve(ctx, app, ln, cfg.Server.ShutdownTimeout, closers...)
+}
+
+// postgresHandlers dan mongoHandlers adalah composition root: satu-satunya tempat
+// repository dibuat dari koneksi database lalu disuntikkan ke handler. Setiap
+// repository dibungkus audit log agar perubahan lewat /api/pg dan /api/mg
+// juga tercatat.
+func postgresHandlers(db *sql.DB, auditLog *audit.Log) route.PostgresHandlers {
+	users := audit.PostgresUsers(auditLog, repository.NewUserRepository(db))
+	return route.PostgresHandlers{
+		Auth:      service.NewAuthHandler(users),
+		User:      service.NewUserHandler(users),
+		Alumni:    service.NewAlumniHandler(audit.PostgresAlumni(auditLog, repository.NewAlumniRepository(db))),
+		Pekerjaan: service.NewPekerjaanHandler(audit.PostgresPekerjaan(auditLog, repository.NewPekerjaanRepository(db))),
+		Analytics: service.NewAnalyticsHandler(repository.NewAnalyticsRepository(db)),
+	}
+}
+
+func mongoHandlers(db *mongodriver.Database, auditLog *audit.Log) route.MongoHandlers {
+	users := audit.MongoUsers(auditLog, audit.BackendMongo, mongoRepo.NewUserRepository(db))
+	files := audit.MongoFiles(auditLog, audit.BackendMongo, mongoRepo.NewFileRepository(db))
+	return route.MongoHandlers{
+		Auth:      mongoService.NewAuthHandler(users),
+		User:      mongoService.NewUserHandler(users),
+		File:      mongoService.NewFileHandler(files),
+		Alumni:    mongoService.NewAlumniHandler(audit.MongoAlumni(auditLog, audit.BackendMongo, mongoRepo.NewAlumniRepository(db))),
+		Pekerjaan: mongoService.NewPekerjaanHandler(audit.MongoPekerjaan(auditLog, audit.BackendMongo, mongoRepo.NewPekerjaanRepository(db))),
+		FileRepo:  files,
+		Analytics: mongoService.NewAnalyticsHandler(mongoRepo.NewAnalyticsRepository(db)),
+	}
+}
+
+// storageHandlers merakit /api/v1 di atas database yang dipilih storage.driver.
+func storageHandlers(driver string, db *sql.DB, mdb *mongodriver.Database, auditLog *audit.Log) route.V1Handlers {
+	if driver == v1Repo.DriverMongo {
+		analytics := mongoService.NewAnalyticsHandler(mongoRepo.NewAnalyticsRepository(mdb))
+		return v1Handlers(mongoStorage(mdb, auditLog), route.MongoAnalytics(analytics), auditLog)
+	}
+	analytics := service.NewAnalyticsHandler(repository.NewAnalyticsRepository(db))
+	return v1Handlers(postgresStorage(db, auditLog), route.PostgresAnalytics(analytics), auditLog)
+}
+
+// postgresStorage dan mongoStorage membuat storage /api/v1 yang repository-nya
+// dibungkus audit log, dipakai server dan CLI.
+func postgresStorage(db *sql.DB, auditLog *audit.Log) v1Repo.Storage {
+	return v1Repo.NewPostgresStorage(
+		audit.PostgresUsers(auditLog, repository.NewUserRepository(db)),
+		audit.PostgresAlumni(auditLog, repository.NewAlumniRepository(db)),
+		audit.PostgresPekerjaan(auditLog, repository.NewPekerjaanRepository(db)),
+		audit.PostgresFiles(auditLog, repository.NewFileRepository(db)))
+}
+
+func mongoStorage(db *mongodriver.Database, auditLog *audit.Log) v1Repo.Storage {
+	return v1Repo.NewMongoStorage(v1Repo.DriverMongo,
+		audit.MongoUsers(auditLog, audit.BackendMongo, mongoRepo.NewUserRepository(db)),
+		audit.MongoAlumni(auditLog, audit.BackendMongo, mongoRepo.NewAlumniRepository(db)),
+		audit.MongoPekerjaan(auditLog, audit.BackendMongo, mongoRepo.NewPekerjaanRepository(db)),
+		audit.MongoFiles(auditLog, audit.BackendMongo, mongoRepo.NewFileRepository(db)))
+}
+
+// newAuditLog membuat audit log di database driver (storage.driver untuk
+// server) sehingga perubahan dari semua backend masuk ke satu rantai. nil jika
+// audit.enabled false.
+func newAuditLog(cfg *config.Config, driver string, db *sql.DB, mdb *mongodriver.Database) *audit.Log {
+	if !cfg.Audit.Enabled {
+		return nil
+	}
+	if driver == v1Repo.DriverMongo {
+		return audit.New(audit.NewMongoStore(mdb))
+	}
+	return audit.New(audit.NewPostgresStore(db))
+}
+
+// readinessChecks adalah check /readyz: kedua database yang selalu dipakai
+// (/api/pg dan /api/mg tetap terpasang), folder upload dan sisa disk.
+func readinessChecks(cfg *config.Config, db *sql.DB, client *mongodriver.Client) []health.Check {
+	timeout := cfg.Health.CheckTimeout
+	return append([]health.Check{
+		health.Postgres(db, timeout),
+		health.Mongo(client, timeout),
+	}, storageChecks(cfg)...)
+}
+
+// storageChecks memeriksa folder upload, dipakai juga oleh mode demo.
+func storageChecks(cfg *config.Config) []health.Check {
+	timeout := cfg.Health.CheckTimeout
+	return []health.Check{
+		health.UploadDir(cfg.Upload.Dir, timeout),
+		health.DiskSpace(cfg.Upload.Dir, cfg.Health.MinFreeDiskMB<<20, timeout),
+	}
+}
+
+// v1Handlers membuat handler /api/v1 dari storage. auditLog nil berarti
+// /api/v1/admin/audit tidak dipasang.
+func v1Handlers(s v1Repo.Storage, analytics *route.AnalyticsHandlers, auditLog *audit.Log) route.V1Handlers {
+	h := route.V1Handlers{
+		Driver:    s.Driver,
+		Auth:      v1Service.NewAuthHandler(s.Driver, s.Users),
+		User:      v1Service.NewUserHandler(s.Users),
+		Alumni:    v1Service.NewAlumniHandler(s.Alumni),
+		Pekerjaan: v1Service.NewPekerjaanHandler(s.Pekerjaan, s.Alumni),
+		File:      v1Service.NewFileHandler(s.Files, s.Users),
+		Analytics: analytics,
+	}
+	if auditLog != nil {
+		h.Audit = v1Service.NewAuditHandler(auditLog.Store())
+	}
+	return h
 }
@@ -0,0 +1,121 @@
+// Package metrics mengumpulkan metrik Prometheus aplikasi dan menyajikannya di
+// /metrics. Semua metrik didaftarkan ke Registry milik package ini (bukan
+// registry global prometheus) agar test bisa membaca nilainya tanpa bentrok.
+package metrics
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry berisi semua metrik aplikasi beserta metrik runtime Go dan proses.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Jumlah request HTTP per method, template route dan status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency request HTTP per method, template route dan status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	mongoDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mongo_command_duration_seconds",
+		Help:    "Latency command MongoDB per nama command dan hasil.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"command", "status"})
+
+	uploads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "file_uploads_total",
+		Help: "Jumlah upload file yang tersimpan per tipe file.",
+	}, []string{"type"})
+
+	uploadBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "file_upload_bytes_total",
+		Help: "Total ukuran file yang berhasil diupload per tipe file.",
+	}, []string{"type"})
+
+	logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "login_attempts_total",
+		Help: "Jumlah percobaan login per API (pg, mg, v1) dan hasil (success, failure).",
+	}, []string{"api", "result"})
+
+	rateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rate_limited_requests_total",
+		Help: "Jumlah request yang ditolak rate limiter per nama limiter.",
+	}, []string{"limiter"})
+
+	auditEntries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "audit_entries_total",
+		Help: "Jumlah entri audit log per hasil penulisan (ok atau error).",
+	}, []string{"result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration, mongoDuration, uploads, uploadBytes, logins, rateLimited, auditEntries,
+	)
+}
+
+// Handler menyajikan Registry dalam format teks Prometheus.
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
+
+// ObserveHTTP mencatat satu request. route adalah template route fiber
+// (mis. /api/v1/alumni/:id), bukan path asli, agar jumlah label tetap kecil.
+func ObserveHTTP(method, route string, status int, d time.Duration) {
+	s := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, s).Inc()
+	httpDuration.WithLabelValues(method, route, s).Observe(d.Seconds())
+}
+
+// RegisterDB mengekspor sql.DB.Stats() (koneksi terbuka, idle, wait, ...)
+// dengan label db_name=name.
+func RegisterDB(db *sql.DB, name string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveUpload mencatat file yang berhasil disimpan.
+func ObserveUpload(contentType string, size int64) {
+	uploads.WithLabelValues(contentType).Inc()
+	uploadBytes.WithLabelValues(contentType).Add(float64(size))
+}
+
+// Hasil login untuk ObserveLogin.
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+)
+
+// ObserveLogin mencatat hasil login pada api "pg", "mg" atau "v1".
+func ObserveLogin(api, result string) {
+	logins.WithLabelValues(api, result).Inc()
+}
+
+// ObserveRateLimited mencatat request yang ditolak limiter name.
+func ObserveRateLimited(name string) {
+	rateLimited.WithLabelValues(name).Inc()
+}
+
+// ObserveAudit mencatat hasil penulisan satu entri audit log.
+func ObserveAudit(err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	auditEntries.WithLabelValues(result).Inc()
+}
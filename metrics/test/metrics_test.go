@@ -0,0 +1,110 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"latihan2/metrics"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	_ "github.com/lib/pq"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// find mencari metrik name dengan label persis labels di metrics.Registry.
+func find(t *testing.T, name string, labels map[string]string) *dto.Metric {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			if matchLabels(m, labels) {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+func matchLabels(m *dto.Metric, labels map[string]string) bool {
+	if len(m.GetLabel()) != len(labels) {
+		return false
+	}
+	for _, l := range m.GetLabel() {
+		if labels[l.GetName()] != l.GetValue() {
+			return false
+		}
+	}
+	return true
+}
+
+func counter(t *testing.T, name string, labels map[string]string) float64 {
+	t.Helper()
+	m := find(t, name, labels)
+	if m == nil {
+		return 0
+	}
+	return m.GetCounter().GetValue()
+}
+
+func TestObserveLoginDanUpload(t *testing.T) {
+	ok := map[string]string{"api": "v1", "result": metrics.LoginSuccess}
+	fail := map[string]string{"api": "v1", "result": metrics.LoginFailure}
+	okBefore, failBefore := counter(t, "login_attempts_total", ok), counter(t, "login_attempts_total", fail)
+
+	metrics.ObserveLogin("v1", metrics.LoginSuccess)
+	metrics.ObserveLogin("v1", metrics.LoginFailure)
+	metrics.ObserveLogin("v1", metrics.LoginFailure)
+	assert.Equal(t, okBefore+1, counter(t, "login_attempts_total", ok))
+	assert.Equal(t, failBefore+2, counter(t, "login_attempts_total", fail))
+
+	pdf := map[string]string{"type": "application/pdf"}
+	metrics.ObserveUpload("application/pdf", 1500)
+	metrics.ObserveUpload("application/pdf", 500)
+	assert.Equal(t, float64(2), counter(t, "file_uploads_total", pdf))
+	assert.Equal(t, float64(2000), counter(t, "file_upload_bytes_total", pdf))
+}
+
+func TestMongoMonitor(t *testing.T) {
+	mon := metrics.MongoMonitor()
+	mon.Succeeded(context.Background(), &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find", Duration: 30 * time.Millisecond},
+	})
+	mon.Failed(context.Background(), &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "insert", Duration: time.Millisecond},
+	})
+
+	ok := find(t, "mongo_command_duration_seconds", map[string]string{"command": "find", "status": "success"})
+	require.NotNil(t, ok)
+	assert.Equal(t, uint64(1), ok.GetHistogram().GetSampleCount())
+	assert.InDelta(t, 0.03, ok.GetHistogram().GetSampleSum(), 1e-9)
+
+	failed := find(t, "mongo_command_duration_seconds", map[string]string{"command": "insert", "status": "failure"})
+	require.NotNil(t, failed)
+	assert.Equal(t, uint64(1), failed.GetHistogram().GetSampleCount())
+}
+
+func TestRegisterDBDanHandler(t *testing.T) {
+	db, err := sql.Open("postgres", "postgres://localhost/tidak-dipakai?sslmode=disable")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, metrics.RegisterDB(db, "test"))
+
+	app := fiber.New()
+	app.Get("/metrics", metrics.Handler())
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `go_sql_open_connections{db_name="test"} 0`)
+	assert.Contains(t, string(body), "go_goroutines")
+}
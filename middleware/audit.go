@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"fmt"
+	"latihan2/audit"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuditActor menyimpan user yang login, request ID dan IP klien di
+// UserContext agar pembungkus repository dari package audit tahu siapa yang
+// mengubah data. Pasang setelah middleware auth.
+func AuditActor() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		actor := audit.Actor{}
+		if id := userIDFromLocals(c); id != nil {
+			actor.ID = fmt.Sprint(id)
+		}
+		actor.Username, _ = c.Locals("username").(string)
+		actor.Role, _ = c.Locals("role").(string)
+		requestID, _ := c.Locals("requestID").(string)
+
+		ctx := audit.WithActor(c.UserContext(), actor)
+		c.SetUserContext(audit.WithRequest(ctx, requestID, c.IP()))
+		return c.Next()
+	}
+}
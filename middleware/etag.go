@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"latihan2/response"
+	"latihan2/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// IfMatch mewajibkan header If-Match pada update agar perubahan tidak menimpa
+// data yang sudah diubah orang lain. Version dari header disimpan di Locals
+// "ifMatch" (0 untuk "*") dan dicek oleh repository saat update.
+func IfMatch() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(fiber.HeaderIfMatch)
+		if header == "" {
+			return response.Fail(c, fiber.StatusPreconditionRequired, "Header If-Match diperlukan, ambil ETag dari GET data terlebih dahulu")
+		}
+
+		version, err := utils.ParseIfMatch(header)
+		if err != nil {
+			return response.Fail(c, fiber.StatusPreconditionFailed, "If-Match tidak cocok dengan ETag data")
+		}
+
+		c.Locals("ifMatch", version)
+		return c.Next()
+	}
+}
+
+// IfMatchVersion mengambil version dari IfMatch. Handler yang dipasang tanpa
+// middleware IfMatch mendapat 0, yaitu update tanpa pengecekan version.
+func IfMatchVersion(c *fiber.Ctx) int {
+	version, _ := c.Locals("ifMatch").(int)
+	return version
+}
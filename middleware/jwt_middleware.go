@@ -1,7 +1,8 @@
 package middleware
 
 import (
-	"os"
+	"latihan2/response"
+	"latihan2/utils"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,22 +14,22 @@ func JWTMiddleware() fiber.Handler {
 		// Ambil Authorization header
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Missing token"})
+			return response.Fail(c, fiber.StatusUnauthorized, "Missing token")
 		}
 
 		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
 
 		// Parse token
 		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("JWT_SECRET_KEY")), nil
+			return utils.JWTSecret(), nil
 		})
 		if err != nil || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid token"})
+			return response.Fail(c, fiber.StatusUnauthorized, "Invalid token")
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid claims"})
+			return response.Fail(c, fiber.StatusUnauthorized, "Invalid claims")
 		}
 
 		// Set locals
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"latihan2/response"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit menolak request dengan body lebih dari limit byte, kecuali path
+// yang ada di routes (misalnya endpoint upload) yang memakai batasnya sendiri.
+// Batas ini lebih kecil dari fiber.Config.BodyLimit, yang harus cukup besar
+// untuk route terbesar karena fiber membaca body sebelum routing.
+func BodyLimit(limit int, routes map[string]int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		max := limit
+		if n, ok := routes[strings.TrimSuffix(c.Path(), "/")]; ok {
+			max = n
+		}
+		if len(c.Body()) > max {
+			return response.Fail(c, fiber.StatusRequestEntityTooLarge,
+				fmt.Sprintf("Body request maksimal %s", formatBytes(max)))
+		}
+		return c.Next()
+	}
+}
+
+// formatBytes menulis ukuran dalam KB atau MB, misalnya "256 KB".
+func formatBytes(n int) string {
+	if n >= 1<<20 && n%(1<<20) == 0 {
+		return fmt.Sprintf("%d MB", n>>20)
+	}
+	return fmt.Sprintf("%d KB", n>>10)
+}
+
+// Timeout memberi batas waktu d pada context request (c.UserContext()) yang
+// diteruskan repository ke query Postgres dan command Mongo, sehingga query
+// yang terlalu lama dibatalkan. Jika batas waktu terlewati dan handler
+// menjawab dengan error server, response diganti 503.
+func Timeout(d time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), d)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return err
+		}
+		if err == nil && c.Response().StatusCode() < fiber.StatusInternalServerError {
+			return nil
+		}
+		c.Response().ResetBody()
+		return response.Fail(c, fiber.StatusServiceUnavailable, "Request melebihi batas waktu, coba lagi")
+	}
+}
@@ -1,12 +1,112 @@
 package middleware
 
 import (
-	"fmt"
+	"latihan2/logging"
+	"log/slog"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// HeaderRequestID dipakai untuk menerima dan mengembalikan ID request.
+const HeaderRequestID = "X-Request-ID"
+
+// RequestID memakai X-Request-ID dari klien jika valid atau membuat UUID baru,
+// mengembalikannya di header response, lalu menyimpan logger yang sudah berisi
+// request_id (dan trace_id jika ada span dari Tracing) di UserContext agar bisa
+// diambil lewat logging.FromContext.
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(HeaderRequestID)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Set(HeaderRequestID, id)
+		c.Locals("requestID", id)
+
+		l := logging.FromContext(c.UserContext()).With("request_id", id)
+		if sc := trace.SpanContextFromContext(c.UserContext()); sc.IsValid() {
+			l = l.With("trace_id", sc.TraceID().String())
+		}
+		c.SetUserContext(logging.WithContext(c.UserContext(), l))
+		return c.Next()
+	}
+}
+
+// validRequestID membatasi ID dari klien ke karakter aman agar tidak bisa
+// menyisipkan isi aneh ke log.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// LoggerMiddleware menulis satu baris log per request setelah handler selesai,
+// berisi route, status, latency dan user yang login. Error dari handler
+// diteruskan ke ErrorHandler lebih dulu agar status yang dicatat adalah status
+// yang benar-benar dikirim.
 func LoggerMiddleware(c *fiber.Ctx) error {
-	fmt.Println("Request:", c.Method(), c.Path())
-	return c.Next()
-}
\ No newline at end of file
+	start := time.Now()
+	handleError(c, c.Next())
+
+	status := c.Response().StatusCode()
+	attrs := []any{
+		"method", c.Method(),
+		"path", c.Path(),
+		"route", routeTemplate(c, status),
+		"status", status,
+		"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+		"ip", c.IP(),
+	}
+	if userID := userIDFromLocals(c); userID != nil {
+		attrs = append(attrs, "user_id", userID)
+	}
+	if role, ok := c.Locals("role").(string); ok {
+		attrs = append(attrs, "role", role)
+	}
+
+	level := slog.LevelInfo
+	switch {
+	case status >= fiber.StatusInternalServerError:
+		level = slog.LevelError
+	case status >= fiber.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+	logging.FromContext(c.UserContext()).Log(c.UserContext(), level, "request", attrs...)
+	return nil
+}
+
+// handleError menjalankan ErrorHandler untuk err dari handler berikutnya agar
+// middleware yang mencatat status melihat status akhir. Setelah itu error
+// dianggap sudah ditangani sehingga ErrorHandler tidak dipanggil dua kali.
+func handleError(c *fiber.Ctx, err error) {
+	if err == nil {
+		return
+	}
+	if herr := c.App().ErrorHandler(c, err); herr != nil {
+		_ = c.SendStatus(fiber.StatusInternalServerError)
+	}
+}
+
+// userIDFromLocals membaca user ID dari AuthRequired ("user_id" int) atau
+// AuthRequiredMongo/AuthRequiredV1 ("userID" string).
+func userIDFromLocals(c *fiber.Ctx) any {
+	if id, ok := c.Locals("user_id").(int); ok {
+		return id
+	}
+	if id, ok := c.Locals("userID").(string); ok && id != "" {
+		return id
+	}
+	return nil
+}
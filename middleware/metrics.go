@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"latihan2/metrics"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics mencatat jumlah dan latency request per template route dan status.
+// Request yang tidak cocok dengan route mana pun dicatat dengan route
+// "unmatched" agar path acak tidak menambah label baru.
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		handleError(c, c.Next())
+
+		status := c.Response().StatusCode()
+		metrics.ObserveHTTP(c.Method(), routeTemplate(c, status), status, time.Since(start))
+		return nil
+	}
+}
+
+// routeTemplate mengembalikan template route yang melayani request. Untuk 404
+// tanpa route, fiber hanya mencatat middleware global ("/") sebagai route.
+func routeTemplate(c *fiber.Ctx, status int) string {
+	route := c.Route().Path
+	if status == fiber.StatusNotFound && route == "/" && c.Path() != "/" {
+		return "unmatched"
+	}
+	return route
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"fmt"
+	"latihan2/logging"
+	"latihan2/metrics"
+	"latihan2/ratelimit"
+	"latihan2/response"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Header rate limit (draft IETF RateLimit header fields).
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// RateLimitIdentity mengembalikan identitas pemilik bucket: "user:<id>" jika
+// middleware auth sudah berjalan (Locals "userID" dari /api/v1 dan /api/mg,
+// "user_id" dari /api/pg), selain itu "ip:<alamat>". Belum ada identitas
+// API key karena satu-satunya kredensial API adalah JWT (BearerAuth), dan
+// setiap token, termasuk yang dibuat lewat "token issue", membawa user ID.
+func RateLimitIdentity(c *fiber.Ctx) string {
+	if id, ok := c.Locals("userID").(string); ok && id != "" {
+		return "user:" + id
+	}
+	if id, ok := c.Locals("user_id").(int); ok {
+		return "user:" + strconv.Itoa(id)
+	}
+	return "ip:" + c.IP()
+}
+
+// RateLimit membatasi request per identitas (lihat RateLimitIdentity) dengan
+// bucket bernama name, sehingga setiap kelompok route punya batas sendiri.
+// Limiter yang dipasang sebelum middleware auth selalu memakai IP, jadi
+// limiter per user harus dipasang sesudahnya (lihat route.Limits).
+//
+// Setiap response diberi header RateLimit-*. Jika beberapa limiter berlaku
+// pada satu request, header menunjukkan limiter dengan sisa paling sedikit.
+// Request yang ditolak mendapat 429 dengan Retry-After. Jika store gagal,
+// request tetap dilayani agar gangguan store tidak mematikan API.
+func RateLimit(name string, limit ratelimit.Limit, store ratelimit.Store) fiber.Handler {
+	policy := fmt.Sprintf("%d;w=%d;burst=%d", limit.Rate, int(limit.Per.Seconds()), limit.Burst)
+	return func(c *fiber.Ctx) error {
+		if limit.Unlimited() {
+			return c.Next()
+		}
+		res, err := store.Take(c.UserContext(), name+":"+RateLimitIdentity(c), limit)
+		if err != nil {
+			logging.FromContext(c.UserContext()).Warn("rate limit dilewati, store gagal", "limiter", name, "error", err)
+			return c.Next()
+		}
+
+		if prev, ok := c.Locals("rateLimitRemaining").(int); !ok || res.Remaining <= prev {
+			c.Locals("rateLimitRemaining", res.Remaining)
+			c.Set(HeaderRateLimitLimit, strconv.Itoa(res.Limit))
+			c.Set(HeaderRateLimitRemaining, strconv.Itoa(res.Remaining))
+			c.Set(HeaderRateLimitReset, strconv.Itoa(seconds(res.Reset)))
+			c.Set(HeaderRateLimitPolicy, policy)
+		}
+		if res.Allowed {
+			return c.Next()
+		}
+
+		metrics.ObserveRateLimited(name)
+		retry := seconds(res.RetryAfter)
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retry))
+		return response.Fail(c, fiber.StatusTooManyRequests,
+			fmt.Sprintf("Terlalu banyak request, coba lagi dalam %d detik", retry))
+	}
+}
+
+// seconds membulatkan d ke atas agar klien tidak mencoba lagi terlalu cepat.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
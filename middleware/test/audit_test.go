@@ -0,0 +1,43 @@
+package test
+
+import (
+	"latihan2/audit"
+	"latihan2/middleware"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditActor(t *testing.T) {
+	var got audit.Actor
+	app := fiber.New()
+	app.Use(middleware.RequestID())
+	app.Get("/pg", func(c *fiber.Ctx) error {
+		c.Locals("user_id", 7)
+		c.Locals("username", "budi")
+		c.Locals("role", "admin")
+		return c.Next()
+	}, middleware.AuditActor(), func(c *fiber.Ctx) error {
+		got = audit.ActorFrom(c.UserContext())
+		return nil
+	})
+	app.Get("/v1", func(c *fiber.Ctx) error {
+		c.Locals("userID", "65a000000000000000000001")
+		c.Locals("role", "user")
+		return c.Next()
+	}, middleware.AuditActor(), func(c *fiber.Ctx) error {
+		got = audit.ActorFrom(c.UserContext())
+		return nil
+	})
+
+	_, err := app.Test(httptest.NewRequest("GET", "/pg", nil))
+	require.NoError(t, err)
+	assert.Equal(t, audit.Actor{ID: "7", Username: "budi", Role: "admin"}, got)
+
+	_, err = app.Test(httptest.NewRequest("GET", "/v1", nil))
+	require.NoError(t, err)
+	assert.Equal(t, audit.Actor{ID: "65a000000000000000000001", Role: "user"}, got)
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"io"
+	"latihan2/middleware"
+	"latihan2/response"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLimitPerRoute(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Use(middleware.BodyLimit(1<<10, map[string]int{"/upload": 1 << 20}))
+	app.Post("/*", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	send := func(path string, size int) (int, string) {
+		resp, err := app.Test(httptest.NewRequest("POST", path, strings.NewReader(strings.Repeat("a", size))))
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := send("/json", 2<<10)
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	assert.Contains(t, body, "Body request maksimal 1 KB")
+
+	status, _ = send("/json", 1<<10)
+	assert.Equal(t, fiber.StatusOK, status)
+	status, _ = send("/upload/", 512<<10)
+	assert.Equal(t, fiber.StatusOK, status)
+
+	status, body = send("/upload", 2<<20)
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	assert.Contains(t, body, "Body request maksimal 1 MB")
+}
+
+func TestTimeout(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Use(middleware.Timeout(20 * time.Millisecond))
+	// /query meniru repository yang membatalkan query saat context habis.
+	app.Get("/query", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.Status(fiber.StatusInternalServerError).SendString(c.UserContext().Err().Error())
+	})
+	app.Get("/lambat", func(c *fiber.Ctx) error {
+		time.Sleep(40 * time.Millisecond)
+		return c.SendString("selesai")
+	})
+	app.Get("/cepat", func(c *fiber.Ctx) error {
+		_, ok := c.UserContext().Deadline()
+		assert.True(t, ok)
+		return c.SendString("ok")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/query", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"code":"service_unavailable"`)
+	assert.NotContains(t, string(body), "deadline exceeded")
+
+	// Response yang sudah berhasil tidak dibuang meski melewati batas waktu.
+	resp, err = app.Test(httptest.NewRequest("GET", "/lambat", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/cepat", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"latihan2/logging"
+	"latihan2/middleware"
+	"latihan2/response"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newApp memasang RequestID dan LoggerMiddleware dengan slog.Default yang
+// menulis ke buf. Route /alumni/:id meniru handler yang sudah melewati auth.
+func newApp(t *testing.T, buf *bytes.Buffer) *fiber.App {
+	t.Helper()
+	prev := slog.Default()
+	slog.SetDefault(logging.New(buf))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Use(middleware.RequestID())
+	app.Use(middleware.LoggerMiddleware)
+	app.Get("/alumni/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", 7)
+		c.Locals("role", "admin")
+		logging.FromContext(c.UserContext()).Info("di handler")
+		return c.SendString("ok")
+	})
+	app.Get("/gagal", func(c *fiber.Ctx) error {
+		return fiber.ErrConflict
+	})
+	return app
+}
+
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var m map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &m), line)
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestRequestIDDibuatDanDiteruskan(t *testing.T) {
+	var buf bytes.Buffer
+	app := newApp(t, &buf)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/alumni/1", nil))
+	require.NoError(t, err)
+	generated := resp.Header.Get(middleware.HeaderRequestID)
+	assert.Len(t, generated, 36)
+
+	req := httptest.NewRequest("GET", "/alumni/1", nil)
+	req.Header.Set(middleware.HeaderRequestID, "req-123")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, "req-123", resp.Header.Get(middleware.HeaderRequestID))
+
+	req = httptest.NewRequest("GET", "/alumni/1", nil)
+	req.Header.Set(middleware.HeaderRequestID, "bukan id\"valid")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.NotEqual(t, "bukan id\"valid", resp.Header.Get(middleware.HeaderRequestID))
+}
+
+func TestAccessLogBerisiFieldRequest(t *testing.T) {
+	var buf bytes.Buffer
+	app := newApp(t, &buf)
+
+	req := httptest.NewRequest("GET", "/alumni/42", nil)
+	req.Header.Set(middleware.HeaderRequestID, "req-abc")
+	_, err := app.Test(req)
+	require.NoError(t, err)
+
+	lines := logLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "di handler", lines[0]["msg"])
+	assert.Equal(t, "req-abc", lines[0]["request_id"])
+
+	access := lines[1]
+	assert.Equal(t, "request", access["msg"])
+	assert.Equal(t, "INFO", access["level"])
+	assert.Equal(t, "req-abc", access["request_id"])
+	assert.Equal(t, "/alumni/:id", access["route"])
+	assert.Equal(t, "/alumni/42", access["path"])
+	assert.Equal(t, float64(200), access["status"])
+	assert.Equal(t, float64(7), access["user_id"])
+	assert.Equal(t, "admin", access["role"])
+	assert.Contains(t, access, "latency_ms")
+}
+
+func TestAccessLogMencatatStatusDariErrorHandler(t *testing.T) {
+	var buf bytes.Buffer
+	app := newApp(t, &buf)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/gagal", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	assert.Equal(t, response.ProblemContentType, resp.Header.Get(fiber.HeaderContentType))
+
+	lines := logLines(t, &buf)
+	access := lines[len(lines)-1]
+	assert.Equal(t, float64(409), access["status"])
+	assert.Equal(t, "WARN", access["level"])
+	assert.NotContains(t, access, "user_id")
+}
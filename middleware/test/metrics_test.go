@@ -0,0 +1,41 @@
+package test
+
+import (
+	"io"
+	"latihan2/metrics"
+	"latihan2/middleware"
+	"latihan2/response"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsPerTemplateRoute(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Use(middleware.Metrics())
+	app.Get("/metrics", metrics.Handler())
+	app.Get("/metrik-test/:id", func(c *fiber.Ctx) error {
+		if c.Params("id") == "0" {
+			return fiber.ErrBadRequest
+		}
+		return c.SendString("ok")
+	})
+
+	for _, path := range []string{"/metrik-test/1", "/metrik-test/2", "/metrik-test/0", "/metrik-tidak-ada/abc"} {
+		_, err := app.Test(httptest.NewRequest("GET", path, nil))
+		require.NoError(t, err)
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	text := string(body)
+	assert.Contains(t, text, `http_requests_total{method="GET",route="/metrik-test/:id",status="200"} 2`)
+	assert.Contains(t, text, `http_requests_total{method="GET",route="/metrik-test/:id",status="400"} 1`)
+	assert.Contains(t, text, `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, text, `http_request_duration_seconds_count{method="GET",route="/metrik-test/:id",status="200"} 2`)
+	assert.NotContains(t, text, "/metrik-test/1")
+}
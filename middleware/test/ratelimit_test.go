@@ -0,0 +1,108 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"latihan2/metrics"
+	"latihan2/middleware"
+	"latihan2/ratelimit"
+	"latihan2/response"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rateLimitApp memasang limiter "ip" untuk semua request dan limiter "user"
+// setelah auth palsu yang membaca header X-User.
+func rateLimitApp(ip, user ratelimit.Limit, store ratelimit.Store) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Use(middleware.RateLimit("ip", ip, store))
+	app.Use(func(c *fiber.Ctx) error {
+		if id := c.Get("X-User"); id != "" {
+			c.Locals("userID", id)
+		}
+		return c.Next()
+	})
+	app.Use(middleware.RateLimit("user", user, store))
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	return app
+}
+
+func get(t *testing.T, app *fiber.App, user string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/", nil)
+	if user != "" {
+		req.Header.Set("X-User", user)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestRateLimitHeaderDan429(t *testing.T) {
+	app := rateLimitApp(ratelimit.Limit{}, ratelimit.Limit{Rate: 2, Per: time.Minute, Burst: 2}, ratelimit.NewMemoryStore())
+
+	resp := get(t, app, "")
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get(middleware.HeaderRateLimitLimit))
+	assert.Equal(t, "1", resp.Header.Get(middleware.HeaderRateLimitRemaining))
+	assert.Equal(t, "30", resp.Header.Get(middleware.HeaderRateLimitReset))
+	assert.Equal(t, "2;w=60;burst=2", resp.Header.Get(middleware.HeaderRateLimitPolicy))
+
+	get(t, app, "")
+	resp = get(t, app, "")
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get(fiber.HeaderRetryAfter))
+	assert.Equal(t, "0", resp.Header.Get(middleware.HeaderRateLimitRemaining))
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"code":"too_many_requests"`)
+
+	// User yang login dihitung terpisah dari IP-nya.
+	assert.Equal(t, fiber.StatusOK, get(t, app, "u1").StatusCode)
+	assert.Equal(t, fiber.StatusOK, get(t, app, "u2").StatusCode)
+}
+
+func TestRateLimitHeaderDariLimiterTersempit(t *testing.T) {
+	app := rateLimitApp(
+		ratelimit.Limit{Rate: 100, Per: time.Minute, Burst: 100},
+		ratelimit.Limit{Rate: 5, Per: time.Minute, Burst: 5},
+		ratelimit.NewMemoryStore())
+
+	resp := get(t, app, "u1")
+	assert.Equal(t, "5", resp.Header.Get(middleware.HeaderRateLimitLimit))
+	assert.Equal(t, "4", resp.Header.Get(middleware.HeaderRateLimitRemaining))
+}
+
+func TestRateLimitMetrik(t *testing.T) {
+	app := rateLimitApp(ratelimit.Limit{Rate: 1, Per: time.Hour, Burst: 1}, ratelimit.Limit{}, ratelimit.NewMemoryStore())
+	get(t, app, "")
+	assert.Equal(t, fiber.StatusTooManyRequests, get(t, app, "").StatusCode)
+
+	m := fiber.New()
+	m.Get("/metrics", metrics.Handler())
+	resp, err := m.Test(httptest.NewRequest("GET", "/metrics", nil))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `rate_limited_requests_total{limiter="ip"}`)
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store tidak bisa dihubungi")
+}
+
+func TestRateLimitStoreGagalTetapDilayani(t *testing.T) {
+	app := rateLimitApp(ratelimit.Limit{Rate: 1, Per: time.Hour, Burst: 1}, ratelimit.Limit{}, failingStore{})
+	for i := 0; i < 3; i++ {
+		resp := get(t, app, "")
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get(middleware.HeaderRateLimitLimit))
+	}
+}
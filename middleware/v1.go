@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"latihan2/response"
+	"latihan2/utils"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuthRequiredV1 memvalidasi token /api/v1 untuk driver storage yang aktif.
+// Locals yang diisi sama dengan AuthRequiredMongo ("userID" string, "role",
+// "username") sehingga FileOwnerOrAdmin dan FileHandler bisa dipakai ulang.
+func AuthRequiredV1(driver string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authHeader := c.Get("Authorization")
+		if authHeader == "" {
+			return response.Fail(c, fiber.StatusUnauthorized, "Token akses diperlukan")
+		}
+
+		tokenParts := strings.Split(authHeader, " ")
+		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+			return response.Fail(c, fiber.StatusUnauthorized, "Format token tidak valid, gunakan 'Bearer <token>'")
+		}
+
+		claims, err := utils.ValidateTokenV1(driver, tokenParts[1])
+		if err != nil {
+			return response.Fail(c, fiber.StatusUnauthorized, "Token tidak valid atau expired")
+		}
+
+		c.Locals("userID", claims.UserID)
+		c.Locals("role", claims.Role)
+		c.Locals("username", claims.Username)
+
+		return c.Next()
+	}
+}
+
+// Deprecated menandai route lama sebagai usang lewat header Deprecation
+// dan menunjuk penggantinya lewat header Link.
+func Deprecated(successor string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set("Deprecation", "true")
+		c.Set(fiber.HeaderLink, "<"+successor+">; rel=\"successor-version\"")
+		return c.Next()
+	}
+}
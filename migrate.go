@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"latihan2/config"
+	"latihan2/database"
+	"latihan2/database/migration"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+const migrateUsage = `Pemakaian: latihan2 migrate [--db=all|postgres|mongo] <perintah>
+
+Perintah:
+  up            jalankan semua migrasi yang belum dijalankan
+  down [n]      batalkan n migrasi terakhir (default 1)
+  status        tampilkan migrasi yang sudah dan belum dijalankan
+  to <versi>    naik/turun sampai tepat di versi tersebut (0 = kosong)
+`
+
+// runMigrate menjalankan subcommand "migrate" dan mengembalikan exit code.
+func runMigrate(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	db := fs.String("db", "all", "database yang dimigrasi: all, postgres, atau mongo")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), migrateUsage)
+		fs.PrintDefaults()
+	}
+	cfg, code := loadConfig(fs, args)
+	if cfg == nil {
+		return code
+	}
+	if *db != "all" && *db != "postgres" && *db != "mongo" {
+		fmt.Fprintf(os.Stderr, "--db tidak valid: %s\n", *db)
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	command, rest := fs.Arg(0), fs.Args()[1:]
+	run, err := migrateCommand(command, rest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fs.Usage()
+		return 2
+	}
+
+	migrators, closeDB, err := openMigrators(cfg, *db)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeDB()
+
+	ctx := context.Background()
+	for _, m := range migrators {
+		fmt.Printf("== %s ==\n", m.name)
+		if err := run(ctx, m.Migrator); err != nil {
+			fmt.Fprintf(os.Stderr, "migrasi %s gagal: %v\n", m.name, err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// migrateCommand memvalidasi perintah dan argumennya sebelum database dibuka.
+func migrateCommand(command string, args []string) (func(context.Context, migration.Migrator) error, error) {
+	switch command {
+	case "up":
+		return func(ctx context.Context, m migration.Migrator) error {
+			if err := m.Up(ctx); err != nil {
+				return err
+			}
+			return printStatus(ctx, m)
+		}, nil
+
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("jumlah langkah down tidak valid: %s", args[0])
+			}
+			steps = n
+		}
+		return func(ctx context.Context, m migration.Migrator) error {
+			if err := m.Down(ctx, steps); err != nil {
+				return err
+			}
+			return printStatus(ctx, m)
+		}, nil
+
+	case "status":
+		return printStatus, nil
+
+	case "to":
+		if len(args) == 0 {
+			return nil, fmt.Errorf("perintah to membutuhkan versi")
+		}
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || version < 0 {
+			return nil, fmt.Errorf("versi tidak valid: %s", args[0])
+		}
+		return func(ctx context.Context, m migration.Migrator) error {
+			if err := m.To(ctx, version); err != nil {
+				return err
+			}
+			return printStatus(ctx, m)
+		}, nil
+	}
+	return nil, fmt.Errorf("perintah migrate tidak dikenal: %s", command)
+}
+
+func printStatus(ctx context.Context, m migration.Migrator) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSI\tNAMA\tSTATUS\tDIJALANKAN")
+	for _, s := range status {
+		state, at := "pending", "-"
+		if s.Applied {
+			state, at = "applied", s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, at)
+	}
+	return w.Flush()
+}
+
+type namedMigrator struct {
+	name string
+	migration.Migrator
+}
+
+// openMigrators membuka koneksi yang dibutuhkan saja, sehingga "--db=postgres"
+// tetap bisa dipakai tanpa MongoDB dan sebaliknya.
+func openMigrators(cfg *config.Config, db string) ([]namedMigrator, func(), error) {
+	var migrators []namedMigrator
+	var closers []func()
+	closeAll := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+
+	if db == "all" || db == "postgres" {
+		database.InitPostgresDB(cfg.Postgres.DSN)
+		closers = append(closers, func() { database.DB.Close() })
+		pg, err := migration.NewPostgres(database.DB)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		migrators = append(migrators, namedMigrator{"postgres", pg})
+	}
+	if db == "all" || db == "mongo" {
+		database.InitMongoDB(cfg.Mongo.URI, cfg.Mongo.Database)
+		closers = append(closers, func() { database.MongoClient.Disconnect(context.Background()) })
+		mg, err := migration.NewMongo(database.MongoDB)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		migrators = append(migrators, namedMigrator{"mongo", mg})
+	}
+	return migrators, closeAll, nil
+}
+
+// autoMigrate menjalankan semua migrasi yang belum dijalankan saat server start.
+func autoMigrate(ctx context.Context) error {
+	pg, err := migration.NewPostgres(database.DB)
+	if err != nil {
+		return err
+	}
+	if err := pg.Up(ctx); err != nil {
+		return err
+	}
+	mg, err := migration.NewMongo(database.MongoDB)
+	if err != nil {
+		return err
+	}
+	return mg.Up(ctx)
+}
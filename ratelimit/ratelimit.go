@@ -0,0 +1,186 @@
+// Package ratelimit membatasi jumlah request dengan algoritma token bucket.
+// Setiap key (misalnya "login:ip:10.0.0.1") punya bucket berisi paling banyak
+// Burst token yang terisi kembali Rate token per Per. Satu request memakai satu
+// token; jika bucket kosong request ditolak sampai token berikutnya tersedia.
+//
+// Bucket disimpan di Store. MemoryStore cukup untuk satu instance; jika server
+// dijalankan lebih dari satu instance, pakai Store bersama (misalnya Redis)
+// agar batasnya berlaku untuk semua instance.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit adalah aturan satu bucket. Limit kosong (Rate 0) berarti tidak dibatasi.
+type Limit struct {
+	Rate  int
+	Per   time.Duration
+	Burst int
+}
+
+// ParseLimit membaca format "<rate>/<durasi>" dengan burst opsional, misalnya
+// "10/1m" (10 request per menit, burst 10) atau "100/1h,burst=20". String
+// kosong atau "off" berarti tidak dibatasi.
+func ParseLimit(s string) (Limit, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "off" {
+		return Limit{}, nil
+	}
+	spec, burst, hasBurst := strings.Cut(s, ",")
+	rate, per, ok := strings.Cut(spec, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("batas %q tidak valid, contoh: 10/1m atau 100/1h,burst=20", s)
+	}
+	var l Limit
+	var err error
+	if l.Rate, err = strconv.Atoi(strings.TrimSpace(rate)); err != nil || l.Rate <= 0 {
+		return Limit{}, fmt.Errorf("jumlah request pada %q harus bilangan bulat lebih dari 0", s)
+	}
+	if l.Per, err = time.ParseDuration(strings.TrimSpace(per)); err != nil || l.Per <= 0 {
+		return Limit{}, fmt.Errorf("durasi pada %q tidak valid, contoh: 1s, 1m, 1h", s)
+	}
+	l.Burst = l.Rate
+	if hasBurst {
+		value, ok := strings.CutPrefix(strings.TrimSpace(burst), "burst=")
+		if l.Burst, err = strconv.Atoi(value); !ok || err != nil || l.Burst <= 0 {
+			return Limit{}, fmt.Errorf("burst pada %q harus berformat burst=<n> dengan n lebih dari 0", s)
+		}
+	}
+	return l, nil
+}
+
+// String mengembalikan format yang dibaca ParseLimit.
+func (l Limit) String() string {
+	if l.Unlimited() {
+		return "off"
+	}
+	s := fmt.Sprintf("%d/%s", l.Rate, l.Per)
+	if l.Burst != l.Rate {
+		s += fmt.Sprintf(",burst=%d", l.Burst)
+	}
+	return s
+}
+
+// Unlimited melaporkan apakah l tidak membatasi apa pun.
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0 || l.Per <= 0
+}
+
+// interval adalah waktu yang dibutuhkan untuk mengisi satu token.
+func (l Limit) interval() time.Duration {
+	return l.Per / time.Duration(l.Rate)
+}
+
+// Result adalah hasil Take untuk satu request.
+type Result struct {
+	Allowed bool
+	// Limit adalah kapasitas bucket (Burst).
+	Limit int
+	// Remaining adalah sisa token setelah request ini.
+	Remaining int
+	// Reset adalah waktu sampai bucket penuh kembali.
+	Reset time.Duration
+	// RetryAfter adalah waktu sampai satu token tersedia; 0 jika Allowed.
+	RetryAfter time.Duration
+}
+
+// Store menyimpan bucket. Take harus atomik per key: dua request bersamaan
+// tidak boleh memakai token yang sama, termasuk dari instance server berbeda
+// jika store dipakai bersama.
+type Store interface {
+	Take(ctx context.Context, key string, l Limit) (Result, error)
+}
+
+// bucket menyimpan jumlah token pada waktu last. full adalah waktu bucket
+// penuh kembali jika tidak ada request lagi.
+type bucket struct {
+	tokens float64
+	last   time.Time
+	full   time.Time
+}
+
+// MemoryStore menyimpan bucket di memory proses. Bucket yang sudah penuh
+// kembali dibuang secara berkala karena isinya sama dengan bucket baru.
+type MemoryStore struct {
+	// Now dipakai untuk waktu sekarang; nil berarti time.Now.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// sweepInterval adalah jarak minimal antara dua kali pembersihan bucket.
+const sweepInterval = time.Minute
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}}
+}
+
+func (s *MemoryStore) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, l Limit) (Result, error) {
+	if l.Unlimited() {
+		return Result{Allowed: true}, nil
+	}
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		for k, b := range s.buckets {
+			if !now.Before(b.full) {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.Burst), last: now}
+		s.buckets[key] = b
+	}
+	return b.take(l, now), nil
+}
+
+// Len mengembalikan jumlah bucket yang sedang disimpan.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buckets)
+}
+
+// take mengisi ulang bucket sesuai waktu yang sudah lewat lalu mencoba memakai
+// satu token.
+func (b *bucket) take(l Limit, now time.Time) Result {
+	interval := l.interval()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(l.Burst), b.tokens+float64(elapsed)/float64(interval))
+	}
+	b.last = now
+
+	res := Result{Limit: l.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = time.Duration((1 - b.tokens) * float64(interval))
+	}
+	res.Remaining = int(b.tokens)
+	res.Reset = time.Duration((float64(l.Burst) - b.tokens) * float64(interval))
+	b.full = now.Add(res.Reset)
+	return res
+}
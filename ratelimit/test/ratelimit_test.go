@@ -0,0 +1,123 @@
+package test
+
+import (
+	"context"
+	"latihan2/ratelimit"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	l, err := ratelimit.ParseLimit("10/1m")
+	require.NoError(t, err)
+	assert.Equal(t, ratelimit.Limit{Rate: 10, Per: time.Minute, Burst: 10}, l)
+
+	l, err = ratelimit.ParseLimit(" 100/1h,burst=20 ")
+	require.NoError(t, err)
+	assert.Equal(t, ratelimit.Limit{Rate: 100, Per: time.Hour, Burst: 20}, l)
+	again, err := ratelimit.ParseLimit(l.String())
+	require.NoError(t, err)
+	assert.Equal(t, l, again)
+
+	for _, off := range []string{"", "off"} {
+		l, err = ratelimit.ParseLimit(off)
+		require.NoError(t, err)
+		assert.True(t, l.Unlimited())
+	}
+
+	for _, bad := range []string{"10", "0/1m", "x/1m", "10/menit", "10/0s", "10/1m,20", "10/1m,burst=0"} {
+		_, err := ratelimit.ParseLimit(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+// clock adalah waktu palsu untuk MemoryStore.Now.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time      { return c.t }
+func (c *clock) add(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestMemoryStoreTokenBucket(t *testing.T) {
+	clk := &clock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store := ratelimit.NewMemoryStore()
+	store.Now = clk.now
+	ctx := context.Background()
+	// 1 token per 10 detik, burst 3.
+	l := ratelimit.Limit{Rate: 6, Per: time.Minute, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		res, err := store.Take(ctx, "a", l)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 3, res.Limit)
+		assert.Equal(t, i, res.Remaining)
+	}
+
+	res, err := store.Take(ctx, "a", l)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed, "burst habis")
+	assert.Equal(t, 10*time.Second, res.RetryAfter)
+	assert.Equal(t, 30*time.Second, res.Reset)
+
+	// Key lain punya bucket sendiri.
+	res, err = store.Take(ctx, "b", l)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	clk.add(10 * time.Second)
+	res, err = store.Take(ctx, "a", l)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "satu token terisi setelah 10 detik")
+	assert.Equal(t, 0, res.Remaining)
+
+	// Lama tidak dipakai: bucket penuh lagi tetapi tidak melebihi burst.
+	clk.add(time.Hour)
+	res, err = store.Take(ctx, "a", l)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Remaining)
+}
+
+func TestMemoryStoreMembuangBucketPenuh(t *testing.T) {
+	clk := &clock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store := ratelimit.NewMemoryStore()
+	store.Now = clk.now
+	l := ratelimit.Limit{Rate: 1, Per: time.Second, Burst: 1}
+
+	for _, key := range []string{"a", "b", "c"} {
+		_, err := store.Take(context.Background(), key, l)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, store.Len())
+
+	clk.add(2 * time.Minute)
+	_, err := store.Take(context.Background(), "d", l)
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestMemoryStoreBersamaan(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	l := ratelimit.Limit{Rate: 50, Per: time.Hour, Burst: 50}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := store.Take(context.Background(), "k", l)
+			if err == nil && res.Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, allowed)
+}
@@ -0,0 +1,198 @@
+package response
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"latihan2/app/repository"
+	mongoRepo "latihan2/app/repository/mongo"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/logging"
+	"latihan2/validation"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// ProblemContentType adalah media type problem details (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// Code adalah kode error yang stabil; klien sebaiknya memakai code, bukan detail.
+const (
+	CodeBadRequest           = "bad_request"
+	CodeUnauthorized         = "unauthorized"
+	CodeForbidden            = "forbidden"
+	CodeNotFound             = "not_found"
+	CodeMethodNotAllowed     = "method_not_allowed"
+	CodeConflict             = "conflict"
+	CodeDuplicate            = "duplicate"
+	CodePreconditionFailed   = "precondition_failed"
+	CodeVersionConflict      = "version_conflict"
+	CodePayloadTooLarge      = "payload_too_large"
+	CodeUnsupportedMedia     = "unsupported_media_type"
+	CodeValidation           = "validation_failed"
+	CodePreconditionRequired = "precondition_required"
+	CodeTooManyRequests      = "too_many_requests"
+	CodeInternal             = "internal_error"
+	CodeUnavailable          = "service_unavailable"
+)
+
+var statusCodes = map[int]string{
+	fiber.StatusBadRequest:            CodeBadRequest,
+	fiber.StatusUnauthorized:          CodeUnauthorized,
+	fiber.StatusForbidden:             CodeForbidden,
+	fiber.StatusNotFound:              CodeNotFound,
+	fiber.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	fiber.StatusConflict:              CodeConflict,
+	fiber.StatusPreconditionFailed:    CodePreconditionFailed,
+	fiber.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	fiber.StatusUnsupportedMediaType:  CodeUnsupportedMedia,
+	fiber.StatusUnprocessableEntity:   CodeValidation,
+	fiber.StatusPreconditionRequired:  CodePreconditionRequired,
+	fiber.StatusTooManyRequests:       CodeTooManyRequests,
+	fiber.StatusInternalServerError:   CodeInternal,
+	fiber.StatusServiceUnavailable:    CodeUnavailable,
+}
+
+// codeFor memberi code bawaan untuk status yang tidak punya code khusus.
+func codeFor(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// Problem adalah body application/problem+json. Type selalu "about:blank"
+// sehingga Title adalah nama status HTTP; penjelasan ada di Detail dan jenis
+// error di Code.
+type Problem struct {
+	Type     string                  `json:"type"`
+	Title    string                  `json:"title"`
+	Status   int                     `json:"status"`
+	Code     string                  `json:"code"`
+	Detail   string                  `json:"detail,omitempty"`
+	Instance string                  `json:"instance,omitempty"`
+	Errors   []validation.FieldError `json:"errors,omitempty"`
+}
+
+// HTTPError adalah error yang sudah tahu status HTTP dan code-nya. Err adalah
+// penyebab aslinya dan hanya dicatat di log, tidak dikirim ke klien.
+type HTTPError struct {
+	Status int
+	Code   string
+	Detail string
+	Err    error
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Detail, e.Err)
+	}
+	return e.Detail
+}
+
+func (e *HTTPError) Unwrap() error { return e.Err }
+
+// New membuat HTTPError dengan code bawaan untuk status.
+func New(status int, detail string) *HTTPError {
+	return &HTTPError{Status: status, Code: codeFor(status), Detail: detail}
+}
+
+// Fail menulis problem dengan status dan detail yang diberikan.
+func Fail(c *fiber.Ctx, status int, detail string) error {
+	return Error(c, New(status, detail))
+}
+
+// Error menulis err sebagai problem. Error domain dari repository dan hasil
+// validasi dipetakan ke status yang sesuai (lihat From); error lain menjadi 500
+// dengan detail umum dan dicatat di log.
+func Error(c *fiber.Ctx, err error) error {
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		lang := validation.Language(c.Get(fiber.HeaderAcceptLanguage))
+		c.Set(fiber.HeaderContentLanguage, lang)
+		return write(c, Problem{
+			Status: fiber.StatusUnprocessableEntity,
+			Code:   CodeValidation,
+			Detail: validation.Message(lang),
+			Errors: validation.Translate(err, lang),
+		})
+	}
+
+	e := From(err)
+	if e.Status >= fiber.StatusInternalServerError {
+		logging.FromContext(c.UserContext()).Error("request gagal", "status", e.Status, "error", err)
+	}
+	return write(c, Problem{Status: e.Status, Code: e.Code, Detail: e.Detail})
+}
+
+func write(c *fiber.Ctx, p Problem) error {
+	p.Type = "about:blank"
+	p.Title = http.StatusText(p.Status)
+	p.Instance = c.OriginalURL()
+	c.Status(p.Status)
+	if err := c.JSON(p); err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, ProblemContentType)
+	return nil
+}
+
+// From memetakan error ke *HTTPError. Error domain yang sama dari ketiga lapisan
+// repository (Postgres, Mongo, v1) menghasilkan status dan code yang sama.
+func From(err error) *HTTPError {
+	var e *HTTPError
+	if errors.As(err, &e) {
+		return e
+	}
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		return New(fe.Code, fe.Message)
+	}
+
+	var dup *v1Repo.DuplicateError
+	var pgDup *repository.DuplicateKeyError
+	var mgDup *mongoRepo.DuplicateKeyError
+	switch {
+	case errors.Is(err, v1Repo.ErrNotFound),
+		errors.Is(err, sql.ErrNoRows),
+		errors.Is(err, mongodriver.ErrNoDocuments),
+		errors.Is(err, repository.ErrPekerjaanNotFound),
+		errors.Is(err, mongoRepo.ErrPekerjaanNotFound):
+		return &HTTPError{Status: fiber.StatusNotFound, Code: CodeNotFound, Detail: "Data tidak ditemukan", Err: err}
+	case errors.Is(err, v1Repo.ErrForbidden),
+		errors.Is(err, repository.ErrForbidden),
+		errors.Is(err, mongoRepo.ErrForbidden):
+		return &HTTPError{Status: fiber.StatusForbidden, Code: CodeForbidden, Detail: "Akses ditolak", Err: err}
+	case errors.Is(err, v1Repo.ErrVersionConflict),
+		errors.Is(err, repository.ErrVersionConflict),
+		errors.Is(err, mongoRepo.ErrVersionConflict):
+		return &HTTPError{Status: fiber.StatusPreconditionFailed, Code: CodeVersionConflict,
+			Detail: "Data sudah diubah, ambil ulang data terbaru", Err: err}
+	case errors.As(err, &dup):
+		return &HTTPError{Status: fiber.StatusConflict, Code: CodeDuplicate, Detail: dup.Error(), Err: err}
+	case errors.As(err, &pgDup):
+		return &HTTPError{Status: fiber.StatusConflict, Code: CodeDuplicate, Detail: pgDup.Error(), Err: err}
+	case errors.As(err, &mgDup):
+		return &HTTPError{Status: fiber.StatusConflict, Code: CodeDuplicate, Detail: mgDup.Error(), Err: err}
+	case errors.Is(err, v1Repo.ErrDuplikat), errors.Is(err, repository.ErrDuplikat), errors.Is(err, mongoRepo.ErrDuplikat):
+		return &HTTPError{Status: fiber.StatusConflict, Code: CodeDuplicate, Detail: "Data sudah terdaftar", Err: err}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &HTTPError{Status: fiber.StatusServiceUnavailable, Code: CodeUnavailable,
+			Detail: "Request melebihi batas waktu, coba lagi", Err: err}
+	}
+	return &HTTPError{Status: fiber.StatusInternalServerError, Code: CodeInternal, Detail: "Terjadi kesalahan pada server", Err: err}
+}
+
+// ErrorHandler dipasang di fiber.Config agar error yang dikembalikan handler
+// atau middleware (termasuk 404 route tidak ada dan 413 body terlalu besar)
+// juga ditulis sebagai problem.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	return Error(c, err)
+}
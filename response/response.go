@@ -0,0 +1,40 @@
+// Package response menyeragamkan bentuk response API. Response sukses memakai
+// envelope {"success": true, "data": ..., "meta": ..., "message": ...}; error
+// memakai application/problem+json (RFC 7807) dengan member "code" yang stabil
+// untuk dibaca klien.
+package response
+
+import "github.com/gofiber/fiber/v2"
+
+// Envelope adalah bentuk semua response sukses. Meta dan Message boleh kosong.
+type Envelope struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
+	Message string      `json:"message,omitempty"`
+}
+
+// Send menulis env dengan status yang diberikan. Success selalu true karena
+// error ditulis lewat Fail atau Error.
+func Send(c *fiber.Ctx, status int, env Envelope) error {
+	env.Success = true
+	return c.Status(status).JSON(env)
+}
+
+func OK(c *fiber.Ctx, data interface{}) error {
+	return Send(c, fiber.StatusOK, Envelope{Data: data})
+}
+
+func Created(c *fiber.Ctx, data interface{}) error {
+	return Send(c, fiber.StatusCreated, Envelope{Data: data})
+}
+
+// List dipakai endpoint yang mengembalikan data beserta meta pagination.
+func List(c *fiber.Ctx, data, meta interface{}) error {
+	return Send(c, fiber.StatusOK, Envelope{Data: data, Meta: meta})
+}
+
+// Message dipakai response yang hanya berisi pesan, mis. setelah delete.
+func Message(c *fiber.Ctx, message string) error {
+	return Send(c, fiber.StatusOK, Envelope{Message: message})
+}
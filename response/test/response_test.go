@@ -0,0 +1,116 @@
+package test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"latihan2/app/repository"
+	mongoRepo "latihan2/app/repository/mongo"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/response"
+	"latihan2/validation"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestFromMemetakanErrorRepository(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{v1Repo.ErrNotFound, fiber.StatusNotFound, response.CodeNotFound},
+		{sql.ErrNoRows, fiber.StatusNotFound, response.CodeNotFound},
+		{mongo.ErrNoDocuments, fiber.StatusNotFound, response.CodeNotFound},
+		{fmt.Errorf("cari: %w", repository.ErrPekerjaanNotFound), fiber.StatusNotFound, response.CodeNotFound},
+		{mongoRepo.ErrForbidden, fiber.StatusForbidden, response.CodeForbidden},
+		{repository.ErrVersionConflict, fiber.StatusPreconditionFailed, response.CodeVersionConflict},
+		{v1Repo.ErrVersionConflict, fiber.StatusPreconditionFailed, response.CodeVersionConflict},
+		{v1Repo.ErrDuplikat, fiber.StatusConflict, response.CodeDuplicate},
+		{fiber.NewError(fiber.StatusRequestEntityTooLarge, "Request Entity Too Large"), fiber.StatusRequestEntityTooLarge, response.CodePayloadTooLarge},
+		{errors.New("koneksi putus"), fiber.StatusInternalServerError, response.CodeInternal},
+	}
+	for _, c := range cases {
+		e := response.From(c.err)
+		assert.Equal(t, c.status, e.Status, c.err.Error())
+		assert.Equal(t, c.code, e.Code, c.err.Error())
+	}
+}
+
+func TestFromTidakMembocorkanErrorInternal(t *testing.T) {
+	e := response.From(errors.New("pq: password authentication failed"))
+	assert.Equal(t, "Terjadi kesalahan pada server", e.Detail)
+	assert.ErrorContains(t, e, "password authentication failed")
+}
+
+func newApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		return response.Message(c, "beres")
+	})
+	app.Get("/gagal", func(c *fiber.Ctx) error {
+		return response.Fail(c, fiber.StatusConflict, "bentrok")
+	})
+	app.Get("/validasi", func(c *fiber.Ctx) error {
+		req := struct {
+			Email string `json:"email" validate:"required,email"`
+		}{Email: "bukan-email"}
+		return response.Error(c, validation.Struct(req))
+	})
+	return app
+}
+
+func TestEnvelopeTanpaFieldKosong(t *testing.T) {
+	resp, err := newApp().Test(httptest.NewRequest("GET", "/ok", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, map[string]interface{}{"success": true, "message": "beres"}, body)
+}
+
+func TestProblemDetails(t *testing.T) {
+	cases := []struct {
+		path   string
+		status int
+		code   string
+	}{
+		{"/gagal", fiber.StatusConflict, response.CodeConflict},
+		{"/tidak-ada", fiber.StatusNotFound, response.CodeNotFound},
+		{"/validasi", fiber.StatusUnprocessableEntity, response.CodeValidation},
+	}
+	for _, c := range cases {
+		resp, err := newApp().Test(httptest.NewRequest("GET", c.path, nil))
+		require.NoError(t, err)
+		assert.Equal(t, c.status, resp.StatusCode, c.path)
+		assert.Equal(t, response.ProblemContentType, resp.Header.Get(fiber.HeaderContentType), c.path)
+
+		var p response.Problem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+		assert.Equal(t, "about:blank", p.Type)
+		assert.Equal(t, c.status, p.Status)
+		assert.Equal(t, c.code, p.Code)
+		assert.Equal(t, c.path, p.Instance)
+	}
+}
+
+func TestProblemValidasiMengikutiBahasa(t *testing.T) {
+	req := httptest.NewRequest("GET", "/validasi", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "en-US,en;q=0.9")
+	resp, err := newApp().Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, "en", resp.Header.Get(fiber.HeaderContentLanguage))
+
+	var p response.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+	assert.Equal(t, "Validation failed", p.Detail)
+	require.Len(t, p.Errors, 1)
+	assert.Equal(t, "email", p.Errors[0].Field)
+}
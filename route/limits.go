@@ -0,0 +1,29 @@
+package route
+
+import "github.com/gofiber/fiber/v2"
+
+// Limits adalah middleware rate limit per kelompok route (lihat
+// middleware.RateLimit). Field nil berarti kelompok itu tidak dibatasi, jadi
+// Limits kosong mematikan rate limit.
+type Limits struct {
+	// IP dipasang di depan setiap prefix API sebelum auth, termasuk untuk
+	// request yang akhirnya ditolak karena token salah.
+	IP fiber.Handler
+	// User dipasang setelah auth sehingga dihitung per user.
+	User fiber.Handler
+	// Login dan Upload adalah batas tambahan yang lebih ketat untuk endpoint login dan upload file.
+	Login  fiber.Handler
+	Upload fiber.Handler
+}
+
+// chain membuang handler nil agar limiter yang tidak aktif bisa langsung
+// ditulis di daftar handler route.
+func chain(handlers ...fiber.Handler) []fiber.Handler {
+	result := handlers[:0:0]
+	for _, h := range handlers {
+		if h != nil {
+			result = append(result, h)
+		}
+	}
+	return result
+}
@@ -1,6 +1,7 @@
 package route
 
 import (
+	mongoRepo "latihan2/app/repository/mongo"
 	"latihan2/app/service"
 	"latihan2/app/service/mongo"
 	"latihan2/middleware"
@@ -8,78 +9,125 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupRoutesPostgres(app *fiber.App) {
-	api := app.Group("/api/pg")
+// PostgresHandlers adalah handler /api/pg yang sudah dirakit dengan repository-nya di main.
+// /api/pg dan /api/mg sudah usang; klien baru memakai /api/v1 (lihat SetupRoutesV1).
+type PostgresHandlers struct {
+	Auth      *service.AuthHandler
+	User      *service.UserHandler
+	Alumni    *service.AlumniHandler
+	Pekerjaan *service.PekerjaanHandler
+	Analytics *service.AnalyticsHandler
+	Limits    Limits
+}
+
+// MongoHandlers adalah handler /api/mg. FileRepo dipakai middleware FileOwnerOrAdmin.
+// Analytics nil berarti /api/mg/analytics tidak dipasang.
+type MongoHandlers struct {
+	Auth      *mongo.AuthHandler
+	User      *mongo.UserHandler
+	File      *mongo.FileHandler
+	Alumni    *mongo.AlumniHandler
+	Pekerjaan *mongo.PekerjaanHandler
+	FileRepo  mongoRepo.FileRepository
+	Analytics *mongo.AnalyticsHandler
+	Limits    Limits
+}
 
-	api.Post("/login", service.Login)
-	protected := api.Group("", middleware.AuthRequired())
-	protected.Get("/profile", service.GetProfile)
+func SetupRoutesPostgres(app *fiber.App, h PostgresHandlers) {
+	api := app.Group("/api/pg", chain(h.Limits.IP, middleware.Deprecated("/api/v1"))...)
+
+	api.Post("/login", chain(h.Limits.Login, h.Auth.Login)...)
+	protected := api.Group("", chain(middleware.AuthRequired(), middleware.AuditActor(), h.Limits.User)...)
+	protected.Get("/profile", h.Auth.GetProfile)
 
 	// dengan Pagination, Sorting, & Search
-	api.Get("/users", service.GetUsersService)
-	api.Get("/users/:id", service.GetUserByIDService)
-	// api.Delete("/users/:id", service.SoftDeleteUserService)
-	// api.Get("/alumnus", service.GetAlumniService)
-	// api.Get("/semua-pekerjaan", service.GetPekerjaanService)
+	api.Get("/users", h.User.GetUsersService)
+	api.Get("/users/:id", h.User.GetUserByIDService)
+	// api.Delete("/users/:id", h.User.SoftDeleteUserService)
+	// api.Get("/alumnus", h.Alumni.GetAlumniService)
+	// api.Get("/semua-pekerjaan", h.Pekerjaan.GetPekerjaanService)
 
 	alumni := protected.Group("/alumni")
-	alumni.Get("/", service.GetAlumniService)
-	// alumni.Get("/", service.GetAllAlumni)
-	alumni.Get("/:id", service.GetAlumniByID)
-	alumni.Get("/tahun/:tahun", service.GetAlumniByTahunLulus)
-	alumni.Post("/", middleware.AdminOnly(), service.CreateAlumni)
-	alumni.Put("/:id", middleware.AdminOnly(), service.UpdateAlumni)
-	alumni.Delete("/:id", middleware.AdminOnly(), service.DeleteAlumni)
-	// alumni.Delete("/:id", middleware.AdminOnly(), service.SoftDeleteAlumniService)
+	alumni.Get("/", h.Alumni.GetAlumniService)
+	alumni.Get("/search", h.Alumni.SearchAlumniService)
+	// alumni.Get("/", h.Alumni.GetAllAlumni)
+	alumni.Get("/:id", h.Alumni.GetAlumniByID)
+	alumni.Get("/tahun/:tahun", h.Alumni.GetAlumniByTahunLulus)
+	alumni.Post("/", middleware.AdminOnly(), h.Alumni.CreateAlumni)
+	alumni.Put("/:id", middleware.AdminOnly(), middleware.IfMatch(), h.Alumni.UpdateAlumni)
+	alumni.Delete("/:id", middleware.AdminOnly(), h.Alumni.DeleteAlumni)
+	// alumni.Delete("/:id", middleware.AdminOnly(), h.Alumni.SoftDeleteAlumniService)
 
 	pekerjaan := protected.Group("/pekerjaan")
-	pekerjaan.Get("/", service.GetPekerjaanService)
-	// pekerjaan.Get("/", service.GetAllPekerjaan)
-	pekerjaan.Get("/:id", middleware.JWTMiddleware(), service.GetPekerjaanByID)
-	pekerjaan.Get("/alumni/:alumni_id", middleware.AdminOnly(), service.GetPekerjaanByAlumniID)
-	pekerjaan.Post("/", middleware.AdminOnly(), service.CreatePekerjaan)
-	pekerjaan.Put("/:id", middleware.AdminOnly(), service.UpdatePekerjaan)
-	// pekerjaan.Delete("/:id", middleware.AdminOnly(), service.DeletePekerjaan)
-	pekerjaan.Delete("/soft-delete/:id", service.SoftDeletePekerjaan)
-	pekerjaan.Post("/restore/:id", service.RestorePekerjaanService)
-	pekerjaan.Get("/trash/:id", service.GetTrashPekerjaanByIDService)
-	pekerjaan.Delete("/hard-delete/:id", service.HardDeletePekerjaanService)
+	pekerjaan.Get("/", h.Pekerjaan.GetPekerjaanService)
+	pekerjaan.Get("/search", h.Pekerjaan.SearchPekerjaanService)
+	// pekerjaan.Get("/", h.Pekerjaan.GetAllPekerjaan)
+	pekerjaan.Get("/:id", middleware.JWTMiddleware(), h.Pekerjaan.GetPekerjaanByID)
+	pekerjaan.Get("/alumni/:alumni_id", middleware.AdminOnly(), h.Pekerjaan.GetPekerjaanByAlumniID)
+	pekerjaan.Post("/", middleware.AdminOnly(), h.Pekerjaan.CreatePekerjaan)
+	pekerjaan.Put("/:id", middleware.AdminOnly(), middleware.IfMatch(), h.Pekerjaan.UpdatePekerjaan)
+	pekerjaan.Post("/migrasi-gaji", middleware.AdminOnly(), h.Pekerjaan.MigrateGajiService)
+	// pekerjaan.Delete("/:id", middleware.AdminOnly(), h.Pekerjaan.DeletePekerjaan)
+	pekerjaan.Delete("/soft-delete/:id", h.Pekerjaan.SoftDeletePekerjaan)
+	pekerjaan.Post("/restore/:id", h.Pekerjaan.RestorePekerjaanService)
+	pekerjaan.Get("/trash/:id", h.Pekerjaan.GetTrashPekerjaanByIDService)
+	pekerjaan.Delete("/hard-delete/:id", h.Pekerjaan.HardDeletePekerjaanService)
+
+	analytics := protected.Group("/analytics")
+	analytics.Get("/employment-rate", h.Analytics.GetEmploymentRateService)
+	analytics.Get("/time-to-first-job", h.Analytics.GetTimeToFirstJobService)
+	analytics.Get("/distribusi/:dimensi", h.Analytics.GetDistribusiPekerjaanService)
+	analytics.Get("/gaji", h.Analytics.GetDistribusiGajiService)
+	analytics.Get("/trend", h.Analytics.GetCohortTrendService)
 
 }
 
-func SetupRoutesMongo(app *fiber.App) {
-	api := app.Group("/api/mg")
+func SetupRoutesMongo(app *fiber.App, h MongoHandlers) {
+	api := app.Group("/api/mg", chain(h.Limits.IP, middleware.Deprecated("/api/v1"))...)
 
-	api.Post("/login", mongo.LoginMongo)
-	protectedm := api.Group("", middleware.AuthRequiredMongo())
+	api.Post("/login", chain(h.Limits.Login, h.Auth.LoginMongo)...)
+	protectedm := api.Group("", chain(middleware.AuthRequiredMongo(), middleware.AuditActor(), h.Limits.User)...)
 
 	usersm := protectedm.Group("/users")
-	usersm.Get("/", mongo.GetAllUsers)
-	usersm.Get("/:id/", mongo.GetUsersByID)
+	usersm.Get("/", h.User.GetAllUsers)
+	usersm.Get("/:id/", h.User.GetUsersByID)
 
 	files := protectedm.Group("/files")
-	files.Post("/upload", mongo.UploadFile)
-	files.Get("/", mongo.GetAllFiles)
-	files.Get("/:id", mongo.GetFileByID)
-	files.Get("/open/:id", mongo.GetContentByID)
-	files.Delete("/:id", middleware.FileOwnerOrAdmin(), mongo.DeleteFile)
+	files.Post("/upload", chain(h.Limits.Upload, h.File.UploadFile)...)
+	files.Get("/", h.File.GetAllFiles)
+	files.Get("/:id", h.File.GetFileByID)
+	files.Get("/open/:id", h.File.GetContentByID)
+	files.Delete("/:id", middleware.FileOwnerOrAdmin(h.FileRepo), h.File.DeleteFile)
 
 	alumnim := protectedm.Group("/alumni")
-	alumnim.Get("/", mongo.GetAllAlumni)
-	alumnim.Get("/:id/", mongo.GetAlumniByID)
-	alumnim.Post("/", mongo.CreateAlumni)
-	alumnim.Put("/:id", mongo.UpdateAlumni)
-	alumnim.Delete("/soft-delete/:id", mongo.SoftDeleteAlumni)
+	alumnim.Get("/", h.Alumni.GetAllAlumni)
+	alumnim.Get("/search", h.Alumni.SearchAlumni)
+	alumnim.Get("/:id/", h.Alumni.GetAlumniByID)
+	alumnim.Post("/", h.Alumni.CreateAlumni)
+	alumnim.Put("/:id", middleware.IfMatch(), h.Alumni.UpdateAlumni)
+	alumnim.Delete("/soft-delete/:id", h.Alumni.SoftDeleteAlumni)
 
 	pekerjaanm := protectedm.Group("/pekerjaan")
-	pekerjaanm.Get("/", mongo.GetAllPekerjaan)
-	pekerjaanm.Get("/alumni/:alumni_id/", mongo.GetPekerjaanByAlumniID)
-	pekerjaanm.Get("/:id/", mongo.GetPekerjaanByID)
-	pekerjaanm.Post("/", mongo.CreatePekerjaan)
-	pekerjaanm.Put("/:id", mongo.UpdatePekerjaan)
-	pekerjaanm.Delete("/soft-delete/:id", mongo.SoftDeletePekerjaan)
-	pekerjaanm.Get("/trash/:id", mongo.GetTrashPekerjaan)
-	pekerjaanm.Post("/restore/:id", mongo.RestorePekerjaan)
-	pekerjaanm.Delete("/hard-delete/:id", mongo.HardDeletePekerjaan)
+	pekerjaanm.Get("/", h.Pekerjaan.GetAllPekerjaan)
+	pekerjaanm.Get("/search", h.Pekerjaan.SearchPekerjaan)
+	pekerjaanm.Get("/alumni/:alumni_id/", h.Pekerjaan.GetPekerjaanByAlumniID)
+	pekerjaanm.Get("/:id/", h.Pekerjaan.GetPekerjaanByID)
+	pekerjaanm.Post("/", h.Pekerjaan.CreatePekerjaan)
+	pekerjaanm.Put("/:id", middleware.IfMatch(), h.Pekerjaan.UpdatePekerjaan)
+	pekerjaanm.Post("/migrasi-gaji", middleware.AdminOnly(), h.Pekerjaan.MigrateGaji)
+	pekerjaanm.Delete("/soft-delete/:id", h.Pekerjaan.SoftDeletePekerjaan)
+	pekerjaanm.Get("/trash/:id", h.Pekerjaan.GetTrashPekerjaan)
+	pekerjaanm.Post("/restore/:id", h.Pekerjaan.RestorePekerjaan)
+	pekerjaanm.Delete("/hard-delete/:id", h.Pekerjaan.HardDeletePekerjaan)
+
+	if h.Analytics == nil {
+		return
+	}
+	analyticsm := protectedm.Group("/analytics")
+	analyticsm.Get("/employment-rate", h.Analytics.GetEmploymentRate)
+	analyticsm.Get("/time-to-first-job", h.Analytics.GetTimeToFirstJob)
+	analyticsm.Get("/distribusi/:dimensi", h.Analytics.GetDistribusiPekerjaan)
+	analyticsm.Get("/gaji", h.Analytics.GetDistribusiGaji)
+	analyticsm.Get("/trend", h.Analytics.GetCohortTrend)
 }
 
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"io"
+	"latihan2/app/repository/memory"
+	mongoService "latihan2/app/service/mongo"
+	"latihan2/middleware"
+	"latihan2/ratelimit"
+	"latihan2/response"
+	"latihan2/route"
+	"latihan2/utils"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newMongoApp memasang /api/mg di atas repository memori yang sudah diisi
+// data contoh, sama seperti mode demo.
+func newMongoApp(t *testing.T) *fiber.App {
+	return newMongoAppWithLimits(t, route.Limits{})
+}
+
+func newMongoAppWithLimits(t *testing.T, limits route.Limits) *fiber.App {
+	t.Helper()
+	store := memory.NewStore()
+	require.NoError(t, memory.Seed(store))
+	files := memory.NewFileRepository(store)
+	users := memory.NewUserRepository(store)
+
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Use(middleware.RequestID())
+	route.SetupRoutesMongo(app, route.MongoHandlers{
+		Auth:      mongoService.NewAuthHandler(users),
+		User:      mongoService.NewUserHandler(users),
+		File:      mongoService.NewFileHandler(files),
+		Alumni:    mongoService.NewAlumniHandler(memory.NewAlumniRepository(store)),
+		Pekerjaan: mongoService.NewPekerjaanHandler(memory.NewPekerjaanRepository(store)),
+		FileRepo:  files,
+		Analytics: mongoService.NewAnalyticsHandler(memory.NewAnalyticsRepository(store)),
+		Limits:    limits,
+	})
+	return app
+}
+
+func mongoToken(t *testing.T, role string) string {
+	t.Helper()
+	token, err := utils.GenerateMongoToken(primitive.NewObjectID(), role+"-test", role)
+	require.NoError(t, err)
+	return token
+}
+
+func TestMongoMigrasiGajiHanyaAdmin(t *testing.T) {
+	app := newMongoApp(t)
+
+	tests := []struct {
+		name   string
+		role   string
+		status int
+		detail string
+	}{
+		// Ditolak middleware.AdminOnly sebelum sampai ke handler.
+		{"user ditolak", "user", fiber.StatusForbidden, "Akses ditolak. Hanya admin yang diizinkan"},
+		{"admin boleh", "admin", fiber.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/mg/pekerjaan/migrasi-gaji?dry_run=true", nil)
+			req.Header.Set("Authorization", "Bearer "+mongoToken(t, tt.role))
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.detail != "" {
+				body, _ := io.ReadAll(resp.Body)
+				assert.Contains(t, string(body), tt.detail)
+			}
+		})
+	}
+}
+
+// Analytics /api/mg bisa dipasang di atas repository memori seperti mode demo.
+func TestMongoAnalyticsMemory(t *testing.T) {
+	app := newMongoApp(t)
+
+	for _, path := range []string{
+		"/api/mg/analytics/employment-rate",
+		"/api/mg/analytics/time-to-first-job",
+		"/api/mg/analytics/distribusi/bidang_industri",
+		"/api/mg/analytics/gaji",
+		"/api/mg/analytics/trend?jurusan=Teknik%20Informatika",
+	} {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+mongoToken(t, "user"))
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode, path)
+	}
+}
+
+// PUT ke ID yang tidak ada dijawab 404, bukan 500.
+func TestMongoUpdatePekerjaanTidakAda(t *testing.T) {
+	app := newMongoApp(t)
+
+	req := httptest.NewRequest("PUT", "/api/mg/pekerjaan/"+primitive.NewObjectID().Hex(),
+		strings.NewReader(`{"nama_perusahaan":"Update Gagal"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+mongoToken(t, "admin"))
+	req.Header.Set("If-Match", "*")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+// Limiter User dipasang setelah auth sehingga dua user dari IP yang sama
+// mendapat bucket masing-masing.
+func TestMongoRateLimitPerUser(t *testing.T) {
+	app := newMongoAppWithLimits(t, route.Limits{
+		User: middleware.RateLimit("user", ratelimit.Limit{Rate: 1, Per: time.Minute, Burst: 1}, ratelimit.NewMemoryStore()),
+	})
+	admin, user := mongoToken(t, "admin"), mongoToken(t, "user")
+
+	employmentRate := func(token string) int {
+		req := httptest.NewRequest("GET", "/api/mg/analytics/employment-rate", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+	assert.Equal(t, fiber.StatusOK, employmentRate(admin))
+	assert.Equal(t, fiber.StatusTooManyRequests, employmentRate(admin))
+	assert.Equal(t, fiber.StatusOK, employmentRate(user), "bucket user lain tidak ikut terpakai")
+}
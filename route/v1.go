@@ -0,0 +1,118 @@
+package route
+
+import (
+	"latihan2/app/service"
+	"latihan2/app/service/mongo"
+	v1Service "latihan2/app/service/v1"
+	"latihan2/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// V1Handlers adalah handler /api/v1 untuk driver storage yang dipilih di main.
+// Analytics nil jika driver tidak punya endpoint analytics; Audit nil jika
+// audit log dimatikan.
+type V1Handlers struct {
+	Driver    string
+	Auth      *v1Service.AuthHandler
+	User      *v1Service.UserHandler
+	Alumni    *v1Service.AlumniHandler
+	Pekerjaan *v1Service.PekerjaanHandler
+	File      *v1Service.FileHandler
+	Analytics *AnalyticsHandlers
+	Audit     *v1Service.AuditHandler
+	Limits    Limits
+}
+
+// AnalyticsHandlers adalah handler analytics satu driver.
+type AnalyticsHandlers struct {
+	EmploymentRate fiber.Handler
+	TimeToFirstJob fiber.Handler
+	Distribusi     fiber.Handler
+	Gaji           fiber.Handler
+	Trend          fiber.Handler
+}
+
+// PostgresAnalytics dan MongoAnalytics memakai handler analytics /api/pg dan
+// /api/mg untuk /api/v1. MongoAnalytics juga dipakai driver memory.
+func PostgresAnalytics(h *service.AnalyticsHandler) *AnalyticsHandlers {
+	return &AnalyticsHandlers{
+		EmploymentRate: h.GetEmploymentRateService,
+		TimeToFirstJob: h.GetTimeToFirstJobService,
+		Distribusi:     h.GetDistribusiPekerjaanService,
+		Gaji:           h.GetDistribusiGajiService,
+		Trend:          h.GetCohortTrendService,
+	}
+}
+
+func MongoAnalytics(h *mongo.AnalyticsHandler) *AnalyticsHandlers {
+	return &AnalyticsHandlers{
+		EmploymentRate: h.GetEmploymentRate,
+		TimeToFirstJob: h.GetTimeToFirstJob,
+		Distribusi:     h.GetDistribusiPekerjaan,
+		Gaji:           h.GetDistribusiGaji,
+		Trend:          h.GetCohortTrend,
+	}
+}
+
+// SetupRoutesV1 memasang /api/v1. Bentuk request, response, ID dan aturan
+// soft delete sama untuk semua driver.
+func SetupRoutesV1(app *fiber.App, h V1Handlers) {
+	api := app.Group("/api/v1", chain(h.Limits.IP)...)
+
+	api.Post("/login", chain(h.Limits.Login, h.Auth.Login)...)
+	protected := api.Group("", chain(middleware.AuthRequiredV1(h.Driver), middleware.AuditActor(), h.Limits.User)...)
+	protected.Get("/profile", h.Auth.GetProfile)
+
+	users := protected.Group("/users")
+	users.Get("/", h.User.GetUsers)
+	users.Get("/:id", h.User.GetUserByID)
+	users.Patch("/:id", middleware.AdminOnly(), h.User.PatchUser)
+
+	alumni := protected.Group("/alumni")
+	alumni.Get("/", h.Alumni.GetAlumni)
+	alumni.Get("/search", h.Alumni.SearchAlumni)
+	alumni.Get("/:id", h.Alumni.GetAlumniByID)
+	alumni.Post("/", middleware.AdminOnly(), h.Alumni.CreateAlumni)
+	alumni.Put("/:id", middleware.AdminOnly(), middleware.IfMatch(), h.Alumni.UpdateAlumni)
+	alumni.Patch("/:id", middleware.AdminOnly(), middleware.IfMatch(), h.Alumni.PatchAlumni)
+	alumni.Delete("/:id", middleware.AdminOnly(), h.Alumni.DeleteAlumni)
+
+	pekerjaan := protected.Group("/pekerjaan")
+	pekerjaan.Get("/", h.Pekerjaan.GetPekerjaan)
+	pekerjaan.Get("/search", h.Pekerjaan.SearchPekerjaan)
+	pekerjaan.Get("/alumni/:alumni_id", middleware.AdminOnly(), h.Pekerjaan.GetPekerjaanByAlumniID)
+	pekerjaan.Post("/migrasi-gaji", middleware.AdminOnly(), h.Pekerjaan.MigrateGaji)
+	pekerjaan.Get("/trash/:id", h.Pekerjaan.GetTrashPekerjaanByID)
+	pekerjaan.Post("/trash/:id/restore", h.Pekerjaan.RestorePekerjaan)
+	pekerjaan.Delete("/trash/:id", h.Pekerjaan.HardDeletePekerjaan)
+	pekerjaan.Get("/:id", h.Pekerjaan.GetPekerjaanByID)
+	pekerjaan.Post("/", middleware.AdminOnly(), h.Pekerjaan.CreatePekerjaan)
+	pekerjaan.Put("/:id", middleware.AdminOnly(), middleware.IfMatch(), h.Pekerjaan.UpdatePekerjaan)
+	pekerjaan.Patch("/:id", middleware.AdminOnly(), middleware.IfMatch(), h.Pekerjaan.PatchPekerjaan)
+	pekerjaan.Delete("/:id", h.Pekerjaan.DeletePekerjaan)
+
+	files := protected.Group("/files")
+	files.Post("/upload", chain(h.Limits.Upload, h.File.UploadFile)...)
+	files.Get("/", h.File.GetFiles)
+	files.Get("/:id", h.File.GetFileByID)
+	files.Get("/open/:id", h.File.GetContentByID)
+	files.Delete("/:id", h.File.DeleteFile)
+
+	admin := protected.Group("/admin", middleware.AdminOnly())
+	admin.Get("/log-level", v1Service.GetLogLevel)
+	admin.Put("/log-level", v1Service.SetLogLevel)
+	if h.Audit != nil {
+		admin.Get("/audit", h.Audit.GetAuditLog)
+		admin.Get("/audit/verify", h.Audit.VerifyAuditLog)
+	}
+
+	if h.Analytics != nil {
+		analytics := protected.Group("/analytics")
+		analytics.Get("/employment-rate", h.Analytics.EmploymentRate)
+		analytics.Get("/time-to-first-job", h.Analytics.TimeToFirstJob)
+		analytics.Get("/distribusi/:dimensi", h.Analytics.Distribusi)
+		analytics.Get("/gaji", h.Analytics.Gaji)
+		analytics.Get("/trend", h.Analytics.Trend)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"latihan2/database"
+	"latihan2/database/datasync"
+	"os"
+	"text/tabwriter"
+)
+
+const syncUsage = `Pemakaian: latihan2 sync --from=postgres|mongo [--full] [--dry-run] [--overwrite]
+
+Menyalin users, alumni dan pekerjaan dari database --from ke database lainnya.
+Tanpa --full hanya record yang berubah sejak sinkronisasi terakhir ke arah yang
+sama yang disalin. Pasangan ID disimpan di tabel Postgres sync_id_map, jadi
+migrasi Postgres harus sudah dijalankan.
+
+Exit code 0 jika berhasil, 1 jika gagal, 3 jika ada konflik.
+`
+
+// runSync menjalankan subcommand "sync" dan mengembalikan exit code.
+func runSync(args []string) int {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	from := fs.String("from", "", "database sumber: postgres atau mongo")
+	full := fs.Bool("full", false, "bandingkan semua record, abaikan waktu sinkronisasi terakhir")
+	dryRun := fs.Bool("dry-run", false, "tampilkan laporan tanpa menulis ke database")
+	overwrite := fs.Bool("overwrite", false, "timpa record tujuan yang juga berubah (konflik)")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), syncUsage)
+		fs.PrintDefaults()
+	}
+	cfg, code := loadConfig(fs, args)
+	if cfg == nil {
+		return code
+	}
+	if *from != string(datasync.SidePostgres) && *from != string(datasync.SideMongo) {
+		fmt.Fprintf(os.Stderr, "--from tidak valid: %q\n", *from)
+		fs.Usage()
+		return 2
+	}
+
+	database.InitPostgresDB(cfg.Postgres.DSN)
+	defer database.DB.Close()
+	database.InitMongoDB(cfg.Mongo.URI, cfg.Mongo.Database)
+	defer database.MongoClient.Disconnect(context.Background())
+
+	pg, mg := datasync.NewPostgresStore(database.DB), datasync.NewMongoStore(database.MongoDB)
+	src, dst := pg, mg
+	if *from == string(datasync.SideMongo) {
+		src, dst = mg, pg
+	}
+
+	report, err := datasync.Run(context.Background(), src, dst, datasync.NewPostgresMapping(database.DB),
+		datasync.Options{Full: *full, DryRun: *dryRun, Overwrite: *overwrite})
+	if report != nil {
+		printSyncReport(report)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sinkronisasi gagal: %v\n", err)
+		return 1
+	}
+	if len(report.Conflicts) > 0 {
+		return 3
+	}
+	return 0
+}
+
+func printSyncReport(r *datasync.Report) {
+	since := "awal"
+	if !r.Since.IsZero() {
+		since = r.Since.Format("2006-01-02 15:04:05")
+	}
+	dry := ""
+	if r.DryRun {
+		dry = " (dry run)"
+	}
+	fmt.Printf("== %s sejak %s%s ==\n", r.Direction, since, dry)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ENTITAS\tDIBUAT\tDIUBAH\tSAMA\tKONFLIK")
+	for _, s := range r.Stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", s.Entity, s.Dibuat, s.Diubah, s.Sama, s.Konflik)
+	}
+	w.Flush()
+
+	if len(r.Conflicts) == 0 {
+		return
+	}
+	fmt.Println("\nKonflik (tidak disalin; waktu sinkronisasi terakhir tidak dimajukan):")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ENTITAS\tID SUMBER\tID TUJUAN\tALASAN")
+	for _, c := range r.Conflicts {
+		target := c.TargetID
+		if target == "" {
+			target = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Entity, c.SourceID, target, c.Alasan)
+	}
+	w.Flush()
+}
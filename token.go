@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"latihan2/app/model"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/utils"
+	"os"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const tokenUsage = `Pemakaian: latihan2 token issue [--api=v1|pg|mg] [--db=postgres|mongo] [--ttl=1h] <username>
+
+Membuat token JWT untuk user yang sudah ada tanpa login, untuk debugging.
+Token ditandatangani dengan secret server sehingga berlaku penuh sampai
+kedaluwarsa; jangan dibagikan. Hanya token yang dicetak ke stdout, jadi bisa
+langsung dipakai: curl -H "Authorization: Bearer $(latihan2 token issue budi)" ...
+`
+
+// runToken menjalankan subcommand "token" dan mengembalikan exit code.
+func runToken(args []string) int {
+	if len(args) == 0 || args[0] != "issue" {
+		fmt.Fprint(os.Stderr, tokenUsage)
+		return 2
+	}
+	fs := flag.NewFlagSet("token issue", flag.ContinueOnError)
+	api := fs.String("api", "v1", "API tujuan token: v1, pg (/api/pg) atau mg (/api/mg)")
+	db := dbFlag(fs)
+	ttl := fs.Duration("ttl", 0, "masa berlaku token (default auth.token_ttl atau auth.mongo_token_ttl)")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), tokenUsage)
+		fs.PrintDefaults()
+	}
+	cfg, code := loadConfig(fs, args[1:])
+	if cfg == nil {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	// /api/pg dan /api/mg selalu terikat ke database masing-masing.
+	driver := driverOf(cfg, *db)
+	switch *api {
+	case "v1":
+	case "pg":
+		driver = v1Repo.DriverPostgres
+	case "mg":
+		driver = v1Repo.DriverMongo
+	default:
+		fmt.Fprintf(os.Stderr, "--api tidak valid: %q\n", *api)
+		return 2
+	}
+	if *db != "" && *db != driver {
+		fmt.Fprintf(os.Stderr, "--api=%s hanya bisa memakai --db=%s\n", *api, driver)
+		return 2
+	}
+	if *ttl < 0 {
+		fmt.Fprintln(os.Stderr, "--ttl tidak boleh negatif")
+		return 2
+	}
+	if *ttl > 0 {
+		utils.TokenTTL, utils.MongoTokenTTL = *ttl, *ttl
+	}
+
+	storage, closeDB, err := openStorage(cfg, driver)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	defer closeDB()
+
+	u, code := findUser(storage, fs.Arg(0))
+	if u == nil {
+		return code
+	}
+	token, expires, err := issueToken(*api, storage.Driver, u.ID, u.Username, u.Role)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gagal membuat token: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "token /api/%s untuk %s (role %s), berlaku sampai %s\n",
+		*api, u.Username, u.Role, expires.Format(time.RFC3339))
+	fmt.Println(token)
+	return 0
+}
+
+// issueToken memakai fungsi yang sama dengan endpoint login API tersebut.
+func issueToken(api, driver, id, username, role string) (string, time.Time, error) {
+	now := time.Now()
+	switch api {
+	case "pg":
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			return "", now, err
+		}
+		token, err := utils.GenerateToken(model.User{ID: n, Username: username, Role: role})
+		return token, now.Add(utils.TokenTTL), err
+	case "mg":
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return "", now, err
+		}
+		token, err := utils.GenerateMongoToken(objID, username, role)
+		return token, now.Add(utils.MongoTokenTTL), err
+	}
+	token, err := utils.GenerateTokenV1(driver, id, username, role)
+	return token, now.Add(utils.TokenTTL), err
+}
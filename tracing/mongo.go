@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type mongoKey struct {
+	conn string
+	id   int64
+}
+
+// MongoMonitor membuat span untuk setiap command Mongo. Span adalah anak dari
+// span di context operasi (mis. Find(ctx, ...)); repository Mongo memakai
+// context request lewat WithContext.
+func MongoMonitor() *event.CommandMonitor {
+	var spans sync.Map
+	end := func(conn string, id int64, err error) {
+		if s, ok := spans.LoadAndDelete(mongoKey{conn, id}); ok {
+			End(s.(trace.Span), err)
+		}
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			attrs := []attribute.KeyValue{
+				semconv.DBSystemMongoDB,
+				semconv.DBNamespace(e.DatabaseName),
+				semconv.DBOperationName(e.CommandName),
+			}
+			if coll, ok := e.Command.Lookup(e.CommandName).StringValueOK(); ok {
+				attrs = append(attrs, semconv.DBCollectionName(coll))
+			}
+			_, span := Tracer().Start(ctx, "mongo."+e.CommandName,
+				trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+			spans.Store(mongoKey{e.ConnectionID, e.RequestID}, span)
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			end(e.ConnectionID, e.RequestID, nil)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			end(e.ConnectionID, e.RequestID, errors.New(e.Failure))
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package tracing
+
+import (
+	"database/sql"
+
+	"github.com/XSAM/otelsql"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// OpenDB sama dengan sql.Open tetapi setiap query, exec, transaksi dan
+// pembacaan rows menjadi span anak dari span di context yang dipakai
+// (QueryContext, ExecContext, BeginTx). Query tanpa context tetap tercatat
+// sebagai span tersendiri.
+func OpenDB(driverName, dsn string) (*sql.DB, error) {
+	return otelsql.Open(driverName, dsn,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			DisableErrSkip:       true,
+			OmitConnResetSession: true,
+		}),
+	)
+}
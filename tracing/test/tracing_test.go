@@ -0,0 +1,157 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"latihan2/app/model"
+	"latihan2/app/repository"
+	"latihan2/middleware"
+	"latihan2/response"
+	"latihan2/tracing"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// setup memasang TracerProvider global dengan exporter in-memory dan
+// mengembalikan provider lama setelah test selesai.
+func setup(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracing.NewProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return exporter
+}
+
+func byName(spans tracetest.SpanStubs, name string) *tracetest.SpanStub {
+	for i := range spans {
+		if spans[i].Name == name {
+			return &spans[i]
+		}
+	}
+	return nil
+}
+
+func attr(s *tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestSpanRequestDanQuerySQL(t *testing.T) {
+	exporter := setup(t)
+
+	_, mock, err := sqlmock.NewWithDSN("tracing_test")
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	db, err := tracing.OpenDB("sqlmock", "tracing_test")
+	require.NoError(t, err)
+	defer db.Close()
+	repo := repository.NewPekerjaanRepository(db)
+
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Use(middleware.Tracing())
+	app.Get("/pekerjaan/:id", func(c *fiber.Ctx) error {
+		total, err := repo.WithContext(c.UserContext()).CountPekerjaanRepo("", "admin", 1, model.GajiFilter{}, model.Filter{})
+		if err != nil {
+			return err
+		}
+		return c.JSON(fiber.Map{"total": total})
+	})
+
+	req := httptest.NewRequest("GET", "/pekerjaan/7", nil)
+	req.Header.Set("traceparent", traceparent)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	spans := exporter.GetSpans()
+	server := byName(spans, "GET /pekerjaan/:id")
+	require.NotNil(t, server, "span server harus memakai template route")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	assert.Equal(t, int64(200), attr(server, "http.response.status_code").AsInt64())
+
+	query := byName(spans, "sql.conn.query")
+	require.NotNil(t, query)
+	assert.Equal(t, server.SpanContext.SpanID(), query.Parent.SpanID(), "query harus anak span request")
+	assert.Contains(t, attr(query, "db.statement").AsString(), "SELECT count(*)")
+}
+
+func TestSpanRequestErrorServer(t *testing.T) {
+	exporter := setup(t)
+
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Use(middleware.Tracing())
+	app.Get("/gagal", func(c *fiber.Ctx) error {
+		return errors.New("koneksi putus")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/gagal", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+
+	server := byName(exporter.GetSpans(), "GET /gagal")
+	require.NotNil(t, server)
+	assert.Equal(t, codes.Error, server.Status.Code)
+	assert.False(t, server.Parent.IsValid())
+}
+
+func TestMongoMonitor(t *testing.T) {
+	exporter := setup(t)
+	mon := tracing.MongoMonitor()
+
+	ctx, parent := tracing.Start(context.Background(), "parent")
+	cmd, err := bson.Marshal(bson.D{{Key: "find", Value: "alumni"}})
+	require.NoError(t, err)
+	mon.Started(ctx, &event.CommandStartedEvent{Command: cmd, DatabaseName: "alumni_db", CommandName: "find", RequestID: 1, ConnectionID: "c1"})
+	mon.Started(ctx, &event.CommandStartedEvent{Command: cmd, DatabaseName: "alumni_db", CommandName: "insert", RequestID: 2, ConnectionID: "c1"})
+	mon.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{
+		CommandName: "find", RequestID: 1, ConnectionID: "c1", Duration: time.Millisecond}})
+	mon.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{
+		CommandName: "insert", RequestID: 2, ConnectionID: "c1"}, Failure: "duplicate key"})
+	parent.End()
+
+	spans := exporter.GetSpans()
+	find := byName(spans, "mongo.find")
+	require.NotNil(t, find)
+	assert.Equal(t, parent.SpanContext().SpanID(), find.Parent.SpanID())
+	assert.Equal(t, "alumni", attr(find, "db.collection.name").AsString())
+	assert.Equal(t, "mongodb", attr(find, "db.system").AsString())
+
+	insert := byName(spans, "mongo.insert")
+	require.NotNil(t, insert)
+	assert.Equal(t, codes.Error, insert.Status.Code)
+	assert.Equal(t, "duplicate key", insert.Status.Description)
+}
+
+func TestFileIO(t *testing.T) {
+	exporter := setup(t)
+
+	err := tracing.FileIO(context.Background(), "save", "uploads/a.pdf", func() error { return errors.New("disk penuh") })
+	assert.EqualError(t, err, "disk penuh")
+
+	span := byName(exporter.GetSpans(), "file.save")
+	require.NotNil(t, span)
+	assert.Equal(t, "uploads/a.pdf", attr(span, "file.path").AsString())
+	assert.Equal(t, codes.Error, span.Status.Code)
+}
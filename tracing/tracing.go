@@ -0,0 +1,90 @@
+// Package tracing memasang OpenTelemetry tracing. Span dibuat untuk setiap
+// request Fiber (Middleware), setiap query SQL (lewat driver yang dibungkus
+// otelsql di package database), setiap command Mongo (MongoMonitor) dan I/O
+// file upload (Start). Context trace dibaca dan diteruskan dengan format W3C
+// traceparent/tracestate.
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName dipakai sebagai service.name jika OTEL_SERVICE_NAME kosong.
+const ServiceName = "alumni-api"
+
+const tracerName = "latihan2"
+
+func init() {
+	// Propagator dipasang walaupun exporter tidak aktif agar traceparent dari
+	// klien tetap diteruskan ke log dan service lain.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Init memasang exporter OTLP/HTTP jika OTEL_EXPORTER_OTLP_ENDPOINT (atau
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT) diisi. Konfigurasi lain (header,
+// sampler, ...) dibaca exporter dan SDK dari variabel OTEL_* standar. Tanpa
+// endpoint, tracing tidak aktif dan shutdown tidak melakukan apa-apa.
+func Init(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tp := NewProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// NewProvider membuat TracerProvider dengan resource service.name. Test
+// memakai ini dengan sdktrace.WithSyncer(tracetest.NewInMemoryExporter()).
+func NewProvider(opts ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	name := os.Getenv("OTEL_SERVICE_NAME")
+	if name == "" {
+		name = ServiceName
+	}
+	res := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(name))
+	opts = append([]sdktrace.TracerProviderOption{sdktrace.WithResource(res)}, opts...)
+	return sdktrace.NewTracerProvider(opts...)
+}
+
+// Tracer mengembalikan tracer aplikasi dari TracerProvider global saat ini.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Start membuat span anak dari span di ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End menutup span dan menandainya error jika err tidak nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// FileIO menjalankan fn (baca/tulis/hapus file di storage upload) di dalam
+// span "file.<op>" dengan atribut file.path.
+func FileIO(ctx context.Context, op, path string, fn func() error) error {
+	_, span := Start(ctx, "file."+op, attribute.String("file.path", path))
+	err := fn()
+	End(span, err)
+	return err
+}
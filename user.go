@@ -0,0 +1,210 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"latihan2/app/model"
+	v1 "latihan2/app/model/v1"
+	v1Repo "latihan2/app/repository/v1"
+	"latihan2/utils"
+	"latihan2/validation"
+	"os"
+)
+
+const userUsage = `Pemakaian: latihan2 user <perintah> [flag] [username]
+
+Perintah:
+  create                      buat user baru
+  promote <username>          jadikan user sebagai admin
+  reset-password <username>   ganti password user
+
+Jika --password tidak diisi, password acak dibuat dan dicetak sekali.
+`
+
+// runUser menjalankan subcommand "user" dan mengembalikan exit code.
+func runUser(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(os.Stderr, userUsage)
+		return 2
+	}
+	switch args[0] {
+	case "create":
+		return runUserCreate(args[1:])
+	case "promote":
+		return runUserPromote(args[1:])
+	case "reset-password":
+		return runUserResetPassword(args[1:])
+	}
+	fmt.Fprintf(os.Stderr, "perintah user tidak dikenal: %s\n\n%s", args[0], userUsage)
+	return 2
+}
+
+// userFlagSet membuat FlagSet "user <name>" dengan --db dan usage yang sama.
+func userFlagSet(name, synopsis string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("user "+name, flag.ContinueOnError)
+	db := dbFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Pemakaian: latihan2 user %s %s\n\n", name, synopsis)
+		fs.PrintDefaults()
+	}
+	return fs, db
+}
+
+// createUserInput memakai aturan validasi yang sama dengan update user dari API.
+type createUserInput struct {
+	model.UpdateUserRequest
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+func runUserCreate(args []string) int {
+	fs, db := userFlagSet("create", "--username=... --email=... [--role=user|admin] [--password=...]")
+	var in createUserInput
+	fs.StringVar(&in.Username, "username", "", "username")
+	fs.StringVar(&in.Email, "email", "", "email")
+	fs.StringVar(&in.Role, "role", "user", "role: user atau admin")
+	fs.StringVar(&in.Password, "password", "", "password, minimal 8 karakter (default acak)")
+	cfg, code := loadConfig(fs, args)
+	if cfg == nil {
+		return code
+	}
+	generated := in.Password == ""
+	if generated {
+		in.Password = randomPassword()
+	}
+	if err := validation.Struct(in); err != nil {
+		printInvalid(err)
+		return 2
+	}
+
+	storage, closeDB, err := openStorage(cfg, driverOf(cfg, *db))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	defer closeDB()
+
+	hash, err := utils.HashPassword(in.Password)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	u, err := storage.Users.CreateUser(in.Username, in.Email, in.Role, hash)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gagal membuat user: %v\n", err)
+		return 1
+	}
+	fmt.Printf("%s: user %s (id %s, role %s) dibuat\n", storage.Driver, u.Username, u.ID, u.Role)
+	if generated {
+		fmt.Printf("password: %s\n", in.Password)
+	}
+	return 0
+}
+
+func runUserPromote(args []string) int {
+	fs, db := userFlagSet("promote", "[--db=postgres|mongo] <username>")
+	cfg, code := loadConfig(fs, args)
+	if cfg == nil {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	storage, closeDB, err := openStorage(cfg, driverOf(cfg, *db))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	defer closeDB()
+
+	u, code := findUser(storage, fs.Arg(0))
+	if u == nil {
+		return code
+	}
+	if u.Role == "admin" {
+		fmt.Printf("%s: %s sudah admin\n", storage.Driver, u.Username)
+		return 0
+	}
+	_, err = storage.Users.UpdateUser(u.ID, model.UpdateUserRequest{Username: u.Username, Email: u.Email, Role: "admin"})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gagal mengubah role: %v\n", err)
+		return 1
+	}
+	fmt.Printf("%s: %s sekarang admin\n", storage.Driver, u.Username)
+	return 0
+}
+
+func runUserResetPassword(args []string) int {
+	fs, db := userFlagSet("reset-password", "[--db=postgres|mongo] [--password=...] <username>")
+	password := fs.String("password", "", "password baru, minimal 8 karakter (default acak)")
+	cfg, code := loadConfig(fs, args)
+	if cfg == nil {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	generated := *password == ""
+	if generated {
+		*password = randomPassword()
+	}
+	if len(*password) < 8 {
+		fmt.Fprintln(os.Stderr, "password minimal 8 karakter")
+		return 2
+	}
+
+	storage, closeDB, err := openStorage(cfg, driverOf(cfg, *db))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	defer closeDB()
+
+	u, code := findUser(storage, fs.Arg(0))
+	if u == nil {
+		return code
+	}
+	hash, err := utils.HashPassword(*password)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := storage.Users.UpdatePassword(u.ID, hash); err != nil {
+		fmt.Fprintf(os.Stderr, "gagal mengganti password: %v\n", err)
+		return 1
+	}
+	fmt.Printf("%s: password %s diganti\n", storage.Driver, u.Username)
+	if generated {
+		fmt.Printf("password: %s\n", *password)
+	}
+	return 0
+}
+
+// findUser mencari user berdasarkan username; user nil berarti perintah harus
+// berhenti dengan exit code code.
+func findUser(storage v1Repo.Storage, username string) (*v1.User, int) {
+	u, _, err := storage.Users.GetUserByUsername(username)
+	if errors.Is(err, v1Repo.ErrNotFound) {
+		fmt.Fprintf(os.Stderr, "user %s tidak ditemukan di %s\n", username, storage.Driver)
+		return nil, 1
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil, 1
+	}
+	return u, 0
+}
+
+// randomPassword membuat password 16 karakter dari 12 byte acak.
+func randomPassword() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"latihan2/app/model"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ParseAnalyticsFilter membaca query tahun_mulai, tahun_akhir dan jurusan
+// untuk endpoint analytics.
+func ParseAnalyticsFilter(tahunMulai, tahunAkhir, jurusan string) (model.AnalyticsFilter, error) {
+	f := model.AnalyticsFilter{Jurusan: jurusan}
+	var err error
+	if tahunMulai != "" {
+		if f.TahunMulai, err = strconv.Atoi(tahunMulai); err != nil {
+			return f, errors.New("tahun_mulai harus berupa angka")
+		}
+	}
+	if tahunAkhir != "" {
+		if f.TahunAkhir, err = strconv.Atoi(tahunAkhir); err != nil {
+			return f, errors.New("tahun_akhir harus berupa angka")
+		}
+	}
+	if f.TahunMulai > 0 && f.TahunAkhir > 0 && f.TahunMulai > f.TahunAkhir {
+		return f, errors.New("tahun_mulai tidak boleh lebih besar dari tahun_akhir")
+	}
+	return f, nil
+}
+
+// AnalyticsToSQL menerjemahkan filter analytics menjadi kondisi WHERE untuk
+// tabel alumni dengan alias alias (mis. "a."), placeholder lanjutan dari args.
+// Alumni yang sudah di-soft-delete selalu dikecualikan, sama dengan
+// AnalyticsToBSON.
+func AnalyticsToSQL(f model.AnalyticsFilter, alias string, args []interface{}) (string, []interface{}) {
+	conds := []string{alias + "deleted_at IS NULL"}
+	if f.TahunMulai > 0 {
+		args = append(args, f.TahunMulai)
+		conds = append(conds, fmt.Sprintf("%stahun_lulus >= $%d", alias, len(args)))
+	}
+	if f.TahunAkhir > 0 {
+		args = append(args, f.TahunAkhir)
+		conds = append(conds, fmt.Sprintf("%stahun_lulus <= $%d", alias, len(args)))
+	}
+	if f.Jurusan != "" {
+		args = append(args, f.Jurusan)
+		conds = append(conds, fmt.Sprintf("%sjurusan = $%d", alias, len(args)))
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// AnalyticsToBSON menerjemahkan filter analytics menjadi $match untuk dokumen
+// alumni di path prefix (mis. "alumni."). Alumni yang sudah di-soft-delete
+// selalu dikecualikan.
+func AnalyticsToBSON(f model.AnalyticsFilter, prefix string) bson.M {
+	match := bson.M{prefix + "deleted_at": bson.M{"$exists": false}}
+	tahun := bson.M{}
+	if f.TahunMulai > 0 {
+		tahun["$gte"] = f.TahunMulai
+	}
+	if f.TahunAkhir > 0 {
+		tahun["$lte"] = f.TahunAkhir
+	}
+	if len(tahun) > 0 {
+		match[prefix+"tahun_lulus"] = tahun
+	}
+	if f.Jurusan != "" {
+		match[prefix+"jurusan"] = f.Jurusan
+	}
+	return match
+}
+
+// RingkasBulan mengisi statistik TimeToFirstJob dari daftar bulan per alumni.
+func RingkasBulan(r model.TimeToFirstJob, bulan []float64) model.TimeToFirstJob {
+	r.Sampel = len(bulan)
+	if r.Sampel == 0 {
+		return r
+	}
+	r.Min, r.Max = bulan[0], bulan[0]
+	var total float64
+	for _, b := range bulan {
+		total += b
+		if b < r.Min {
+			r.Min = b
+		}
+		if b > r.Max {
+			r.Max = b
+		}
+	}
+	r.RataRata = Round2(total / float64(r.Sampel))
+	r.Median = Median(bulan)
+	return r
+}
+
+// SusunGajiBand mengubah hitungan per indeks model.GajiBands (-1 untuk tidak diketahui)
+// menjadi daftar band yang lengkap, termasuk band kosong.
+func SusunGajiBand(counts map[int]int, total int) []model.SalaryBandItem {
+	result := make([]model.SalaryBandItem, 0, len(model.GajiBands)+1)
+	for i, band := range model.GajiBands {
+		result = append(result, model.SalaryBandItem{
+			GajiBand:   band,
+			Jumlah:     counts[i],
+			Persentase: Persentase(counts[i], total),
+		})
+	}
+	if counts[-1] > 0 {
+		result = append(result, model.SalaryBandItem{
+			GajiBand:   model.GajiBand{Label: model.LabelTidakDiketahui},
+			Jumlah:     counts[-1],
+			Persentase: Persentase(counts[-1], total),
+		})
+	}
+	return result
+}
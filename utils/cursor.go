@@ -0,0 +1,247 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"latihan2/app/model"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrCursorTidakValid = errors.New("cursor tidak valid")
+
+// cursorWire adalah bentuk cursor sebelum di-base64. T menyimpan tipe nilai
+// sort (s: string, i: int64, t: time) agar bisa dikembalikan ke tipe aslinya.
+type cursorWire struct {
+	S  string `json:"s"`
+	O  string `json:"o"`
+	T  string `json:"t"`
+	V  string `json:"v"`
+	ID string `json:"id"`
+	P  bool   `json:"p,omitempty"`
+}
+
+// CursorRequested melaporkan apakah klien meminta pagination mode cursor.
+func CursorRequested(pagination, cursor string) bool {
+	return strings.EqualFold(pagination, "cursor") || cursor != ""
+}
+
+func EncodeCursor(c model.Cursor) string {
+	w := cursorWire{S: c.SortBy, O: c.Order, ID: c.ID, P: c.Prev}
+	switch v := c.Value.(type) {
+	case int64:
+		w.T, w.V = "i", strconv.FormatInt(v, 10)
+	case time.Time:
+		w.T, w.V = "t", v.UTC().Format(time.RFC3339Nano)
+	default:
+		w.T, w.V = "s", fmt.Sprint(v)
+	}
+	b, _ := json.Marshal(w)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string) (model.Cursor, error) {
+	var c model.Cursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrCursorTidakValid
+	}
+	var w cursorWire
+	if err := json.Unmarshal(b, &w); err != nil || w.S == "" || w.ID == "" {
+		return c, ErrCursorTidakValid
+	}
+	c = model.Cursor{SortBy: w.S, Order: w.O, ID: w.ID, Prev: w.P}
+	switch w.T {
+	case "i":
+		c.Value, err = strconv.ParseInt(w.V, 10, 64)
+	case "t":
+		c.Value, err = time.Parse(time.RFC3339Nano, w.V)
+	case "s":
+		c.Value = w.V
+	default:
+		err = ErrCursorTidakValid
+	}
+	if err != nil {
+		return c, ErrCursorTidakValid
+	}
+	return c, nil
+}
+
+// ParseCursorPage menyusun CursorPage dari query. Jika cursor diisi, sortBy dan
+// order diambil dari cursor sehingga klien cukup mengirim cursor saja. sortable
+// adalah kolom yang boleh dipakai: kolom nullable tidak bisa dipakai sebagai keyset.
+func ParseCursorPage(cursor, sortBy, order string, limit int, sortable map[string]bool) (model.CursorPage, error) {
+	p := model.CursorPage{SortBy: sortBy, Order: order, Limit: limit}
+	if cursor != "" {
+		c, err := DecodeCursor(cursor)
+		if err != nil {
+			return p, err
+		}
+		p.SortBy, p.Order, p.Cursor = c.SortBy, c.Order, &c
+	}
+	if p.Order != "desc" {
+		p.Order = "asc"
+	}
+	if !sortable[p.SortBy] {
+		return p, fmt.Errorf("sortBy %q tidak bisa dipakai untuk pagination cursor", p.SortBy)
+	}
+	if p.Limit <= 0 {
+		p.Limit = 10
+	}
+	return p, nil
+}
+
+// forwardAsc melaporkan arah urutan sebenarnya di query: halaman mundur
+// dibaca dengan urutan terbalik lalu dibalik lagi oleh CursorResult.
+func forwardAsc(p model.CursorPage) bool {
+	return (p.Order == "asc") != p.Backward()
+}
+
+// CursorToSQL menghasilkan kondisi keyset dan ORDER BY untuk kolom sort dan
+// kolom id. Query harus memakai LIMIT p.Limit+1 agar CursorResult tahu masih ada halaman lanjutan.
+func CursorToSQL(p model.CursorPage, column, idColumn string, args []interface{}) (string, string, []interface{}) {
+	cmp, dir := "<", "DESC"
+	if forwardAsc(p) {
+		cmp, dir = ">", "ASC"
+	}
+
+	orderBy := fmt.Sprintf("%s %s, %s %s", column, dir, idColumn, dir)
+	if column == idColumn {
+		orderBy = fmt.Sprintf("%s %s", idColumn, dir)
+	}
+	if p.Cursor == nil {
+		return "1=1", orderBy, args
+	}
+
+	if column == idColumn {
+		args = append(args, p.Cursor.ID)
+		return fmt.Sprintf("%s %s $%d", idColumn, cmp, len(args)), orderBy, args
+	}
+	args = append(args, p.Cursor.Value, p.Cursor.ID)
+	where := fmt.Sprintf("(%s, %s) %s ($%d, $%d)", column, idColumn, cmp, len(args)-1, len(args))
+	return where, orderBy, args
+}
+
+// ApplyCursorBSON menambahkan kondisi keyset ke $and pada base dan mengembalikan
+// urutan sort yang harus dipakai. Id di Mongo selalu field _id bertipe ObjectID.
+func ApplyCursorBSON(base bson.M, p model.CursorPage) (bson.M, bson.D, error) {
+	cmp, dir := "$lt", -1
+	if forwardAsc(p) {
+		cmp, dir = "$gt", 1
+	}
+
+	sort := bson.D{{Key: p.SortBy, Value: dir}, {Key: "_id", Value: dir}}
+	if p.SortBy == "_id" {
+		sort = bson.D{{Key: "_id", Value: dir}}
+	}
+	if p.Cursor == nil {
+		return base, sort, nil
+	}
+
+	id, err := primitive.ObjectIDFromHex(p.Cursor.ID)
+	if err != nil {
+		return base, sort, ErrCursorTidakValid
+	}
+	cond := bson.M{"_id": bson.M{cmp: id}}
+	if p.SortBy != "_id" {
+		cond = bson.M{"$or": []bson.M{
+			{p.SortBy: bson.M{cmp: p.Cursor.Value}},
+			{p.SortBy: p.Cursor.Value, "_id": bson.M{cmp: id}},
+		}}
+	}
+	and, _ := base["$and"].([]bson.M)
+	base["$and"] = append(and, cond)
+	return base, sort, nil
+}
+
+// CursorResult memotong baris tambahan dari hasil query keyset (yang diambil
+// sebanyak Limit+1) dan menyusun next/prev cursor. Nilai sort dan id dibaca
+// dari field item dengan tag json atau bson yang sama dengan nama kolom.
+func CursorResult[T any](items []T, p model.CursorPage) ([]T, string, string) {
+	hasMore := len(items) > p.Limit
+	if hasMore {
+		items = items[:p.Limit]
+	}
+	if p.Backward() {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+	if len(items) == 0 {
+		return items, "", ""
+	}
+
+	cursorAt := func(item T, prev bool) string {
+		value, id := cursorKey(item, p.SortBy)
+		return EncodeCursor(model.Cursor{SortBy: p.SortBy, Order: p.Order, Value: value, ID: id, Prev: prev})
+	}
+
+	var next, prev string
+	if p.Backward() {
+		next = cursorAt(items[len(items)-1], false)
+		if hasMore {
+			prev = cursorAt(items[0], true)
+		}
+	} else {
+		if hasMore {
+			next = cursorAt(items[len(items)-1], false)
+		}
+		if p.Cursor != nil {
+			prev = cursorAt(items[0], true)
+		}
+	}
+	return items, next, prev
+}
+
+func cursorKey(item interface{}, sortBy string) (interface{}, string) {
+	v := reflect.Indirect(reflect.ValueOf(item))
+	id := cursorValue(fieldByTag(v, "id"))
+	if sortBy == "_id" || sortBy == "id" {
+		return id, fmt.Sprint(id)
+	}
+	return cursorValue(fieldByTag(v, sortBy)), fmt.Sprint(id)
+}
+
+// fieldByTag mencari field (termasuk field embedded) dengan nama tag json atau bson name.
+func fieldByTag(v reflect.Value, name string) reflect.Value {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if found := fieldByTag(v.Field(i), name); found.IsValid() {
+				return found
+			}
+			continue
+		}
+		for _, tag := range []string{f.Tag.Get("json"), f.Tag.Get("bson")} {
+			if strings.Split(tag, ",")[0] == name {
+				return v.Field(i)
+			}
+		}
+	}
+	return reflect.Value{}
+}
+
+func cursorValue(f reflect.Value) interface{} {
+	if !f.IsValid() {
+		return ""
+	}
+	switch v := f.Interface().(type) {
+	case time.Time:
+		return v
+	case primitive.ObjectID:
+		return v.Hex()
+	}
+	switch f.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return f.Int()
+	}
+	return fmt.Sprint(f.Interface())
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrETagTidakValid = errors.New("If-Match tidak valid")
+
+// ETag membentuk strong ETag dari version data, misalnya "3".
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ParseIfMatch membaca header If-Match menjadi version. "*" berarti version
+// apa pun dan dikembalikan sebagai 0. Weak ETag (W/"3") ditolak karena
+// If-Match hanya boleh dibandingkan secara strong.
+func ParseIfMatch(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return 0, nil
+	}
+	if len(header) < 3 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, ErrETagTidakValid
+	}
+	version, err := strconv.Atoi(header[1 : len(header)-1])
+	if err != nil || version < 1 {
+		return 0, ErrETagTidakValid
+	}
+	return version, nil
+}
@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"fmt"
+	"latihan2/app/model"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MaxFilterConditions membatasi jumlah kondisi dalam satu query filter.
+const MaxFilterConditions = 20
+
+var sqlOperators = map[model.FilterOperator]string{
+	model.FilterEq:  "=",
+	model.FilterNe:  "<>",
+	model.FilterGt:  ">",
+	model.FilterGte: ">=",
+	model.FilterLt:  "<",
+	model.FilterLte: "<=",
+}
+
+var bsonOperators = map[model.FilterOperator]string{
+	model.FilterEq:  "$eq",
+	model.FilterNe:  "$ne",
+	model.FilterGt:  "$gt",
+	model.FilterGte: "$gte",
+	model.FilterLt:  "$lt",
+	model.FilterLte: "$lte",
+	model.FilterIn:  "$in",
+	model.FilterNin: "$nin",
+}
+
+// ParseFilter membaca query filter seperti "tahun_lulus:gte:2020,jurusan:in:TI|SI"
+// menjadi model.Filter. Kondisi dipisah koma, nilai operator in/nin dipisah "|",
+// dan karakter pemisah di dalam nilai bisa di-escape dengan backslash.
+// Field dan operator yang tidak ada di whitelist fields ditolak.
+func ParseFilter(raw string, fields model.FilterFields) (model.Filter, error) {
+	var f model.Filter
+	if strings.TrimSpace(raw) == "" {
+		return f, nil
+	}
+
+	clauses := splitEscaped(raw, ',', -1)
+	if len(clauses) > MaxFilterConditions {
+		return f, fmt.Errorf("filter: maksimal %d kondisi", MaxFilterConditions)
+	}
+
+	for _, clause := range clauses {
+		parts := splitEscaped(clause, ':', 3)
+		if len(parts) != 3 {
+			return f, fmt.Errorf("filter: %q harus berformat field:operator:nilai", clause)
+		}
+		name := strings.TrimSpace(parts[0])
+		op := model.FilterOperator(strings.ToLower(strings.TrimSpace(parts[1])))
+
+		field, ok := fields[name]
+		if !ok {
+			return f, fmt.Errorf("filter: field %q tidak bisa difilter", name)
+		}
+		if !field.Allows(op) {
+			return f, fmt.Errorf("filter: operator %q tidak didukung untuk field %q", op, name)
+		}
+
+		raws := []string{parts[2]}
+		if op == model.FilterIn || op == model.FilterNin {
+			raws = splitEscaped(parts[2], '|', -1)
+		}
+
+		cond := model.FilterCondition{Field: name, Operator: op}
+		for _, r := range raws {
+			v, err := filterValue(field.Type, op, unescape(r))
+			if err != nil {
+				return f, fmt.Errorf("filter: nilai %q untuk %s: %w", unescape(r), name, err)
+			}
+			cond.Values = append(cond.Values, v)
+		}
+		f.Conditions = append(f.Conditions, cond)
+	}
+	return f, nil
+}
+
+func filterValue(t model.FilterType, op model.FilterOperator, s string) (interface{}, error) {
+	if op == model.FilterNull {
+		return strconv.ParseBool(s)
+	}
+	switch t {
+	case model.FilterInt:
+		v, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("harus berupa angka")
+		}
+		return v, nil
+	case model.FilterDate:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if v, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("harus berformat YYYY-MM-DD atau RFC3339")
+	}
+	if op == model.FilterLike && s == "" {
+		return nil, fmt.Errorf("tidak boleh kosong")
+	}
+	return s, nil
+}
+
+// splitEscaped memecah s pada sep yang tidak didahului backslash. n < 0 berarti tanpa batas.
+func splitEscaped(s string, sep byte, n int) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == sep && (n < 0 || len(parts) < n-1) {
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// FilterToSQL menerjemahkan filter menjadi kondisi WHERE dengan placeholder
+// lanjutan dari args. alias ditambahkan di depan nama kolom, mis. "pa.".
+// Hasilnya "1=1" jika filter kosong sehingga selalu aman di-AND-kan.
+func FilterToSQL(f model.Filter, alias string, args []interface{}) (string, []interface{}) {
+	conds := []string{"1=1"}
+	for _, c := range f.Conditions {
+		column := alias + c.Field
+		switch c.Operator {
+		case model.FilterNull:
+			if c.Values[0].(bool) {
+				conds = append(conds, column+" IS NULL")
+			} else {
+				conds = append(conds, column+" IS NOT NULL")
+			}
+		case model.FilterLike:
+			args = append(args, "%"+escapeLike(c.Values[0].(string))+"%")
+			conds = append(conds, fmt.Sprintf("%s ILIKE $%d", column, len(args)))
+		case model.FilterIn, model.FilterNin:
+			placeholders := make([]string, 0, len(c.Values))
+			for _, v := range c.Values {
+				args = append(args, v)
+				placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+			}
+			keyword := "IN"
+			if c.Operator == model.FilterNin {
+				keyword = "NOT IN"
+			}
+			conds = append(conds, fmt.Sprintf("%s %s (%s)", column, keyword, strings.Join(placeholders, ", ")))
+		default:
+			args = append(args, c.Values[0])
+			conds = append(conds, fmt.Sprintf("%s %s $%d", column, sqlOperators[c.Operator], len(args)))
+		}
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// FilterToBSON menerjemahkan filter menjadi daftar kondisi BSON yang digabung dengan $and.
+func FilterToBSON(f model.Filter) []bson.M {
+	conds := make([]bson.M, 0, len(f.Conditions))
+	for _, c := range f.Conditions {
+		switch c.Operator {
+		case model.FilterNull:
+			if c.Values[0].(bool) {
+				conds = append(conds, bson.M{c.Field: nil})
+			} else {
+				conds = append(conds, bson.M{c.Field: bson.M{"$ne": nil}})
+			}
+		case model.FilterLike:
+			pattern := regexp.QuoteMeta(c.Values[0].(string))
+			conds = append(conds, bson.M{c.Field: bson.M{"$regex": pattern, "$options": "i"}})
+		case model.FilterIn, model.FilterNin:
+			conds = append(conds, bson.M{c.Field: bson.M{bsonOperators[c.Operator]: c.Values}})
+		default:
+			conds = append(conds, bson.M{c.Field: bson.M{bsonOperators[c.Operator]: c.Values[0]}})
+		}
+	}
+	return conds
+}
+
+// ApplyFilterBSON menambahkan kondisi filter ke $and pada base dan mengembalikan base.
+func ApplyFilterBSON(base bson.M, f model.Filter) bson.M {
+	if f.IsEmpty() {
+		return base
+	}
+	and, _ := base["$and"].([]bson.M)
+	base["$and"] = append(and, FilterToBSON(f)...)
+	return base
+}
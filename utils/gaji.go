@@ -0,0 +1,240 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"latihan2/app/model"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const MataUangDefault = "IDR"
+
+var (
+	ErrGajiKosong       = errors.New("gaji_range kosong")
+	ErrGajiTidakDikenal = errors.New("format gaji_range tidak dikenali")
+)
+
+var (
+	angkaPattern      = regexp.MustCompile(`(\d+(?:[.,]\d+)*)\s*(juta|jt|ribu|rb|k\b)?`)
+	ribuanPattern     = regexp.MustCompile(`^\d{1,3}(?:\.\d{3})+$`)
+	ribuanKomaPattern = regexp.MustCompile(`^\d{1,3}(?:,\d{3})+$`)
+	mataUangPattern   = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// satuanGaji adalah pengali satuan yang ditulis langsung setelah angka.
+var satuanGaji = map[string]float64{
+	"juta": 1e6,
+	"jt":   1e6,
+	"ribu": 1e3,
+	"rb":   1e3,
+	"k":    1e3,
+}
+
+// mataUangAlias dicek berurutan; alias pertama yang ditemukan di teks dipakai.
+var mataUangAlias = []struct{ alias, kode string }{
+	{"idr", "IDR"},
+	{"rp", "IDR"},
+	{"usd", "USD"},
+	{"$", "USD"},
+	{"sgd", "SGD"},
+	{"eur", "EUR"},
+	{"myr", "MYR"},
+}
+
+// ParseGajiRange membaca gaji_range teks bebas seperti "Rp 4.000.000 - 6.000.000",
+// "5-7 juta", "> 10jt" atau "USD 3,000 - 4,000 per tahun" menjadi RentangGaji.
+func ParseGajiRange(s string) (model.RentangGaji, error) {
+	var g model.RentangGaji
+	text := strings.ToLower(strings.TrimSpace(s))
+	if text == "" || text == "-" {
+		return g, ErrGajiKosong
+	}
+
+	g.GajiMataUang = MataUangDefault
+	for _, m := range mataUangAlias {
+		if strings.Contains(text, m.alias) {
+			g.GajiMataUang = m.kode
+			break
+		}
+	}
+
+	g.GajiPeriode = model.PeriodeGajiBulanan
+	for _, kata := range []string{"tahun", "/thn", "per year", "annual", "p.a"} {
+		if strings.Contains(text, kata) {
+			g.GajiPeriode = model.PeriodeGajiTahunan
+			break
+		}
+	}
+
+	matches := angkaPattern.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 || len(matches) > 2 {
+		return g, ErrGajiTidakDikenal
+	}
+
+	// Setiap angka memakai satuannya sendiri; angka tanpa satuan memakai
+	// satuan angka sesudahnya, jadi "5-7 juta" berarti 5 juta sampai 7 juta
+	// dan "500 ribu - 1 juta" berarti 500 ribu sampai 1 juta.
+	pengali := make([]float64, len(matches))
+	satuan := 1.0
+	for i := len(matches) - 1; i >= 0; i-- {
+		if m := matches[i][2]; m != "" {
+			satuan = satuanGaji[m]
+		}
+		pengali[i] = satuan
+	}
+
+	values := make([]int64, 0, len(matches))
+	for i, m := range matches {
+		v, err := parseAngka(m[1])
+		if err != nil {
+			return g, ErrGajiTidakDikenal
+		}
+		// Pengali hanya berlaku untuk angka kecil: "5-7 juta" tapi bukan "5.000.000 juta".
+		if v < 1000 {
+			v *= pengali[i]
+		}
+		values = append(values, int64(v))
+	}
+
+	if len(values) == 2 {
+		g.GajiMin, g.GajiMax = &values[0], &values[1]
+	} else {
+		switch {
+		case strings.ContainsAny(text, ">+") || strings.Contains(text, "lebih") || strings.Contains(text, "di atas") || strings.Contains(text, "minimal"):
+			g.GajiMin = &values[0]
+		case strings.Contains(text, "<") || strings.Contains(text, "kurang") || strings.Contains(text, "di bawah") || strings.Contains(text, "maksimal"):
+			g.GajiMax = &values[0]
+		default:
+			g.GajiMin, g.GajiMax = &values[0], &values[0]
+		}
+	}
+
+	if err := ValidateRentangGaji(g); err != nil {
+		return g, err
+	}
+	return g, nil
+}
+
+func parseAngka(tok string) (float64, error) {
+	switch {
+	case ribuanPattern.MatchString(tok):
+		tok = strings.ReplaceAll(tok, ".", "")
+	case ribuanKomaPattern.MatchString(tok):
+		tok = strings.ReplaceAll(tok, ",", "")
+	default:
+		tok = strings.Replace(tok, ",", ".", 1)
+	}
+	return strconv.ParseFloat(tok, 64)
+}
+
+// ValidateRentangGaji memastikan rentang gaji konsisten. Rentang kosong dianggap valid.
+func ValidateRentangGaji(g model.RentangGaji) error {
+	if g.IsEmpty() {
+		return nil
+	}
+	if g.GajiMin != nil && *g.GajiMin < 0 {
+		return errors.New("gaji_min tidak boleh negatif")
+	}
+	if g.GajiMax != nil && *g.GajiMax < 0 {
+		return errors.New("gaji_max tidak boleh negatif")
+	}
+	if g.GajiMin != nil && g.GajiMax != nil && *g.GajiMax < *g.GajiMin {
+		return errors.New("gaji_max tidak boleh lebih kecil dari gaji_min")
+	}
+	if !mataUangPattern.MatchString(g.GajiMataUang) {
+		return errors.New("gaji_mata_uang harus kode ISO 4217, mis. IDR")
+	}
+	if g.GajiPeriode != model.PeriodeGajiBulanan && g.GajiPeriode != model.PeriodeGajiTahunan {
+		return fmt.Errorf("gaji_periode harus %s atau %s", model.PeriodeGajiBulanan, model.PeriodeGajiTahunan)
+	}
+	return nil
+}
+
+// ResolveGaji menentukan rentang gaji dari request. Field terstruktur diutamakan;
+// jika kosong, gajiRange diparse. Mengembalikan rentang yang sudah dinormalisasi
+// beserta teks gaji_range yang disimpan untuk klien lama.
+func ResolveGaji(gajiRange string, g model.RentangGaji) (model.RentangGaji, string, error) {
+	if g.IsEmpty() {
+		if strings.TrimSpace(gajiRange) == "" {
+			return model.RentangGaji{}, "", nil
+		}
+		parsed, err := ParseGajiRange(gajiRange)
+		if err != nil {
+			return parsed, "", fmt.Errorf("gaji_range %q: %w", gajiRange, err)
+		}
+		return parsed, gajiRange, nil
+	}
+
+	if g.GajiMataUang == "" {
+		g.GajiMataUang = MataUangDefault
+	}
+	g.GajiMataUang = strings.ToUpper(g.GajiMataUang)
+	if g.GajiPeriode == "" {
+		g.GajiPeriode = model.PeriodeGajiBulanan
+	}
+	if err := ValidateRentangGaji(g); err != nil {
+		return g, "", err
+	}
+	return g, FormatGaji(g), nil
+}
+
+// FormatGaji menyusun teks gaji_range dari rentang terstruktur.
+func FormatGaji(g model.RentangGaji) string {
+	if g.IsEmpty() {
+		return ""
+	}
+	var rentang string
+	switch {
+	case g.GajiMin != nil && g.GajiMax != nil && *g.GajiMin == *g.GajiMax:
+		rentang = formatRibuan(*g.GajiMin)
+	case g.GajiMin != nil && g.GajiMax != nil:
+		rentang = formatRibuan(*g.GajiMin) + " - " + formatRibuan(*g.GajiMax)
+	case g.GajiMin != nil:
+		rentang = "> " + formatRibuan(*g.GajiMin)
+	default:
+		rentang = "< " + formatRibuan(*g.GajiMax)
+	}
+	periode := "bulan"
+	if g.GajiPeriode == model.PeriodeGajiTahunan {
+		periode = "tahun"
+	}
+	return fmt.Sprintf("%s %s per %s", g.GajiMataUang, rentang, periode)
+}
+
+func formatRibuan(v int64) string {
+	s := strconv.FormatInt(v, 10)
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ParseGajiFilter membaca query gaji_min, gaji_max dan mata_uang untuk daftar pekerjaan.
+func ParseGajiFilter(min, max, mataUang string) (model.GajiFilter, error) {
+	var f model.GajiFilter
+	if min != "" {
+		v, err := strconv.ParseInt(min, 10, 64)
+		if err != nil {
+			return f, errors.New("gaji_min harus berupa angka")
+		}
+		f.Min = &v
+	}
+	if max != "" {
+		v, err := strconv.ParseInt(max, 10, 64)
+		if err != nil {
+			return f, errors.New("gaji_max harus berupa angka")
+		}
+		f.Max = &v
+	}
+	if f.Min != nil && f.Max != nil && *f.Max < *f.Min {
+		return f, errors.New("gaji_max tidak boleh lebih kecil dari gaji_min")
+	}
+	f.MataUang = strings.ToUpper(mataUang)
+	return f, nil
+}
@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"fmt"
 	"latihan2/app/model"
 	"os"
 	"strings"
@@ -10,20 +9,37 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func getJWTSecret() []byte {
+// Masa berlaku token, diisi config.Apply dari auth.token_ttl dan auth.mongo_token_ttl.
+var (
+	TokenTTL      = 24 * time.Hour
+	MongoTokenTTL = 72 * time.Hour
+)
+
+var jwtSecret []byte
+
+// SetJWTSecret memasang secret token dari konfigurasi. Selama belum
+// dipanggil (misalnya di test), JWTSecret membaca env JWT_SECRET_KEY.
+func SetJWTSecret(secret string) {
+	jwtSecret = []byte(strings.TrimSpace(secret))
+}
+
+// JWTSecret mengembalikan secret penandatangan token /api/v1 dan /api/pg.
+func JWTSecret() []byte {
+	if jwtSecret != nil {
+		return jwtSecret
+	}
 	return []byte(strings.TrimSpace(os.Getenv("JWT_SECRET_KEY")))
 }
 
 // untuk PostgreSQL
 func GenerateToken(user model.User) (string, error) {
-	secret := getJWTSecret()
-	fmt.Printf("SECRET in GenerateToken: %q\n", secret)
+	secret := JWTSecret()
 	claims := model.JWTClaims{
 		UserID:   user.ID,
 		Username: user.Username,
 		Role:     user.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
@@ -35,7 +51,7 @@ func GenerateToken(user model.User) (string, error) {
 func ValidateToken(tokenString string) (*model.JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &model.JWTClaims{},
 		func(token *jwt.Token) (interface{}, error) {
-			return getJWTSecret(), nil
+			return JWTSecret(), nil
 		})
 	if err != nil {
 		return nil, err
@@ -2,7 +2,6 @@ package utils
 
 import (
 	"errors"
-	"log"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -24,18 +23,15 @@ func GenerateMongoToken(userID primitive.ObjectID, username, role string) (strin
 		Username: username,
 		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(72 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(MongoTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString(jwtSecretKeyMongo)
 	if err != nil {
-		log.Printf("[ERROR-GenerateMongoToken] Gagal membuat token: %v", err)
 		return "", err
 	}
-	log.Printf("[DEBUG-GenerateMongoToken] Token dibuat untuk UserID=%s, Username=%s, Role=%s",
-		claims.UserID, claims.Username, claims.Role)
 
 	return tokenString, nil
 }
@@ -45,18 +41,13 @@ func ValidateMongoToken(tokenString string) (*MongoClaims, error) {
 		return jwtSecretKeyMongo, nil
 	})
 	if err != nil {
-		log.Printf("[DEBUG-JWT-ValidateMongo] Token gagal di-parse: %v", err)
 		return nil, err
 	}
 	claims, ok := token.Claims.(*MongoClaims)
 	if !ok || !token.Valid {
-		log.Printf("[DEBUG-JWT-ValidateMongo] Token tidak valid / gagal casting claims")
 		return nil, errors.New("token tidak valid")
 	}
-	// log.Printf("[DEBUG-JWT-ValidateMongo] Parsed: UserID='%s', Username='%s', Role='%s'",
-	// 	claims.UserID, claims.Username, claims.Role)
 	if claims.UserID == "" || claims.Role == "" {
-		// log.Printf("[DEBUG-JWT-ValidateMongo] Klaim kosong: UserID='%s', Role='%s'", claims.UserID, claims.Role)
 		return nil, errors.New("klaim token tidak lengkap")
 	}
 
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// V1Claims adalah klaim token /api/v1. UserID berupa string agar sama untuk
+// semua driver storage.
+type V1Claims struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// v1Audience mengikat token ke driver storage yang menerbitkannya, sehingga
+// ID user dari Postgres tidak bisa dipakai saat server berjalan dengan Mongo.
+func v1Audience(driver string) string {
+	return "api/v1/" + driver
+}
+
+// GenerateTokenV1 membuat token /api/v1 yang ditandatangani dengan JWT_SECRET_KEY.
+func GenerateTokenV1(driver, userID, username, role string) (string, error) {
+	secret := JWTSecret()
+	if len(secret) == 0 {
+		return "", errors.New("JWT_SECRET_KEY belum diisi")
+	}
+	claims := V1Claims{
+		UserID:   userID,
+		Username: username,
+		Role:     role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{v1Audience(driver)},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+// ValidateTokenV1 memeriksa tanda tangan, masa berlaku dan audience token.
+func ValidateTokenV1(driver, tokenString string) (*V1Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &V1Claims{},
+		func(token *jwt.Token) (interface{}, error) {
+			return JWTSecret(), nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(v1Audience(driver)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(*V1Claims)
+	if !ok || !token.Valid || claims.UserID == "" || claims.Role == "" {
+		return nil, errors.New("token tidak valid")
+	}
+	return claims, nil
+}
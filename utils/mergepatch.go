@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MergePatchContentType adalah media type JSON Merge Patch (RFC 7396).
+const MergePatchContentType = "application/merge-patch+json"
+
+var ErrPatchBukanObjek = errors.New("body PATCH harus berupa objek JSON")
+
+// MergePatch menerapkan patch ke target sesuai RFC 7396: member bernilai null
+// dihapus, objek digabung secara rekursif, nilai lain (termasuk array) mengganti
+// nilai lama. target tidak diubah.
+func MergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, _ := target.(map[string]interface{})
+	result := make(map[string]interface{}, len(t)+len(p))
+	for k, v := range t {
+		result[k] = v
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+		result[k] = MergePatch(result[k], v)
+	}
+	return result
+}
+
+// ApplyMergePatch menerapkan body PATCH ke current, biasanya request update yang
+// diisi dari data saat ini, lalu mendecode hasilnya ke dst yang masih kosong
+// (field yang di-null-kan tidak ada di hasil merge). Field yang boleh
+// di-patch adalah field JSON milik current; field lain ditolak. Tipe nilai
+// dicek saat decode ke dst. Hasilnya adalah daftar field yang ada di patch
+// (termasuk yang bernilai null) agar validasi hanya menyentuh field tersebut.
+func ApplyMergePatch(current interface{}, body []byte, dst interface{}) (map[string]bool, error) {
+	var patch interface{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return nil, errors.New("body PATCH bukan JSON yang valid")
+	}
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return nil, ErrPatchBukanObjek
+	}
+
+	raw, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	supplied := make(map[string]bool, len(patchObj))
+	var unknown []string
+	for k := range patchObj {
+		if _, ok := doc[k]; !ok {
+			unknown = append(unknown, k)
+		}
+		supplied[k] = true
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("field tidak bisa diubah: %s", strings.Join(unknown, ", "))
+	}
+
+	merged, err := json.Marshal(MergePatch(doc, patchObj))
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("%s harus bertipe %s", typeErr.Field, jsonTypeName(typeErr.Type))
+		}
+		return nil, err
+	}
+	return supplied, nil
+}
+
+// jsonTypeName menyebut tipe Go dengan nama tipe JSON-nya untuk pesan error.
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+	return "object"
+}
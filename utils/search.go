@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	SnippetStart  = "<mark>"
+	SnippetStop   = "</mark>"
+	snippetRadius = 60
+)
+
+// SearchTerms memecah query pencarian menjadi kata-kata, tanpa tanda kutip dan
+// kata yang dikecualikan (diawali "-"), mengikuti sintaks websearch Postgres.
+func SearchTerms(q string) []string {
+	var terms []string
+	for _, w := range strings.Fields(q) {
+		if strings.HasPrefix(w, "-") || strings.EqualFold(w, "or") {
+			continue
+		}
+		w = strings.Trim(w, `"'`)
+		if w != "" {
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+// Snippet membuat potongan teks di sekitar kata pertama yang cocok dengan q
+// dan menandai setiap kata yang cocok dengan <mark>, sama seperti ts_headline
+// di Postgres. Dipakai untuk Mongo dan untuk pencarian fallback. Hasilnya
+// berupa HTML: teks asli di-escape sehingga hanya <mark> yang menjadi tag.
+func Snippet(text, q string) string {
+	terms := SearchTerms(q)
+	if len(terms) == 0 || text == "" {
+		return html.EscapeString(truncateRunes(text, 2*snippetRadius))
+	}
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = regexp.QuoteMeta(t)
+	}
+	re := regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+
+	loc := re.FindStringIndex(text)
+	if loc == nil {
+		return html.EscapeString(truncateRunes(text, 2*snippetRadius))
+	}
+
+	start, end := loc[0]-snippetRadius, loc[1]+snippetRadius
+	prefix, suffix := "…", "…"
+	if start <= 0 {
+		start, prefix = 0, ""
+	}
+	if end >= len(text) {
+		end, suffix = len(text), ""
+	}
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	return prefix + markMatches(re, text[start:end]) + suffix
+}
+
+// markMatches meng-escape text dan membungkus setiap bagian yang cocok dengan
+// re dengan SnippetStart dan SnippetStop.
+func markMatches(re *regexp.Regexp, text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:loc[0]]))
+		b.WriteString(SnippetStart + html.EscapeString(text[loc[0]:loc[1]]) + SnippetStop)
+		last = loc[1]
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "…"
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"math"
+	"sort"
+)
+
+// Persentase mengembalikan part/total dalam persen, dibulatkan 2 desimal.
+func Persentase(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return Round2(float64(part) * 100 / float64(total))
+}
+
+func Round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// Mean mengembalikan rata-rata values, atau 0 jika kosong seperti
+// COALESCE(AVG(...), 0) di Postgres.
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
+
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"latihan2/app/model"
+	"latihan2/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMedian(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"kosong", nil, 0},
+		{"satu", []float64{4}, 4},
+		{"ganjil tidak urut", []float64{9, 1, 5}, 5},
+		{"genap", []float64{8, 2, 4, 6}, 5},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, utils.Median(tc.values))
+		})
+	}
+
+	values := []float64{3, 1, 2}
+	utils.Median(values)
+	assert.Equal(t, []float64{3, 1, 2}, values, "input tidak ikut diurutkan")
+}
+
+func TestMean(t *testing.T) {
+	assert.Equal(t, 0.0, utils.Mean(nil))
+	assert.Equal(t, 2.5, utils.Mean([]float64{1, 2, 3, 4}))
+}
+
+func TestPersentase(t *testing.T) {
+	assert.Equal(t, 0.0, utils.Persentase(3, 0))
+	assert.Equal(t, 50.0, utils.Persentase(1, 2))
+	assert.Equal(t, 33.33, utils.Persentase(1, 3))
+	assert.Equal(t, 66.67, utils.Persentase(2, 3))
+}
+
+func TestRingkasBulan(t *testing.T) {
+	r := model.TimeToFirstJob{TahunLulus: 2022, Jurusan: "TI"}
+
+	kosong := utils.RingkasBulan(r, nil)
+	assert.Equal(t, r, kosong)
+
+	got := utils.RingkasBulan(r, []float64{6, 0, 3, 10})
+	assert.Equal(t, model.TimeToFirstJob{
+		TahunLulus: 2022, Jurusan: "TI", Sampel: 4, RataRata: 4.75, Median: 4.5, Min: 0, Max: 10,
+	}, got)
+}
+
+func TestSusunGajiBand(t *testing.T) {
+	items := utils.SusunGajiBand(map[int]int{0: 1, 3: 2}, 3)
+	assert.Len(t, items, len(model.GajiBands), "band kosong tetap ada, tanpa band tidak diketahui")
+	assert.Equal(t, 1, items[0].Jumlah)
+	assert.Equal(t, 33.33, items[0].Persentase)
+	assert.Equal(t, 0, items[1].Jumlah)
+	assert.Equal(t, 66.67, items[3].Persentase)
+
+	items = utils.SusunGajiBand(map[int]int{1: 1, -1: 3}, 4)
+	last := items[len(items)-1]
+	assert.Equal(t, model.LabelTidakDiketahui, last.Label)
+	assert.Equal(t, 3, last.Jumlah)
+	assert.Equal(t, 75.0, last.Persentase)
+}
+
+func TestAnalyticsToSQL(t *testing.T) {
+	where, args := utils.AnalyticsToSQL(model.AnalyticsFilter{}, "a.", nil)
+	assert.Equal(t, "a.deleted_at IS NULL", where)
+	assert.Empty(t, args)
+
+	where, args = utils.AnalyticsToSQL(model.AnalyticsFilter{TahunMulai: 2019, TahunAkhir: 2022, Jurusan: "TI"},
+		"a.", []interface{}{"sudah ada"})
+	assert.Equal(t, "a.deleted_at IS NULL AND a.tahun_lulus >= $2 AND a.tahun_lulus <= $3 AND a.jurusan = $4", where)
+	assert.Equal(t, []interface{}{"sudah ada", 2019, 2022, "TI"}, args)
+}
+
+func TestAnalyticsToBSON(t *testing.T) {
+	assert.Equal(t, bson.M{"deleted_at": bson.M{"$exists": false}}, utils.AnalyticsToBSON(model.AnalyticsFilter{}, ""))
+
+	assert.Equal(t, bson.M{
+		"alumni.deleted_at":  bson.M{"$exists": false},
+		"alumni.tahun_lulus": bson.M{"$gte": 2019},
+		"alumni.jurusan":     "TI",
+	}, utils.AnalyticsToBSON(model.AnalyticsFilter{TahunMulai: 2019, Jurusan: "TI"}, "alumni."))
+}
+
+func TestParseAnalyticsFilter(t *testing.T) {
+	f, err := utils.ParseAnalyticsFilter("", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, model.AnalyticsFilter{}, f)
+
+	f, err = utils.ParseAnalyticsFilter("2019", "2022", "TI")
+	assert.NoError(t, err)
+	assert.Equal(t, model.AnalyticsFilter{TahunMulai: 2019, TahunAkhir: 2022, Jurusan: "TI"}, f)
+
+	_, err = utils.ParseAnalyticsFilter("dua ribu", "", "")
+	assert.EqualError(t, err, "tahun_mulai harus berupa angka")
+	_, err = utils.ParseAnalyticsFilter("", "x", "")
+	assert.EqualError(t, err, "tahun_akhir harus berupa angka")
+	_, err = utils.ParseAnalyticsFilter("2023", "2020", "")
+	assert.EqualError(t, err, "tahun_mulai tidak boleh lebih besar dari tahun_akhir")
+}
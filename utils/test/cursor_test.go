@@ -0,0 +1,73 @@
+package test
+
+import (
+	"latihan2/app/model"
+	"latihan2/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var sortable = map[string]bool{"id": true, "nama": true, "tahun_lulus": true, "created_at": true}
+
+func TestCursorRoundTrip(t *testing.T) {
+	created := time.Date(2024, 5, 1, 8, 30, 0, 123000, time.UTC)
+	for _, value := range []interface{}{"Budi", int64(2021), created} {
+		in := model.Cursor{SortBy: "nama", Order: "desc", Value: value, ID: "42", Prev: true}
+		out, err := utils.DecodeCursor(utils.EncodeCursor(in))
+		assert.NoError(t, err)
+		assert.Equal(t, in.Value, out.Value)
+		assert.Equal(t, in, out)
+	}
+
+	_, err := utils.DecodeCursor("bukan-cursor")
+	assert.ErrorIs(t, err, utils.ErrCursorTidakValid)
+}
+
+func TestParseCursorPageUsesCursorSort(t *testing.T) {
+	cursor := utils.EncodeCursor(model.Cursor{SortBy: "tahun_lulus", Order: "desc", Value: int64(2020), ID: "7"})
+	page, err := utils.ParseCursorPage(cursor, "id", "asc", 5, sortable)
+	assert.NoError(t, err)
+	assert.Equal(t, "tahun_lulus", page.SortBy)
+	assert.Equal(t, "desc", page.Order)
+
+	_, err = utils.ParseCursorPage("", "no_telepon", "asc", 5, sortable)
+	assert.Error(t, err)
+}
+
+func TestCursorToSQL(t *testing.T) {
+	page := model.CursorPage{SortBy: "nama", Order: "asc", Limit: 2}
+	where, orderBy, args := utils.CursorToSQL(page, "nama", "id", []interface{}{"%"})
+	assert.Equal(t, "1=1", where)
+	assert.Equal(t, "nama ASC, id ASC", orderBy)
+	assert.Len(t, args, 1)
+
+	page.Cursor = &model.Cursor{SortBy: "nama", Order: "asc", Value: "Budi", ID: "3", Prev: true}
+	where, orderBy, args = utils.CursorToSQL(page, "nama", "id", []interface{}{"%"})
+	assert.Equal(t, "(nama, id) < ($2, $3)", where)
+	assert.Equal(t, "nama DESC, id DESC", orderBy)
+	assert.Equal(t, []interface{}{"%", "Budi", "3"}, args)
+}
+
+func TestCursorResult(t *testing.T) {
+	rows := []model.Alumni{{ID: "1", Nama: "Ani"}, {ID: "2", Nama: "Budi"}, {ID: "3", Nama: "Citra"}}
+	page := model.CursorPage{SortBy: "nama", Order: "asc", Limit: 2}
+
+	items, next, prev := utils.CursorResult(rows, page)
+	assert.Len(t, items, 2)
+	assert.Empty(t, prev)
+	c, err := utils.DecodeCursor(next)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Cursor{SortBy: "nama", Order: "asc", Value: "Budi", ID: "2"}, c)
+
+	// Halaman mundur dibaca terbalik dari database lalu dikembalikan ke urutan asli.
+	page.Cursor = &model.Cursor{SortBy: "nama", Order: "asc", Value: "Dewi", ID: "4", Prev: true}
+	backward := []model.Alumni{{ID: "3", Nama: "Citra"}, {ID: "2", Nama: "Budi"}, {ID: "1", Nama: "Ani"}}
+	items, next, prev = utils.CursorResult(backward, page)
+	assert.Equal(t, []model.Alumni{{ID: "2", Nama: "Budi"}, {ID: "3", Nama: "Citra"}}, items)
+	assert.NotEmpty(t, next)
+	c, err = utils.DecodeCursor(prev)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Cursor{SortBy: "nama", Order: "asc", Value: "Budi", ID: "2", Prev: true}, c)
+}
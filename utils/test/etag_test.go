@@ -0,0 +1,54 @@
+package test
+
+import (
+	"latihan2/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		version int
+	}{
+		{`"3"`, 3},
+		{` "12" `, 12},
+		// "*" berarti version apa pun, dikirim ke repository sebagai AnyVersion.
+		{"*", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			version, err := utils.ParseIfMatch(tt.header)
+			require.NoError(t, err)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestParseIfMatchTidakValid(t *testing.T) {
+	for _, header := range []string{
+		`W/"3"`, // weak ETag tidak boleh dipakai untuk If-Match
+		`"0"`,   // version dimulai dari 1; 0 dicadangkan untuk "*"
+		`"-1"`,
+		`"abc"`,
+		`3`,
+		`""`,
+		`"3`,
+		`"3", "4"`,
+		"",
+	} {
+		t.Run(header, func(t *testing.T) {
+			_, err := utils.ParseIfMatch(header)
+			assert.ErrorIs(t, err, utils.ErrETagTidakValid)
+		})
+	}
+}
+
+func TestETagRoundTrip(t *testing.T) {
+	assert.Equal(t, `"7"`, utils.ETag(7))
+	version, err := utils.ParseIfMatch(utils.ETag(7))
+	require.NoError(t, err)
+	assert.Equal(t, 7, version)
+}
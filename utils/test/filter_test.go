@@ -0,0 +1,65 @@
+package test
+
+import (
+	"latihan2/app/model"
+	"latihan2/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := utils.ParseFilter("tahun_lulus:gte:2020,jurusan:in:TI|SI", model.AlumniFilterFields)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.FilterCondition{
+		{Field: "tahun_lulus", Operator: model.FilterGte, Values: []interface{}{int64(2020)}},
+		{Field: "jurusan", Operator: model.FilterIn, Values: []interface{}{"TI", "SI"}},
+	}, f.Conditions)
+}
+
+func TestParseFilterEscape(t *testing.T) {
+	f, err := utils.ParseFilter(`nama:eq:Budi\, S.Kom,nim:like:a\:b`, model.AlumniFilterFields)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"Budi, S.Kom"}, f.Conditions[0].Values)
+	assert.Equal(t, []interface{}{"a:b"}, f.Conditions[1].Values)
+}
+
+func TestParseFilterRejected(t *testing.T) {
+	cases := []string{
+		"password:eq:x",           // field di luar whitelist
+		"nama:gte:a",              // operator tidak diizinkan untuk string
+		"tahun_lulus:eq:dua ribu", // bukan angka
+		"created_at:lt:kemarin",   // bukan tanggal
+		"jurusan",                 // format salah
+		"nama:null:true",          // null hanya untuk field nullable
+	}
+	for _, raw := range cases {
+		_, err := utils.ParseFilter(raw, model.AlumniFilterFields)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestFilterToSQL(t *testing.T) {
+	f, err := utils.ParseFilter("gaji_min:gte:5000000,status_pekerjaan:nin:resign|kontrak,tanggal_selesai_kerja:null:true,posisi_jabatan:like:50%", model.PekerjaanFilterFields)
+	assert.NoError(t, err)
+
+	where, args := utils.FilterToSQL(f, "pa.", []interface{}{"%"})
+	assert.Equal(t, "1=1 AND pa.gaji_min >= $2 AND pa.status_pekerjaan NOT IN ($3, $4) AND pa.tanggal_selesai_kerja IS NULL AND pa.posisi_jabatan ILIKE $5", where)
+	assert.Equal(t, []interface{}{"%", int64(5000000), "resign", "kontrak", `%50\%%`}, args)
+}
+
+func TestFilterToBSON(t *testing.T) {
+	f, err := utils.ParseFilter("tahun_lulus:lt:2023,jurusan:nin:TI|SI,nama:like:a.b", model.AlumniFilterFields)
+	assert.NoError(t, err)
+
+	base := utils.ApplyFilterBSON(bson.M{"deleted_at": bson.M{"$exists": false}}, f)
+	assert.Equal(t, bson.M{
+		"deleted_at": bson.M{"$exists": false},
+		"$and": []bson.M{
+			{"tahun_lulus": bson.M{"$lt": int64(2023)}},
+			{"jurusan": bson.M{"$nin": []interface{}{"TI", "SI"}}},
+			{"nama": bson.M{"$regex": `a\.b`, "$options": "i"}},
+		},
+	}, base)
+}
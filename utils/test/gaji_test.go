@@ -0,0 +1,158 @@
+package test
+
+import (
+	"latihan2/app/model"
+	"latihan2/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func i64(v int64) *int64 { return &v }
+
+func TestParseGajiRange(t *testing.T) {
+	tests := []struct {
+		in       string
+		min, max *int64
+		mataUang string
+		periode  string
+	}{
+		{"Rp 4.000.000 - 6.000.000", i64(4000000), i64(6000000), "IDR", model.PeriodeGajiBulanan},
+		{"5-7 juta", i64(5000000), i64(7000000), "IDR", model.PeriodeGajiBulanan},
+		{"2,5 - 3 jt", i64(2500000), i64(3000000), "IDR", model.PeriodeGajiBulanan},
+		{"500 ribu - 1 juta", i64(500000), i64(1000000), "IDR", model.PeriodeGajiBulanan},
+		{"750rb - 1,5jt", i64(750000), i64(1500000), "IDR", model.PeriodeGajiBulanan},
+		{"800k - 1 juta", i64(800000), i64(1000000), "IDR", model.PeriodeGajiBulanan},
+		{"5.000.000 - 7 juta", i64(5000000), i64(7000000), "IDR", model.PeriodeGajiBulanan},
+		{"> 10jt", i64(10000000), nil, "IDR", model.PeriodeGajiBulanan},
+		{"di bawah 3 juta", nil, i64(3000000), "IDR", model.PeriodeGajiBulanan},
+		{"8 juta", i64(8000000), i64(8000000), "IDR", model.PeriodeGajiBulanan},
+		{"USD 3,000 - 4,000 per tahun", i64(3000), i64(4000), "USD", model.PeriodeGajiTahunan},
+		{"$5k - 6k", i64(5000), i64(6000), "USD", model.PeriodeGajiBulanan},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			g, err := utils.ParseGajiRange(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.min, g.GajiMin, "gaji_min")
+			assert.Equal(t, tt.max, g.GajiMax, "gaji_max")
+			assert.Equal(t, tt.mataUang, g.GajiMataUang)
+			assert.Equal(t, tt.periode, g.GajiPeriode)
+		})
+	}
+}
+
+func TestParseGajiRangeError(t *testing.T) {
+	tests := []struct {
+		in  string
+		err error
+	}{
+		{"", utils.ErrGajiKosong},
+		{" - ", utils.ErrGajiKosong},
+		{"negotiable", utils.ErrGajiTidakDikenal},
+		{"1 - 2 - 3 juta", utils.ErrGajiTidakDikenal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := utils.ParseGajiRange(tt.in)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	_, err := utils.ParseGajiRange("7 - 5 juta")
+	assert.EqualError(t, err, "gaji_max tidak boleh lebih kecil dari gaji_min")
+}
+
+func TestValidateRentangGaji(t *testing.T) {
+	tests := []struct {
+		name string
+		g    model.RentangGaji
+		err  string
+	}{
+		{"kosong", model.RentangGaji{}, ""},
+		{"valid", model.RentangGaji{GajiMin: i64(1), GajiMax: i64(2), GajiMataUang: "IDR", GajiPeriode: model.PeriodeGajiBulanan}, ""},
+		{"min negatif", model.RentangGaji{GajiMin: i64(-1), GajiMataUang: "IDR", GajiPeriode: model.PeriodeGajiBulanan}, "gaji_min tidak boleh negatif"},
+		{"max negatif", model.RentangGaji{GajiMax: i64(-1), GajiMataUang: "IDR", GajiPeriode: model.PeriodeGajiBulanan}, "gaji_max tidak boleh negatif"},
+		{"max < min", model.RentangGaji{GajiMin: i64(5), GajiMax: i64(4), GajiMataUang: "IDR", GajiPeriode: model.PeriodeGajiBulanan}, "gaji_max tidak boleh lebih kecil dari gaji_min"},
+		{"mata uang", model.RentangGaji{GajiMin: i64(5), GajiMataUang: "rupiah", GajiPeriode: model.PeriodeGajiBulanan}, "gaji_mata_uang harus kode ISO 4217, mis. IDR"},
+		{"periode", model.RentangGaji{GajiMin: i64(5), GajiMataUang: "IDR", GajiPeriode: "harian"}, "gaji_periode harus bulanan atau tahunan"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidateRentangGaji(tt.g)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestResolveGaji(t *testing.T) {
+	tests := []struct {
+		name      string
+		gajiRange string
+		g         model.RentangGaji
+		want      model.RentangGaji
+		teks      string
+		err       bool
+	}{
+		{name: "kosong semua"},
+		{
+			name:      "dari gaji_range",
+			gajiRange: "500 ribu - 1 juta",
+			want:      model.RentangGaji{GajiMin: i64(500000), GajiMax: i64(1000000), GajiMataUang: "IDR", GajiPeriode: model.PeriodeGajiBulanan},
+			teks:      "500 ribu - 1 juta",
+		},
+		{
+			name:      "field terstruktur diutamakan dan diberi default",
+			gajiRange: "1 juta",
+			g:         model.RentangGaji{GajiMin: i64(4000000), GajiMax: i64(6000000), GajiMataUang: "usd"},
+			want:      model.RentangGaji{GajiMin: i64(4000000), GajiMax: i64(6000000), GajiMataUang: "USD", GajiPeriode: model.PeriodeGajiBulanan},
+			teks:      "USD 4.000.000 - 6.000.000 per bulan",
+		},
+		{name: "gaji_range tidak dikenali", gajiRange: "nego", err: true},
+		{name: "field terstruktur tidak valid", g: model.RentangGaji{GajiMin: i64(5), GajiMax: i64(1)}, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, teks, err := utils.ResolveGaji(tt.gajiRange, tt.g)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.teks, teks)
+		})
+	}
+}
+
+func TestParseGajiFilter(t *testing.T) {
+	tests := []struct {
+		name               string
+		min, max, mataUang string
+		want               model.GajiFilter
+		err                string
+	}{
+		{name: "kosong"},
+		{name: "lengkap", min: "3000000", max: "5000000", mataUang: "idr", want: model.GajiFilter{Min: i64(3000000), Max: i64(5000000), MataUang: "IDR"}},
+		{name: "hanya min", min: "1", want: model.GajiFilter{Min: i64(1)}},
+		{name: "min bukan angka", min: "3jt", err: "gaji_min harus berupa angka"},
+		{name: "max bukan angka", max: "x", err: "gaji_max harus berupa angka"},
+		{name: "max < min", min: "5", max: "4", err: "gaji_max tidak boleh lebih kecil dari gaji_min"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.ParseGajiFilter(tt.min, tt.max, tt.mataUang)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"encoding/json"
+	"latihan2/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Contoh dari RFC 7396 Appendix A.
+func TestMergePatchRFC7396(t *testing.T) {
+	cases := []struct{ target, patch, want string }{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, c := range cases {
+		var target, patch interface{}
+		require.NoError(t, json.Unmarshal([]byte(c.target), &target))
+		require.NoError(t, json.Unmarshal([]byte(c.patch), &patch))
+		got, err := json.Marshal(utils.MergePatch(target, patch))
+		require.NoError(t, err)
+		assert.JSONEq(t, c.want, string(got), "target %s patch %s", c.target, c.patch)
+	}
+}
+
+type patchTarget struct {
+	Nama     string `json:"nama"`
+	Angkatan int    `json:"angkatan"`
+	Alamat   string `json:"alamat"`
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	current := patchTarget{Nama: "Budi", Angkatan: 2019, Alamat: "Surabaya"}
+
+	var got patchTarget
+	supplied, err := utils.ApplyMergePatch(current, []byte(`{"angkatan":2020,"alamat":null}`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, patchTarget{Nama: "Budi", Angkatan: 2020}, got)
+	assert.Equal(t, map[string]bool{"angkatan": true, "alamat": true}, supplied)
+
+	_, err = utils.ApplyMergePatch(current, []byte(`{"angkatan":"2020"}`), &patchTarget{})
+	assert.EqualError(t, err, "angkatan harus bertipe integer")
+
+	_, err = utils.ApplyMergePatch(current, []byte(`{"nim":"1","id":2}`), &patchTarget{})
+	assert.EqualError(t, err, "field tidak bisa diubah: id, nim")
+
+	_, err = utils.ApplyMergePatch(current, []byte(`["nama"]`), &patchTarget{})
+	assert.ErrorIs(t, err, utils.ErrPatchBukanObjek)
+}
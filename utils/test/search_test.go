@@ -0,0 +1,42 @@
+package test
+
+import (
+	"latihan2/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"backend", "golang"}, utils.SearchTerms(`"backend" -java or golang`))
+}
+
+func TestSnippet(t *testing.T) {
+	assert.Equal(t, "PT Maju · <mark>Backend</mark> Engineer", utils.Snippet("PT Maju · Backend Engineer", "backend"))
+
+	long := "Membangun layanan internal untuk tim keuangan dan operasional. " +
+		"Bertanggung jawab atas API pembayaran berbasis Go dan integrasi dengan bank mitra di seluruh Indonesia."
+	snippet := utils.Snippet(long, "pembayaran")
+	assert.Contains(t, snippet, "<mark>pembayaran</mark>")
+	assert.True(t, len(snippet) < len(long)+len("<mark></mark>"))
+	assert.Equal(t, "…", snippet[:len("…")])
+}
+
+func TestSnippetEscapeHTML(t *testing.T) {
+	tests := []struct {
+		name, text, q, want string
+	}{
+		{"tag di teks", `<script>alert(1)</script> Backend`, "backend",
+			`&lt;script&gt;alert(1)&lt;/script&gt; <mark>Backend</mark>`},
+		{"kata yang cocok di-escape", `R&D "Lab"`, "r&d",
+			`<mark>R&amp;D</mark> &#34;Lab&#34;`},
+		{"tidak ada yang cocok", `<b>PT Maju</b>`, "golang", `&lt;b&gt;PT Maju&lt;/b&gt;`},
+		{"query kosong", `O'Brien & Co`, "", `O&#39;Brien &amp; Co`},
+		{"query berisi tag", `PT <mark> Jaya`, "<mark>", `PT <mark>&lt;mark&gt;</mark> Jaya`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.Snippet(tt.text, tt.q))
+		})
+	}
+}
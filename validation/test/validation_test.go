@@ -0,0 +1,96 @@
+package test
+
+import (
+	"latihan2/app/model"
+	mongoModel "latihan2/app/model/mongo"
+	"latihan2/validation"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fieldErrors(err error, lang string) map[string]string {
+	result := map[string]string{}
+	for _, fe := range validation.Translate(err, lang) {
+		result[fe.Field] = fe.Message
+	}
+	return result
+}
+
+func TestAlumniRules(t *testing.T) {
+	valid := model.CreateAlumniRequest{
+		NIM: "2019001", Nama: "Budi", Jurusan: "TI", Angkatan: 2019, TahunLulus: 2023, Email: "budi@example.com",
+	}
+	assert.NoError(t, validation.Struct(valid))
+
+	invalid := valid
+	invalid.NIM = "19A"
+	invalid.Email = "bukan-email"
+	invalid.TahunLulus = 2017
+	invalid.Nama = ""
+	err := validation.Struct(invalid)
+	require.Error(t, err)
+
+	id := fieldErrors(err, validation.LangID)
+	assert.Len(t, id, 4)
+	assert.Equal(t, "nim harus berupa 7 sampai 15 digit angka", id["nim"])
+	assert.Equal(t, "tahun_lulus tidak boleh lebih awal dari angkatan", id["tahun_lulus"])
+	assert.Contains(t, id, "email")
+	assert.Contains(t, id, "nama")
+
+	en := fieldErrors(err, validation.LangEN)
+	assert.Equal(t, "nim must be 7 to 15 digits", en["nim"])
+	assert.Equal(t, "email must be a valid email address", en["email"])
+	assert.Equal(t, "nama is a required field", en["nama"])
+}
+
+func TestTanggalRules(t *testing.T) {
+	req := model.CreatePekerjaanRequest{
+		NamaPerusahaan: "PT Maju", PosisiJabatan: "Engineer", StatusPekerjaan: "aktif",
+		TanggalMulaiKerja: "2023-01-02", TanggalSelesaiKerja: "2022-12-31",
+	}
+	en := fieldErrors(validation.Struct(req), validation.LangEN)
+	assert.Equal(t, map[string]string{
+		"tanggal_selesai_kerja": "tanggal_selesai_kerja must be after tanggal_mulai_kerja",
+	}, en)
+
+	req.TanggalMulaiKerja, req.TanggalSelesaiKerja = "02-01-2023", ""
+	id := fieldErrors(validation.Struct(req), validation.LangID)
+	assert.Equal(t, "tanggal_mulai_kerja harus berformat YYYY-MM-DD", id["tanggal_mulai_kerja"])
+
+	mulai := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	selesai := mulai.AddDate(0, 0, -1)
+	update := mongoModel.UpdatePekerjaanRequest{TanggalMulaiKerja: &mulai, TanggalSelesaiKerja: &selesai}
+	assert.Error(t, validation.Struct(update), "aturan setelah juga berlaku untuk *time.Time")
+	selesai = mulai.AddDate(1, 0, 0)
+	assert.NoError(t, validation.Struct(update))
+}
+
+func TestPartial(t *testing.T) {
+	req := model.UpdatePekerjaanRequest{NamaPerusahaan: "PT Maju"}
+	assert.Error(t, validation.Struct(req))
+	assert.NoError(t, validation.Partial(req, map[string]bool{"nama_perusahaan": true}))
+
+	req.GajiPeriode = "harian"
+	err := validation.Partial(req, map[string]bool{"nama_perusahaan": true, "gaji_periode": true})
+	assert.Equal(t, []string{"gaji_periode"}, fields(validation.Translate(err, validation.LangID)),
+		"field di struct embedded ikut divalidasi")
+}
+
+func fields(errs []validation.FieldError) []string {
+	names := make([]string, 0, len(errs))
+	for _, fe := range errs {
+		names = append(names, fe.Field)
+	}
+	return names
+}
+
+func TestLanguage(t *testing.T) {
+	assert.Equal(t, validation.LangID, validation.Language(""))
+	assert.Equal(t, validation.LangEN, validation.Language("en-US,en;q=0.9"))
+	assert.Equal(t, validation.LangID, validation.Language("en;q=0.5, id"))
+	assert.Equal(t, validation.LangEN, validation.Language("fr, en;q=0.8"))
+	assert.Equal(t, validation.LangID, validation.Language("fr"))
+}
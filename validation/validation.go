@@ -0,0 +1,280 @@
+// Package validation memvalidasi request DTO dengan tag `validate` milik
+// go-playground/validator dan menerjemahkan errornya ke bahasa Indonesia atau
+// Inggris. Nama field di pesan error memakai nama JSON-nya.
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	idTranslations "github.com/go-playground/validator/v10/translations/id"
+)
+
+const (
+	LangID = "id"
+	LangEN = "en"
+)
+
+var (
+	validate   *validator.Validate
+	translator *ut.UniversalTranslator
+)
+
+// nimPattern: NIM berupa 7 sampai 15 digit angka.
+var nimPattern = regexp.MustCompile(`^[0-9]{7,15}$`)
+
+const formatTanggal = "2006-01-02"
+
+func init() {
+	validate = validator.New()
+	validate.RegisterTagNameFunc(jsonName)
+
+	translator = ut.New(id.New(), id.New(), en.New())
+	idTrans, _ := translator.GetTranslator(LangID)
+	enTrans, _ := translator.GetTranslator(LangEN)
+	must(idTranslations.RegisterDefaultTranslations(validate, idTrans))
+	must(enTranslations.RegisterDefaultTranslations(validate, enTrans))
+
+	for _, r := range rules {
+		must(validate.RegisterValidation(r.tag, r.fn))
+		register(idTrans, r.tag, r.id)
+		register(enTrans, r.tag, r.en)
+	}
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// jsonName dipakai sebagai nama field agar error menyebut "tahun_lulus", bukan "TahunLulus".
+func jsonName(f reflect.StructField) string {
+	name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// rule adalah aturan validasi tambahan beserta pesannya; {0} diganti nama
+// field, {1} parameter tag.
+type rule struct {
+	tag    string
+	fn     validator.Func
+	id, en string
+}
+
+var rules = []rule{
+	{
+		tag: "nim",
+		fn: func(fl validator.FieldLevel) bool {
+			return nimPattern.MatchString(fl.Field().String())
+		},
+		id: "{0} harus berupa 7 sampai 15 digit angka",
+		en: "{0} must be 7 to 15 digits",
+	},
+	{
+		// tahun_lulus membandingkan dengan field Angkatan di struct yang sama.
+		// Nilai 0 berarti belum diisi dan tidak dibandingkan.
+		tag: "tahun_lulus",
+		fn: func(fl validator.FieldLevel) bool {
+			angkatan := fl.Parent().FieldByName("Angkatan")
+			if !angkatan.IsValid() || angkatan.Int() == 0 || fl.Field().Int() == 0 {
+				return true
+			}
+			return fl.Field().Int() >= angkatan.Int()
+		},
+		id: "{0} tidak boleh lebih awal dari angkatan",
+		en: "{0} must not be earlier than angkatan",
+	},
+	{
+		tag: "tanggal",
+		fn: func(fl validator.FieldLevel) bool {
+			_, err := time.Parse(formatTanggal, fl.Field().String())
+			return err == nil
+		},
+		id: "{0} harus berformat YYYY-MM-DD",
+		en: "{0} must use the YYYY-MM-DD format",
+	},
+	{
+		// setelah=Field: tanggal harus setelah tanggal di field lain. Berlaku
+		// untuk string YYYY-MM-DD, time.Time dan *time.Time; nilai kosong di
+		// salah satu sisi tidak dibandingkan.
+		tag: "setelah",
+		fn: func(fl validator.FieldLevel) bool {
+			end, ok := asTime(fl.Field())
+			if !ok {
+				return true
+			}
+			start, ok := asTime(fl.Parent().FieldByName(fl.Param()))
+			if !ok {
+				return true
+			}
+			return end.After(start)
+		},
+		id: "{0} harus setelah {1}",
+		en: "{0} must be after {1}",
+	},
+}
+
+func asTime(v reflect.Value) (time.Time, bool) {
+	if !v.IsValid() {
+		return time.Time{}, false
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return time.Time{}, false
+		}
+		v = v.Elem()
+	}
+	switch t := v.Interface().(type) {
+	case time.Time:
+		return t, !t.IsZero()
+	case string:
+		parsed, err := time.Parse(formatTanggal, t)
+		return parsed, err == nil
+	}
+	return time.Time{}, false
+}
+
+func register(trans ut.Translator, tag, text string) {
+	must(validate.RegisterTranslation(tag, trans,
+		func(t ut.Translator) error { return t.Add(tag, text, true) },
+		func(t ut.Translator, fe validator.FieldError) string {
+			msg, _ := t.T(tag, fe.Field(), paramName(fe))
+			return msg
+		}))
+}
+
+// paramName menyebut field pembanding dengan nama JSON-nya, mis. "tanggal_mulai_kerja".
+func paramName(fe validator.FieldError) string {
+	if fe.Tag() != "setelah" {
+		return fe.Param()
+	}
+	return toSnake(fe.Param())
+}
+
+func toSnake(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+// Struct memvalidasi seluruh field s. Error yang dikembalikan berupa
+// validator.ValidationErrors dan bisa diterjemahkan dengan Translate.
+func Struct(s interface{}) error {
+	return validate.Struct(s)
+}
+
+// Partial hanya memvalidasi field yang namanya (nama JSON) ada di fields,
+// dipakai PATCH agar field yang tidak dikirim tidak ikut divalidasi.
+func Partial(s interface{}, fields map[string]bool) error {
+	t := reflect.TypeOf(s)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var names []string
+	collectFields(t, "", fields, &names)
+	if len(names) == 0 {
+		return nil
+	}
+	return validate.StructPartial(s, names...)
+}
+
+// collectFields menyusun nama field Go yang dipakai StructPartial untuk field
+// yang nama JSON-nya ada di fields. Field di struct embedded seperti
+// RentangGaji diberi prefix, mis. "RentangGaji.GajiMin".
+func collectFields(t reflect.Type, ns string, fields map[string]bool, names *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			collectFields(f.Type, ns+f.Name+".", fields, names)
+			continue
+		}
+		if fields[jsonName(f)] {
+			*names = append(*names, ns+f.Name)
+		}
+	}
+}
+
+// FieldError adalah satu error validasi yang sudah diterjemahkan.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Translate menerjemahkan error dari Struct atau Partial ke bahasa lang
+// (LangID atau LangEN). Error lain dikembalikan sebagai satu FieldError tanpa field.
+func Translate(err error, lang string) []FieldError {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return []FieldError{{Message: err.Error()}}
+	}
+	trans, _ := translator.GetTranslator(lang)
+	result := make([]FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		result = append(result, FieldError{Field: fe.Field(), Message: fe.Translate(trans)})
+	}
+	return result
+}
+
+// Language memilih LangEN jika Accept-Language lebih mengutamakan bahasa
+// Inggris, selain itu LangID.
+func Language(acceptLanguage string) string {
+	best, bestQ := LangID, -1.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, q := parseLanguage(part)
+		if tag == "" {
+			continue
+		}
+		base := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if (base == LangID || base == LangEN) && q > bestQ {
+			best, bestQ = base, q
+		}
+	}
+	return best
+}
+
+func parseLanguage(part string) (string, float64) {
+	fields := strings.Split(strings.TrimSpace(part), ";")
+	q := 1.0
+	for _, p := range fields[1:] {
+		p = strings.TrimSpace(p)
+		if strings.HasPrefix(p, "q=") {
+			v, err := strconv.ParseFloat(p[2:], 64)
+			if err != nil {
+				v = 0
+			}
+			q = v
+		}
+	}
+	return strings.TrimSpace(fields[0]), q
+}
+
+var pesanGagal = map[string]string{
+	LangID: "Validasi gagal",
+	LangEN: "Validation failed",
+}
+
+// Message adalah ringkasan error validasi dalam bahasa lang.
+func Message(lang string) string {
+	return pesanGagal[lang]
+}